@@ -1,11 +1,22 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"runtime"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/sciefylab/tensordb/pkg/tensor" // Pastikan path ini benar
@@ -32,6 +43,18 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// Reopen menutup semua handle lalu menginisialisasi ulang Storage dan Executor di atas direktori data
+// yang sama, seolah database dibuka oleh proses baru. Berguna untuk memastikan data yang ditulis
+// bertahan dan indeks dibangun ulang dengan benar. Tidak aman dipanggil bersamaan dengan metode lain.
+func (c *Client) Reopen() error {
+	executor, err := c.executor.Reopen()
+	if err != nil {
+		return fmt.Errorf("gagal membuka ulang database: %w", err)
+	}
+	c.executor = executor
+	return nil
+}
+
 func (c *Client) CreateTensor(name string, shape []int, dataType string) error {
 	if name == "" {
 		return fmt.Errorf("nama tensor tidak boleh kosong")
@@ -45,6 +68,211 @@ func (c *Client) CreateTensor(name string, shape []int, dataType string) error {
 	return err
 }
 
+// CreateFilled membuat tensor baru dengan setiap elemen bernilai value dalam satu langkah. Untuk tipe
+// integer value harus bilangan bulat yang muat dalam tipe tersebut; untuk bool hanya 0 atau 1.
+func (c *Client) CreateFilled(name string, shape []int, dataType string, value float64) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	if _, err := tensor.GetElementSize(dataType); err != nil {
+		return fmt.Errorf("tipe data tidak valid '%s': %w", dataType, err)
+	}
+	query := &tensor.Query{
+		Type:          tensor.CreateTensorQuery,
+		TensorNames:   []string{name},
+		Shape:         shape,
+		DataType:      dataType,
+		ScalarOperand: strconv.FormatFloat(value, 'f', -1, 64),
+	}
+	_, err := c.executor.Execute(query)
+	return err
+}
+
+// CreateIdentity membuat matriks identitas n×n bertipe dataType: 1 pada diagonal dan 0 di tempat lain.
+// n harus positif.
+func (c *Client) CreateIdentity(name string, n int, dataType string) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	if n <= 0 {
+		return fmt.Errorf("ukuran matriks identitas harus positif, didapat %d", n)
+	}
+	if _, err := tensor.GetElementSize(dataType); err != nil {
+		return fmt.Errorf("tipe data tidak valid '%s': %w", dataType, err)
+	}
+	query := &tensor.Query{
+		Type:        tensor.CreateTensorQuery,
+		TensorNames: []string{name},
+		Shape:       []int{n, n},
+		DataType:    dataType,
+		Identity:    true,
+	}
+	_, err := c.executor.Execute(query)
+	return err
+}
+
+// CreateRandom membuat tensor berisi nilai pseudo-acak yang dapat direproduksi dari seed: [0, 1) untuk
+// tipe float, [0, 100) untuk tipe integer, dan 0 atau 1 untuk bool. Seed yang sama menghasilkan data yang sama.
+func (c *Client) CreateRandom(name string, shape []int, dataType string, seed int64) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	if _, err := tensor.GetElementSize(dataType); err != nil {
+		return fmt.Errorf("tipe data tidak valid '%s': %w", dataType, err)
+	}
+	query := &tensor.Query{
+		Type:        tensor.CreateTensorQuery,
+		TensorNames: []string{name},
+		Shape:       shape,
+		DataType:    dataType,
+		Random:      true,
+		Seed:        &seed,
+	}
+	_, err := c.executor.Execute(query)
+	return err
+}
+
+// CreateRange membuat tensor 1-D berisi from, from+step, ... yang berhenti sebelum to (seperti arange),
+// dengan ceil((to-from)/step) elemen. step tidak boleh nol dan arahnya harus menuju to; untuk tipe
+// integer ketiga nilai harus bilangan bulat.
+func (c *Client) CreateRange(name string, from, to, step float64, dataType string) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	if _, err := tensor.GetElementSize(dataType); err != nil {
+		return fmt.Errorf("tipe data tidak valid '%s': %w", dataType, err)
+	}
+	query := &tensor.Query{
+		Type:        tensor.CreateTensorQuery,
+		TensorNames: []string{name},
+		DataType:    dataType,
+		RangeFrom:   strconv.FormatFloat(from, 'f', -1, 64),
+		RangeTo:     strconv.FormatFloat(to, 'f', -1, 64),
+		RangeStep:   strconv.FormatFloat(step, 'f', -1, 64),
+	}
+	_, err := c.executor.Execute(query)
+	return err
+}
+
+// DropTensor menghapus tensor beserta file-filenya dari disk dan dari indeks.
+// Menghapus tensor yang tidak ada menghasilkan error.
+func (c *Client) DropTensor(name string) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	query := &tensor.Query{Type: tensor.DropTensorQuery, TensorNames: []string{name}}
+	_, err := c.executor.Execute(query)
+	return err
+}
+
+// RenameTensor mengganti nama tensor oldName menjadi newName. newName tidak boleh sudah ada.
+func (c *Client) RenameTensor(oldName, newName string) error {
+	if oldName == "" || newName == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	query := &tensor.Query{Type: tensor.RenameTensorQuery, TensorNames: []string{oldName, newName}}
+	_, err := c.executor.Execute(query)
+	return err
+}
+
+// CreateFromSelect membuat tensor baru dari hasil SELECT (slice opsional) atas tensor sumber.
+// Shape dan tipe data diturunkan dari sumber; sliceRanges nil berarti seluruh tensor.
+func (c *Client) CreateFromSelect(name string, sourceName string, sliceRanges [][2]int) error {
+	if name == "" || sourceName == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	query := &tensor.Query{
+		Type:        tensor.CreateTensorQuery,
+		TensorNames: []string{name},
+		SourceQuery: &tensor.Query{Type: tensor.SelectTensorQuery, TensorNames: []string{sourceName}, Slices: [][][2]int{sliceRanges}},
+	}
+	_, err := c.executor.Execute(query)
+	return err
+}
+
+// CreateFromData membuat tensor dan mengisinya dengan data dalam satu panggilan.
+// Tipe data diturunkan dari tipe slice ([]float32, []float64, []int8, []int16, []int32, []int64,
+// []uint8, []uint32, []uint64, atau []bool), dan jumlah elemen divalidasi terhadap shape sebelum tensor
+// dibuat. Jika pengisian data gagal, tensor yang baru dibuat dihapus lagi.
+func (c *Client) CreateFromData(name string, shape []int, data interface{}) error {
+	var dataType string
+	var numElements int
+	switch d := data.(type) {
+	case []float32:
+		dataType, numElements = tensor.DataTypeFloat32, len(d)
+	case []float64:
+		dataType, numElements = tensor.DataTypeFloat64, len(d)
+	case []int32:
+		dataType, numElements = tensor.DataTypeInt32, len(d)
+	case []int64:
+		dataType, numElements = tensor.DataTypeInt64, len(d)
+	case []int8:
+		dataType, numElements = tensor.DataTypeInt8, len(d)
+	case []int16:
+		dataType, numElements = tensor.DataTypeInt16, len(d)
+	case []uint8:
+		dataType, numElements = tensor.DataTypeUint8, len(d)
+	case []uint32:
+		dataType, numElements = tensor.DataTypeUint32, len(d)
+	case []uint64:
+		dataType, numElements = tensor.DataTypeUint64, len(d)
+	case []bool:
+		dataType, numElements = tensor.DataTypeBool, len(d)
+	default:
+		return fmt.Errorf("tipe data tidak didukung untuk CreateFromData: %T", data)
+	}
+
+	expectedElements := 1
+	for _, dim := range shape {
+		if dim < 0 {
+			return fmt.Errorf("dimensi shape tidak boleh negatif: %v", shape)
+		}
+		expectedElements *= dim
+	}
+	if numElements != expectedElements {
+		return fmt.Errorf("jumlah data %d tidak sesuai dengan shape %v (%d elemen)", numElements, shape, expectedElements)
+	}
+
+	if err := c.CreateTensor(name, shape, dataType); err != nil {
+		return err
+	}
+	var err error
+	switch d := data.(type) {
+	case []float32:
+		err = c.InsertFloat32Data(name, d)
+	case []float64:
+		err = c.InsertFloat64Data(name, d)
+	case []int32:
+		err = c.InsertInt32Data(name, d)
+	case []int8:
+		err = c.InsertInt8Data(name, d)
+	case []int16:
+		err = c.InsertInt16Data(name, d)
+	case []uint8:
+		err = c.InsertUint8Data(name, d)
+	case []uint32:
+		err = c.InsertUint32Data(name, d)
+	case []uint64:
+		err = c.InsertUint64Data(name, d)
+	case []bool:
+		// Nilai bool disisipkan sebagai literal true/false, sama seperti INSERT ... VALUES pada tensor bool.
+		values := make([]string, len(d))
+		for i, v := range d {
+			values[i] = strconv.FormatBool(v)
+		}
+		_, err = c.executor.Execute(&tensor.Query{Type: tensor.InsertTensorQuery, TensorNames: []string{name}, Data: values})
+	default:
+		err = c.InsertInt64Data(name, d.([]int64))
+	}
+	if err != nil {
+		if dropErr := c.DropTensor(name); dropErr != nil {
+			return fmt.Errorf("gagal mengisi data tensor '%s': %w (tensor juga gagal dihapus: %v)", name, err, dropErr)
+		}
+		return fmt.Errorf("gagal mengisi data tensor '%s': %w", name, err)
+	}
+	return nil
+}
+
 // --- Metode InsertData spesifik tipe (DIMODIFIKASI) ---
 
 func (c *Client) InsertFloat32Data(tensorName string, data []float32) error {
@@ -128,112 +356,696 @@ func (c *Client) InsertInt64Data(tensorName string, data []int64) error {
 	return execErr
 }
 
-// --- Akhir metode InsertData spesifik tipe ---
-
-func (c *Client) SelectData(tensorName string, sliceRanges [][2]int) (interface{}, error) {
+func (c *Client) InsertInt8Data(tensorName string, data []int8) error {
 	if tensorName == "" {
-		return nil, fmt.Errorf("nama tensor tidak boleh kosong")
+		return fmt.Errorf("nama tensor tidak boleh kosong")
 	}
-	query := &tensor.Query{Type: tensor.SelectTensorQuery, TensorNames: []string{tensorName}, Slices: [][][2]int{sliceRanges}}
-	return c.executor.Execute(query)
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.LittleEndian, data)
+	if err != nil {
+		return fmt.Errorf("gagal serialisasi data int8 ke bytes: %w", err)
+	}
+	query := &tensor.Query{
+		Type:        tensor.InsertTensorQuery,
+		TensorNames: []string{tensorName},
+		RawData:     buf.Bytes(),
+		Data:        nil,
+	}
+	_, execErr := c.executor.Execute(query)
+	return execErr
 }
 
-func (c *Client) GetData(tensorNames []string, slices [][][2]int, batchSize int) (interface{}, error) {
-	if len(tensorNames) == 0 {
-		return nil, fmt.Errorf("setidaknya satu nama tensor harus disediakan")
+func (c *Client) InsertInt16Data(tensorName string, data []int16) error {
+	if tensorName == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
 	}
-	if slices != nil && len(slices) != len(tensorNames) {
-		return nil, fmt.Errorf("jumlah definisi slice (%d) harus cocok dengan jumlah nama tensor (%d) atau nil", len(slices), len(tensorNames))
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.LittleEndian, data)
+	if err != nil {
+		return fmt.Errorf("gagal serialisasi data int16 ke bytes: %w", err)
 	}
-	querySlices := slices
-	if slices == nil && len(tensorNames) > 0 {
-		querySlices = make([][][2]int, len(tensorNames))
+	query := &tensor.Query{
+		Type:        tensor.InsertTensorQuery,
+		TensorNames: []string{tensorName},
+		RawData:     buf.Bytes(),
+		Data:        nil,
 	}
-	query := &tensor.Query{Type: tensor.GetDataTensorQuery, TensorNames: tensorNames, Slices: querySlices, BatchSize: batchSize}
-	return c.executor.Execute(query)
+	_, execErr := c.executor.Execute(query)
+	return execErr
 }
 
-func (c *Client) GetTensorMetadata(tensorName string) (*tensor.TensorMetadata, error) {
+func (c *Client) InsertUint8Data(tensorName string, data []uint8) error {
 	if tensorName == "" {
-		return nil, fmt.Errorf("nama tensor tidak boleh kosong")
-	}
-	metadata, _, cleanupFunc, err := c.GetTensorMmap(tensorName)
-	if cleanupFunc != nil {
-		defer cleanupFunc()
+		return fmt.Errorf("nama tensor tidak boleh kosong")
 	}
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.LittleEndian, data)
 	if err != nil {
-		return nil, fmt.Errorf("gagal mendapatkan metadata untuk tensor '%s': %w", tensorName, err)
+		return fmt.Errorf("gagal serialisasi data uint8 ke bytes: %w", err)
 	}
-	if metadata != nil {
-		return metadata, nil
+	query := &tensor.Query{
+		Type:        tensor.InsertTensorQuery,
+		TensorNames: []string{tensorName},
+		RawData:     buf.Bytes(),
+		Data:        nil,
 	}
-	return nil, fmt.Errorf("GetTensorMmap berhasil tetapi tidak mengembalikan metadata untuk '%s'", tensorName)
+	_, execErr := c.executor.Execute(query)
+	return execErr
 }
 
-func (c *Client) GetTensorMmap(tensorName string) (*tensor.TensorMetadata, mmap.MMap, func() error, error) {
+func (c *Client) InsertUint32Data(tensorName string, data []uint32) error {
 	if tensorName == "" {
-		return nil, nil, nil, fmt.Errorf("nama tensor tidak boleh kosong")
+		return fmt.Errorf("nama tensor tidak boleh kosong")
 	}
-	metadata, _, mmapInstance, cleanupFunc, err := c.executor.GetTensorMmap(tensorName)
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.LittleEndian, data)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("client.GetTensorMmap: gagal mendapatkan mmap dari executor untuk tensor '%s': %w", tensorName, err)
+		return fmt.Errorf("gagal serialisasi data uint32 ke bytes: %w", err)
 	}
-	return metadata, mmapInstance, cleanupFunc, nil
+	query := &tensor.Query{
+		Type:        tensor.InsertTensorQuery,
+		TensorNames: []string{tensorName},
+		RawData:     buf.Bytes(),
+		Data:        nil,
+	}
+	_, execErr := c.executor.Execute(query)
+	return execErr
 }
 
-func calculateTotalElementsFromShape(shape []int) int {
-	if len(shape) == 0 {
-		return 1
+func (c *Client) InsertUint64Data(tensorName string, data []uint64) error {
+	if tensorName == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
 	}
-	totalElements := 1
-	isZeroDim := false
-	for _, dim := range shape {
-		if dim == 0 {
-			isZeroDim = true
-			break
-		}
-		totalElements *= dim
+	buf := new(bytes.Buffer)
+	err := binary.Write(buf, binary.LittleEndian, data)
+	if err != nil {
+		return fmt.Errorf("gagal serialisasi data uint64 ke bytes: %w", err)
 	}
-	if isZeroDim {
-		return 0
+	query := &tensor.Query{
+		Type:        tensor.InsertTensorQuery,
+		TensorNames: []string{tensorName},
+		RawData:     buf.Bytes(),
+		Data:        nil,
 	}
-	return totalElements
+	_, execErr := c.executor.Execute(query)
+	return execErr
 }
 
-func readDataFromMmapInternal[T tensor.Numeric](metadata *tensor.TensorMetadata, mmapInst mmap.MMap, useUnsafe bool, targetDataTypeStr string) ([]T, error) {
-	if metadata == nil {
-		return nil, errors.New("metadata tidak boleh nil")
+// --- Akhir metode InsertData spesifik tipe ---
+
+// UpdateElement mengubah satu elemen tensor pada koordinat coords tanpa menulis ulang elemen lain.
+// Untuk tensor integer, value harus bilangan bulat dalam jangkauan tipe datanya.
+func (c *Client) UpdateElement(name string, coords []int, value float64) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	query := &tensor.Query{
+		Type:          tensor.UpdateElementQuery,
+		TensorNames:   []string{name},
+		Coordinate:    coords,
+		ScalarOperand: strconv.FormatFloat(value, 'f', -1, 64),
 	}
+	_, err := c.executor.Execute(query)
+	return err
+}
 
-	typeStrT, err := tensor.GetDataTypeString[T]()
+func (c *Client) SelectData(tensorName string, sliceRanges [][2]int) (interface{}, error) {
+	if tensorName == "" {
+		return nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	query := &tensor.Query{Type: tensor.SelectTensorQuery, TensorNames: []string{tensorName}, Slices: [][][2]int{sliceRanges}}
+	return c.executor.Execute(query)
+}
+
+// SelectMatrix64 mengembalikan tensor 2-D float64 sebagai [][]float64 bertipe (baris x kolom),
+// bukan []interface{} bersarang seperti SelectData. Tensor harus 2-D dan bertipe float64.
+func (c *Client) SelectMatrix64(tensorName string) ([][]float64, error) {
+	loaded, err := c.LoadTensorFloat64(tensorName)
 	if err != nil {
-		return nil, fmt.Errorf("tipe generik T tidak valid: %w", err)
+		return nil, err
 	}
-	if metadata.DataType != typeStrT {
-		return nil, fmt.Errorf("tipe data metadata ('%s') tidak cocok dengan tipe generik T yang diminta ('%s')", metadata.DataType, typeStrT)
+	if len(loaded.Shape) != 2 {
+		return nil, fmt.Errorf("tensor '%s' bukan matriks 2-D (shape %v)", tensorName, loaded.Shape)
 	}
-	if metadata.DataType != targetDataTypeStr {
-		return nil, fmt.Errorf("tipe data metadata ('%s') tidak cocok dengan tipe target metode ('%s')", metadata.DataType, targetDataTypeStr)
+	rows, cols := loaded.Shape[0], loaded.Shape[1]
+	matrix := make([][]float64, rows)
+	for r := range matrix {
+		// Kapasitas dibatasi agar append pada satu baris tidak menimpa baris berikutnya.
+		matrix[r] = loaded.Data[r*cols : (r+1)*cols : (r+1)*cols]
 	}
+	return matrix, nil
+}
 
-	numElements := calculateTotalElementsFromShape(metadata.Shape)
+// GetData mengambil data beberapa tensor (dimuat secara paralel) untuk inferensi. Untuk lebih dari satu
+// tensor, hasilnya [][]tensor.TensorDataResult yang selalu berurutan sesuai tensorNames; error dari beberapa
+// tensor juga digabung dalam urutan tersebut.
+func (c *Client) GetData(tensorNames []string, slices [][][2]int, batchSize int) (interface{}, error) {
+	if len(tensorNames) == 0 {
+		return nil, fmt.Errorf("setidaknya satu nama tensor harus disediakan")
+	}
+	if slices != nil && len(slices) != len(tensorNames) {
+		return nil, fmt.Errorf("jumlah definisi slice (%d) harus cocok dengan jumlah nama tensor (%d) atau nil", len(slices), len(tensorNames))
+	}
+	querySlices := slices
+	if slices == nil && len(tensorNames) > 0 {
+		querySlices = make([][][2]int, len(tensorNames))
+	}
+	query := &tensor.Query{Type: tensor.GetDataTensorQuery, TensorNames: tensorNames, Slices: querySlices, BatchSize: batchSize}
+	return c.executor.Execute(query)
+}
 
-	if mmapInst == nil {
-		if numElements == 0 {
-			return make([]T, 0), nil
+// StreamBatches adalah versi streaming GetData untuk satu tensor: alih-alih membuat semua batch sekaligus,
+// fungsi next yang dikembalikan membaca satu batch per panggilan langsung dari mmap, hanya bagian slice
+// yang dibutuhkan batch tersebut. Pembagian batch sama dengan GetDataForInference (batchSize elemen
+// berurutan row-major dari slice; batchSize <= 0 menghasilkan satu batch berisi seluruh slice dengan
+// BatchInfo nil), dan slice nil berarti seluruh tensor. Setelah batch terakhir next mengembalikan
+// (nil, false, nil).
+//
+// Data dibaca dari mmap read-only privat (OpenTensorView), sehingga kueri lain atas tensor yang sama
+// dapat berjalan selama iterasi. Mmap dilepas otomatis saat iterator habis atau next mengembalikan
+// error; pemanggil yang berhenti lebih awal wajib memanggil fungsi close yang dikembalikan (aman
+// dipanggil lebih dari sekali, juga setelah iterator habis). Setelah close, next mengembalikan error.
+// Penulisan di tempat pada tensor terlihat di batch berikutnya, jadi tensor tidak boleh diubah selama
+// iterasi.
+func (c *Client) StreamBatches(tensorName string, slice [][2]int, batchSize int) (func() (*tensor.TensorDataResult, bool, error), func() error, error) {
+	if tensorName == "" {
+		return nil, nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	metadata, mmapInst, err := c.executor.OpenTensorView(tensorName)
+	if err != nil {
+		return nil, nil, err
+	}
+	released := false
+	release := func() error {
+		if released {
+			return nil
 		}
-		return nil, errors.New("mmapInst tidak boleh nil untuk tensor yang tidak kosong")
+		released = true
+		if mmapInst == nil {
+			return nil
+		}
+		return mmapInst.Unmap()
+	}
+	isReleased := func() bool { return released }
+	var next func() (*tensor.TensorDataResult, bool, error)
+	switch metadata.DataType {
+	case tensor.DataTypeFloat32:
+		next, err = streamBatchesTyped[float32](metadata, mmapInst, release, isReleased, slice, batchSize)
+	case tensor.DataTypeFloat64:
+		next, err = streamBatchesTyped[float64](metadata, mmapInst, release, isReleased, slice, batchSize)
+	case tensor.DataTypeInt32:
+		next, err = streamBatchesTyped[int32](metadata, mmapInst, release, isReleased, slice, batchSize)
+	case tensor.DataTypeInt64:
+		next, err = streamBatchesTyped[int64](metadata, mmapInst, release, isReleased, slice, batchSize)
+	case tensor.DataTypeInt8:
+		next, err = streamBatchesTyped[int8](metadata, mmapInst, release, isReleased, slice, batchSize)
+	case tensor.DataTypeInt16:
+		next, err = streamBatchesTyped[int16](metadata, mmapInst, release, isReleased, slice, batchSize)
+	case tensor.DataTypeUint8, tensor.DataTypeBool:
+		next, err = streamBatchesTyped[uint8](metadata, mmapInst, release, isReleased, slice, batchSize)
+	case tensor.DataTypeUint32:
+		next, err = streamBatchesTyped[uint32](metadata, mmapInst, release, isReleased, slice, batchSize)
+	case tensor.DataTypeUint64:
+		next, err = streamBatchesTyped[uint64](metadata, mmapInst, release, isReleased, slice, batchSize)
+	default:
+		err = fmt.Errorf("tipe data '%s' pada tensor '%s' tidak didukung untuk streaming batch", metadata.DataType, tensorName)
 	}
-
-	if numElements == 0 {
-		return make([]T, 0), nil
+	if err != nil {
+		release()
+		return nil, nil, err
 	}
+	return next, release, nil
+}
 
+func streamBatchesTyped[T tensor.Numeric](metadata *tensor.TensorMetadata, mmapInst mmap.MMap, release func() error, isReleased func() bool, slice [][2]int, batchSize int) (func() (*tensor.TensorDataResult, bool, error), error) {
 	elementSize, err := tensor.GetElementSize(metadata.DataType)
 	if err != nil {
-		return nil, fmt.Errorf("gagal mendapatkan ukuran elemen untuk tipe %s: %w", metadata.DataType, err)
+		return nil, err
 	}
-	expectedBytes := numElements * elementSize
+	// Tanpa slice, seluruh tensor dipilih; tensor skalar diperlakukan sebagai satu elemen.
+	ranges := slice
+	shape, strides := metadata.Shape, metadata.Strides
+	if len(ranges) == 0 {
+		ranges = make([][2]int, len(metadata.Shape))
+		for i, dim := range metadata.Shape {
+			ranges[i] = [2]int{0, dim}
+		}
+	} else {
+		if len(ranges) != len(metadata.Shape) {
+			return nil, fmt.Errorf("jumlah dimensi slice (%d) tidak cocok dengan jumlah dimensi tensor '%s' (%d)", len(ranges), metadata.Name, len(metadata.Shape))
+		}
+		shape = make([]int, len(ranges))
+		for i, r := range ranges {
+			if r[0] < 0 || r[1] > metadata.Shape[i] || r[0] > r[1] {
+				return nil, fmt.Errorf("rentang slice [%d:%d] tidak valid untuk dimensi %d tensor '%s' berukuran %d", r[0], r[1], i, metadata.Name, metadata.Shape[i])
+			}
+			shape[i] = r[1] - r[0]
+		}
+		strides = make([]int, len(shape))
+		if calculateTotalElementsFromShape(shape) > 0 {
+			strides[len(shape)-1] = 1
+			for i := len(shape) - 2; i >= 0; i-- {
+				strides[i] = strides[i+1] * shape[i+1]
+			}
+		}
+	}
+	totalElements := calculateTotalElementsFromShape(shape)
+
+	numBatches := 1
+	var batchInfo func(i int) *tensor.BatchInfo
+	if batchSize > 0 && totalElements > 0 {
+		numBatches = (totalElements + batchSize - 1) / batchSize
+		batchInfo = func(i int) *tensor.BatchInfo {
+			return &tensor.BatchInfo{BatchSize: batchSize, NumBatches: numBatches, CurrentBatchIndex: i}
+		}
+	} else {
+		batchSize = totalElements
+	}
+
+	// position adalah indeks multidimensi (dalam koordinat tensor) dari elemen slice berikutnya.
+	position := make([]int, len(ranges))
+	for i, r := range ranges {
+		position[i] = r[0]
+	}
+	nextBatch := 0
+	return func() (*tensor.TensorDataResult, bool, error) {
+		if nextBatch >= numBatches {
+			return nil, false, release()
+		}
+		if isReleased() {
+			return nil, false, fmt.Errorf("stream batch tensor '%s' sudah ditutup", metadata.Name)
+		}
+		start := nextBatch * batchSize
+		count := totalElements - start
+		if count > batchSize {
+			count = batchSize
+		}
+		data := make([]T, count)
+		for filled := 0; filled < count; {
+			// Salin satu run kontigu di sepanjang dimensi terakhir, dibatasi sisa batch.
+			offset := 0
+			for dim, idx := range position {
+				offset += idx * metadata.Strides[dim]
+			}
+			run := 1
+			if last := len(position) - 1; last >= 0 {
+				run = ranges[last][1] - position[last]
+			}
+			if run > count-filled {
+				run = count - filled
+			}
+			src := mmapInst[offset*elementSize : (offset+run)*elementSize]
+			if err := binary.Read(bytes.NewReader(src), binary.LittleEndian, data[filled:filled+run]); err != nil {
+				release()
+				return nil, false, fmt.Errorf("gagal membaca batch %d tensor '%s': %w", nextBatch, metadata.Name, err)
+			}
+			filled += run
+			for dim := len(position) - 1; dim >= 0; dim-- {
+				if dim == len(position)-1 {
+					position[dim] += run
+				} else {
+					position[dim]++
+				}
+				if position[dim] < ranges[dim][1] {
+					break
+				}
+				position[dim] = ranges[dim][0]
+			}
+		}
+		var info *tensor.BatchInfo
+		if batchInfo != nil {
+			info = batchInfo(nextBatch)
+		}
+		nextBatch++
+		return &tensor.TensorDataResult{
+			Name: metadata.Name, Shape: shape, NumDimensions: len(shape), DataType: metadata.DataType,
+			TotalElements: totalElements, DataSizeBytes: count * elementSize, Strides: strides,
+			BatchInfo: info, Data: data,
+		}, true, nil
+	}, nil
+}
+
+// ReassembleFloat32 merakit ulang tensor dari batch hasil GET DATA atau StreamBatches untuk satu tensor
+// (atau satu slice-nya): Data setiap batch digabung dalam urutan BatchInfo.CurrentBatchIndex (urutan
+// masukan boleh acak), jumlah elemennya harus sama dengan TotalElements, dan tensor hasil memakai Shape
+// serta Strides dari batch. Batch tanpa BatchInfo (GET DATA tanpa batching) hanya boleh satu. Fungsi
+// ReassembleXxx lainnya sama untuk tipe data masing-masing. Tipe data batch harus sama persis dengan
+// tipe yang diminta, jadi batch tensor bool ditolak oleh ReassembleUint8 (gunakan ReassembleBool).
+func ReassembleFloat32(batches []tensor.TensorDataResult) (*tensor.Tensor[float32], error) {
+	return reassembleTyped[float32](batches, tensor.DataTypeFloat32)
+}
+
+func ReassembleFloat64(batches []tensor.TensorDataResult) (*tensor.Tensor[float64], error) {
+	return reassembleTyped[float64](batches, tensor.DataTypeFloat64)
+}
+
+func ReassembleInt32(batches []tensor.TensorDataResult) (*tensor.Tensor[int32], error) {
+	return reassembleTyped[int32](batches, tensor.DataTypeInt32)
+}
+
+func ReassembleInt64(batches []tensor.TensorDataResult) (*tensor.Tensor[int64], error) {
+	return reassembleTyped[int64](batches, tensor.DataTypeInt64)
+}
+
+func ReassembleInt8(batches []tensor.TensorDataResult) (*tensor.Tensor[int8], error) {
+	return reassembleTyped[int8](batches, tensor.DataTypeInt8)
+}
+
+func ReassembleInt16(batches []tensor.TensorDataResult) (*tensor.Tensor[int16], error) {
+	return reassembleTyped[int16](batches, tensor.DataTypeInt16)
+}
+
+func ReassembleUint8(batches []tensor.TensorDataResult) (*tensor.Tensor[uint8], error) {
+	return reassembleTyped[uint8](batches, tensor.DataTypeUint8)
+}
+
+func ReassembleUint32(batches []tensor.TensorDataResult) (*tensor.Tensor[uint32], error) {
+	return reassembleTyped[uint32](batches, tensor.DataTypeUint32)
+}
+
+func ReassembleUint64(batches []tensor.TensorDataResult) (*tensor.Tensor[uint64], error) {
+	return reassembleTyped[uint64](batches, tensor.DataTypeUint64)
+}
+
+// ReassembleBool merakit ulang batch tensor bool. Seperti LoadTensorAny dan StreamBatches, data bool
+// disimpan sebagai satu byte per elemen, jadi hasilnya *tensor.Tensor[uint8] dengan DataType bool dan
+// setiap elemen harus 0 atau 1.
+func ReassembleBool(batches []tensor.TensorDataResult) (*tensor.Tensor[uint8], error) {
+	result, err := reassembleTyped[uint8](batches, tensor.DataTypeBool)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range result.Data {
+		if v > 1 {
+			return nil, fmt.Errorf("elemen %d batch tensor bool '%s' bernilai %d, bukan 0 atau 1", i, result.Name, v)
+		}
+	}
+	return result, nil
+}
+
+func reassembleTyped[T tensor.Numeric](batches []tensor.TensorDataResult, dataType string) (*tensor.Tensor[T], error) {
+	if len(batches) == 0 {
+		return nil, fmt.Errorf("tidak ada batch untuk dirakit ulang")
+	}
+	first := batches[0]
+	if first.DataType != dataType {
+		return nil, fmt.Errorf("tipe data batch tensor '%s' ('%s') tidak cocok dengan tipe yang diminta ('%s')", first.Name, first.DataType, dataType)
+	}
+	ordered := make([]*tensor.TensorDataResult, len(batches))
+	for i := range batches {
+		b := &batches[i]
+		if b.Name != first.Name || b.DataType != first.DataType || b.TotalElements != first.TotalElements {
+			return nil, fmt.Errorf("batch %d berasal dari tensor atau slice yang berbeda ('%s', %d elemen) dari batch pertama ('%s', %d elemen)", i, b.Name, b.TotalElements, first.Name, first.TotalElements)
+		}
+		index := 0
+		if b.BatchInfo != nil {
+			if b.BatchInfo.NumBatches != len(batches) {
+				return nil, fmt.Errorf("batch tensor '%s' berjumlah %d, tetapi BatchInfo menyebut %d batch", first.Name, len(batches), b.BatchInfo.NumBatches)
+			}
+			index = b.BatchInfo.CurrentBatchIndex
+		} else if len(batches) != 1 {
+			return nil, fmt.Errorf("batch %d tensor '%s' tidak memiliki BatchInfo", i, first.Name)
+		}
+		if index < 0 || index >= len(batches) || ordered[index] != nil {
+			return nil, fmt.Errorf("indeks batch %d tensor '%s' tidak valid atau duplikat", index, first.Name)
+		}
+		ordered[index] = b
+	}
+
+	data := make([]T, 0, first.TotalElements)
+	for i, b := range ordered {
+		part, ok := b.Data.([]T)
+		if !ok {
+			return nil, fmt.Errorf("gagal mengonversi data batch %d tensor '%s' ke %T, data aktual adalah %T", i, first.Name, part, b.Data)
+		}
+		data = append(data, part...)
+	}
+	if len(data) != first.TotalElements {
+		return nil, fmt.Errorf("jumlah elemen batch tensor '%s' (%d) tidak cocok dengan TotalElements (%d)", first.Name, len(data), first.TotalElements)
+	}
+	result, err := tensor.NewTensor[T](first.Name, first.Shape, first.DataType)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.SetData(data); err != nil {
+		return nil, fmt.Errorf("gagal mengatur data untuk tensor '%s': %w", first.Name, err)
+	}
+	result.Strides = first.Strides
+	return result, nil
+}
+
+// GetTensorMetadata membaca metadata tensor tanpa membuka file datanya, sehingga handle file/mmap yang
+// di-cache executor untuk tensor tersebut tetap dapat dipakai ulang oleh pemuatan berikutnya.
+func (c *Client) GetTensorMetadata(tensorName string) (*tensor.TensorMetadata, error) {
+	if tensorName == "" {
+		return nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	metadata, err := c.executor.LoadTensorMetadata(tensorName)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendapatkan metadata untuk tensor '%s': %w", tensorName, err)
+	}
+	return metadata, nil
+}
+
+// GetTensorMetadataBatch mengambil metadata beberapa tensor tanpa membuka file data seperti
+// GetTensorMetadata. Metadata dilayani dari indeks dalam memori; hanya nama yang tidak terindeks yang
+// dimuat dari file .meta, secara paralel dengan paling banyak GOMAXPROCS pekerja. Metadata yang berhasil
+// dimuat dan error per nama dikembalikan dalam dua map terpisah; setiap nama unik muncul di tepat
+// salah satunya.
+func (c *Client) GetTensorMetadataBatch(names []string) (map[string]*tensor.TensorMetadata, map[string]error) {
+	found := make(map[string]*tensor.TensorMetadata)
+	failed := make(map[string]error)
+	var misses []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if name == "" {
+			failed[name] = fmt.Errorf("nama tensor tidak boleh kosong")
+		} else if metadata, ok := c.executor.IndexedTensorMetadata(name); ok {
+			found[name] = metadata
+		} else {
+			misses = append(misses, name)
+		}
+	}
+	if len(misses) == 0 {
+		return found, failed
+	}
+
+	// Setiap pekerja hanya menulis ke slot indeks nama yang diambilnya, sehingga tidak perlu mutex.
+	metas := make([]*tensor.TensorMetadata, len(misses))
+	errs := make([]error, len(misses))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(misses)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				metadata, err := c.executor.LoadTensorMetadata(misses[i])
+				if err != nil {
+					errs[i] = fmt.Errorf("gagal memuat metadata untuk tensor '%s': %w", misses[i], err)
+					continue
+				}
+				metas[i] = metadata
+			}
+		}()
+	}
+	for i := range misses {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, name := range misses {
+		if errs[i] != nil {
+			failed[name] = errs[i]
+		} else {
+			found[name] = metas[i]
+		}
+	}
+	return found, failed
+}
+
+func (c *Client) GetTensorMmap(tensorName string) (*tensor.TensorMetadata, mmap.MMap, func() error, error) {
+	if tensorName == "" {
+		return nil, nil, nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	metadata, _, mmapInstance, cleanupFunc, err := c.executor.GetTensorMmap(tensorName)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("client.GetTensorMmap: gagal mendapatkan mmap dari executor untuk tensor '%s': %w", tensorName, err)
+	}
+	return metadata, mmapInstance, cleanupFunc, nil
+}
+
+// ReadOnlyView adalah tampilan read-only tanpa salinan atas data tensor di disk, dibuat dengan SelectView.
+// Nilai yang dikembalikan accessor dibaca langsung dari mmap:
+//   - View tidak valid setelah Close; slice dari Float32Slice tidak boleh dipakai lagi karena memorinya
+//     sudah dilepas (akses setelahnya dapat membuat proses crash).
+//   - View tidak boleh hidup melewati penulisan ke tensornya. Penulisan di tempat (UPDATE, operasi
+//     IN PLACE, INSERT ke slice) langsung terlihat melalui view, sedangkan INSERT penuh, APPEND, dan
+//     RENAME mengganti file sehingga view tetap menampilkan data lama. Tutup view sebelum menulis.
+//   - Menulis ke slice dari Float32Slice menyebabkan fault karena mmap dipetakan read-only.
+//
+// Metode ReadOnlyView tidak aman dipanggil bersamaan dengan Close dari goroutine lain.
+type ReadOnlyView struct {
+	metadata    *tensor.TensorMetadata
+	mmap        mmap.MMap
+	elementSize int
+	closed      bool
+}
+
+// SelectView membuka tensor sebagai ReadOnlyView tanpa menyalin datanya seperti LoadTensor.
+// Pemanggil wajib memanggil Close setelah selesai membaca.
+func (c *Client) SelectView(name string) (*ReadOnlyView, error) {
+	if name == "" {
+		return nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	metadata, mmapInst, err := c.executor.OpenTensorView(name)
+	if err != nil {
+		return nil, err
+	}
+	elementSize, err := tensor.GetElementSize(metadata.DataType)
+	if err != nil {
+		if mmapInst != nil {
+			mmapInst.Unmap()
+		}
+		return nil, err
+	}
+	return &ReadOnlyView{metadata: metadata, mmap: mmapInst, elementSize: elementSize}, nil
+}
+
+// Metadata mengembalikan metadata tensor saat view dibuka.
+func (v *ReadOnlyView) Metadata() *tensor.TensorMetadata {
+	return v.metadata
+}
+
+// At mengembalikan elemen pada koordinat coord (satu indeks per dimensi; kosong untuk skalar) sebagai
+// float64, apa pun tipe data tensornya. Tensor bool menghasilkan 0 atau 1.
+func (v *ReadOnlyView) At(coord []int) (float64, error) {
+	if v.closed {
+		return 0, fmt.Errorf("view tensor '%s' sudah ditutup", v.metadata.Name)
+	}
+	shape := v.metadata.Shape
+	if len(coord) != len(shape) {
+		return 0, fmt.Errorf("koordinat %v memiliki %d dimensi, tensor '%s' memiliki %d", coord, len(coord), v.metadata.Name, len(shape))
+	}
+	offset := 0
+	for i, idx := range coord {
+		if idx < 0 || idx >= shape[i] {
+			return 0, fmt.Errorf("koordinat %v di luar batas shape %v tensor '%s'", coord, shape, v.metadata.Name)
+		}
+		offset += idx * v.metadata.Strides[i]
+	}
+	b := v.mmap[offset*v.elementSize : (offset+1)*v.elementSize]
+	switch v.metadata.DataType {
+	case tensor.DataTypeFloat32:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), nil
+	case tensor.DataTypeFloat64:
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case tensor.DataTypeInt32:
+		return float64(int32(binary.LittleEndian.Uint32(b))), nil
+	case tensor.DataTypeInt64:
+		return float64(int64(binary.LittleEndian.Uint64(b))), nil
+	case tensor.DataTypeInt8:
+		return float64(int8(b[0])), nil
+	case tensor.DataTypeInt16:
+		return float64(int16(binary.LittleEndian.Uint16(b))), nil
+	case tensor.DataTypeUint8, tensor.DataTypeBool:
+		return float64(b[0]), nil
+	case tensor.DataTypeUint32:
+		return float64(binary.LittleEndian.Uint32(b)), nil
+	case tensor.DataTypeUint64:
+		return float64(binary.LittleEndian.Uint64(b)), nil
+	default:
+		return 0, fmt.Errorf("tipe data '%s' tidak didukung oleh view", v.metadata.DataType)
+	}
+}
+
+// Float32Slice mengembalikan seluruh data tensor float32 sebagai slice yang menunjuk langsung ke mmap
+// (tanpa salinan, mengasumsikan host little-endian). Slice hanya valid sampai Close.
+func (v *ReadOnlyView) Float32Slice() ([]float32, error) {
+	if v.closed {
+		return nil, fmt.Errorf("view tensor '%s' sudah ditutup", v.metadata.Name)
+	}
+	if v.metadata.DataType != tensor.DataTypeFloat32 {
+		return nil, fmt.Errorf("tipe data tensor aktual ('%s') tidak cocok dengan tipe yang diminta ('%s') untuk tensor '%s'", v.metadata.DataType, tensor.DataTypeFloat32, v.metadata.Name)
+	}
+	if len(v.mmap) == 0 {
+		return []float32{}, nil
+	}
+	return unsafe.Slice((*float32)(unsafe.Pointer(&v.mmap[0])), len(v.mmap)/v.elementSize), nil
+}
+
+// Close melepas mmap view. Memanggil Close lebih dari sekali aman.
+func (v *ReadOnlyView) Close() error {
+	if v.closed {
+		return nil
+	}
+	v.closed = true
+	if v.mmap == nil {
+		return nil
+	}
+	err := v.mmap.Unmap()
+	v.mmap = nil
+	return err
+}
+
+func calculateTotalElementsFromShape(shape []int) int {
+	if len(shape) == 0 {
+		return 1
+	}
+	totalElements := 1
+	isZeroDim := false
+	for _, dim := range shape {
+		if dim == 0 {
+			isZeroDim = true
+			break
+		}
+		totalElements *= dim
+	}
+	if isZeroDim {
+		return 0
+	}
+	return totalElements
+}
+
+func readDataFromMmapInternal[T tensor.Numeric](metadata *tensor.TensorMetadata, mmapInst mmap.MMap, useUnsafe bool, targetDataTypeStr string) ([]T, error) {
+	if metadata == nil {
+		return nil, errors.New("metadata tidak boleh nil")
+	}
+
+	typeStrT, err := tensor.GetDataTypeString[T]()
+	if err != nil {
+		return nil, fmt.Errorf("tipe generik T tidak valid: %w", err)
+	}
+	if metadata.DataType != typeStrT {
+		return nil, fmt.Errorf("tipe data metadata ('%s') tidak cocok dengan tipe generik T yang diminta ('%s')", metadata.DataType, typeStrT)
+	}
+	if metadata.DataType != targetDataTypeStr {
+		return nil, fmt.Errorf("tipe data metadata ('%s') tidak cocok dengan tipe target metode ('%s')", metadata.DataType, targetDataTypeStr)
+	}
+
+	numElements := calculateTotalElementsFromShape(metadata.Shape)
+
+	if mmapInst == nil {
+		if numElements == 0 {
+			return make([]T, 0), nil
+		}
+		return nil, errors.New("mmapInst tidak boleh nil untuk tensor yang tidak kosong")
+	}
+
+	if numElements == 0 {
+		return make([]T, 0), nil
+	}
+
+	elementSize, err := tensor.GetElementSize(metadata.DataType)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mendapatkan ukuran elemen untuk tipe %s: %w", metadata.DataType, err)
+	}
+	expectedBytes := numElements * elementSize
 
 	if len(mmapInst) < expectedBytes {
 		return nil, fmt.Errorf("ukuran mmap (%d bytes) lebih kecil dari ukuran data yang diharapkan (%d bytes) untuk %d elemen tipe %s", len(mmapInst), expectedBytes, numElements, metadata.DataType)
@@ -278,6 +1090,136 @@ func (c *Client) ReadInt32DataFromMmap(metadata *tensor.TensorMetadata, mmapInst
 func (c *Client) ReadInt64DataFromMmap(metadata *tensor.TensorMetadata, mmapInst mmap.MMap, useUnsafe bool) ([]int64, error) {
 	return readDataFromMmapInternal[int64](metadata, mmapInst, useUnsafe, tensor.DataTypeInt64)
 }
+func (c *Client) ReadInt8DataFromMmap(metadata *tensor.TensorMetadata, mmapInst mmap.MMap, useUnsafe bool) ([]int8, error) {
+	return readDataFromMmapInternal[int8](metadata, mmapInst, useUnsafe, tensor.DataTypeInt8)
+}
+func (c *Client) ReadInt16DataFromMmap(metadata *tensor.TensorMetadata, mmapInst mmap.MMap, useUnsafe bool) ([]int16, error) {
+	return readDataFromMmapInternal[int16](metadata, mmapInst, useUnsafe, tensor.DataTypeInt16)
+}
+func (c *Client) ReadUint8DataFromMmap(metadata *tensor.TensorMetadata, mmapInst mmap.MMap, useUnsafe bool) ([]uint8, error) {
+	return readDataFromMmapInternal[uint8](metadata, mmapInst, useUnsafe, tensor.DataTypeUint8)
+}
+func (c *Client) ReadUint32DataFromMmap(metadata *tensor.TensorMetadata, mmapInst mmap.MMap, useUnsafe bool) ([]uint32, error) {
+	return readDataFromMmapInternal[uint32](metadata, mmapInst, useUnsafe, tensor.DataTypeUint32)
+}
+func (c *Client) ReadUint64DataFromMmap(metadata *tensor.TensorMetadata, mmapInst mmap.MMap, useUnsafe bool) ([]uint64, error) {
+	return readDataFromMmapInternal[uint64](metadata, mmapInst, useUnsafe, tensor.DataTypeUint64)
+}
+
+// RowIteratorFloat32 mengembalikan fungsi next yang membaca tensor 2-D float32 satu baris per panggilan
+// langsung dari mmap, sehingga memori yang dipakai dibatasi satu baris, beserta fungsi close untuk
+// melepas mmap jika iterasi dihentikan lebih awal. Lihat rowIterator.
+func (c *Client) RowIteratorFloat32(tensorName string) (func() ([]float32, bool, error), func() error, error) {
+	return rowIterator[float32](c, tensorName, tensor.DataTypeFloat32)
+}
+
+func (c *Client) RowIteratorFloat64(tensorName string) (func() ([]float64, bool, error), func() error, error) {
+	return rowIterator[float64](c, tensorName, tensor.DataTypeFloat64)
+}
+
+func (c *Client) RowIteratorInt32(tensorName string) (func() ([]int32, bool, error), func() error, error) {
+	return rowIterator[int32](c, tensorName, tensor.DataTypeInt32)
+}
+
+func (c *Client) RowIteratorInt64(tensorName string) (func() ([]int64, bool, error), func() error, error) {
+	return rowIterator[int64](c, tensorName, tensor.DataTypeInt64)
+}
+
+func (c *Client) RowIteratorInt8(tensorName string) (func() ([]int8, bool, error), func() error, error) {
+	return rowIterator[int8](c, tensorName, tensor.DataTypeInt8)
+}
+
+func (c *Client) RowIteratorInt16(tensorName string) (func() ([]int16, bool, error), func() error, error) {
+	return rowIterator[int16](c, tensorName, tensor.DataTypeInt16)
+}
+
+func (c *Client) RowIteratorUint8(tensorName string) (func() ([]uint8, bool, error), func() error, error) {
+	return rowIterator[uint8](c, tensorName, tensor.DataTypeUint8)
+}
+
+func (c *Client) RowIteratorUint32(tensorName string) (func() ([]uint32, bool, error), func() error, error) {
+	return rowIterator[uint32](c, tensorName, tensor.DataTypeUint32)
+}
+
+func (c *Client) RowIteratorUint64(tensorName string) (func() ([]uint64, bool, error), func() error, error) {
+	return rowIterator[uint64](c, tensorName, tensor.DataTypeUint64)
+}
+
+// rowIterator membuka mmap tensor 2-D bertipe targetDataType dan mengembalikan fungsi next serta close,
+// seperti StreamBatches. Setiap panggilan next mengembalikan salinan satu baris (offset byte dihitung
+// dari Strides[0]) dan true; setelah baris terakhir next mengembalikan (nil, false, nil) dan mmap
+// dilepas. Mmap juga dilepas ketika next mengembalikan error. Pemanggil yang berhenti lebih awal wajib
+// memanggil close (aman dipanggil lebih dari sekali, juga setelah iterator habis); setelah close, next
+// mengembalikan error. Mmap adalah pemetaan read-only privat (OpenTensorView), sehingga beberapa
+// iterator atas tensor yang sama serta kueri lain dapat berjalan bersamaan; penulisan di tempat tetap
+// terlihat melalui iterator, jadi tensor tidak boleh diubah selama iterasi berlangsung.
+func rowIterator[T tensor.Numeric](c *Client, tensorName string, targetDataType string) (func() ([]T, bool, error), func() error, error) {
+	if tensorName == "" {
+		return nil, nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	metadata, mmapInst, err := c.executor.OpenTensorView(tensorName)
+	if err != nil {
+		return nil, nil, err
+	}
+	released, closed := false, false
+	release := func() error {
+		if released {
+			return nil
+		}
+		released = true
+		if mmapInst == nil {
+			return nil
+		}
+		return mmapInst.Unmap()
+	}
+	closeIterator := func() error {
+		closed = true
+		return release()
+	}
+	if metadata.DataType != targetDataType {
+		release()
+		return nil, nil, fmt.Errorf("tipe data tensor aktual ('%s') tidak cocok dengan tipe yang diminta ('%s') untuk tensor '%s'", metadata.DataType, targetDataType, tensorName)
+	}
+	if len(metadata.Shape) != 2 {
+		release()
+		return nil, nil, fmt.Errorf("tensor '%s' bukan matriks 2-D (shape %v)", tensorName, metadata.Shape)
+	}
+	elementSize, err := tensor.GetElementSize(metadata.DataType)
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	rows, cols := metadata.Shape[0], metadata.Shape[1]
+	rowStride := metadata.Strides[0]
+	nextRow := 0
+	next := func() ([]T, bool, error) {
+		if closed {
+			return nil, false, fmt.Errorf("iterator baris tensor '%s' sudah ditutup", tensorName)
+		}
+		if nextRow >= rows {
+			return nil, false, release()
+		}
+		if released {
+			return nil, false, fmt.Errorf("iterator baris tensor '%s' sudah ditutup setelah error", tensorName)
+		}
+		row := make([]T, cols)
+		if cols > 0 {
+			start := nextRow * rowStride * elementSize
+			end := start + cols*elementSize
+			if end > len(mmapInst) {
+				release()
+				return nil, false, fmt.Errorf("baris %d tensor '%s' berada di luar ukuran mmap (%d byte)", nextRow, tensorName, len(mmapInst))
+			}
+			if err := binary.Read(bytes.NewReader(mmapInst[start:end]), binary.LittleEndian, row); err != nil {
+				release()
+				return nil, false, fmt.Errorf("gagal membaca baris %d tensor '%s': %w", nextRow, tensorName, err)
+			}
+		}
+		nextRow++
+		return row, true, nil
+	}
+	return next, closeIterator, nil
+}
 
 func (c *Client) loadTensorInternal(tensorName string, expectedDataTypeStr string) (*tensor.TensorMetadata, interface{}, error) {
 	if tensorName == "" {
@@ -308,6 +1250,16 @@ func (c *Client) loadTensorInternal(tensorName string, expectedDataTypeStr strin
 				return metadata, []int32{}, nil
 			case tensor.DataTypeInt64:
 				return metadata, []int64{}, nil
+			case tensor.DataTypeInt8:
+				return metadata, []int8{}, nil
+			case tensor.DataTypeInt16:
+				return metadata, []int16{}, nil
+			case tensor.DataTypeUint8:
+				return metadata, []uint8{}, nil
+			case tensor.DataTypeUint32:
+				return metadata, []uint32{}, nil
+			case tensor.DataTypeUint64:
+				return metadata, []uint64{}, nil
 			}
 		}
 		return nil, nil, fmt.Errorf("hasil tidak terduga saat memuat data tensor '%s', got type %T", tensorName, resultInterface)
@@ -391,43 +1343,588 @@ func (c *Client) LoadTensorInt64(tensorName string) (*tensor.Tensor[int64], erro
 	loadedTensor.Strides = metadata.Strides
 	return loadedTensor, nil
 }
-
-// --- Metode Klien untuk Operasi Matematika ---
-func (c *Client) AddTensors(tensorAName, tensorBName, resultTensorName string) (string, error) {
-	q := &tensor.Query{
-		Type:             tensor.MathOperationQuery,
-		MathOperator:     "ADD_TENSORS",
-		InputTensorNames: []string{tensorAName, tensorBName},
-		OutputTensorName: resultTensorName,
-	}
-	result, err := c.executor.Execute(q)
+func (c *Client) LoadTensorInt8(tensorName string) (*tensor.Tensor[int8], error) {
+	metadata, dataInterface, err := c.loadTensorInternal(tensorName, tensor.DataTypeInt8)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if resultStr, ok := result.(string); ok {
-		return resultStr, nil
+	actualData, ok := dataInterface.([]int8)
+	if !ok {
+		return nil, fmt.Errorf("gagal mengonversi data tensor '%s' ke []int8, data aktual adalah %T", tensorName, dataInterface)
 	}
-	return "", fmt.Errorf("hasil tidak terduga dari operasi ADD_TENSORS: %v", result)
-}
-
-func (c *Client) AddScalarToTensor(scalar float32, tensorName, resultTensorName string) (string, error) {
-	scalarStr := strconv.FormatFloat(float64(scalar), 'f', -1, 32)
-	q := &tensor.Query{
-		Type:             tensor.MathOperationQuery,
-		MathOperator:     "ADD_SCALAR",
-		InputTensorNames: []string{tensorName},
-		ScalarOperand:    scalarStr,
-		OutputTensorName: resultTensorName,
+	loadedTensor, errNew := tensor.NewTensor[int8](metadata.Name, metadata.Shape, metadata.DataType)
+	if errNew != nil {
+		return nil, errNew
 	}
-	result, err := c.executor.Execute(q)
+	if errSet := loadedTensor.SetData(actualData); errSet != nil {
+		return nil, fmt.Errorf("gagal mengatur data untuk tensor[int8] '%s': %w", tensorName, errSet)
+	}
+	loadedTensor.Strides = metadata.Strides
+	return loadedTensor, nil
+}
+func (c *Client) LoadTensorInt16(tensorName string) (*tensor.Tensor[int16], error) {
+	metadata, dataInterface, err := c.loadTensorInternal(tensorName, tensor.DataTypeInt16)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if resultStr, ok := result.(string); ok {
-		return resultStr, nil
+	actualData, ok := dataInterface.([]int16)
+	if !ok {
+		return nil, fmt.Errorf("gagal mengonversi data tensor '%s' ke []int16, data aktual adalah %T", tensorName, dataInterface)
 	}
-	return "", fmt.Errorf("hasil tidak terduga dari operasi ADD_SCALAR: %v", result)
-}
+	loadedTensor, errNew := tensor.NewTensor[int16](metadata.Name, metadata.Shape, metadata.DataType)
+	if errNew != nil {
+		return nil, errNew
+	}
+	if errSet := loadedTensor.SetData(actualData); errSet != nil {
+		return nil, fmt.Errorf("gagal mengatur data untuk tensor[int16] '%s': %w", tensorName, errSet)
+	}
+	loadedTensor.Strides = metadata.Strides
+	return loadedTensor, nil
+}
+func (c *Client) LoadTensorUint8(tensorName string) (*tensor.Tensor[uint8], error) {
+	metadata, dataInterface, err := c.loadTensorInternal(tensorName, tensor.DataTypeUint8)
+	if err != nil {
+		return nil, err
+	}
+	actualData, ok := dataInterface.([]uint8)
+	if !ok {
+		return nil, fmt.Errorf("gagal mengonversi data tensor '%s' ke []uint8, data aktual adalah %T", tensorName, dataInterface)
+	}
+	loadedTensor, errNew := tensor.NewTensor[uint8](metadata.Name, metadata.Shape, metadata.DataType)
+	if errNew != nil {
+		return nil, errNew
+	}
+	if errSet := loadedTensor.SetData(actualData); errSet != nil {
+		return nil, fmt.Errorf("gagal mengatur data untuk tensor[uint8] '%s': %w", tensorName, errSet)
+	}
+	loadedTensor.Strides = metadata.Strides
+	return loadedTensor, nil
+}
+func (c *Client) LoadTensorUint32(tensorName string) (*tensor.Tensor[uint32], error) {
+	metadata, dataInterface, err := c.loadTensorInternal(tensorName, tensor.DataTypeUint32)
+	if err != nil {
+		return nil, err
+	}
+	actualData, ok := dataInterface.([]uint32)
+	if !ok {
+		return nil, fmt.Errorf("gagal mengonversi data tensor '%s' ke []uint32, data aktual adalah %T", tensorName, dataInterface)
+	}
+	loadedTensor, errNew := tensor.NewTensor[uint32](metadata.Name, metadata.Shape, metadata.DataType)
+	if errNew != nil {
+		return nil, errNew
+	}
+	if errSet := loadedTensor.SetData(actualData); errSet != nil {
+		return nil, fmt.Errorf("gagal mengatur data untuk tensor[uint32] '%s': %w", tensorName, errSet)
+	}
+	loadedTensor.Strides = metadata.Strides
+	return loadedTensor, nil
+}
+func (c *Client) LoadTensorUint64(tensorName string) (*tensor.Tensor[uint64], error) {
+	metadata, dataInterface, err := c.loadTensorInternal(tensorName, tensor.DataTypeUint64)
+	if err != nil {
+		return nil, err
+	}
+	actualData, ok := dataInterface.([]uint64)
+	if !ok {
+		return nil, fmt.Errorf("gagal mengonversi data tensor '%s' ke []uint64, data aktual adalah %T", tensorName, dataInterface)
+	}
+	loadedTensor, errNew := tensor.NewTensor[uint64](metadata.Name, metadata.Shape, metadata.DataType)
+	if errNew != nil {
+		return nil, errNew
+	}
+	if errSet := loadedTensor.SetData(actualData); errSet != nil {
+		return nil, fmt.Errorf("gagal mengatur data untuk tensor[uint64] '%s': %w", tensorName, errSet)
+	}
+	loadedTensor.Strides = metadata.Strides
+	return loadedTensor, nil
+}
+
+// LoadTensorAny memuat tensor tanpa perlu mengetahui tipenya lebih dulu. Hasilnya adalah *tensor.Tensor[T]
+// konkret sesuai DataType pada metadata (mis. *tensor.Tensor[float32]) yang dibungkus interface{}.
+func (c *Client) LoadTensorAny(tensorName string) (interface{}, *tensor.TensorMetadata, error) {
+	metadata, err := c.GetTensorMetadata(tensorName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var loaded interface{}
+	switch metadata.DataType {
+	case tensor.DataTypeFloat32:
+		loaded, err = c.LoadTensorFloat32(tensorName)
+	case tensor.DataTypeFloat64:
+		loaded, err = c.LoadTensorFloat64(tensorName)
+	case tensor.DataTypeInt32:
+		loaded, err = c.LoadTensorInt32(tensorName)
+	case tensor.DataTypeInt64:
+		loaded, err = c.LoadTensorInt64(tensorName)
+	case tensor.DataTypeInt8:
+		loaded, err = c.LoadTensorInt8(tensorName)
+	case tensor.DataTypeInt16:
+		loaded, err = c.LoadTensorInt16(tensorName)
+	case tensor.DataTypeUint8:
+		loaded, err = c.LoadTensorUint8(tensorName)
+	case tensor.DataTypeUint32:
+		loaded, err = c.LoadTensorUint32(tensorName)
+	case tensor.DataTypeUint64:
+		loaded, err = c.LoadTensorUint64(tensorName)
+	default:
+		return nil, nil, fmt.Errorf("tipe data '%s' pada tensor '%s' tidak didukung oleh LoadTensorAny", metadata.DataType, tensorName)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return loaded, metadata, nil
+}
+
+// --- Metode Klien untuk Operasi Matematika ---
+
+// executeMathOperation menjalankan kueri operasi matematika dan mengembalikan pesan hasil.
+func (c *Client) executeMathOperation(q *tensor.Query) (string, error) {
+	q.Type = tensor.MathOperationQuery
+	result, err := c.executor.Execute(q)
+	if err != nil {
+		return "", err
+	}
+	if resultStr, ok := result.(string); ok {
+		return resultStr, nil
+	}
+	return "", fmt.Errorf("hasil tidak terduga dari operasi %s: %v", q.MathOperator, result)
+}
+
+func (c *Client) AddTensors(tensorAName, tensorBName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "ADD_TENSORS",
+		InputTensorNames: []string{tensorAName, tensorBName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// AddTensorsBroadcast menjumlahkan dua tensor yang shape-nya dapat di-broadcast ke resultTensorName,
+// misalnya vektor bias [n] ke setiap baris matriks [m, n].
+func (c *Client) AddTensorsBroadcast(tensorAName, tensorBName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "ADD_TENSORS",
+		InputTensorNames: []string{tensorAName, tensorBName},
+		OutputTensorName: resultTensorName,
+		Broadcast:        true,
+	})
+}
+
+// MultiplyTensors mengalikan dua tensor berbentuk sama elemen per elemen ke resultTensorName.
+func (c *Client) MultiplyTensors(tensorAName, tensorBName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "MULTIPLY_TENSORS",
+		InputTensorNames: []string{tensorAName, tensorBName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// DivideTensors membagi tensor A dengan tensor B elemen per elemen ke resultTensorName.
+// Pembagian integer dengan nol menghasilkan error; pembagian float menyimpan Inf/NaN apa adanya.
+func (c *Client) DivideTensors(tensorAName, tensorBName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "DIVIDE_TENSORS",
+		InputTensorNames: []string{tensorAName, tensorBName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// MatMul mengalikan matriks A [m,n] dengan matriks B [n,p] ke resultTensorName berbentuk [m,p].
+func (c *Client) MatMul(tensorAName, tensorBName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "MATMUL_TENSORS",
+		InputTensorNames: []string{tensorAName, tensorBName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+func (c *Client) AddScalarToTensor(scalar float32, tensorName, resultTensorName string) (string, error) {
+	scalarStr := strconv.FormatFloat(float64(scalar), 'f', -1, 32)
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "ADD_SCALAR",
+		InputTensorNames: []string{tensorName},
+		ScalarOperand:    scalarStr,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// MultiplyScalar mengalikan setiap elemen tensor dengan skalar ke resultTensorName.
+func (c *Client) MultiplyScalar(scalar float32, tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "MUL_SCALAR",
+		InputTensorNames: []string{tensorName},
+		ScalarOperand:    strconv.FormatFloat(float64(scalar), 'f', -1, 32),
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// SubtractScalar mengurangkan skalar dari setiap elemen tensor ke resultTensorName.
+func (c *Client) SubtractScalar(scalar float32, tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "SUB_SCALAR",
+		InputTensorNames: []string{tensorName},
+		ScalarOperand:    strconv.FormatFloat(float64(scalar), 'f', -1, 32),
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// AddScalarToTensorInPlace menambahkan skalar ke setiap elemen tensor secara langsung pada file datanya,
+// tanpa membuat tensor output baru.
+func (c *Client) AddScalarToTensorInPlace(scalar float32, tensorName string) (string, error) {
+	scalarStr := strconv.FormatFloat(float64(scalar), 'f', -1, 32)
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "ADD_SCALAR",
+		InputTensorNames: []string{tensorName},
+		ScalarOperand:    scalarStr,
+		InPlace:          true,
+	})
+}
+
+// ReplaceValue menyalin tensor ke resultTensorName dengan setiap elemen bernilai value diganti replacement.
+// tolerance > 0 (hanya untuk tensor float) juga mencocokkan elemen dengan |v-value| <= tolerance.
+func (c *Client) ReplaceValue(value, replacement, tolerance float64, tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(replaceValueQuery(value, replacement, tolerance, tensorName, resultTensorName))
+}
+
+// ReplaceValueInPlace sama seperti ReplaceValue tetapi mengubah file data tensor secara langsung.
+func (c *Client) ReplaceValueInPlace(value, replacement, tolerance float64, tensorName string) (string, error) {
+	q := replaceValueQuery(value, replacement, tolerance, tensorName, "")
+	q.InPlace = true
+	return c.executeMathOperation(q)
+}
+
+func replaceValueQuery(value, replacement, tolerance float64, tensorName, resultTensorName string) *tensor.Query {
+	q := &tensor.Query{
+		MathOperator:     "REPLACE_VALUE",
+		InputTensorNames: []string{tensorName},
+		MatchValue:       strconv.FormatFloat(value, 'f', -1, 64),
+		ScalarOperand:    strconv.FormatFloat(replacement, 'f', -1, 64),
+		OutputTensorName: resultTensorName,
+	}
+	if tolerance > 0 {
+		q.Tolerance = strconv.FormatFloat(tolerance, 'f', -1, 64)
+	}
+	return q
+}
+
+// Sqrt menghitung akar kuadrat setiap elemen tensor float ke tensor baru.
+func (c *Client) Sqrt(tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "SQRT",
+		InputTensorNames: []string{tensorName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// PairwiseDistances menghitung matriks jarak Euclidean [n, n] antar baris tensor float 2-D [n, d]
+// ke resultTensorName.
+func (c *Client) PairwiseDistances(tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "PDIST",
+		InputTensorNames: []string{tensorName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// ClipNorm menskalakan tensor float ke resultTensorName sehingga norma L2-nya tidak melebihi maxNorm
+// (tensor dengan norma di bawah maxNorm tidak berubah).
+func (c *Client) ClipNorm(tensorName string, maxNorm float64, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "CLIP_NORM",
+		InputTensorNames: []string{tensorName},
+		ScalarOperand:    strconv.FormatFloat(maxNorm, 'g', -1, 64),
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Interleave menyelang-nyeling irisan tensorA dan tensorB di sepanjang axis ke resultTensorName
+// (a[0], b[0], a[1], b[1], ...). Kedua tensor harus berbentuk dan bertipe sama.
+func (c *Client) Interleave(tensorA, tensorB string, axis int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "INTERLEAVE",
+		InputTensorNames: []string{tensorA, tensorB},
+		Axis:             &axis,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// BinaryOp menerapkan operasi biner elemen per elemen opName (bawaan seperti ADD, MOD, MAXIMUM, atau
+// yang didaftarkan dengan tensor.RegisterBinaryOp) pada tensorA dan tensorB ke resultTensorName.
+// Kedua tensor harus berbentuk dan bertipe sama.
+func (c *Client) BinaryOp(opName, tensorA, tensorB, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     strings.ToUpper(opName),
+		InputTensorNames: []string{tensorA, tensorB},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Mod menghitung sisa bagi integer tensorA % tensorB elemen per elemen ke resultTensorName (tanda
+// mengikuti tensorA). Hanya tipe integer yang didukung; pembagi nol menghasilkan error.
+func (c *Client) Mod(tensorA, tensorB, resultTensorName string) (string, error) {
+	return c.BinaryOp("MOD", tensorA, tensorB, resultTensorName)
+}
+
+// FloorDiv menghitung pembagian integer tensorA / tensorB yang dibulatkan ke bawah elemen per elemen
+// ke resultTensorName. Hanya tipe integer yang didukung; pembagi nol menghasilkan error.
+func (c *Client) FloorDiv(tensorA, tensorB, resultTensorName string) (string, error) {
+	return c.BinaryOp("FLOORDIV", tensorA, tensorB, resultTensorName)
+}
+
+// BitwiseAnd menghitung tensorA & tensorB elemen per elemen ke resultTensorName. Hanya tipe integer
+// yang didukung; kedua tensor harus berbentuk dan bertipe sama.
+func (c *Client) BitwiseAnd(tensorA, tensorB, resultTensorName string) (string, error) {
+	return c.BinaryOp("BITWISE_AND", tensorA, tensorB, resultTensorName)
+}
+
+// BitwiseOr menghitung tensorA | tensorB elemen per elemen ke resultTensorName (lihat BitwiseAnd).
+func (c *Client) BitwiseOr(tensorA, tensorB, resultTensorName string) (string, error) {
+	return c.BinaryOp("BITWISE_OR", tensorA, tensorB, resultTensorName)
+}
+
+// BitwiseXor menghitung tensorA ^ tensorB elemen per elemen ke resultTensorName (lihat BitwiseAnd).
+func (c *Client) BitwiseXor(tensorA, tensorB, resultTensorName string) (string, error) {
+	return c.BinaryOp("BITWISE_XOR", tensorA, tensorB, resultTensorName)
+}
+
+// BitwiseNot membalik setiap bit elemen tensorName ke resultTensorName. Hanya tipe integer yang didukung.
+func (c *Client) BitwiseNot(tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "BITWISE_NOT",
+		InputTensorNames: []string{tensorName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// ShiftLeft menggeser bit setiap elemen tensorName ke kiri sebanyak amount ke resultTensorName. Hanya
+// tipe integer yang didukung; amount harus dalam [0, lebar bit tipe).
+func (c *Client) ShiftLeft(tensorName string, amount int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "SHIFT_LEFT",
+		InputTensorNames: []string{tensorName},
+		ScalarOperand:    strconv.Itoa(amount),
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// ShiftRight menggeser bit setiap elemen tensorName ke kanan sebanyak amount ke resultTensorName. Tipe
+// bertanda digeser secara aritmetika (tanda dipertahankan), tipe unsigned secara logis.
+func (c *Client) ShiftRight(tensorName string, amount int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "SHIFT_RIGHT",
+		InputTensorNames: []string{tensorName},
+		ScalarOperand:    strconv.Itoa(amount),
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Concat menggabungkan tensorA dan tensorB di sepanjang axis ke resultTensorName. Kedua tensor harus
+// bertipe sama dan berdimensi sama kecuali pada axis.
+func (c *Client) Concat(tensorA, tensorB string, axis int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "CONCAT",
+		InputTensorNames: []string{tensorA, tensorB},
+		Axis:             &axis,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// SegmentSum menjumlahkan baris tensorName per segmen ke resultTensorName. segmentTensorName adalah
+// tensor integer sepanjang dimensi pertama tensorName berisi id segmen 0..k-1 tanpa celah; hasilnya
+// berbentuk [k, ...] dengan tipe data yang sama dengan tensorName.
+func (c *Client) SegmentSum(tensorName, segmentTensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "SEGMENT_SUM",
+		InputTensorNames: []string{tensorName, segmentTensorName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Covariance menghitung matriks kovarians sampel [d, d] antar kolom tensor 2-D [n, d] ke resultTensorName.
+// Input float32 menghasilkan float32, tipe lain float64.
+func (c *Client) Covariance(tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "COV",
+		InputTensorNames: []string{tensorName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Correlation menghitung matriks korelasi Pearson [d, d] antar kolom tensor 2-D [n, d] ke resultTensorName.
+// Kolom dengan varians nol menghasilkan NaN pada baris dan kolomnya.
+func (c *Client) Correlation(tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "CORR",
+		InputTensorNames: []string{tensorName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// TopK menyimpan K nilai terbesar tensor (urutan menurun) ke valuesTensorName dan
+// indeks datarnya (int64) ke indicesTensorName. K yang melebihi jumlah elemen mengembalikan semua elemen.
+func (c *Client) TopK(tensorName string, k int, valuesTensorName, indicesTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:      "TOPK",
+		InputTensorNames:  []string{tensorName},
+		ScalarOperand:     strconv.Itoa(k),
+		OutputTensorName:  valuesTensorName,
+		IndicesTensorName: indicesTensorName,
+	})
+}
+
+// Batch menumpuk tensor-tensor berbentuk dan bertipe sama menjadi resultTensorName dengan dimensi batch
+// baru di depan. Nama input boleh berupa pola glob (mis. "sample_*") yang diekspansi secara leksikografis.
+func (c *Client) Batch(tensorNames []string, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "BATCH",
+		InputTensorNames: tensorNames,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Normalize menskalakan ulang tensor ke rentang [min, max] secara min-max. Dengan axis nil, min/max diambil
+// dari seluruh tensor; jika tidak, setiap irisan di sepanjang *axis dinormalisasi sendiri-sendiri.
+// Data konstan menghasilkan min. Input float32 menghasilkan float32, tipe lain float64.
+func (c *Client) Normalize(tensorName string, min, max float64, axis *int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "NORMALIZE",
+		InputTensorNames: []string{tensorName},
+		RangeMin:         strconv.FormatFloat(min, 'g', -1, 64),
+		RangeMax:         strconv.FormatFloat(max, 'g', -1, 64),
+		Axis:             axis,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// OneHot mengubah tensor label 1-D (integer) menjadi tensor [n, classes] dengan 1 pada kolom kelas setiap
+// baris. outputDataType kosong berarti float32.
+func (c *Client) OneHot(labelsTensorName string, classes int, outputDataType string, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "ONEHOT",
+		InputTensorNames: []string{labelsTensorName},
+		ScalarOperand:    strconv.Itoa(classes),
+		DataType:         outputDataType,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Reinterpret menyalin byte mentah tensor ke resultTensorName dengan tipe data targetDataType berukuran
+// elemen sama (float32<->int32, float64<->int64) tanpa mengonversi nilai.
+func (c *Client) Reinterpret(tensorName, targetDataType, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "REINTERPRET",
+		InputTensorNames: []string{tensorName},
+		DataType:         targetDataType,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Transpose mempermutasi axis tensor ke resultTensorName: axis ke-i hasil adalah axis perm[i] input.
+// perm nil berarti urutan axis dibalik (transpose matriks biasa untuk tensor 2-D).
+func (c *Client) Transpose(tensorName string, perm []int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "TRANSPOSE",
+		InputTensorNames: []string{tensorName},
+		Perm:             perm,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Reshape menyimpan data tensor dengan shape baru ke resultTensorName tanpa mengubah urutan nilai.
+// Jumlah elemen newShape harus sama dengan jumlah elemen tensor sumber.
+func (c *Client) Reshape(tensorName string, newShape []int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "RESHAPE",
+		InputTensorNames: []string{tensorName},
+		Shape:            newShape,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// MovingAverage menghitung rata-rata bergerak trailing (hanya jendela penuh) di sepanjang axis.
+// Panjang sumbu hasil adalah n-window+1; input float32 menghasilkan float32, tipe lain float64.
+func (c *Client) MovingAverage(tensorName string, window int, axis int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "MOVING_AVG",
+		InputTensorNames: []string{tensorName},
+		ScalarOperand:    strconv.Itoa(window),
+		Axis:             &axis,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Sum menjumlahkan seluruh elemen tensor ke tensor skalar baru bertipe sama dengan input; jumlah
+// integer yang tidak muat dalam tipe tersebut menghasilkan error alih-alih berbalik nilai.
+// Reduksi dilakukan secara streaming sehingga tensor tidak dimuat penuh ke memori.
+func (c *Client) Sum(tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "SUM",
+		InputTensorNames: []string{tensorName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// SumAlongAxis menjumlahkan tensor di sepanjang axis ke resultTensorName; dimensi axis hilang dari shape hasil.
+func (c *Client) SumAlongAxis(tensorName string, axis int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "SUM",
+		InputTensorNames: []string{tensorName},
+		Axis:             &axis,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// MaxAlongAxis mengambil nilai maksimum tensor di sepanjang axis ke resultTensorName (tipe sama dengan input).
+// Tensor tanpa elemen menghasilkan error.
+func (c *Client) MaxAlongAxis(tensorName string, axis int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "MAX",
+		InputTensorNames: []string{tensorName},
+		Axis:             &axis,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// MinAlongAxis mengambil nilai minimum tensor di sepanjang axis ke resultTensorName (tipe sama dengan input).
+// Tensor tanpa elemen menghasilkan error.
+func (c *Client) MinAlongAxis(tensorName string, axis int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "MIN",
+		InputTensorNames: []string{tensorName},
+		Axis:             &axis,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// Mean menghitung rata-rata seluruh elemen tensor ke tensor skalar float64 baru.
+func (c *Client) Mean(tensorName, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "MEAN",
+		InputTensorNames: []string{tensorName},
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// NanSum menjumlahkan elemen tensor float dengan melewati NaN. Axis nil berarti reduksi penuh
+// ke skalar; reduksi dari semua-NaN menghasilkan 0.
+func (c *Client) NanSum(tensorName string, axis *int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "NANSUM",
+		InputTensorNames: []string{tensorName},
+		Axis:             axis,
+		OutputTensorName: resultTensorName,
+	})
+}
+
+// NanMean menghitung rata-rata elemen tensor float (float64) dengan melewati NaN; penyebutnya adalah
+// jumlah elemen non-NaN. Axis nil berarti reduksi penuh; reduksi dari semua-NaN menghasilkan NaN.
+func (c *Client) NanMean(tensorName string, axis *int, resultTensorName string) (string, error) {
+	return c.executeMathOperation(&tensor.Query{
+		MathOperator:     "NANMEAN",
+		InputTensorNames: []string{tensorName},
+		Axis:             axis,
+		OutputTensorName: resultTensorName,
+	})
+}
 
 // Metode baru untuk LIST TENSORS
 func (c *Client) ListTensors(filterDataType string, filterNumDimensions int) ([]tensor.TensorMetadata, error) {
@@ -446,3 +1943,809 @@ func (c *Client) ListTensors(filterDataType string, filterNumDimensions int) ([]
 	}
 	return metadataResults, nil
 }
+
+// DescribeTensor mengembalikan metadata satu tensor beserta jumlah dimensi, jumlah elemen, dan ukuran
+// datanya dalam byte. Tensor yang tidak ada menghasilkan error.
+func (c *Client) DescribeTensor(name string) (*tensor.TensorDescription, error) {
+	if name == "" {
+		return nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	result, err := c.executor.Execute(&tensor.Query{Type: tensor.DescribeTensorQuery, TensorNames: []string{name}})
+	if err != nil {
+		return nil, err
+	}
+	description, ok := result.(*tensor.TensorDescription)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type from DescribeTensor operation: expected *tensor.TensorDescription, got %T", result)
+	}
+	return description, nil
+}
+
+// CountElements mengembalikan jumlah elemen tensor yang dihitung dari shape di metadata, tanpa membaca
+// atau me-mmap file datanya.
+func (c *Client) CountElements(name string) (int, error) {
+	if name == "" {
+		return 0, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	result, err := c.executor.Execute(&tensor.Query{Type: tensor.CountQuery, TensorNames: []string{name}})
+	if err != nil {
+		return 0, err
+	}
+	count, ok := result.(int)
+	if !ok {
+		return 0, fmt.Errorf("unexpected result type from CountElements operation: expected int, got %T", result)
+	}
+	return count, nil
+}
+
+// ValidateTensor memindai data tensor dan melaporkan kecocokan ukuran file dan checksum, jumlah NaN/Inf,
+// serta nilai minimum dan maksimum. Masalah data dilaporkan di report, bukan sebagai error.
+func (c *Client) ValidateTensor(name string) (*tensor.ValidationReport, error) {
+	if name == "" {
+		return nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	result, err := c.executor.Execute(&tensor.Query{Type: tensor.ValidateTensorQuery, TensorNames: []string{name}})
+	if err != nil {
+		return nil, err
+	}
+	report, ok := result.(*tensor.ValidationReport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type from ValidateTensor operation: expected *tensor.ValidationReport, got %T", result)
+	}
+	return report, nil
+}
+
+// Fingerprint mengembalikan hash SHA-256 (heksadesimal) atas tipe data, shape, dan data tensor name.
+// Tensor dengan tipe data, shape, dan isi identik memiliki fingerprint yang sama; perubahan satu elemen
+// pun mengubahnya.
+func (c *Client) Fingerprint(name string) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	return c.executor.Fingerprint(name)
+}
+
+// TensorStats mengembalikan rata-rata dan variansi berjalan tensor yang dibuat dengan TRACK_STATS.
+// Tensor tanpa TRACK_STATS menghasilkan error.
+func (c *Client) TensorStats(name string) (*tensor.TensorStats, error) {
+	if name == "" {
+		return nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	result, err := c.executor.Execute(&tensor.Query{Type: tensor.StatsTensorQuery, TensorNames: []string{name}})
+	if err != nil {
+		return nil, err
+	}
+	stats, ok := result.(*tensor.TensorStats)
+	if !ok {
+		return nil, fmt.Errorf("unexpected result type from TensorStats operation: expected *tensor.TensorStats, got %T", result)
+	}
+	return stats, nil
+}
+
+// EachTensorMeta adalah versi streaming dari ListTensors: fn dipanggil untuk metadata setiap tensor
+// yang cocok dengan filter tanpa membangun daftar lengkap. Iterasi berhenti pada error pertama dari fn.
+func (c *Client) EachTensorMeta(filterDataType string, filterNumDims int, fn func(meta *tensor.TensorMetadata) error) error {
+	return c.executor.EachTensorMetadata(filterDataType, filterNumDims, fn)
+}
+
+// Summary adalah ringkasan read-only seluruh isi store.
+type Summary struct {
+	TotalTensors    int
+	ByDataType      map[string]int // Jumlah tensor per tipe data
+	ByNumDimensions map[int]int    // Jumlah tensor per jumlah dimensi
+	TotalElements   int64
+	TotalBytes      int64 // Ukuran file .meta dan .data di disk; blob dedup bersama dihitung sekali
+}
+
+// Summary menghitung ringkasan seluruh tensor dalam satu kali iterasi EachTensorMeta:
+// jumlah tensor, rincian per tipe data dan per jumlah dimensi, total elemen, dan total ukuran file.
+// Pada mode dedup (tensor.WithDedup), file blob yang dirujuk beberapa tensor hanya dihitung sekali.
+func (c *Client) Summary() (Summary, error) {
+	summary := Summary{
+		ByDataType:      make(map[string]int),
+		ByNumDimensions: make(map[int]int),
+	}
+	countedBlobs := make(map[string]bool)
+	err := c.EachTensorMeta("", -1, func(meta *tensor.TensorMetadata) error {
+		size, err := c.executor.TensorFileSize(meta.Name)
+		if err != nil {
+			return fmt.Errorf("gagal membaca ukuran file tensor '%s': %w", meta.Name, err)
+		}
+		numElements := int64(1)
+		for _, dim := range meta.Shape {
+			numElements *= int64(dim)
+		}
+		if meta.DataRef != "" {
+			if countedBlobs[meta.DataRef] {
+				// Isi blob sama persis dengan data tensor, jadi ukurannya dihitung dari shape.
+				elementSize, err := tensor.GetElementSize(meta.DataType)
+				if err != nil {
+					return err
+				}
+				size -= numElements * int64(elementSize)
+			}
+			countedBlobs[meta.DataRef] = true
+		}
+		summary.TotalTensors++
+		summary.ByDataType[meta.DataType]++
+		summary.ByNumDimensions[meta.NumDimensions()]++
+		summary.TotalElements += numElements
+		summary.TotalBytes += size
+		return nil
+	})
+	if err != nil {
+		return Summary{}, err
+	}
+	return summary, nil
+}
+
+// CatalogEntry adalah satu entri katalog hasil ExportCatalog: metadata tensor tanpa datanya.
+// Created diambil dari waktu modifikasi file .meta (lihat tensor.Storage.MetadataModTime), jadi berubah
+// setiap kali metadata tensor ditulis ulang. Storage belum menyimpan deskripsi tensor, sehingga
+// Description selalu kosong; kolomnya tetap ditulis agar skema katalog stabil.
+type CatalogEntry struct {
+	Name          string    `json:"name"`
+	Shape         []int     `json:"shape"`
+	DataType      string    `json:"datatype"`
+	Strides       []int     `json:"strides"`
+	Created       time.Time `json:"created"`
+	Description   string    `json:"description"`
+	DataSizeBytes int64     `json:"data_size_bytes"` // Ukuran data dihitung dari shape dan tipe data
+}
+
+// ExportCatalog menulis katalog seluruh tensor ke w sebagai array JSON CatalogEntry yang diurutkan
+// menurut nama, dibangun dengan EachTensorMeta sehingga file .data tidak dibaca. Store kosong
+// menghasilkan array kosong.
+func (c *Client) ExportCatalog(w io.Writer) error {
+	catalog := []CatalogEntry{}
+	err := c.EachTensorMeta("", -1, func(meta *tensor.TensorMetadata) error {
+		elementSize, err := tensor.GetElementSize(meta.DataType)
+		if err != nil {
+			return fmt.Errorf("tipe data tidak valid pada tensor '%s': %w", meta.Name, err)
+		}
+		shape, strides := meta.Shape, meta.Strides
+		if shape == nil {
+			shape = []int{}
+		}
+		if strides == nil {
+			strides = []int{}
+		}
+		created, err := c.executor.MetadataModTime(meta.Name)
+		if err != nil {
+			return err
+		}
+		catalog = append(catalog, CatalogEntry{
+			Name:          meta.Name,
+			Shape:         shape,
+			DataType:      meta.DataType,
+			Strides:       strides,
+			Created:       created.UTC(),
+			DataSizeBytes: int64(calculateTotalElementsFromShape(meta.Shape)) * int64(elementSize),
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Name < catalog[j].Name })
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(catalog); err != nil {
+		return fmt.Errorf("gagal menulis katalog JSON: %w", err)
+	}
+	return nil
+}
+
+// ReplayLog mengeksekusi ulang setiap entri log operasi (lihat tensor.WithOpLog) secara berurutan.
+// Biasanya dipakai terhadap storage baru untuk membangun ulang state dari log. Operasi biner kustom
+// (tensor.RegisterBinaryOp) tidak ikut tersimpan di log, jadi harus didaftarkan sebelum replay.
+func (c *Client) ReplayLog(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return fmt.Errorf("gagal membaca oplog pada baris %d: %w", lineNum, readErr)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			var entry tensor.OpLogEntry
+			if err := json.Unmarshal(line, &entry); err != nil {
+				return fmt.Errorf("entri oplog tidak valid pada baris %d: %w", lineNum, err)
+			}
+			if entry.Query == nil {
+				return fmt.Errorf("entri oplog pada baris %d tidak memiliki kueri", lineNum)
+			}
+			if _, err := c.executor.Execute(entry.Query); err != nil {
+				return fmt.Errorf("gagal memutar ulang oplog pada baris %d: %w", lineNum, err)
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// AssertClose memeriksa bahwa setiap elemen tensor nameA dan nameB memenuhi |a-b| <= atol + rtol*|b|
+// (semantik numpy.allclose). Kedua tensor harus memiliki shape dan tipe data yang sama. Tensor integer
+// (dan bool) dibandingkan persis dalam tipe aslinya; rtol dan atol hanya berlaku untuk tensor float.
+// Jika ada elemen yang gagal, error menyebut koordinat elemen pertama yang gagal beserta kedua nilainya.
+func (c *Client) AssertClose(nameA, nameB string, rtol, atol float64) error {
+	metaA, err := c.GetTensorMetadata(nameA)
+	if err != nil {
+		return err
+	}
+	metaB, err := c.GetTensorMetadata(nameB)
+	if err != nil {
+		return err
+	}
+	if metaA.DataType != metaB.DataType {
+		return fmt.Errorf("tipe data tensor tidak sama: '%s' bertipe %s, '%s' bertipe %s", nameA, metaA.DataType, nameB, metaB.DataType)
+	}
+	if !tensor.ShapesEqual(metaA.Shape, metaB.Shape) {
+		return fmt.Errorf("shape tensor tidak sama: '%s' ber-shape %v, '%s' ber-shape %v", nameA, metaA.Shape, nameB, metaB.Shape)
+	}
+	if metaA.DataType != tensor.DataTypeFloat32 && metaA.DataType != tensor.DataTypeFloat64 {
+		return c.assertEqualExact(nameA, nameB, metaA)
+	}
+	_, dataA, err := c.loadFloat64Values(nameA)
+	if err != nil {
+		return err
+	}
+	_, dataB, err := c.loadFloat64Values(nameB)
+	if err != nil {
+		return err
+	}
+	idx := tensor.FirstNotClose(dataA, dataB, rtol, atol)
+	if idx < 0 {
+		return nil
+	}
+	a, b := dataA[idx], dataB[idx]
+	return fmt.Errorf("tensor '%s' dan '%s' tidak berdekatan pada indeks %v: %v vs %v (|a-b| = %g, toleransi atol + rtol*|b| = %g)",
+		nameA, nameB, flatIndexCoordinate(metaA.Shape, idx), a, b, math.Abs(a-b), atol+rtol*math.Abs(b))
+}
+
+// assertEqualExact adalah AssertClose untuk tensor non-float: setiap elemen harus persis sama.
+func (c *Client) assertEqualExact(nameA, nameB string, metadata *tensor.TensorMetadata) error {
+	_, dataA, err := c.loadTensorInternal(nameA, metadata.DataType)
+	if err != nil {
+		return err
+	}
+	_, dataB, err := c.loadTensorInternal(nameB, metadata.DataType)
+	if err != nil {
+		return err
+	}
+	var idx int
+	var a, b interface{}
+	switch x := dataA.(type) {
+	case []int32:
+		idx, a, b = firstDifference(x, dataB.([]int32))
+	case []int64:
+		idx, a, b = firstDifference(x, dataB.([]int64))
+	case []int8:
+		idx, a, b = firstDifference(x, dataB.([]int8))
+	case []int16:
+		idx, a, b = firstDifference(x, dataB.([]int16))
+	case []uint8:
+		idx, a, b = firstDifference(x, dataB.([]uint8))
+	case []uint32:
+		idx, a, b = firstDifference(x, dataB.([]uint32))
+	case []uint64:
+		idx, a, b = firstDifference(x, dataB.([]uint64))
+	default:
+		return fmt.Errorf("tipe data '%s' pada tensor '%s' tidak didukung untuk perbandingan", metadata.DataType, nameA)
+	}
+	if idx < 0 {
+		return nil
+	}
+	return fmt.Errorf("tensor '%s' dan '%s' tidak sama pada indeks %v: %v vs %v (tensor %s dibandingkan persis)",
+		nameA, nameB, flatIndexCoordinate(metadata.Shape, idx), a, b, metadata.DataType)
+}
+
+func firstDifference[T comparable](a, b []T) (int, interface{}, interface{}) {
+	idx := tensor.FirstNotEqual(a, b)
+	if idx < 0 {
+		return -1, nil, nil
+	}
+	return idx, a[idx], b[idx]
+}
+
+// flatIndexCoordinate mengubah indeks datar row-major menjadi koordinat pada shape.
+func flatIndexCoordinate(shape []int, idx int) []int {
+	coordinate := make([]int, len(shape))
+	for dim, rem := len(shape)-1, idx; dim >= 0; dim-- {
+		coordinate[dim] = rem % shape[dim]
+		rem /= shape[dim]
+	}
+	return coordinate
+}
+
+// loadFloat64Values memuat seluruh elemen tensor bertipe apa pun sebagai []float64.
+func (c *Client) loadFloat64Values(tensorName string) (*tensor.TensorMetadata, []float64, error) {
+	metadata, err := c.GetTensorMetadata(tensorName)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, dataInterface, err := c.loadTensorInternal(tensorName, metadata.DataType)
+	if err != nil {
+		return nil, nil, err
+	}
+	var values []float64
+	switch data := dataInterface.(type) {
+	case []float32:
+		values = convertToFloat64(data)
+	case []float64:
+		values = data
+	case []int32:
+		values = convertToFloat64(data)
+	case []int64:
+		values = convertToFloat64(data)
+	case []int8:
+		values = convertToFloat64(data)
+	case []int16:
+		values = convertToFloat64(data)
+	case []uint8:
+		values = convertToFloat64(data)
+	case []uint32:
+		values = convertToFloat64(data)
+	case []uint64:
+		values = convertToFloat64(data)
+	default:
+		return nil, nil, fmt.Errorf("tipe data '%s' pada tensor '%s' tidak didukung untuk perbandingan", metadata.DataType, tensorName)
+	}
+	return metadata, values, nil
+}
+
+func convertToFloat64[T tensor.Numeric](data []T) []float64 {
+	values := make([]float64, len(data))
+	for i, v := range data {
+		values[i] = float64(v)
+	}
+	return values
+}
+
+// ImportCSV membaca CSV persegi dari r, membuat tensor 2-D bernama name dengan shape [baris, kolom]
+// bertipe dataType, lalu menyisipkan sel-selnya dalam urutan row-major. Setiap sel diurai sesuai
+// dataType seperti nilai INSERT ... VALUES. Baris dengan jumlah kolom berbeda dan input kosong ditolak;
+// jika penyisipan gagal, tensor yang baru dibuat dihapus kembali.
+func (c *Client) ImportCSV(name string, r io.Reader, dataType string) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	if _, err := tensor.GetElementSize(dataType); err != nil {
+		return fmt.Errorf("tipe data tidak valid '%s': %w", dataType, err)
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Jumlah kolom diperiksa sendiri agar pesan error menyebut barisnya.
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return fmt.Errorf("gagal membaca CSV untuk tensor '%s': %w", name, err)
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("CSV untuk tensor '%s' kosong: setidaknya satu baris diperlukan", name)
+	}
+	cols := len(records[0])
+	values := make([]string, 0, len(records)*cols)
+	for i, record := range records {
+		if len(record) != cols {
+			return fmt.Errorf("baris CSV %d memiliki %d kolom, sedangkan baris 1 memiliki %d kolom", i+1, len(record), cols)
+		}
+		values = append(values, record...)
+	}
+
+	if err := c.CreateTensor(name, []int{len(records), cols}, dataType); err != nil {
+		return err
+	}
+	insertQuery := &tensor.Query{Type: tensor.InsertTensorQuery, TensorNames: []string{name}, Data: values}
+	if _, err := c.executor.Execute(insertQuery); err != nil {
+		if dropErr := c.DropTensor(name); dropErr != nil {
+			return fmt.Errorf("gagal menyisipkan data CSV ke tensor '%s': %w (tensor juga gagal dihapus: %v)", name, err, dropErr)
+		}
+		return fmt.Errorf("gagal menyisipkan data CSV ke tensor '%s': %w", name, err)
+	}
+	return nil
+}
+
+// ExportCSV menulis tensor 2-D sebagai CSV ke w, satu baris tensor per baris CSV. Float ditulis dengan
+// presisi minimum yang tetap round-trip, dan tensor bool ditulis sebagai true/false.
+func (c *Client) ExportCSV(name string, w io.Writer) error {
+	metadata, err := c.GetTensorMetadata(name)
+	if err != nil {
+		return err
+	}
+	if len(metadata.Shape) != 2 {
+		return fmt.Errorf("tensor '%s' bukan matriks 2-D (shape %v)", name, metadata.Shape)
+	}
+	result, err := c.SelectData(name, nil)
+	if err != nil {
+		return err
+	}
+	rows, ok := result.([]interface{})
+	if !ok {
+		return fmt.Errorf("hasil SELECT tidak terduga untuk tensor '%s': %T", name, result)
+	}
+	writer := csv.NewWriter(w)
+	for i, row := range rows {
+		cells, ok := row.([]interface{})
+		if !ok {
+			return fmt.Errorf("baris %d tensor '%s' tidak terduga: %T", i, name, row)
+		}
+		record := make([]string, len(cells))
+		for j, cell := range cells {
+			record[j] = formatCSVValue(cell)
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("gagal menulis baris CSV %d tensor '%s': %w", i+1, name, err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("gagal menulis CSV tensor '%s': %w", name, err)
+	}
+	return nil
+}
+
+func formatCSVValue(value interface{}) string {
+	switch v := value.(type) {
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// npyDescriptors memetakan tipe data tensor ke deskriptor dtype NumPy (little-endian).
+var npyDescriptors = map[string]string{
+	tensor.DataTypeFloat32: "<f4",
+	tensor.DataTypeFloat64: "<f8",
+	tensor.DataTypeInt8:    "|i1",
+	tensor.DataTypeInt16:   "<i2",
+	tensor.DataTypeInt32:   "<i4",
+	tensor.DataTypeInt64:   "<i8",
+	tensor.DataTypeUint8:   "|u1",
+	tensor.DataTypeUint32:  "<u4",
+	tensor.DataTypeUint64:  "<u8",
+	tensor.DataTypeBool:    "|b1",
+}
+
+// ExportNPY menulis tensor ke w dalam format .npy versi 1.0: magic, panjang header, header dict
+// (descr, fortran_order=False, shape) yang dipad spasi hingga kelipatan 64 byte, lalu data mentah
+// little-endian yang disalin langsung dari mmap.
+func (c *Client) ExportNPY(name string, w io.Writer) error {
+	metadata, mmapInst, cleanupFunc, err := c.GetTensorMmap(name)
+	if err != nil {
+		return err
+	}
+	defer cleanupFunc()
+	descr, ok := npyDescriptors[metadata.DataType]
+	if !ok {
+		return fmt.Errorf("tipe data '%s' pada tensor '%s' tidak didukung untuk ekspor .npy", metadata.DataType, name)
+	}
+	elementSize, err := tensor.GetElementSize(metadata.DataType)
+	if err != nil {
+		return err
+	}
+
+	dims := make([]string, len(metadata.Shape))
+	for i, dim := range metadata.Shape {
+		dims[i] = strconv.Itoa(dim)
+	}
+	shape := strings.Join(dims, ", ")
+	if len(dims) == 1 {
+		shape += ","
+	}
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': (%s), }", descr, shape)
+	// Magic (6) + versi (2) + panjang header (2) + header + '\n' harus habis dibagi 64.
+	const preambleLen = 10
+	padding := (64 - (preambleLen+len(header)+1)%64) % 64
+	header += strings.Repeat(" ", padding) + "\n"
+
+	preamble := make([]byte, preambleLen)
+	copy(preamble, "\x93NUMPY")
+	preamble[6], preamble[7] = 1, 0
+	binary.LittleEndian.PutUint16(preamble[8:], uint16(len(header)))
+	if _, err := w.Write(preamble); err != nil {
+		return fmt.Errorf("gagal menulis header .npy tensor '%s': %w", name, err)
+	}
+	if _, err := io.WriteString(w, header); err != nil {
+		return fmt.Errorf("gagal menulis header .npy tensor '%s': %w", name, err)
+	}
+	dataBytes := calculateTotalElementsFromShape(metadata.Shape) * elementSize
+	if dataBytes > 0 {
+		if len(mmapInst) < dataBytes {
+			return fmt.Errorf("ukuran mmap (%d bytes) lebih kecil dari ukuran data yang diharapkan (%d bytes) untuk tensor '%s'", len(mmapInst), dataBytes, name)
+		}
+		if _, err := w.Write(mmapInst[:dataBytes]); err != nil {
+			return fmt.Errorf("gagal menulis data .npy tensor '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Regex untuk tiap kunci header dict .npy. Kunci dicari satu per satu agar urutannya dalam dict bebas,
+// karena penulis .npy selain NumPy tidak selalu memakai urutan descr, fortran_order, shape.
+var (
+	npyDescrRegex        = regexp.MustCompile(`['"]descr['"]\s*:\s*['"]([^'"]*)['"]`)
+	npyFortranOrderRegex = regexp.MustCompile(`['"]fortran_order['"]\s*:\s*(True|False)`)
+	npyShapeRegex        = regexp.MustCompile(`['"]shape['"]\s*:\s*\(([^)]*)\)`)
+)
+
+// parseNPYHeader mengambil descr, fortran_order, dan isi tuple shape dari header dict .npy. Setiap
+// kunci harus muncul tepat sekali.
+func parseNPYHeader(header string) (descr string, fortranOrder bool, shapeStr string, err error) {
+	if !strings.HasPrefix(header, "{") || !strings.HasSuffix(header, "}") {
+		return "", false, "", fmt.Errorf("header .npy tidak valid: %q", header)
+	}
+	values := make([]string, 3)
+	for i, key := range []struct {
+		name  string
+		regex *regexp.Regexp
+	}{{"descr", npyDescrRegex}, {"fortran_order", npyFortranOrderRegex}, {"shape", npyShapeRegex}} {
+		matches := key.regex.FindAllStringSubmatch(header, -1)
+		if len(matches) != 1 {
+			return "", false, "", fmt.Errorf("header .npy tidak valid: kunci '%s' harus muncul tepat sekali: %q", key.name, header)
+		}
+		values[i] = matches[0][1]
+	}
+	return values[0], values[1] == "True", values[2], nil
+}
+
+// npyDataType mengembalikan tipe data tensor untuk descr .npy. Prefix urutan byte '<' (little-endian),
+// '|' (tidak relevan), dan '=' (native, hanya pada host little-endian) diterima; big-endian '>' hanya
+// diterima untuk tipe satu byte, yang tidak memiliki urutan byte.
+func npyDataType(descr string) (string, error) {
+	if len(descr) < 2 {
+		return "", fmt.Errorf("dtype .npy '%s' tidak didukung", descr)
+	}
+	for dt, known := range npyDescriptors {
+		if descr[1:] != known[1:] {
+			continue
+		}
+		singleByte := known[0] == '|'
+		switch descr[0] {
+		case '<', '|':
+			return dt, nil
+		case '=':
+			if binary.NativeEndian.Uint16([]byte{1, 0}) == 1 || singleByte {
+				return dt, nil
+			}
+			return "", fmt.Errorf("dtype .npy native '%s' pada host big-endian tidak didukung: hanya little-endian yang didukung", descr)
+		case '>':
+			if singleByte {
+				return dt, nil
+			}
+			return "", fmt.Errorf("dtype .npy big-endian '%s' tidak didukung: hanya little-endian yang didukung", descr)
+		}
+	}
+	return "", fmt.Errorf("dtype .npy '%s' tidak didukung", descr)
+}
+
+// ImportNPY membaca file .npy (versi 1.0 hingga 3.0) dari r, membuat tensor name dengan shape dan tipe
+// data dari header, lalu menyisipkan data mentahnya lewat jalur insert RawData. Urutan kunci header
+// bebas. Dtype big-endian (lihat npyDataType), dtype yang tidak memiliki padanan tipe data tensor, dan
+// array fortran_order=True ditolak. Jika penyisipan
+// gagal, tensor yang baru dibuat dihapus kembali.
+func (c *Client) ImportNPY(name string, r io.Reader) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	preamble := make([]byte, 8)
+	if _, err := io.ReadFull(r, preamble); err != nil {
+		return fmt.Errorf("gagal membaca header .npy: %w", err)
+	}
+	if string(preamble[:6]) != "\x93NUMPY" {
+		return fmt.Errorf("bukan file .npy: magic string tidak cocok")
+	}
+	var headerLen int
+	switch major := preamble[6]; major {
+	case 1:
+		lenBytes := make([]byte, 2)
+		if _, err := io.ReadFull(r, lenBytes); err != nil {
+			return fmt.Errorf("gagal membaca panjang header .npy: %w", err)
+		}
+		headerLen = int(binary.LittleEndian.Uint16(lenBytes))
+	case 2, 3:
+		lenBytes := make([]byte, 4)
+		if _, err := io.ReadFull(r, lenBytes); err != nil {
+			return fmt.Errorf("gagal membaca panjang header .npy: %w", err)
+		}
+		headerLen = int(binary.LittleEndian.Uint32(lenBytes))
+	default:
+		return fmt.Errorf("versi .npy %d.%d tidak didukung", major, preamble[7])
+	}
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return fmt.Errorf("gagal membaca header .npy: %w", err)
+	}
+
+	descr, fortranOrder, shapeStr, err := parseNPYHeader(strings.TrimSpace(string(header)))
+	if err != nil {
+		return err
+	}
+	if fortranOrder {
+		return fmt.Errorf("array .npy dengan fortran_order=True belum didukung")
+	}
+	dataType, err := npyDataType(descr)
+	if err != nil {
+		return err
+	}
+	shape := []int{}
+	for _, part := range strings.Split(shapeStr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		dim, err := strconv.Atoi(part)
+		if err != nil || dim < 0 {
+			return fmt.Errorf("dimensi shape .npy tidak valid '%s'", part)
+		}
+		shape = append(shape, dim)
+	}
+
+	elementSize, err := tensor.GetElementSize(dataType)
+	if err != nil {
+		return err
+	}
+	rawData, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("gagal membaca data .npy: %w", err)
+	}
+	if expected := calculateTotalElementsFromShape(shape) * elementSize; len(rawData) != expected {
+		return fmt.Errorf("ukuran data .npy (%d byte) tidak sesuai shape %v bertipe %s (%d byte)", len(rawData), shape, dataType, expected)
+	}
+
+	if err := c.CreateTensor(name, shape, dataType); err != nil {
+		return err
+	}
+	if len(rawData) == 0 {
+		return nil
+	}
+	insertQuery := &tensor.Query{Type: tensor.InsertTensorQuery, TensorNames: []string{name}, RawData: rawData}
+	if _, err := c.executor.Execute(insertQuery); err != nil {
+		if dropErr := c.DropTensor(name); dropErr != nil {
+			return fmt.Errorf("gagal menyisipkan data .npy ke tensor '%s': %w (tensor juga gagal dihapus: %v)", name, err, dropErr)
+		}
+		return fmt.Errorf("gagal menyisipkan data .npy ke tensor '%s': %w", name, err)
+	}
+	return nil
+}
+
+// tensorJSON adalah bentuk JSON satu tensor utuh untuk ExportJSON dan ImportJSON.
+type tensorJSON struct {
+	Name     string      `json:"name"`
+	Shape    []int       `json:"shape"`
+	DataType string      `json:"datatype"`
+	Strides  []int       `json:"strides"`
+	Data     interface{} `json:"data"`
+}
+
+// Sentinel string untuk nilai float yang tidak dapat ditulis sebagai angka JSON; ImportJSON
+// menerimanya kembali di posisi elemen.
+const (
+	jsonNaN         = "NaN"
+	jsonPosInfinity = "Infinity"
+	jsonNegInfinity = "-Infinity"
+)
+
+// ExportJSON menulis tensor ke w sebagai objek JSON {name, shape, datatype, strides, data}, dengan data
+// berupa array bersarang hasil FormatMultidimensional (sama seperti SELECT). NaN dan tak hingga ditulis
+// sebagai string "NaN", "Infinity", dan "-Infinity".
+func (c *Client) ExportJSON(name string, w io.Writer) error {
+	metadata, err := c.GetTensorMetadata(name)
+	if err != nil {
+		return err
+	}
+	data, err := c.SelectData(name, nil)
+	if err != nil {
+		return err
+	}
+	doc := tensorJSON{Name: metadata.Name, Shape: metadata.Shape, DataType: metadata.DataType, Strides: metadata.Strides, Data: encodeNonFiniteJSON(data)}
+	if err := json.NewEncoder(w).Encode(doc); err != nil {
+		return fmt.Errorf("gagal menulis JSON tensor '%s': %w", name, err)
+	}
+	return nil
+}
+
+// encodeNonFiniteJSON mengganti elemen float NaN dan tak hingga pada array bersarang node dengan
+// sentinel string, karena encoding/json menolak menulisnya. Node lain dikembalikan apa adanya.
+func encodeNonFiniteJSON(node interface{}) interface{} {
+	var f float64
+	switch v := node.(type) {
+	case []interface{}:
+		encoded := make([]interface{}, len(v))
+		for i, item := range v {
+			encoded[i] = encodeNonFiniteJSON(item)
+		}
+		return encoded
+	case float32:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return node
+	}
+	switch {
+	case math.IsNaN(f):
+		return jsonNaN
+	case math.IsInf(f, 1):
+		return jsonPosInfinity
+	case math.IsInf(f, -1):
+		return jsonNegInfinity
+	}
+	return node
+}
+
+// ImportJSON membaca objek JSON hasil ExportJSON dari r dan membuat tensor baru darinya. Array data
+// bersarang harus persis mengikuti shape yang dideklarasikan; elemennya diratakan dalam urutan
+// row-major dan diurai sesuai datatype seperti nilai INSERT ... VALUES, termasuk sentinel string
+// "NaN", "Infinity", dan "-Infinity" dari ExportJSON. Strides dihitung ulang dari
+// shape. Jika penyisipan gagal, tensor yang baru dibuat dihapus kembali.
+func (c *Client) ImportJSON(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber() // Pertahankan presisi int64/uint64.
+	var doc tensorJSON
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf("gagal membaca JSON tensor: %w", err)
+	}
+	if doc.Name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	values := make([]string, 0, calculateTotalElementsFromShape(doc.Shape))
+	values, err := flattenJSONData(doc.Data, doc.Shape, 0, "data", values)
+	if err != nil {
+		return fmt.Errorf("data JSON tensor '%s' tidak sesuai shape %v: %w", doc.Name, doc.Shape, err)
+	}
+
+	if err := c.CreateTensor(doc.Name, doc.Shape, doc.DataType); err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	insertQuery := &tensor.Query{Type: tensor.InsertTensorQuery, TensorNames: []string{doc.Name}, Data: values}
+	if _, err := c.executor.Execute(insertQuery); err != nil {
+		if dropErr := c.DropTensor(doc.Name); dropErr != nil {
+			return fmt.Errorf("gagal menyisipkan data JSON ke tensor '%s': %w (tensor juga gagal dihapus: %v)", doc.Name, err, dropErr)
+		}
+		return fmt.Errorf("gagal menyisipkan data JSON ke tensor '%s': %w", doc.Name, err)
+	}
+	return nil
+}
+
+// flattenJSONData menambahkan elemen node (array bersarang pada kedalaman depth) ke values dalam urutan
+// row-major, sambil memastikan setiap level berupa array sepanjang shape[depth] dan daun berupa skalar.
+// path menunjuk lokasi node untuk pesan error, mis. "data[1][0]".
+func flattenJSONData(node interface{}, shape []int, depth int, path string, values []string) ([]string, error) {
+	if depth == len(shape) {
+		switch v := node.(type) {
+		case json.Number:
+			return append(values, v.String()), nil
+		case bool:
+			return append(values, strconv.FormatBool(v)), nil
+		case string:
+			if v == jsonNaN || v == jsonPosInfinity || v == jsonNegInfinity {
+				return append(values, v), nil
+			}
+			return nil, fmt.Errorf("%s harus berupa angka, bool, atau \"NaN\"/\"Infinity\"/\"-Infinity\", bukan string %q", path, v)
+		default:
+			return nil, fmt.Errorf("%s harus berupa angka atau bool, bukan %T", path, node)
+		}
+	}
+	items, ok := node.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s harus berupa array dengan %d elemen, bukan %T", path, shape[depth], node)
+	}
+	if len(items) != shape[depth] {
+		return nil, fmt.Errorf("%s memiliki %d elemen, sedangkan dimensi %d berukuran %d", path, len(items), depth, shape[depth])
+	}
+	for i, item := range items {
+		var err error
+		values, err = flattenJSONData(item, shape, depth+1, fmt.Sprintf("%s[%d]", path, i), values)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,221 @@
+package tensor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// binaryOps adalah registry operasi biner elemen per elemen: nama (huruf besar) -> tipe data ->
+// func(a, b T) T. Operasi terdaftar dapat dipanggil dengan OP name TENSOR a WITH TENSOR b INTO c.
+var (
+	binaryOpsMu sync.RWMutex
+	binaryOps   = make(map[string]map[string]interface{})
+	// builtinBinaryOps berisi nama operasi bawaan yang didaftarkan saat init; operasi ini tidak dapat
+	// dihapus dengan UnregisterBinaryOp.
+	builtinBinaryOps = make(map[string]bool)
+)
+
+// binaryOpNameRegex mencocokkan nama operasi biner yang valid, sama dengan nama pada kueri OP.
+var binaryOpNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// integerDivisionOps adalah operasi bawaan yang membagi dengan operand kedua; pembagi nol ditolak
+// oleh ApplyBinaryOp sebelum operasi dijalankan.
+var integerDivisionOps = map[string]bool{"MOD": true, "FLOORDIV": true}
+
+// integer adalah batasan tipe untuk tipe integer yang didukung Tensor.
+type integer interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint32 | ~uint64
+}
+
+func init() {
+	registerBuiltinBinaryOps[float32]()
+	registerBuiltinBinaryOps[float64]()
+	registerBuiltinBinaryOps[int8]()
+	registerBuiltinBinaryOps[int16]()
+	registerBuiltinBinaryOps[int32]()
+	registerBuiltinBinaryOps[int64]()
+	registerBuiltinBinaryOps[uint8]()
+	registerBuiltinBinaryOps[uint32]()
+	registerBuiltinBinaryOps[uint64]()
+	registerIntegerBinaryOps[int8]()
+	registerIntegerBinaryOps[int16]()
+	registerIntegerBinaryOps[int32]()
+	registerIntegerBinaryOps[int64]()
+	registerIntegerBinaryOps[uint8]()
+	registerIntegerBinaryOps[uint32]()
+	registerIntegerBinaryOps[uint64]()
+}
+
+// registerBuiltinBinaryOps mendaftarkan ADD (dipakai ADD_TENSORS) dan MAXIMUM untuk tipe T.
+// Maksimum elemen per elemen dinamai MAXIMUM karena MAX sudah menjadi operator reduksi satu input.
+func registerBuiltinBinaryOps[T Numeric]() {
+	mustRegisterBinaryOps(map[string]func(a, b T) T{
+		"ADD":     func(a, b T) T { return a + b },
+		"MAXIMUM": maximumOp[T],
+	})
+}
+
+// registerIntegerBinaryOps mendaftarkan MOD, FLOORDIV, dan operasi bitwise BITWISE_AND, BITWISE_OR,
+// serta BITWISE_XOR untuk tipe integer T. Semuanya sengaja tidak didaftarkan untuk float, sehingga
+// tensor float ditolak dengan "does not support dtype".
+func registerIntegerBinaryOps[T integer]() {
+	mustRegisterBinaryOps(map[string]func(a, b T) T{
+		"MOD":         modOp[T],
+		"FLOORDIV":    floorDivOp[T],
+		"BITWISE_AND": func(a, b T) T { return a & b },
+		"BITWISE_OR":  func(a, b T) T { return a | b },
+		"BITWISE_XOR": func(a, b T) T { return a ^ b },
+	})
+}
+
+func mustRegisterBinaryOps[T Numeric](ops map[string]func(a, b T) T) {
+	for name, fn := range ops {
+		if err := RegisterBinaryOp(name, fn); err != nil {
+			panic(err)
+		}
+		builtinBinaryOps[name] = true
+	}
+}
+
+// modOp adalah sisa bagi a % b dengan tanda mengikuti a (seperti % di Go), misalnya -7 MOD 3 = -1.
+// Karena itu a = b*FLOORDIV(a, b) + MOD(a, b) hanya berlaku jika a dan b bertanda sama.
+func modOp[T integer](a, b T) T {
+	return a % b
+}
+
+// floorDivOp adalah pembagian yang dibulatkan ke minus tak hingga, misalnya -7 FLOORDIV 2 = -4,
+// berbeda dengan pembagian integer Go yang membulatkan ke nol.
+func floorDivOp[T integer](a, b T) T {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// maximumOp mengembalikan elemen terbesar; NaN pada salah satu operand menghasilkan NaN.
+func maximumOp[T Numeric](a, b T) T {
+	if a != a || a > b {
+		return a
+	}
+	return b
+}
+
+// RegisterBinaryOp mendaftarkan fn sebagai operasi biner elemen per elemen bernama name (tidak
+// membedakan huruf besar-kecil) untuk tensor bertipe T. Go tidak mengizinkan nilai fungsi generik,
+// jadi operasi yang mendukung beberapa tipe didaftarkan sekali per tipe; tipe yang tidak didaftarkan
+// ditolak saat eksekusi. Nama operator matematika bawaan dan pasangan nama/tipe yang sudah terdaftar
+// menghasilkan error. Registrasi hanya ada di memori proses, sedangkan kueri OP tetap dicatat ke log
+// operasi (lihat WithOpLog); operasi kustom harus didaftarkan lagi sebelum log tersebut diputar ulang.
+func RegisterBinaryOp[T Numeric](name string, fn func(a, b T) T) error {
+	if !binaryOpNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid binary operation name '%s'", name)
+	}
+	if fn == nil {
+		return fmt.Errorf("binary operation %s requires a function", name)
+	}
+	name = strings.ToUpper(name)
+	if _, ok := mathOperators[name]; ok {
+		return fmt.Errorf("binary operation %s conflicts with a built-in math operator", name)
+	}
+	dataType, err := GetDataTypeString[T]()
+	if err != nil {
+		return err
+	}
+	binaryOpsMu.Lock()
+	defer binaryOpsMu.Unlock()
+	if binaryOps[name] == nil {
+		binaryOps[name] = make(map[string]interface{})
+	}
+	if _, exists := binaryOps[name][dataType]; exists {
+		return fmt.Errorf("binary operation %s is already registered for dtype %s", name, dataType)
+	}
+	binaryOps[name][dataType] = fn
+	return nil
+}
+
+// UnregisterBinaryOp menghapus registrasi operasi biner name untuk tipe T yang sebelumnya dibuat dengan
+// RegisterBinaryOp. Operasi bawaan dan pasangan nama/tipe yang tidak terdaftar menghasilkan error.
+func UnregisterBinaryOp[T Numeric](name string) error {
+	name = strings.ToUpper(name)
+	if builtinBinaryOps[name] {
+		return fmt.Errorf("binary operation %s is built in and cannot be unregistered", name)
+	}
+	dataType, err := GetDataTypeString[T]()
+	if err != nil {
+		return err
+	}
+	binaryOpsMu.Lock()
+	defer binaryOpsMu.Unlock()
+	if _, exists := binaryOps[name][dataType]; !exists {
+		return fmt.Errorf("binary operation %s is not registered for dtype %s", name, dataType)
+	}
+	delete(binaryOps[name], dataType)
+	if len(binaryOps[name]) == 0 {
+		delete(binaryOps, name)
+	}
+	return nil
+}
+
+// binaryOpDataTypes mengembalikan tipe data yang didaftarkan untuk operasi name dalam urutan
+// numericDataTypes, atau nil jika operasi tidak terdaftar.
+func binaryOpDataTypes(name string) []string {
+	binaryOpsMu.RLock()
+	defer binaryOpsMu.RUnlock()
+	fns, ok := binaryOps[name]
+	if !ok {
+		return nil
+	}
+	var dataTypes []string
+	for _, dt := range numericDataTypes {
+		if _, ok := fns[dt]; ok {
+			dataTypes = append(dataTypes, dt)
+		}
+	}
+	return dataTypes
+}
+
+// ApplyBinaryOp menerapkan operasi terdaftar name pada setiap pasangan elemen a dan b, yang harus
+// berbentuk dan bertipe sama. Tensor besar diproses paralel (lihat parallelFor), jadi fn harus aman
+// dipanggil bersamaan. Pembagi nol pada MOD dan FLOORDIV menghasilkan error yang menyebut indeks datar
+// elemennya; panic di dalam fungsi operasi lain dikembalikan sebagai error.
+func ApplyBinaryOp[T Numeric](name string, a, b *Tensor[T]) (result *Tensor[T], err error) {
+	if !ShapesEqual(a.Shape, b.Shape) {
+		return nil, fmt.Errorf("shape %v of tensor '%s' does not match shape %v of tensor '%s'", b.Shape, b.Name, a.Shape, a.Name)
+	}
+	if a.DataType != b.DataType {
+		return nil, fmt.Errorf("data types of %s (%s) and %s (%s) do not match for %s", a.Name, a.DataType, b.Name, b.DataType, name)
+	}
+	name = strings.ToUpper(name)
+	binaryOpsMu.RLock()
+	fn, ok := binaryOps[name][storageDataType(a.DataType)].(func(a, b T) T)
+	binaryOpsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("operation %s does not support dtype %s", name, a.DataType)
+	}
+	if integerDivisionOps[name] {
+		for i, v := range b.Data {
+			if v == 0 {
+				return nil, fmt.Errorf("operation %s: integer division by zero at flat index %d", name, i)
+			}
+		}
+	}
+
+	result, err = NewTensor[T]("temp_"+strings.ToLower(name)+"_result", a.Shape, a.DataType)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("operation %s failed: %v", name, r)
+		}
+	}()
+	parallelFor(len(result.Data), func(start, end int) {
+		for i := start; i < end; i++ {
+			result.Data[i] = fn(a.Data[i], b.Data[i])
+		}
+	})
+	return result, nil
+}
@@ -0,0 +1,76 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// CompareFormatted membandingkan dua hasil FormatMultidimensional (struktur []interface{} bersarang).
+// Jika berbeda, string kedua berisi path perbedaan pertama, mis. "[1][2]: expected 5 got 6".
+// Ditujukan untuk test agar kegagalan pada hasil SELECT mudah dilacak.
+func CompareFormatted(expected, actual interface{}) (bool, string) {
+	return compareFormattedAt("", expected, actual)
+}
+
+func compareFormattedAt(path string, expected, actual interface{}) (bool, string) {
+	expSlice, expIsSlice := expected.([]interface{})
+	actSlice, actIsSlice := actual.([]interface{})
+	if expIsSlice && actIsSlice {
+		if len(expSlice) != len(actSlice) {
+			return false, fmt.Sprintf("%s: expected length %d got %d", displayPath(path), len(expSlice), len(actSlice))
+		}
+		for i := range expSlice {
+			if ok, diff := compareFormattedAt(fmt.Sprintf("%s[%d]", path, i), expSlice[i], actSlice[i]); !ok {
+				return false, diff
+			}
+		}
+		return true, ""
+	}
+	if expIsSlice != actIsSlice {
+		return false, fmt.Sprintf("%s: expected %v (%T) got %v (%T)", displayPath(path), expected, expected, actual, actual)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		if reflect.TypeOf(expected) != reflect.TypeOf(actual) {
+			return false, fmt.Sprintf("%s: expected %v (%T) got %v (%T)", displayPath(path), expected, expected, actual, actual)
+		}
+		return false, fmt.Sprintf("%s: expected %v got %v", displayPath(path), expected, actual)
+	}
+	return true, ""
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
+
+// FirstNotClose mengembalikan indeks pertama i di mana |a[i]-b[i]| > atol + rtol*|b[i]| (semantik
+// numpy.allclose, NaN tidak pernah dianggap dekat), atau -1 jika semua elemen berdekatan. Elemen yang
+// persis sama selalu dekat, termasuk tak hingga bertanda sama yang selisihnya NaN; tak hingga tidak
+// pernah dekat dengan nilai lain, walaupun toleransi rtol*|b| ikut tak hingga. a dan b harus sama
+// panjang.
+func FirstNotClose(a, b []float64, rtol, atol float64) int {
+	for i := range a {
+		if a[i] == b[i] {
+			continue
+		}
+		if math.IsInf(a[i], 0) || math.IsInf(b[i], 0) || !(math.Abs(a[i]-b[i]) <= atol+rtol*math.Abs(b[i])) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FirstNotEqual mengembalikan indeks pertama i di mana a[i] != b[i], atau -1 jika semua elemen sama.
+// Dipakai untuk tensor integer yang dibandingkan persis, tanpa konversi ke float64 yang dapat
+// menghilangkan presisi nilai di atas 2^53. a dan b harus sama panjang.
+func FirstNotEqual[T comparable](a, b []T) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return -1
+}
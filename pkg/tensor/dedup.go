@@ -0,0 +1,325 @@
+package tensor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BlobDirName adalah nama subdirektori di dalam direktori data tempat blob data mode dedup disimpan.
+const BlobDirName = "blobs"
+
+// dataRefRegex mencocokkan nilai data_ref yang valid: hash SHA-256 heksadesimal huruf kecil.
+var dataRefRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// WithDedup mengaktifkan penyimpanan data berbasis konten. Data tensor yang disimpan ditulis sebagai
+// blob BlobDirName/<sha256>.data dan file .meta merujuknya lewat field data_ref, sehingga tensor
+// berisi byte identik (mis. CREATE ... FROM SELECT tanpa perubahan atau INSERT data yang sama) berbagi
+// satu file di disk. Jumlah rujukan dibangun dari file .meta saat storage dibuka lalu dilacak di memori:
+// blob dihapus setelah tensor terakhir yang merujuknya dihapus atau ditimpa. Penulisan di tempat
+// (UPDATE, INSERT ke slice, operasi IN PLACE) lebih dulu menyalin blob menjadi file .data milik tensor
+// itu sendiri (copy-on-write).
+func WithDedup() StorageOption {
+	return func(s *Storage) {
+		s.dedup = true
+	}
+}
+
+// blobPath mengembalikan path blob dengan hash ref.
+func (s *Storage) blobPath(ref string) string {
+	return filepath.Join(s.dataDir, BlobDirName, ref+".data")
+}
+
+// dataFilePath mengembalikan path file data tensor name: blob yang dirujuk metadata jika ada data_ref,
+// selain itu name.data. Tanpa mode dedup, file name.data yang ada dipakai tanpa membaca metadata.
+func (s *Storage) dataFilePath(name string) string {
+	dataFile := filepath.Join(s.dataDir, name+".data")
+	if !s.dedup {
+		if _, err := os.Stat(dataFile); err == nil {
+			return dataFile
+		}
+	}
+	if metadata, err := s.LoadTensorMetadata(name); err == nil && metadata.DataRef != "" {
+		return s.blobPath(metadata.DataRef)
+	}
+	return dataFile
+}
+
+// blobRef mengembalikan rujukan blob untuk data: hash SHA-256 heksadesimal huruf kecil.
+func blobRef(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// writeBlob menyimpan data sebagai blob berdasarkan hash SHA-256-nya dan mengembalikan hash tersebut.
+// Blob yang sudah ada tidak ditulis ulang. Harus dipanggil dengan blobMu terkunci.
+func (s *Storage) writeBlob(data []byte, tensorName string) (string, error) {
+	ref := blobRef(data)
+	path := s.blobPath(ref)
+	if _, err := os.Stat(path); err == nil {
+		return ref, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	tmp, err := s.createTempFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create blob %s for tensor %s: %w", ref, tensorName, err)
+	}
+	tmpPath := tmp.Name()
+	errFill := s.fillDataFile(tmp, data, tensorName)
+	errClose := tmp.Close()
+	if errFill != nil || errClose != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write blob %s for tensor %s: %w", ref, tensorName, errors.Join(errFill, errClose))
+	}
+	return ref, s.publishBlob(tmpPath, ref, tensorName)
+}
+
+// publishBlob memasang file sementara tmpPath yang berisi data dengan hash ref sebagai blob ref. Jika
+// blob tersebut sudah ada, tmpPath cukup dihapus. Harus dipanggil dengan blobMu terkunci.
+func (s *Storage) publishBlob(tmpPath, ref, tensorName string) error {
+	path := s.blobPath(ref)
+	if _, err := os.Stat(path); err == nil {
+		os.Remove(tmpPath)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to publish blob %s for tensor %s: %w", ref, tensorName, err)
+	}
+	return nil
+}
+
+// saveBlobTensor menyimpan data tensor sebagai blob lalu memublikasikan metadata yang merujuknya.
+// Rujukan lama tensor yang ditimpa (blob lain atau file .data sendiri) dilepas setelahnya.
+func (s *Storage) saveBlobTensor(metadata *TensorMetadata, data []byte, exclusive bool) error {
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+
+	var oldRef string
+	if !exclusive {
+		if old, err := s.LoadTensorMetadata(metadata.Name); err == nil {
+			oldRef = old.DataRef
+		}
+	}
+	ref, err := s.writeBlob(data, metadata.Name)
+	if err != nil {
+		return err
+	}
+	return s.publishBlobMetadata(metadata, ref, oldRef, exclusive)
+}
+
+// publishBlobMetadata memublikasikan metadata yang merujuk blob ref (yang sudah terpasang), menghapus
+// file .data lama milik tensor, dan melepas rujukan lamanya oldRef. Jika metadata gagal ditulis, blob
+// ref dilepas kembali. Harus dipanggil dengan blobMu terkunci.
+func (s *Storage) publishBlobMetadata(metadata *TensorMetadata, ref, oldRef string, exclusive bool) error {
+	metadata.DataRef = ref
+	if err := s.writeMetadataFile(metadata, exclusive); err != nil {
+		s.releaseBlobLocked(ref)
+		return err
+	}
+	dataFile := filepath.Join(s.dataDir, metadata.Name+".data")
+	if err := os.Remove(dataFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove data file %s: %w", dataFile, err)
+	}
+	if oldRef != "" && oldRef != ref {
+		if err := s.releaseBlobLocked(oldRef); err != nil {
+			return err
+		}
+	}
+	return s.syncDataDir()
+}
+
+// releaseBlob menghapus blob ref jika tidak ada lagi metadata yang merujuknya.
+func (s *Storage) releaseBlob(ref string) error {
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	return s.releaseBlobLocked(ref)
+}
+
+// releaseBlobLocked adalah releaseBlob untuk pemanggil yang sudah memegang blobMu. Rujukan diambil
+// dari blobRefs, sehingga pelepasan tidak perlu membaca ulang semua file .meta.
+func (s *Storage) releaseBlobLocked(ref string) error {
+	if s.blobRefs.count(ref) > 0 {
+		return nil
+	}
+	path := s.blobPath(ref)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove blob %s: %w", path, err)
+	}
+	return nil
+}
+
+// blobRefTable mencatat blob yang dirujuk setiap tensor. Tabel dibangun sekali dari file .meta saat
+// Storage dibuka (termasuk Reopen, sehingga hitungan tetap benar setelah crash) lalu diperbarui setiap
+// kali file .meta ditulis atau dihapus. Perubahan file .meta di luar Storage ini tidak terlihat sampai
+// direktori data dibuka ulang.
+type blobRefTable struct {
+	mu         sync.Mutex
+	byTensor   map[string]string   // Nama tensor -> data_ref
+	counts     map[string]int      // data_ref -> jumlah tensor yang merujuknya
+	unreadable map[string]struct{} // Tensor yang file .meta-nya gagal dibaca saat tabel dibangun
+}
+
+// loadBlobRefTable membangun blobRefTable dari file .meta di direktori data s.
+func loadBlobRefTable(s *Storage) (*blobRefTable, error) {
+	t := &blobRefTable{
+		byTensor:   make(map[string]string),
+		counts:     make(map[string]int),
+		unreadable: make(map[string]struct{}),
+	}
+	entries, err := os.ReadDir(s.dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data directory %s: %w", s.dataDir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".meta") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".meta")
+		metadata, err := s.loadTensorMetadataInternal(filepath.Join(s.dataDir, entry.Name()))
+		if err != nil {
+			t.unreadable[name] = struct{}{}
+			continue
+		}
+		t.set(name, metadata.DataRef)
+	}
+	return t, nil
+}
+
+// set mencatat bahwa file .meta tensor name kini merujuk ref (kosong jika tanpa blob), menggantikan
+// rujukan sebelumnya.
+func (t *blobRefTable) set(name, ref string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.clearLocked(name)
+	if ref != "" {
+		t.byTensor[name] = ref
+		t.counts[ref]++
+	}
+}
+
+// remove mencatat bahwa file .meta tensor name sudah dihapus.
+func (t *blobRefTable) remove(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.clearLocked(name)
+}
+
+// clearLocked melepas rujukan tensor name. Harus dipanggil dengan mu terkunci.
+func (t *blobRefTable) clearLocked(name string) {
+	delete(t.unreadable, name)
+	ref, ok := t.byTensor[name]
+	if !ok {
+		return
+	}
+	delete(t.byTensor, name)
+	if t.counts[ref]--; t.counts[ref] <= 0 {
+		delete(t.counts, ref)
+	}
+}
+
+// count mengembalikan jumlah tensor yang merujuk blob ref. Metadata yang gagal dibaca saat tabel
+// dibangun tidak bisa dipastikan tidak merujuk blob, sehingga ikut dihitung sebagai rujukan sampai
+// file .meta tersebut ditulis ulang atau dihapus.
+func (t *blobRefTable) count(ref string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.counts[ref] + len(t.unreadable)
+}
+
+// referrers mengembalikan nama tensor yang merujuk blob ref, terurut.
+func (t *blobRefTable) referrers(ref string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var names []string
+	for name, r := range t.byTensor {
+		if r == ref {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TensorsSharingData mengembalikan nama tensor lain (terurut) yang berbagi blob data dedup yang sama
+// dengan tensor name, misalnya untuk memeriksa dampak penghapusan terhadap pemakaian disk. Tensor
+// yang datanya tidak disimpan sebagai blob (tanpa data_ref) menghasilkan daftar kosong.
+func (s *Storage) TensorsSharingData(name string) ([]string, error) {
+	metadata, err := s.LoadTensorMetadata(name)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.DataRef == "" {
+		return []string{}, nil
+	}
+	sharing := []string{}
+	for _, other := range s.blobRefs.referrers(metadata.DataRef) {
+		if other != metadata.Name {
+			sharing = append(sharing, other)
+		}
+	}
+	return sharing, nil
+}
+
+// detachBlob menyiapkan tensor untuk penulisan di tempat: jika metadata merujuk blob bersama, isi blob
+// disalin ke file .data milik tensor, metadata ditulis ulang tanpa data_ref, dan rujukan blob dilepas.
+// Metadata yang dikembalikan harus dipakai pemanggil sebagai pengganti metadata.
+func (s *Storage) detachBlob(metadata *TensorMetadata) (*TensorMetadata, error) {
+	if metadata.DataRef == "" {
+		return metadata, nil
+	}
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+
+	blobFile := s.blobPath(metadata.DataRef)
+	src, err := os.Open(blobFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob %s for tensor %s: %w", blobFile, metadata.Name, err)
+	}
+	defer src.Close()
+	dataFile := filepath.Join(s.dataDir, metadata.Name+".data")
+	tmp, err := s.createTempFile(dataFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create data file %s: %w", dataFile, err)
+	}
+	tmpPath := tmp.Name()
+	_, errCopy := io.Copy(tmp, src)
+	var errSync error
+	if errCopy == nil {
+		errSync = s.syncFile(tmp)
+	}
+	errClose := tmp.Close()
+	if errCopy != nil || errSync != nil || errClose != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to copy blob for tensor %s: %w", metadata.Name, errors.Join(errCopy, errSync, errClose))
+	}
+	if err := os.Rename(tmpPath, dataFile); err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to replace data file %s for tensor %s: %w", dataFile, metadata.Name, err)
+	}
+
+	detached := *metadata
+	detached.DataRef = ""
+	if err := s.writeMetadataFile(&detached, false); err != nil {
+		os.Remove(dataFile)
+		return nil, err
+	}
+	if err := s.releaseBlobLocked(metadata.DataRef); err != nil {
+		return nil, err
+	}
+	return &detached, s.syncDataDir()
+}
@@ -0,0 +1,94 @@
+package tensor
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/edsrzf/mmap-go"
+)
+
+// Durability menentukan seberapa keras Storage memastikan tulisan sampai ke disk.
+// Tingkat yang lebih tinggi lebih tahan crash tetapi memperlambat penulisan.
+type Durability int
+
+const (
+	// DurabilityNone tidak melakukan flush; halaman kotor ditulis oleh OS kapan saja.
+	DurabilityNone Durability = iota
+	// DurabilityFlush melakukan flush (msync) pada mmap data setelah ditulis. Ini perilaku default.
+	DurabilityFlush
+	// DurabilityFullSync melakukan flush lalu fsync pada file data dan metadata, serta fsync
+	// direktori data setelah file dibuat, diganti nama, atau dihapus agar entri direktori ikut tahan crash.
+	DurabilityFullSync
+)
+
+// FileSyncer melakukan fsync file dan direktori. Storage memakai implementasi berbasis os secara
+// default; implementasi lain dapat disuntikkan dengan WithFileSyncer (mis. untuk mencatat panggilan).
+type FileSyncer interface {
+	SyncFile(f *os.File) error
+	SyncDir(dir string) error
+}
+
+// osFileSyncer adalah FileSyncer default yang memanggil fsync sistem operasi.
+type osFileSyncer struct{}
+
+func (osFileSyncer) SyncFile(f *os.File) error {
+	return f.Sync()
+}
+
+func (osFileSyncer) SyncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// WithDurability mengatur tingkat durabilitas penulisan (default DurabilityFlush).
+func WithDurability(d Durability) StorageOption {
+	return func(s *Storage) {
+		s.durability = d
+	}
+}
+
+// WithFileSyncer mengganti FileSyncer yang dipakai pada mode DurabilityFullSync.
+func WithFileSyncer(syncer FileSyncer) StorageOption {
+	return func(s *Storage) {
+		s.syncer = syncer
+	}
+}
+
+// flushMmap menuliskan perubahan mmap data sesuai tingkat durabilitas. Pada DurabilityFullSync
+// file di balik mmap juga di-fsync.
+func (s *Storage) flushMmap(m mmap.MMap, f *os.File, tensorName string) error {
+	if s.durability == DurabilityNone {
+		return nil
+	}
+	if err := m.Flush(); err != nil {
+		return fmt.Errorf("failed to flush mmap for tensor %s: %w", tensorName, err)
+	}
+	return s.syncFile(f)
+}
+
+// syncFile melakukan fsync f jika mode DurabilityFullSync aktif.
+func (s *Storage) syncFile(f *os.File) error {
+	if s.durability < DurabilityFullSync || f == nil {
+		return nil
+	}
+	if err := s.syncer.SyncFile(f); err != nil {
+		return fmt.Errorf("failed to sync %s: %w", f.Name(), err)
+	}
+	return nil
+}
+
+// syncDataDir melakukan fsync direktori data jika mode DurabilityFullSync aktif, sehingga
+// pembuatan, penggantian nama, dan penghapusan file tensor tercatat permanen.
+func (s *Storage) syncDataDir() error {
+	if s.durability < DurabilityFullSync {
+		return nil
+	}
+	if err := s.syncer.SyncDir(s.dataDir); err != nil {
+		return fmt.Errorf("failed to sync data directory %s: %w", s.dataDir, err)
+	}
+	return nil
+}
@@ -2,35 +2,109 @@ package tensor
 
 import (
 	"bytes"
+	"container/list"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io/fs"
+	"math"
+	"math/rand"
 	"os"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/edsrzf/mmap-go"
 )
 
+// DefaultMaxOpenFiles adalah batas default jumlah file/mmap yang di-cache oleh Executor.
+const DefaultMaxOpenFiles = 128
+
 type Executor struct {
 	storage   *Storage
 	mmaps     map[string]mmap.MMap
 	mmapsMux  sync.Mutex
 	openFiles map[string]*os.File
+
+	// maxOpenFiles membatasi jumlah handle hasil pemuatan penuh yang di-cache; handle yang paling lama
+	// tidak dipakai di-unmap dan ditutup saat batas terlampaui (LRU). mmaps dan openFiles hanya berisi
+	// handle ter-cache. Handle dari GetTensorMmap milik pemanggil, tidak dicatat per nama, dan hanya
+	// dilepas oleh fungsi cleanup-nya; borrowed menghitung handle tersebut.
+	maxOpenFiles int
+	borrowed     int
+	lru          *list.List                 // Nama tensor ter-cache, paling baru dipakai di depan
+	lruElems     map[string]*list.Element   // Nama tensor -> elemen di lru
+	handleMeta   map[string]*TensorMetadata // Metadata saat handle ter-cache dibuka dan checksum-nya diverifikasi
+	// handleReaders dipegang (RLock) selama data dibaca langsung dari mmap ter-cache tanpa mmapsMux;
+	// pelepasan handle ter-cache menunggu (Lock) hingga pembacaan tersebut selesai.
+	handleReaders sync.RWMutex
+
+	queryCache *queryCache // Cache hasil kueri baca (nil = nonaktif, lihat WithQueryCache)
+
+	unsafeReads bool             // Lihat WithUnsafeReads
+	opts        []ExecutorOption // Opsi pembuatan, dipakai ulang oleh Reopen
+}
+
+// ExecutorOption mengonfigurasi Executor saat dibuat dengan NewExecutor.
+type ExecutorOption func(*Executor)
+
+// WithMaxOpenFiles mengatur batas jumlah file/mmap yang di-cache (default DefaultMaxOpenFiles).
+// Pemuatan penuh berikutnya atas tensor ter-cache membaca ulang mmap yang sama tanpa membuka file dan
+// memverifikasi checksum lagi. Nilai 0 atau negatif menonaktifkan cache: setiap handle ditutup segera
+// setelah data dibaca.
+func WithMaxOpenFiles(n int) ExecutorOption {
+	return func(e *Executor) {
+		e.maxOpenFiles = n
+	}
 }
 
-func NewExecutor(storage *Storage) *Executor {
-	return &Executor{
-		storage:   storage,
-		mmaps:     make(map[string]mmap.MMap),
-		openFiles: make(map[string]*os.File),
+// WithUnsafeReads mengaktifkan jalur cepat ReadDataUnsafe untuk pemuatan tensor penuh (SELECT, GET DATA
+// tanpa batch, input operasi matematika) pada host little-endian. Hasilnya identik dengan jalur default;
+// pemanggil yang tidak ingin memakai unsafe cukup tidak mengaktifkan opsi ini.
+func WithUnsafeReads() ExecutorOption {
+	return func(e *Executor) {
+		e.unsafeReads = true
+	}
+}
+
+func NewExecutor(storage *Storage, opts ...ExecutorOption) *Executor {
+	e := &Executor{
+		storage:      storage,
+		mmaps:        make(map[string]mmap.MMap),
+		openFiles:    make(map[string]*os.File),
+		maxOpenFiles: DefaultMaxOpenFiles,
+		lru:          list.New(),
+		lruElems:     make(map[string]*list.Element),
+		handleMeta:   make(map[string]*TensorMetadata),
+		opts:         opts,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Reopen menutup semua handle Executor lalu mengembalikan Executor baru dengan opsi yang sama di atas
+// Storage yang dibuka ulang (lihat Storage.Reopen). Executor lama tidak boleh dipakai lagi.
+func (e *Executor) Reopen() (*Executor, error) {
+	if err := e.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close executor before reopen: %w", err)
+	}
+	storage, err := e.storage.Reopen()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reopen storage: %w", err)
 	}
+	return NewExecutor(storage, e.opts...), nil
 }
 
 func (e *Executor) Close() error {
 	e.mmapsMux.Lock()
 	defer e.mmapsMux.Unlock()
+	e.handleReaders.Lock()
+	defer e.handleReaders.Unlock()
 	var overallErr error
 	for name, m := range e.mmaps {
 		currentTensorName := name
@@ -56,120 +130,204 @@ func (e *Executor) Close() error {
 		}
 	}
 	e.openFiles = make(map[string]*os.File)
+	e.lru.Init()
+	e.lruElems = make(map[string]*list.Element)
+	e.handleMeta = make(map[string]*TensorMetadata)
 	return overallErr
 }
 
-func loadFullTensorTyped[T Numeric](e *Executor, tensorName string, metadata *TensorMetadata) (*Tensor[T], error) {
+// OpenHandleCount mengembalikan jumlah file tensor yang sedang dibuka oleh Executor
+// (handle ter-cache ditambah handle yang sedang dipinjam lewat GetTensorMmap).
+func (e *Executor) OpenHandleCount() int {
 	e.mmapsMux.Lock()
-	if oldMmap, exists := e.mmaps[tensorName]; exists {
-		if oldMmap != nil {
-			oldMmap.Unmap()
-		}
-		delete(e.mmaps, tensorName)
+	defer e.mmapsMux.Unlock()
+	return len(e.openFiles) + e.borrowed
+}
+
+// releaseHandleLocked melepas (unmap dan close) handle ter-cache tensor, jika ada. Handle yang dipinjam
+// lewat GetTensorMmap tidak tercatat per nama sehingga tidak pernah tersentuh. Pemanggil harus memegang
+// mmapsMux.
+func (e *Executor) releaseHandleLocked(tensorName string) {
+	if _, cached := e.lruElems[tensorName]; !cached {
+		return
 	}
-	if oldFile, exists := e.openFiles[tensorName]; exists {
-		if oldFile != nil {
-			oldFile.Close()
-		}
-		delete(e.openFiles, tensorName)
+	e.handleReaders.Lock()
+	defer e.handleReaders.Unlock()
+	if m, ok := e.mmaps[tensorName]; ok && m != nil {
+		m.Unmap()
 	}
-	e.mmapsMux.Unlock()
+	delete(e.mmaps, tensorName)
+	if f, ok := e.openFiles[tensorName]; ok && f != nil {
+		f.Close()
+	}
+	delete(e.openFiles, tensorName)
+	if elem, ok := e.lruElems[tensorName]; ok {
+		e.lru.Remove(elem)
+		delete(e.lruElems, tensorName)
+	}
+	delete(e.handleMeta, tensorName)
+}
 
-	totalElements := 1
-	if len(metadata.Shape) == 0 {
-		totalElements = 1
-	} else {
-		isZeroDim := false
-		for _, dim := range metadata.Shape {
-			if dim == 0 {
-				isZeroDim = true
-				break
-			}
-			totalElements *= dim
+// releaseCachedHandles melepas handle ter-cache untuk tensorNames. Handle yang sedang dipinjam lewat
+// GetTensorMmap tidak disentuh.
+func (e *Executor) releaseCachedHandles(tensorNames []string) {
+	e.mmapsMux.Lock()
+	defer e.mmapsMux.Unlock()
+	for _, name := range tensorNames {
+		e.releaseHandleLocked(name)
+	}
+}
+
+// cachedMmapLocked mengembalikan mmap ter-cache untuk tensorName dan menandainya sebagai paling baru
+// dipakai, atau nil jika tidak ada. Handle yang dibuka untuk metadata berbeda (shape, tipe data, atau
+// checksum berubah, mis. ditulis di luar Executor ini) dilepas dan dianggap tidak ada. Pemanggil harus
+// memegang mmapsMux.
+func (e *Executor) cachedMmapLocked(tensorName string, metadata *TensorMetadata) mmap.MMap {
+	elem, ok := e.lruElems[tensorName]
+	if !ok {
+		return nil
+	}
+	if !sameTensorContent(e.handleMeta[tensorName], metadata) {
+		e.releaseHandleLocked(tensorName)
+		return nil
+	}
+	e.lru.MoveToFront(elem)
+	return e.mmaps[tensorName]
+}
+
+// sameTensorContent melaporkan apakah a dan b mendeskripsikan isi file data yang sama. Metadata tanpa
+// checksum hanya dibandingkan shape dan tipe datanya.
+func sameTensorContent(a, b *TensorMetadata) bool {
+	if a == nil || b == nil || a.DataType != b.DataType || !ShapesEqual(a.Shape, b.Shape) {
+		return false
+	}
+	if a.Checksum == nil || b.Checksum == nil {
+		return a.Checksum == nil && b.Checksum == nil
+	}
+	return *a.Checksum == *b.Checksum
+}
+
+// cacheHandle menyimpan handle hasil pemuatan penuh ke cache LRU, lalu mengusir handle yang paling
+// lama tidak dipakai selama jumlah handle ter-cache melebihi maxOpenFiles. Jika cache dinonaktifkan,
+// handle langsung ditutup.
+func (e *Executor) cacheHandle(tensorName string, metadata *TensorMetadata, file *os.File, mmapInstance mmap.MMap) {
+	if e.maxOpenFiles <= 0 {
+		if mmapInstance != nil {
+			mmapInstance.Unmap()
 		}
-		if isZeroDim {
-			totalElements = 0
+		if file != nil {
+			file.Close()
 		}
+		return
+	}
+
+	e.mmapsMux.Lock()
+	defer e.mmapsMux.Unlock()
+	e.releaseHandleLocked(tensorName)
+	e.mmaps[tensorName] = mmapInstance
+	e.openFiles[tensorName] = file
+	e.lruElems[tensorName] = e.lru.PushFront(tensorName)
+	e.handleMeta[tensorName] = metadata
+	for e.lru.Len() > e.maxOpenFiles {
+		oldest := e.lru.Back()
+		e.releaseHandleLocked(oldest.Value.(string))
 	}
+}
 
+// loadFullTensorTyped memuat seluruh data tensor sebagai T. Jika handle tensor masih ter-cache untuk
+// metadata yang sama, data dibaca ulang dari mmap tersebut; selain itu file dibuka, checksum-nya
+// diverifikasi, dan handle-nya di-cache (lihat cacheHandle).
+func loadFullTensorTyped[T Numeric](e *Executor, tensorName string, metadata *TensorMetadata) (*Tensor[T], error) {
+	totalElements := tNilaiTotalElemen(metadata.Shape)
 	elementSize, err := GetElementSize(metadata.DataType)
 	if err != nil {
 		return nil, fmt.Errorf("loadFullTensorTyped: %w", err)
 	}
 
+	e.mmapsMux.Lock()
+	cached := e.cachedMmapLocked(tensorName, metadata)
+	if cached != nil {
+		e.handleReaders.RLock()
+	} else {
+		e.releaseHandleLocked(tensorName)
+	}
+	e.mmapsMux.Unlock()
+	if cached != nil {
+		data, err := readFullData[T](e, cached, totalElements, metadata.DataType)
+		e.handleReaders.RUnlock()
+		if err != nil {
+			return nil, fmt.Errorf("loadFullTensorTyped: failed to read data for %s: %w", tensorName, err)
+		}
+		return newLoadedTensor(tensorName, metadata, data)
+	}
+
 	file, mmapInstance, err := e.storage.OpenFileAndMmap(tensorName, totalElements, elementSize)
 	if err != nil {
 		return nil, fmt.Errorf("loadFullTensorTyped: failed to open/mmap file for %s: %w", tensorName, err)
 	}
+	// Handle tetap privat selama data dibaca agar tidak dapat diusir oleh pemuatan lain secara bersamaan;
+	// setelah itu handle di-cache (atau ditutup jika cache dinonaktifkan), dan ditutup pada jalur error.
+	closeHandle := func() {
+		if mmapInstance != nil {
+			mmapInstance.Unmap()
+		}
+		if file != nil {
+			file.Close()
+		}
+	}
 
-	e.mmapsMux.Lock()
-	e.mmaps[tensorName] = mmapInstance
-	e.openFiles[tensorName] = file
-	e.mmapsMux.Unlock()
+	// Pemuatan penuh membaca file dari awal hingga akhir.
+	e.storage.AdviseMmap(mmapInstance, AccessSequential)
+
+	if err := verifyChecksum(metadata, mmapInstance); err != nil {
+		closeHandle()
+		return nil, fmt.Errorf("loadFullTensorTyped: %w", err)
+	}
 
-	var data []T
-	data, err = ReadData[T](mmapInstance, totalElements, metadata.DataType)
+	data, err := readFullData[T](e, mmapInstance, totalElements, metadata.DataType)
 	if err != nil {
-		e.mmapsMux.Lock()
-		if m, ok := e.mmaps[tensorName]; ok && m != nil {
-			m.Unmap()
-		}
-		delete(e.mmaps, tensorName)
-		if f, ok := e.openFiles[tensorName]; ok && f != nil {
-			f.Close()
-		}
-		delete(e.openFiles, tensorName)
-		e.mmapsMux.Unlock()
+		closeHandle()
 		return nil, fmt.Errorf("loadFullTensorTyped: failed to read data for %s: %w", tensorName, err)
 	}
+	tensorInstance, err := newLoadedTensor(tensorName, metadata, data)
+	if err != nil {
+		closeHandle()
+		return nil, err
+	}
+	e.cacheHandle(tensorName, metadata, file, mmapInstance)
+	return tensorInstance, nil
+}
+
+// readFullData mendekode totalElements elemen dari mmap, lewat ReadDataUnsafe jika WithUnsafeReads aktif.
+func readFullData[T Numeric](e *Executor, mmapInstance mmap.MMap, totalElements int, dataType string) ([]T, error) {
+	if e.unsafeReads {
+		return ReadDataUnsafe[T](mmapInstance, totalElements, dataType)
+	}
+	return ReadData[T](mmapInstance, totalElements, dataType)
+}
 
-	dataTypeStrForT, _ := GetDataTypeString[T]()
-	tensorInstance, err := NewTensor[T](metadata.Name, metadata.Shape, dataTypeStrForT)
+// newLoadedTensor membungkus data hasil pemuatan penuh menjadi Tensor dengan shape dan strides metadata.
+func newLoadedTensor[T Numeric](tensorName string, metadata *TensorMetadata, data []T) (*Tensor[T], error) {
+	tensorInstance, err := NewTensor[T](metadata.Name, metadata.Shape, metadata.DataType)
 	if err != nil {
-		e.mmapsMux.Lock()
-		if m, ok := e.mmaps[tensorName]; ok && m != nil {
-			m.Unmap()
-		}
-		delete(e.mmaps, tensorName)
-		if f, ok := e.openFiles[tensorName]; ok && f != nil {
-			f.Close()
-		}
-		delete(e.openFiles, tensorName)
-		e.mmapsMux.Unlock()
 		return nil, fmt.Errorf("loadFullTensorTyped: failed to create tensor instance for %s: %w", tensorName, err)
 	}
 	if err := tensorInstance.SetData(data); err != nil {
-		e.mmapsMux.Lock()
-		if m, ok := e.mmaps[tensorName]; ok && m != nil {
-			m.Unmap()
-		}
-		delete(e.mmaps, tensorName)
-		if f, ok := e.openFiles[tensorName]; ok && f != nil {
-			f.Close()
-		}
-		delete(e.openFiles, tensorName)
-		e.mmapsMux.Unlock()
 		return nil, fmt.Errorf("loadFullTensorTyped: failed to set data for tensor %s: %w", tensorName, err)
 	}
 	tensorInstance.Strides = metadata.Strides
 	return tensorInstance, nil
 }
 
+// GetTensorMmap mengembalikan mmap data tensor tanpa verifikasi checksum. Penulisan langsung melalui
+// mmap ini tidak memperbarui checksum di .meta, sehingga pemuatan berikutnya akan gagal. Pada mode
+// dedup (WithDedup) mmap menunjuk blob bersama, jadi penulisan juga mengenai tensor lain yang merujuknya.
+// Handle yang dikembalikan milik pemanggil: kueri lain, pengusiran LRU, maupun Close tidak melepasnya,
+// sehingga pemanggil wajib memanggil fungsi cleanup (aman dipanggil lebih dari sekali).
 func (e *Executor) GetTensorMmap(tensorName string) (*TensorMetadata, *os.File, mmap.MMap, func() error, error) {
-	e.mmapsMux.Lock()
-	if oldMmap, exists := e.mmaps[tensorName]; exists {
-		if oldMmap != nil {
-			oldMmap.Unmap()
-		}
-		delete(e.mmaps, tensorName)
-	}
-	if oldFile, exists := e.openFiles[tensorName]; exists {
-		if oldFile != nil {
-			oldFile.Close()
-		}
-		delete(e.openFiles, tensorName)
-	}
-	e.mmapsMux.Unlock()
+	// Handle ter-cache dilepas agar pemuatan berikutnya membuka ulang file dan memverifikasi checksum
+	// terhadap tulisan lewat mmap ini.
+	e.releaseCachedHandles([]string{tensorName})
 
 	metadata, file, mmapInstance, storageErr := e.storage.GetTensorMmap(tensorName)
 	if storageErr != nil {
@@ -177,37 +335,102 @@ func (e *Executor) GetTensorMmap(tensorName string) (*TensorMetadata, *os.File,
 	}
 
 	e.mmapsMux.Lock()
-	e.mmaps[tensorName] = mmapInstance
-	e.openFiles[tensorName] = file
+	e.borrowed++
 	e.mmapsMux.Unlock()
 
+	var once sync.Once
+	var cleanupErr error
 	cleanupFunc := func() error {
-		e.mmapsMux.Lock()
-		defer e.mmapsMux.Unlock()
-		var firstCleanupErr error
-		if m, ok := e.mmaps[tensorName]; ok {
-			if m != nil {
-				if errUnmap := m.Unmap(); errUnmap != nil {
-					firstCleanupErr = fmt.Errorf("cleanupFunc for %s: failed to unmap: %w", tensorName, errUnmap)
+		once.Do(func() {
+			if mmapInstance != nil {
+				if errUnmap := mmapInstance.Unmap(); errUnmap != nil {
+					cleanupErr = fmt.Errorf("cleanupFunc for %s: failed to unmap: %w", tensorName, errUnmap)
 				}
 			}
-			delete(e.mmaps, tensorName)
-		}
-		if f, ok := e.openFiles[tensorName]; ok {
-			if f != nil {
-				if errClose := f.Close(); errClose != nil {
-					if firstCleanupErr == nil {
-						firstCleanupErr = fmt.Errorf("cleanupFunc for %s: failed to close file: %w", tensorName, errClose)
-					}
+			if file != nil {
+				if errClose := file.Close(); errClose != nil && cleanupErr == nil {
+					cleanupErr = fmt.Errorf("cleanupFunc for %s: failed to close file: %w", tensorName, errClose)
 				}
 			}
-			delete(e.openFiles, tensorName)
-		}
-		return firstCleanupErr
+			e.mmapsMux.Lock()
+			e.borrowed--
+			e.mmapsMux.Unlock()
+		})
+		return cleanupErr
 	}
 	return metadata, file, mmapInstance, cleanupFunc, nil
 }
 
+// OpenTensorView mengembalikan mmap read-only privat atas data tensor untuk pembacaan tanpa salinan.
+// Seperti GetTensorMmap, mmap ini tidak masuk cache handle sehingga tidak dapat dilepas oleh kueri lain,
+// tetapi tidak dapat ditulisi dan tidak menahan file terbuka; pemanggil wajib melepasnya dengan Unmap.
+// Checksum tidak diverifikasi.
+func (e *Executor) OpenTensorView(tensorName string) (*TensorMetadata, mmap.MMap, error) {
+	metadata, mmapInstance, err := e.storage.OpenReadOnlyMmap(tensorName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open view of tensor '%s': %w", tensorName, err)
+	}
+	return metadata, mmapInstance, nil
+}
+
+// Fingerprint mengembalikan hash SHA-256 (heksadesimal) atas tipe data, shape, dan byte data tensor.
+// Data di-hash langsung dari mmap read-only tanpa disalin, jadi tensor besar tidak dimuat ke memori.
+// Dua tensor dengan tipe data, shape, dan isi yang sama menghasilkan fingerprint yang sama tanpa
+// memandang namanya, sehingga fingerprint dapat dipakai untuk deteksi perubahan dan deduplikasi.
+func (e *Executor) Fingerprint(tensorName string) (string, error) {
+	metadata, mmapInstance, err := e.storage.OpenReadOnlyMmap(tensorName)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint tensor '%s': %w", tensorName, err)
+	}
+	if mmapInstance != nil {
+		defer mmapInstance.Unmap()
+	}
+	h := sha256.New()
+	// Tipe data diakhiri byte nol dan shape diawali jumlah dimensinya agar pengodean header tidak ambigu.
+	h.Write([]byte(metadata.DataType))
+	h.Write([]byte{0})
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(len(metadata.Shape)))
+	h.Write(buf[:])
+	for _, dim := range metadata.Shape {
+		binary.LittleEndian.PutUint64(buf[:], uint64(dim))
+		h.Write(buf[:])
+	}
+	h.Write(mmapInstance)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ReadTensorRaw mengembalikan metadata dan salinan byte mentah (little-endian) seluruh data tensor
+// tanpa decoding per elemen. Handle file dan mmap dibuka khusus untuk pembacaan ini lalu langsung
+// ditutup, sehingga aman dipanggil bersamaan dengan kueri lain.
+func (e *Executor) ReadTensorRaw(tensorName string) (*TensorMetadata, []byte, error) {
+	metadata, err := e.storage.LoadTensorMetadata(tensorName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tensor '%s' not found for raw read: %w", tensorName, err)
+	}
+	elementSize, err := GetElementSize(metadata.DataType)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalElements := tNilaiTotalElemen(metadata.Shape)
+	file, mmapInstance, err := e.storage.OpenFileAndMmap(tensorName, totalElements, elementSize)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open/mmap file for %s: %w", tensorName, err)
+	}
+	raw := make([]byte, totalElements*elementSize)
+	copy(raw, mmapInstance)
+	if mmapInstance != nil {
+		mmapInstance.Unmap()
+	}
+	if file != nil {
+		file.Close()
+	}
+	if err := verifyChecksum(metadata, raw); err != nil {
+		return nil, nil, err
+	}
+	return metadata, raw, nil
+}
+
 type TensorDataResult struct {
 	Name          string
 	Shape         []int
@@ -220,7 +443,124 @@ type TensorDataResult struct {
 	Data          interface{}
 }
 
+// TensorDescription adalah hasil DESCRIBE: metadata satu tensor beserta besaran turunannya.
+type TensorDescription struct {
+	Name          string
+	Shape         []int
+	DataType      string
+	Strides       []int
+	NumDimensions int
+	TotalElements int // 0 jika ada dimensi bernilai 0; 1 untuk tensor skalar
+	DataSizeBytes int
+}
+
+// ExecuteWithParams mengganti setiap parameter bernama (:name) pada operand kueri dengan nilai dari
+// params lalu menjalankannya seperti Execute. Setiap nilai harus berupa angka seperti operand literal.
+// Query asli tidak diubah sehingga dapat dipakai ulang sebagai template; log operasi mencatat kueri
+// dengan nilai yang sudah diganti.
+func (e *Executor) ExecuteWithParams(query *Query, params map[string]string) (interface{}, error) {
+	bound := *query
+	bound.Params = nil
+	for _, operand := range paramOperands(&bound) {
+		matches := paramTokenRegex.FindStringSubmatch(*operand)
+		if matches == nil {
+			continue
+		}
+		value, ok := params[matches[1]]
+		if !ok {
+			return nil, fmt.Errorf("missing value for parameter '%s'", *operand)
+		}
+		// Nilai diperiksa seperti operand literal di parser; nilai berupa parameter lain ditolak karena
+		// tidak akan pernah diganti.
+		if paramTokenRegex.MatchString(value) {
+			return nil, fmt.Errorf("value '%s' for parameter '%s' must be a number, not a parameter", value, *operand)
+		}
+		if err := validateScalarOperand(value); err != nil {
+			return nil, fmt.Errorf("parameter '%s': %w", *operand, err)
+		}
+		*operand = value
+	}
+	return e.Execute(&bound)
+}
+
+// Execute menjalankan kueri dan, jika berhasil serta log operasi aktif, mencatatnya ke oplog. Jika
+// pencatatan gagal, hasil kueri tetap dikembalikan bersama error yang membungkus ErrOplogWrite.
+// Jika cache hasil kueri aktif, kueri baca dilayani dari cache dan kueri tulis membuang entri yang terkait.
 func (e *Executor) Execute(query *Query) (interface{}, error) {
+	if len(query.Params) > 0 {
+		return nil, fmt.Errorf("query has unbound parameter(s) %s: supply values with ExecuteWithParams", strings.Join(query.Params, ", "))
+	}
+	// Seed dipilih sebelum eksekusi agar oplog mencatat CREATE RANDOM beserta seed-nya dan ReplayLog
+	// membuat ulang data yang sama.
+	query = withRandomSeed(query)
+	if isMutatingQuery(query) {
+		// Snapshot menunggu kueri tulis yang sedang berjalan dan menahan kueri tulis baru.
+		e.storage.writeBarrier.RLock()
+		defer e.storage.writeBarrier.RUnlock()
+	}
+	if e.queryCache != nil {
+		if isMutatingQuery(query) {
+			// Invalidasi dilakukan juga saat kueri gagal karena file mungkin sudah sebagian berubah.
+			defer e.queryCache.invalidate(mutatedTensorNames(query))
+		} else if key, ok := queryFingerprint(query); ok {
+			cached, hit, generation := e.queryCache.lookup(key)
+			if hit {
+				return cached, nil
+			}
+			result, err := e.execute(query)
+			if err != nil {
+				return nil, err
+			}
+			e.queryCache.store(key, generation, append([]string{}, query.TensorNames...), result)
+			return result, nil
+		}
+	}
+	result, err := e.execute(query)
+	if err != nil {
+		return nil, err
+	}
+	if errLog := e.storage.appendOpLog(query); errLog != nil {
+		return result, fmt.Errorf("query executed but %w: %w", ErrOplogWrite, errLog)
+	}
+	return result, nil
+}
+
+// withRandomSeed mengembalikan salinan CREATE RANDOM tanpa SEED yang diberi seed berbasis waktu, agar
+// Query milik pemanggil tidak berubah; seed yang dipakai dilaporkan di hasil sehingga data dapat dibuat
+// ulang dengan SEED. Kueri lain dikembalikan apa adanya.
+func withRandomSeed(query *Query) *Query {
+	if query.Type != CreateTensorQuery || !query.Random || query.Seed != nil {
+		return query
+	}
+	seed := time.Now().UnixNano()
+	seeded := *query
+	seeded.Seed = &seed
+	return &seeded
+}
+
+// execute menjalankan kueri tanpa cache hasil kueri dan oplog. Setelah INSERT, UPDATE, atau operasi
+// IN PLACE berhasil, statistik berjalan tensor yang dibuat dengan TRACK_STATS ikut diperbarui. Kueri
+// tulis melepas handle ter-cache tensor yang disentuhnya agar pemuatan berikutnya membaca isi file yang baru.
+func (e *Executor) execute(query *Query) (interface{}, error) {
+	if isMutatingQuery(query) {
+		// Dilakukan juga saat kueri gagal karena file mungkin sudah sebagian berubah.
+		defer e.releaseCachedHandles(mutatedTensorNames(query))
+	}
+	result, err := e.executeQuery(query)
+	if err != nil {
+		return result, err
+	}
+	tensorName := writtenTensorName(query)
+	if tensorName == "" {
+		return result, nil
+	}
+	if err := e.updateRunningStats(query, tensorName); err != nil {
+		return nil, fmt.Errorf("data written to tensor '%s' but %w", tensorName, err)
+	}
+	return result, nil
+}
+
+func (e *Executor) executeQuery(query *Query) (interface{}, error) {
 	switch query.Type {
 	case CreateTensorQuery:
 		tensorName := query.TensorNames[0]
@@ -231,51 +571,70 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 		if !os.IsNotExist(errors.Unwrap(err)) && err != nil && !strings.Contains(err.Error(), "failed to read metadata") {
 			return nil, fmt.Errorf("error checking existing tensor '%s': %w", tensorName, err)
 		}
+		if query.SourceQuery != nil {
+			return e.createTensorFromSelect(tensorName, query.SourceQuery)
+		}
 
-		var newTensorMetadata *TensorMetadata
-		switch query.DataType {
-		case DataTypeFloat32:
-			tensorInstance, err := NewTensor[float32](tensorName, query.Shape, query.DataType)
-			if err != nil {
-				return nil, err
-			}
-			if err := SaveTensor(e.storage, tensorInstance); err != nil {
-				return nil, err
+		if query.Identity {
+			if len(query.Shape) != 2 || query.Shape[0] != query.Shape[1] || query.Shape[0] <= 0 {
+				return nil, fmt.Errorf("identity tensor '%s' requires a positive size n (shape [n n]), got shape %v", tensorName, query.Shape)
 			}
-			newTensorMetadata = &TensorMetadata{Name: tensorInstance.Name, Shape: tensorInstance.Shape, DataType: tensorInstance.DataType, Strides: tensorInstance.Strides}
-		case DataTypeFloat64:
-			tensorInstance, err := NewTensor[float64](tensorName, query.Shape, query.DataType)
-			if err != nil {
-				return nil, err
+			if query.ScalarOperand != "" {
+				return nil, fmt.Errorf("identity tensor '%s' cannot be combined with FILL", tensorName)
 			}
-			if err := SaveTensor(e.storage, tensorInstance); err != nil {
+		}
+		// Execute sudah memasang seed sebelum mencatat kueri; ini menangani pemanggilan internal execute.
+		query = withRandomSeed(query)
+		if query.RangeStep != "" && query.DataType == DataTypeBool {
+			return nil, fmt.Errorf("CREATE RANGE does not support dtype %s", query.DataType)
+		}
+		if query.DataType == DataTypeBool && query.ScalarOperand != "" {
+			if query, err = normalizeBoolQuery(query); err != nil {
 				return nil, err
 			}
-			newTensorMetadata = &TensorMetadata{Name: tensorInstance.Name, Shape: tensorInstance.Shape, DataType: tensorInstance.DataType, Strides: tensorInstance.Strides}
+		}
+		var newTensorMetadata *TensorMetadata
+		var stats *RunningStats
+		if query.TrackStats {
+			stats = &RunningStats{}
+		}
+		switch query.DataType {
+		case DataTypeFloat32:
+			newTensorMetadata, err = createTensorTyped[float32](e, tensorName, query, stats)
+		case DataTypeFloat64:
+			newTensorMetadata, err = createTensorTyped[float64](e, tensorName, query, stats)
 		case DataTypeInt32:
-			tensorInstance, err := NewTensor[int32](tensorName, query.Shape, query.DataType)
-			if err != nil {
-				return nil, err
-			}
-			if err := SaveTensor(e.storage, tensorInstance); err != nil {
-				return nil, err
-			}
-			newTensorMetadata = &TensorMetadata{Name: tensorInstance.Name, Shape: tensorInstance.Shape, DataType: tensorInstance.DataType, Strides: tensorInstance.Strides}
+			newTensorMetadata, err = createTensorTyped[int32](e, tensorName, query, stats)
 		case DataTypeInt64:
-			tensorInstance, err := NewTensor[int64](tensorName, query.Shape, query.DataType)
-			if err != nil {
-				return nil, err
-			}
-			if err := SaveTensor(e.storage, tensorInstance); err != nil {
-				return nil, err
-			}
-			newTensorMetadata = &TensorMetadata{Name: tensorInstance.Name, Shape: tensorInstance.Shape, DataType: tensorInstance.DataType, Strides: tensorInstance.Strides}
+			newTensorMetadata, err = createTensorTyped[int64](e, tensorName, query, stats)
+		case DataTypeInt8:
+			newTensorMetadata, err = createTensorTyped[int8](e, tensorName, query, stats)
+		case DataTypeInt16:
+			newTensorMetadata, err = createTensorTyped[int16](e, tensorName, query, stats)
+		case DataTypeUint8, DataTypeBool:
+			newTensorMetadata, err = createTensorTyped[uint8](e, tensorName, query, stats)
+		case DataTypeUint32:
+			newTensorMetadata, err = createTensorTyped[uint32](e, tensorName, query, stats)
+		case DataTypeUint64:
+			newTensorMetadata, err = createTensorTyped[uint64](e, tensorName, query, stats)
 		default:
 			return nil, fmt.Errorf("unsupported data type for CREATE TENSOR: %s", query.DataType)
 		}
+		if err != nil {
+			return nil, err
+		}
+		if query.TrackStats {
+			if err := e.storage.writeRunningStats(tensorName, stats); err != nil {
+				e.storage.DeleteTensorFiles(tensorName)
+				return nil, err
+			}
+		}
 		if newTensorMetadata != nil {
 			e.storage.AddTensorToIndex(newTensorMetadata)
 		}
+		if query.Random {
+			return fmt.Sprintf("Tensor %s created with type %s using seed %d", tensorName, query.DataType, *query.Seed), nil
+		}
 		return fmt.Sprintf("Tensor %s created with type %s", tensorName, query.DataType), nil
 
 	case InsertTensorQuery:
@@ -283,6 +642,20 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 		if err != nil {
 			return nil, fmt.Errorf("tensor '%s' not found for insert: %w", query.TensorNames[0], err)
 		}
+		if metadata.DataType == DataTypeBool {
+			if query, err = normalizeBoolQuery(query); err != nil {
+				return nil, err
+			}
+		}
+		if query.Append {
+			return e.executeAppend(query, metadata)
+		}
+		if len(query.Sparse) > 0 {
+			return e.executeSparseInsert(query, metadata)
+		}
+		if len(query.Slices) > 0 && len(query.Slices[0]) > 0 {
+			return e.executeSliceInsert(query, metadata)
+		}
 		expectedElements := 0
 		if len(metadata.Shape) == 0 {
 			expectedElements = 1
@@ -314,8 +687,12 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 				return nil, fmt.Errorf("raw data size (%d) is not a multiple of element size (%d) for data type %s", len(query.RawData), elementSize, metadata.DataType)
 			}
 			if numElementsFromRaw != expectedElements {
-				return nil, fmt.Errorf("raw data provides %d elements, but tensor '%s' of shape %v requires %d elements",
-					numElementsFromRaw, metadata.Name, metadata.Shape, expectedElements)
+				return nil, insertElementCountError("raw", numElementsFromRaw, metadata, expectedElements)
+			}
+			if metadata.DataType == DataTypeBool {
+				if err := validateBoolBytes(query.RawData); err != nil {
+					return nil, err
+				}
 			}
 			switch metadata.DataType {
 			case DataTypeFloat32:
@@ -326,7 +703,9 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 				}
 				tempTensor, _ := NewTensor[float32](metadata.Name, metadata.Shape, metadata.DataType)
 				tempTensor.SetData(typedData)
-				SaveTensor(e.storage, tempTensor)
+				if err := SaveTensor(e.storage, tempTensor); err != nil {
+					return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+				}
 			case DataTypeFloat64:
 				typedData := make([]float64, numElementsFromRaw)
 				reader := bytes.NewReader(query.RawData)
@@ -335,7 +714,9 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 				}
 				tempTensor, _ := NewTensor[float64](metadata.Name, metadata.Shape, metadata.DataType)
 				tempTensor.SetData(typedData)
-				SaveTensor(e.storage, tempTensor)
+				if err := SaveTensor(e.storage, tempTensor); err != nil {
+					return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+				}
 			case DataTypeInt32:
 				typedData := make([]int32, numElementsFromRaw)
 				reader := bytes.NewReader(query.RawData)
@@ -344,7 +725,9 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 				}
 				tempTensor, _ := NewTensor[int32](metadata.Name, metadata.Shape, metadata.DataType)
 				tempTensor.SetData(typedData)
-				SaveTensor(e.storage, tempTensor)
+				if err := SaveTensor(e.storage, tempTensor); err != nil {
+					return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+				}
 			case DataTypeInt64:
 				typedData := make([]int64, numElementsFromRaw)
 				reader := bytes.NewReader(query.RawData)
@@ -353,7 +736,64 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 				}
 				tempTensor, _ := NewTensor[int64](metadata.Name, metadata.Shape, metadata.DataType)
 				tempTensor.SetData(typedData)
-				SaveTensor(e.storage, tempTensor)
+				if err := SaveTensor(e.storage, tempTensor); err != nil {
+					return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+				}
+			case DataTypeInt8:
+				typedData := make([]int8, numElementsFromRaw)
+				reader := bytes.NewReader(query.RawData)
+				if err := binary.Read(reader, binary.LittleEndian, &typedData); err != nil {
+					return nil, fmt.Errorf("failed to deserialize raw data to []int8: %w", err)
+				}
+				tempTensor, _ := NewTensor[int8](metadata.Name, metadata.Shape, metadata.DataType)
+				tempTensor.SetData(typedData)
+				if err := SaveTensor(e.storage, tempTensor); err != nil {
+					return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+				}
+			case DataTypeInt16:
+				typedData := make([]int16, numElementsFromRaw)
+				reader := bytes.NewReader(query.RawData)
+				if err := binary.Read(reader, binary.LittleEndian, &typedData); err != nil {
+					return nil, fmt.Errorf("failed to deserialize raw data to []int16: %w", err)
+				}
+				tempTensor, _ := NewTensor[int16](metadata.Name, metadata.Shape, metadata.DataType)
+				tempTensor.SetData(typedData)
+				if err := SaveTensor(e.storage, tempTensor); err != nil {
+					return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+				}
+			case DataTypeUint8, DataTypeBool:
+				typedData := make([]uint8, numElementsFromRaw)
+				reader := bytes.NewReader(query.RawData)
+				if err := binary.Read(reader, binary.LittleEndian, &typedData); err != nil {
+					return nil, fmt.Errorf("failed to deserialize raw data to []uint8: %w", err)
+				}
+				tempTensor, _ := NewTensor[uint8](metadata.Name, metadata.Shape, metadata.DataType)
+				tempTensor.SetData(typedData)
+				if err := SaveTensor(e.storage, tempTensor); err != nil {
+					return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+				}
+			case DataTypeUint32:
+				typedData := make([]uint32, numElementsFromRaw)
+				reader := bytes.NewReader(query.RawData)
+				if err := binary.Read(reader, binary.LittleEndian, &typedData); err != nil {
+					return nil, fmt.Errorf("failed to deserialize raw data to []uint32: %w", err)
+				}
+				tempTensor, _ := NewTensor[uint32](metadata.Name, metadata.Shape, metadata.DataType)
+				tempTensor.SetData(typedData)
+				if err := SaveTensor(e.storage, tempTensor); err != nil {
+					return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+				}
+			case DataTypeUint64:
+				typedData := make([]uint64, numElementsFromRaw)
+				reader := bytes.NewReader(query.RawData)
+				if err := binary.Read(reader, binary.LittleEndian, &typedData); err != nil {
+					return nil, fmt.Errorf("failed to deserialize raw data to []uint64: %w", err)
+				}
+				tempTensor, _ := NewTensor[uint64](metadata.Name, metadata.Shape, metadata.DataType)
+				tempTensor.SetData(typedData)
+				if err := SaveTensor(e.storage, tempTensor); err != nil {
+					return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+				}
 			default:
 				return nil, fmt.Errorf("unsupported data type '%s' for raw data insert into tensor '%s'", metadata.DataType, metadata.Name)
 			}
@@ -361,86 +801,144 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 		}
 
 		numElementsToInsertFromString := len(query.Data)
-		if numElementsToInsertFromString == 0 && expectedElements == 0 {
-			switch metadata.DataType {
-			case DataTypeFloat32:
-				tempTensor, _ := NewTensor[float32](metadata.Name, metadata.Shape, metadata.DataType)
-				tempTensor.SetData([]float32{})
-				SaveTensor(e.storage, tempTensor)
-			case DataTypeFloat64:
-				tempTensor, _ := NewTensor[float64](metadata.Name, metadata.Shape, metadata.DataType)
-				tempTensor.SetData([]float64{})
-				SaveTensor(e.storage, tempTensor)
-			case DataTypeInt32:
-				tempTensor, _ := NewTensor[int32](metadata.Name, metadata.Shape, metadata.DataType)
-				tempTensor.SetData([]int32{})
-				SaveTensor(e.storage, tempTensor)
-			case DataTypeInt64:
-				tempTensor, _ := NewTensor[int64](metadata.Name, metadata.Shape, metadata.DataType)
-				tempTensor.SetData([]int64{})
-				SaveTensor(e.storage, tempTensor)
-			default:
-				return nil, fmt.Errorf("unsupported data type '%s' for empty string insert into tensor '%s'", metadata.DataType, metadata.Name)
-			}
-			return fmt.Sprintf("Data inserted into %s (0 elements from string)", query.TensorNames[0]), nil
-		}
-
 		if numElementsToInsertFromString != expectedElements {
-			return nil, fmt.Errorf("string data provides %d elements, but tensor '%s' of shape %v requires %d elements",
-				numElementsToInsertFromString, metadata.Name, metadata.Shape, expectedElements)
+			return nil, insertElementCountError("string", numElementsToInsertFromString, metadata, expectedElements)
+		}
+		if expectedElements == 0 {
+			// Tensor kosong (ada dimensi bernilai 0): VALUES () cocok dan file data memang kosong,
+			// sehingga tidak ada yang perlu ditulis.
+			return fmt.Sprintf("Data inserted into %s (0 elements from string)", query.TensorNames[0]), nil
 		}
 
 		switch metadata.DataType {
 		case DataTypeFloat32:
 			typedData := make([]float32, numElementsToInsertFromString)
 			for i, sVal := range query.Data {
-				val, errFloat := strconv.ParseFloat(sVal, 32)
-				if errFloat != nil {
-					return nil, fmt.Errorf("error parsing '%s' as float32: %w", sVal, errFloat)
+				val, errParse := parseInsertValue[float32](sVal, i)
+				if errParse != nil {
+					return nil, errParse
 				}
-				typedData[i] = float32(val)
+				typedData[i] = val
 			}
 			tempTensor, _ := NewTensor[float32](metadata.Name, metadata.Shape, metadata.DataType)
 			tempTensor.SetData(typedData)
-			SaveTensor(e.storage, tempTensor)
+			if err := SaveTensor(e.storage, tempTensor); err != nil {
+				return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+			}
 		case DataTypeFloat64:
 			typedData := make([]float64, numElementsToInsertFromString)
 			for i, sVal := range query.Data {
-				val, errFloat := strconv.ParseFloat(sVal, 64)
-				if errFloat != nil {
-					return nil, fmt.Errorf("error parsing '%s' as float64: %w", sVal, errFloat)
+				val, errParse := parseInsertValue[float64](sVal, i)
+				if errParse != nil {
+					return nil, errParse
 				}
 				typedData[i] = val
 			}
 			tempTensor, _ := NewTensor[float64](metadata.Name, metadata.Shape, metadata.DataType)
 			tempTensor.SetData(typedData)
-			SaveTensor(e.storage, tempTensor)
+			if err := SaveTensor(e.storage, tempTensor); err != nil {
+				return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+			}
 		case DataTypeInt32:
 			typedData := make([]int32, numElementsToInsertFromString)
 			for i, sVal := range query.Data {
-				val, errInt := strconv.ParseInt(sVal, 10, 32)
-				if errInt != nil {
-					return nil, fmt.Errorf("error parsing '%s' as int32: %w", sVal, errInt)
+				val, errParse := parseInsertValue[int32](sVal, i)
+				if errParse != nil {
+					return nil, errParse
 				}
-				typedData[i] = int32(val)
+				typedData[i] = val
 			}
 			tempTensor, _ := NewTensor[int32](metadata.Name, metadata.Shape, metadata.DataType)
 			tempTensor.SetData(typedData)
-			SaveTensor(e.storage, tempTensor)
+			if err := SaveTensor(e.storage, tempTensor); err != nil {
+				return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+			}
 		case DataTypeInt64:
 			typedData := make([]int64, numElementsToInsertFromString)
 			for i, sVal := range query.Data {
-				val, errInt := strconv.ParseInt(sVal, 10, 64)
-				if errInt != nil {
-					return nil, fmt.Errorf("error parsing '%s' as int64: %w", sVal, errInt)
+				val, errParse := parseInsertValue[int64](sVal, i)
+				if errParse != nil {
+					return nil, errParse
 				}
 				typedData[i] = val
 			}
 			tempTensor, _ := NewTensor[int64](metadata.Name, metadata.Shape, metadata.DataType)
 			tempTensor.SetData(typedData)
-			SaveTensor(e.storage, tempTensor)
-		default:
-			return nil, fmt.Errorf("unsupported data type '%s' for string data insert into tensor '%s'", metadata.DataType, metadata.Name)
+			if err := SaveTensor(e.storage, tempTensor); err != nil {
+				return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+			}
+		case DataTypeInt8:
+			typedData := make([]int8, numElementsToInsertFromString)
+			for i, sVal := range query.Data {
+				val, errParse := parseInsertValue[int8](sVal, i)
+				if errParse != nil {
+					return nil, errParse
+				}
+				typedData[i] = val
+			}
+			tempTensor, _ := NewTensor[int8](metadata.Name, metadata.Shape, metadata.DataType)
+			tempTensor.SetData(typedData)
+			if err := SaveTensor(e.storage, tempTensor); err != nil {
+				return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+			}
+		case DataTypeInt16:
+			typedData := make([]int16, numElementsToInsertFromString)
+			for i, sVal := range query.Data {
+				val, errParse := parseInsertValue[int16](sVal, i)
+				if errParse != nil {
+					return nil, errParse
+				}
+				typedData[i] = val
+			}
+			tempTensor, _ := NewTensor[int16](metadata.Name, metadata.Shape, metadata.DataType)
+			tempTensor.SetData(typedData)
+			if err := SaveTensor(e.storage, tempTensor); err != nil {
+				return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+			}
+		case DataTypeUint8, DataTypeBool:
+			typedData := make([]uint8, numElementsToInsertFromString)
+			for i, sVal := range query.Data {
+				val, errParse := parseInsertValue[uint8](sVal, i)
+				if errParse != nil {
+					return nil, errParse
+				}
+				typedData[i] = val
+			}
+			tempTensor, _ := NewTensor[uint8](metadata.Name, metadata.Shape, metadata.DataType)
+			tempTensor.SetData(typedData)
+			if err := SaveTensor(e.storage, tempTensor); err != nil {
+				return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+			}
+		case DataTypeUint32:
+			typedData := make([]uint32, numElementsToInsertFromString)
+			for i, sVal := range query.Data {
+				val, errParse := parseInsertValue[uint32](sVal, i)
+				if errParse != nil {
+					return nil, errParse
+				}
+				typedData[i] = val
+			}
+			tempTensor, _ := NewTensor[uint32](metadata.Name, metadata.Shape, metadata.DataType)
+			tempTensor.SetData(typedData)
+			if err := SaveTensor(e.storage, tempTensor); err != nil {
+				return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+			}
+		case DataTypeUint64:
+			typedData := make([]uint64, numElementsToInsertFromString)
+			for i, sVal := range query.Data {
+				val, errParse := parseInsertValue[uint64](sVal, i)
+				if errParse != nil {
+					return nil, errParse
+				}
+				typedData[i] = val
+			}
+			tempTensor, _ := NewTensor[uint64](metadata.Name, metadata.Shape, metadata.DataType)
+			tempTensor.SetData(typedData)
+			if err := SaveTensor(e.storage, tempTensor); err != nil {
+				return nil, fmt.Errorf("failed to save data for tensor %s: %w", metadata.Name, err)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported data type '%s' for string data insert into tensor '%s'", metadata.DataType, metadata.Name)
 		}
 		return fmt.Sprintf("String data inserted into %s", query.TensorNames[0]), nil
 
@@ -452,8 +950,15 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 		}
 		var formattedResult interface{}
 		currentSliceDef := [][2]int{}
-		if len(query.Slices) > 0 {
-			currentSliceDef = query.Slices[0]
+		var currentSliceSteps []int
+		if len(query.SliceSteps) > 0 {
+			currentSliceSteps = query.SliceSteps[0]
+		}
+		if len(query.Slices) > 0 && len(query.Slices[0]) > 0 {
+			currentSliceDef, err = ResolveSliceRanges(query.Slices[0], metadata.Shape)
+			if err != nil {
+				return nil, fmt.Errorf("failed to slice %s: %w", tensorName, err)
+			}
 		}
 
 		switch metadata.DataType {
@@ -463,15 +968,11 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 				return nil, errLoad
 			}
 			if len(currentSliceDef) > 0 {
-				slicedData, errSlice := tensorInstance.GetSlice(currentSliceDef)
+				slicedData, errSlice := tensorInstance.GetSliceStep(currentSliceDef, currentSliceSteps)
 				if errSlice != nil {
 					return nil, fmt.Errorf("failed to slice %s: %w", tensorName, errSlice)
 				}
-				sliceShape := make([]int, len(currentSliceDef))
-				for i, r := range currentSliceDef {
-					sliceShape[i] = r[1] - r[0]
-				}
-				tempTensor, _ := NewTensor[float32]("sliced_"+tensorInstance.Name, sliceShape, tensorInstance.DataType)
+				tempTensor, _ := NewTensor[float32]("sliced_"+tensorInstance.Name, sliceShape(currentSliceDef, currentSliceSteps), tensorInstance.DataType)
 				tempTensor.SetData(slicedData)
 				formattedResult = tempTensor.FormatMultidimensional()
 			} else {
@@ -483,15 +984,11 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 				return nil, errLoad
 			}
 			if len(currentSliceDef) > 0 {
-				slicedData, errSlice := tensorInstance.GetSlice(currentSliceDef)
+				slicedData, errSlice := tensorInstance.GetSliceStep(currentSliceDef, currentSliceSteps)
 				if errSlice != nil {
 					return nil, fmt.Errorf("failed to slice %s: %w", tensorName, errSlice)
 				}
-				sliceShape := make([]int, len(currentSliceDef))
-				for i, r := range currentSliceDef {
-					sliceShape[i] = r[1] - r[0]
-				}
-				tempTensor, _ := NewTensor[float64]("sliced_"+tensorInstance.Name, sliceShape, tensorInstance.DataType)
+				tempTensor, _ := NewTensor[float64]("sliced_"+tensorInstance.Name, sliceShape(currentSliceDef, currentSliceSteps), tensorInstance.DataType)
 				tempTensor.SetData(slicedData)
 				formattedResult = tempTensor.FormatMultidimensional()
 			} else {
@@ -503,15 +1000,11 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 				return nil, errLoad
 			}
 			if len(currentSliceDef) > 0 {
-				slicedData, errSlice := tensorInstance.GetSlice(currentSliceDef)
+				slicedData, errSlice := tensorInstance.GetSliceStep(currentSliceDef, currentSliceSteps)
 				if errSlice != nil {
 					return nil, fmt.Errorf("failed to slice %s: %w", tensorName, errSlice)
 				}
-				sliceShape := make([]int, len(currentSliceDef))
-				for i, r := range currentSliceDef {
-					sliceShape[i] = r[1] - r[0]
-				}
-				tempTensor, _ := NewTensor[int32]("sliced_"+tensorInstance.Name, sliceShape, tensorInstance.DataType)
+				tempTensor, _ := NewTensor[int32]("sliced_"+tensorInstance.Name, sliceShape(currentSliceDef, currentSliceSteps), tensorInstance.DataType)
 				tempTensor.SetData(slicedData)
 				formattedResult = tempTensor.FormatMultidimensional()
 			} else {
@@ -523,15 +1016,91 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 				return nil, errLoad
 			}
 			if len(currentSliceDef) > 0 {
-				slicedData, errSlice := tensorInstance.GetSlice(currentSliceDef)
+				slicedData, errSlice := tensorInstance.GetSliceStep(currentSliceDef, currentSliceSteps)
 				if errSlice != nil {
 					return nil, fmt.Errorf("failed to slice %s: %w", tensorName, errSlice)
 				}
-				sliceShape := make([]int, len(currentSliceDef))
-				for i, r := range currentSliceDef {
-					sliceShape[i] = r[1] - r[0]
+				tempTensor, _ := NewTensor[int64]("sliced_"+tensorInstance.Name, sliceShape(currentSliceDef, currentSliceSteps), tensorInstance.DataType)
+				tempTensor.SetData(slicedData)
+				formattedResult = tempTensor.FormatMultidimensional()
+			} else {
+				formattedResult = tensorInstance.FormatMultidimensional()
+			}
+		case DataTypeInt8:
+			tensorInstance, errLoad := loadFullTensorTyped[int8](e, tensorName, metadata)
+			if errLoad != nil {
+				return nil, errLoad
+			}
+			if len(currentSliceDef) > 0 {
+				slicedData, errSlice := tensorInstance.GetSliceStep(currentSliceDef, currentSliceSteps)
+				if errSlice != nil {
+					return nil, fmt.Errorf("failed to slice %s: %w", tensorName, errSlice)
+				}
+				tempTensor, _ := NewTensor[int8]("sliced_"+tensorInstance.Name, sliceShape(currentSliceDef, currentSliceSteps), tensorInstance.DataType)
+				tempTensor.SetData(slicedData)
+				formattedResult = tempTensor.FormatMultidimensional()
+			} else {
+				formattedResult = tensorInstance.FormatMultidimensional()
+			}
+		case DataTypeInt16:
+			tensorInstance, errLoad := loadFullTensorTyped[int16](e, tensorName, metadata)
+			if errLoad != nil {
+				return nil, errLoad
+			}
+			if len(currentSliceDef) > 0 {
+				slicedData, errSlice := tensorInstance.GetSliceStep(currentSliceDef, currentSliceSteps)
+				if errSlice != nil {
+					return nil, fmt.Errorf("failed to slice %s: %w", tensorName, errSlice)
+				}
+				tempTensor, _ := NewTensor[int16]("sliced_"+tensorInstance.Name, sliceShape(currentSliceDef, currentSliceSteps), tensorInstance.DataType)
+				tempTensor.SetData(slicedData)
+				formattedResult = tempTensor.FormatMultidimensional()
+			} else {
+				formattedResult = tensorInstance.FormatMultidimensional()
+			}
+		case DataTypeUint8, DataTypeBool:
+			tensorInstance, errLoad := loadFullTensorTyped[uint8](e, tensorName, metadata)
+			if errLoad != nil {
+				return nil, errLoad
+			}
+			if len(currentSliceDef) > 0 {
+				slicedData, errSlice := tensorInstance.GetSliceStep(currentSliceDef, currentSliceSteps)
+				if errSlice != nil {
+					return nil, fmt.Errorf("failed to slice %s: %w", tensorName, errSlice)
 				}
-				tempTensor, _ := NewTensor[int64]("sliced_"+tensorInstance.Name, sliceShape, tensorInstance.DataType)
+				tempTensor, _ := NewTensor[uint8]("sliced_"+tensorInstance.Name, sliceShape(currentSliceDef, currentSliceSteps), tensorInstance.DataType)
+				tempTensor.SetData(slicedData)
+				formattedResult = tempTensor.FormatMultidimensional()
+			} else {
+				formattedResult = tensorInstance.FormatMultidimensional()
+			}
+		case DataTypeUint32:
+			tensorInstance, errLoad := loadFullTensorTyped[uint32](e, tensorName, metadata)
+			if errLoad != nil {
+				return nil, errLoad
+			}
+			if len(currentSliceDef) > 0 {
+				slicedData, errSlice := tensorInstance.GetSliceStep(currentSliceDef, currentSliceSteps)
+				if errSlice != nil {
+					return nil, fmt.Errorf("failed to slice %s: %w", tensorName, errSlice)
+				}
+				tempTensor, _ := NewTensor[uint32]("sliced_"+tensorInstance.Name, sliceShape(currentSliceDef, currentSliceSteps), tensorInstance.DataType)
+				tempTensor.SetData(slicedData)
+				formattedResult = tempTensor.FormatMultidimensional()
+			} else {
+				formattedResult = tensorInstance.FormatMultidimensional()
+			}
+		case DataTypeUint64:
+			tensorInstance, errLoad := loadFullTensorTyped[uint64](e, tensorName, metadata)
+			if errLoad != nil {
+				return nil, errLoad
+			}
+			if len(currentSliceDef) > 0 {
+				slicedData, errSlice := tensorInstance.GetSliceStep(currentSliceDef, currentSliceSteps)
+				if errSlice != nil {
+					return nil, fmt.Errorf("failed to slice %s: %w", tensorName, errSlice)
+				}
+				tempTensor, _ := NewTensor[uint64]("sliced_"+tensorInstance.Name, sliceShape(currentSliceDef, currentSliceSteps), tensorInstance.DataType)
 				tempTensor.SetData(slicedData)
 				formattedResult = tempTensor.FormatMultidimensional()
 			} else {
@@ -540,16 +1109,17 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 		default:
 			return nil, fmt.Errorf("unsupported data type for SELECT on tensor %s: %s", tensorName, metadata.DataType)
 		}
+		if metadata.DataType == DataTypeBool {
+			formattedResult = formatBoolLeaves(formattedResult)
+		}
 		return formattedResult, nil
 
 	case GetDataTensorQuery:
+		// Setiap goroutine hanya menulis ke slot indeksnya sendiri, sehingga hasil (dan error) selalu
+		// berurutan sesuai query.TensorNames, terlepas dari goroutine mana yang selesai lebih dulu.
 		allResultsNonGeneric := make([][]TensorDataResult, len(query.TensorNames))
+		errs := make([]error, len(query.TensorNames))
 		var wg sync.WaitGroup
-		errChan := make(chan error, len(query.TensorNames))
-		resultChan := make(chan struct {
-			index int
-			data  []TensorDataResult
-		}, len(query.TensorNames))
 
 		for i, tensorName := range query.TensorNames {
 			wg.Add(1)
@@ -557,16 +1127,21 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 			if query.Slices != nil && i < len(query.Slices) {
 				currentTensorSlices = query.Slices[i]
 			}
-			go func(idx int, tName string, currentSlicesForThisTensor [][2]int) {
+			var currentTensorSteps []int
+			if i < len(query.SliceSteps) {
+				currentTensorSteps = query.SliceSteps[i]
+			}
+			go func(idx int, tName string, currentSlicesForThisTensor [][2]int, currentStepsForThisTensor []int) {
 				defer wg.Done()
 				metadata, errMeta := e.storage.LoadTensorMetadata(tName)
 				if errMeta != nil {
-					errChan <- fmt.Errorf("tensor '%s' not found for get data: %w", tName, errMeta)
+					errs[idx] = fmt.Errorf("tensor '%s' not found for get data: %w", tName, errMeta)
 					return
 				}
 				var typedResults []TensorDataResult
 				var execErr error
 				inferenceSliceArg := [][][2]int{currentSlicesForThisTensor}
+				inferenceStepArg := [][]int{currentStepsForThisTensor}
 
 				switch metadata.DataType {
 				case DataTypeFloat32:
@@ -575,7 +1150,7 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 						execErr = errLoad
 						break
 					}
-					genericDataBatched, errInfer := tensorInstance.GetDataForInference(inferenceSliceArg, query.BatchSize)
+					genericDataBatched, errInfer := tensorInstance.getDataForInference(inferenceSliceArg, inferenceStepArg, query.BatchSize)
 					if errInfer != nil {
 						execErr = errInfer
 						break
@@ -590,7 +1165,7 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 						execErr = errLoad
 						break
 					}
-					genericDataBatched, errInfer := tensorInstance.GetDataForInference(inferenceSliceArg, query.BatchSize)
+					genericDataBatched, errInfer := tensorInstance.getDataForInference(inferenceSliceArg, inferenceStepArg, query.BatchSize)
 					if errInfer != nil {
 						execErr = errInfer
 						break
@@ -605,7 +1180,7 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 						execErr = errLoad
 						break
 					}
-					genericDataBatched, errInfer := tensorInstance.GetDataForInference(inferenceSliceArg, query.BatchSize)
+					genericDataBatched, errInfer := tensorInstance.getDataForInference(inferenceSliceArg, inferenceStepArg, query.BatchSize)
 					if errInfer != nil {
 						execErr = errInfer
 						break
@@ -620,7 +1195,82 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 						execErr = errLoad
 						break
 					}
-					genericDataBatched, errInfer := tensorInstance.GetDataForInference(inferenceSliceArg, query.BatchSize)
+					genericDataBatched, errInfer := tensorInstance.getDataForInference(inferenceSliceArg, inferenceStepArg, query.BatchSize)
+					if errInfer != nil {
+						execErr = errInfer
+						break
+					}
+					typedResults = make([]TensorDataResult, len(genericDataBatched))
+					for k, gd := range genericDataBatched {
+						typedResults[k] = TensorDataResult{Name: gd.Name, Shape: gd.Shape, NumDimensions: gd.NumDimensions, DataType: gd.DataType, TotalElements: gd.TotalElements, DataSizeBytes: gd.DataSizeBytes, Strides: gd.Strides, BatchInfo: gd.BatchInfo, Data: gd.Data}
+					}
+				case DataTypeInt8:
+					tensorInstance, errLoad := loadFullTensorTyped[int8](e, tName, metadata)
+					if errLoad != nil {
+						execErr = errLoad
+						break
+					}
+					genericDataBatched, errInfer := tensorInstance.getDataForInference(inferenceSliceArg, inferenceStepArg, query.BatchSize)
+					if errInfer != nil {
+						execErr = errInfer
+						break
+					}
+					typedResults = make([]TensorDataResult, len(genericDataBatched))
+					for k, gd := range genericDataBatched {
+						typedResults[k] = TensorDataResult{Name: gd.Name, Shape: gd.Shape, NumDimensions: gd.NumDimensions, DataType: gd.DataType, TotalElements: gd.TotalElements, DataSizeBytes: gd.DataSizeBytes, Strides: gd.Strides, BatchInfo: gd.BatchInfo, Data: gd.Data}
+					}
+				case DataTypeInt16:
+					tensorInstance, errLoad := loadFullTensorTyped[int16](e, tName, metadata)
+					if errLoad != nil {
+						execErr = errLoad
+						break
+					}
+					genericDataBatched, errInfer := tensorInstance.getDataForInference(inferenceSliceArg, inferenceStepArg, query.BatchSize)
+					if errInfer != nil {
+						execErr = errInfer
+						break
+					}
+					typedResults = make([]TensorDataResult, len(genericDataBatched))
+					for k, gd := range genericDataBatched {
+						typedResults[k] = TensorDataResult{Name: gd.Name, Shape: gd.Shape, NumDimensions: gd.NumDimensions, DataType: gd.DataType, TotalElements: gd.TotalElements, DataSizeBytes: gd.DataSizeBytes, Strides: gd.Strides, BatchInfo: gd.BatchInfo, Data: gd.Data}
+					}
+				case DataTypeUint8, DataTypeBool:
+					tensorInstance, errLoad := loadFullTensorTyped[uint8](e, tName, metadata)
+					if errLoad != nil {
+						execErr = errLoad
+						break
+					}
+					genericDataBatched, errInfer := tensorInstance.getDataForInference(inferenceSliceArg, inferenceStepArg, query.BatchSize)
+					if errInfer != nil {
+						execErr = errInfer
+						break
+					}
+					typedResults = make([]TensorDataResult, len(genericDataBatched))
+					for k, gd := range genericDataBatched {
+						typedResults[k] = TensorDataResult{Name: gd.Name, Shape: gd.Shape, NumDimensions: gd.NumDimensions, DataType: gd.DataType, TotalElements: gd.TotalElements, DataSizeBytes: gd.DataSizeBytes, Strides: gd.Strides, BatchInfo: gd.BatchInfo, Data: gd.Data}
+					}
+				case DataTypeUint32:
+					tensorInstance, errLoad := loadFullTensorTyped[uint32](e, tName, metadata)
+					if errLoad != nil {
+						execErr = errLoad
+						break
+					}
+					genericDataBatched, errInfer := tensorInstance.getDataForInference(inferenceSliceArg, inferenceStepArg, query.BatchSize)
+					if errInfer != nil {
+						execErr = errInfer
+						break
+					}
+					typedResults = make([]TensorDataResult, len(genericDataBatched))
+					for k, gd := range genericDataBatched {
+						typedResults[k] = TensorDataResult{Name: gd.Name, Shape: gd.Shape, NumDimensions: gd.NumDimensions, DataType: gd.DataType, TotalElements: gd.TotalElements, DataSizeBytes: gd.DataSizeBytes, Strides: gd.Strides, BatchInfo: gd.BatchInfo, Data: gd.Data}
+					}
+				case DataTypeUint64:
+					tensorInstance, errLoad := loadFullTensorTyped[uint64](e, tName, metadata)
+					if errLoad != nil {
+						execErr = errLoad
+						break
+					}
+					genericDataBatched, errInfer := tensorInstance.getDataForInference(inferenceSliceArg, inferenceStepArg, query.BatchSize)
 					if errInfer != nil {
 						execErr = errInfer
 						break
@@ -633,20 +1283,15 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 					execErr = fmt.Errorf("unsupported data type for GET DATA on tensor %s: %s", tName, metadata.DataType)
 				}
 				if execErr != nil {
-					errChan <- fmt.Errorf("failed to get data for inference from '%s': %w", tName, execErr)
+					errs[idx] = fmt.Errorf("failed to get data for inference from '%s': %w", tName, execErr)
 					return
 				}
-				resultChan <- struct {
-					index int
-					data  []TensorDataResult
-				}{index: idx, data: typedResults}
-			}(i, tensorName, currentTensorSlices)
+				allResultsNonGeneric[idx] = typedResults
+			}(i, tensorName, currentTensorSlices, currentTensorSteps)
 		}
 		wg.Wait()
-		close(resultChan)
-		close(errChan)
 		var multiErr []string
-		for errItem := range errChan {
+		for _, errItem := range errs {
 			if errItem != nil {
 				multiErr = append(multiErr, errItem.Error())
 			}
@@ -654,9 +1299,6 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 		if len(multiErr) > 0 {
 			return nil, errors.New("errors occurred during GET DATA: " + strings.Join(multiErr, "; "))
 		}
-		for resultItem := range resultChan {
-			allResultsNonGeneric[resultItem.index] = resultItem.data
-		}
 		if len(query.TensorNames) == 1 {
 			if len(allResultsNonGeneric) > 0 && len(allResultsNonGeneric[0]) > 0 {
 				return allResultsNonGeneric[0], nil
@@ -673,241 +1315,7 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 		return allResultsNonGeneric, nil
 
 	case MathOperationQuery:
-		var finalResultTensor interface{}
-		var operationError error
-		_, errOutputCheck := e.storage.LoadTensorMetadata(query.OutputTensorName)
-		if errOutputCheck == nil {
-			return nil, fmt.Errorf("output tensor '%s' already exists. Math operations require a new output tensor name", query.OutputTensorName)
-		}
-		if !os.IsNotExist(errors.Unwrap(errOutputCheck)) && errOutputCheck != nil && !strings.Contains(errOutputCheck.Error(), "failed to read metadata") {
-			return nil, fmt.Errorf("error checking existing output tensor '%s': %w", query.OutputTensorName, errOutputCheck)
-		}
-
-		switch query.MathOperator {
-		case "ADD_TENSORS":
-			if len(query.InputTensorNames) != 2 {
-				operationError = errors.New("ADD_TENSORS operation requires two input tensors")
-				break
-			}
-			tensorAName := query.InputTensorNames[0]
-			tensorBName := query.InputTensorNames[1]
-			metaA, errA := e.storage.LoadTensorMetadata(tensorAName)
-			if errA != nil {
-				operationError = fmt.Errorf("failed to load metadata for tensor A '%s': %w", tensorAName, errA)
-				break
-			}
-			metaB, errB := e.storage.LoadTensorMetadata(tensorBName)
-			if errB != nil {
-				operationError = fmt.Errorf("failed to load metadata for tensor B '%s': %w", tensorBName, errB)
-				break
-			}
-			if metaA.DataType != metaB.DataType {
-				operationError = fmt.Errorf("data types of %s (%s) and %s (%s) do not match for ADD_TENSORS", tensorAName, metaA.DataType, tensorBName, metaB.DataType)
-				break
-			}
-
-			switch metaA.DataType {
-			case DataTypeFloat32:
-				tA, loadErrA := loadFullTensorTyped[float32](e, tensorAName, metaA)
-				if loadErrA != nil {
-					operationError = loadErrA
-					break
-				}
-				tB, loadErrB := loadFullTensorTyped[float32](e, tensorBName, metaB)
-				if loadErrB != nil {
-					operationError = loadErrB
-					break
-				}
-				resTensor, opErr := AddTensors[float32](tA, tB)
-				if opErr != nil {
-					operationError = opErr
-					break
-				}
-				resTensor.Name = query.OutputTensorName
-				finalResultTensor = resTensor
-			case DataTypeFloat64:
-				tA, loadErrA := loadFullTensorTyped[float64](e, tensorAName, metaA)
-				if loadErrA != nil {
-					operationError = loadErrA
-					break
-				}
-				tB, loadErrB := loadFullTensorTyped[float64](e, tensorBName, metaB)
-				if loadErrB != nil {
-					operationError = loadErrB
-					break
-				}
-				resTensor, opErr := AddTensors[float64](tA, tB)
-				if opErr != nil {
-					operationError = opErr
-					break
-				}
-				resTensor.Name = query.OutputTensorName
-				finalResultTensor = resTensor
-			case DataTypeInt32:
-				tA, loadErrA := loadFullTensorTyped[int32](e, tensorAName, metaA)
-				if loadErrA != nil {
-					operationError = loadErrA
-					break
-				}
-				tB, loadErrB := loadFullTensorTyped[int32](e, tensorBName, metaB)
-				if loadErrB != nil {
-					operationError = loadErrB
-					break
-				}
-				resTensor, opErr := AddTensors[int32](tA, tB)
-				if opErr != nil {
-					operationError = opErr
-					break
-				}
-				resTensor.Name = query.OutputTensorName
-				finalResultTensor = resTensor
-			case DataTypeInt64:
-				tA, loadErrA := loadFullTensorTyped[int64](e, tensorAName, metaA)
-				if loadErrA != nil {
-					operationError = loadErrA
-					break
-				}
-				tB, loadErrB := loadFullTensorTyped[int64](e, tensorBName, metaB)
-				if loadErrB != nil {
-					operationError = loadErrB
-					break
-				}
-				resTensor, opErr := AddTensors[int64](tA, tB)
-				if opErr != nil {
-					operationError = opErr
-					break
-				}
-				resTensor.Name = query.OutputTensorName
-				finalResultTensor = resTensor
-			default:
-				operationError = fmt.Errorf("unsupported data type for ADD_TENSORS operation: %s", metaA.DataType)
-			}
-		case "ADD_SCALAR":
-			if len(query.InputTensorNames) != 1 || query.ScalarOperand == "" {
-				operationError = errors.New("ADD_SCALAR operation requires one input tensor and a scalar operand")
-				break
-			}
-			tensorAName := query.InputTensorNames[0]
-			metaA, errA := e.storage.LoadTensorMetadata(tensorAName)
-			if errA != nil {
-				operationError = fmt.Errorf("failed to load metadata for tensor '%s': %w", tensorAName, errA)
-				break
-			}
-
-			switch metaA.DataType {
-			case DataTypeFloat32:
-				tA, loadErrA := loadFullTensorTyped[float32](e, tensorAName, metaA)
-				if loadErrA != nil {
-					operationError = loadErrA
-					break
-				}
-				scalarVal, parseErr := strconv.ParseFloat(query.ScalarOperand, 32)
-				if parseErr != nil {
-					operationError = fmt.Errorf("failed to parse scalar operand '%s' as float32: %w", query.ScalarOperand, parseErr)
-					break
-				}
-				resTensor, opErr := AddScalarToTensor[float32](tA, float32(scalarVal))
-				if opErr != nil {
-					operationError = opErr
-					break
-				}
-				resTensor.Name = query.OutputTensorName
-				finalResultTensor = resTensor
-			case DataTypeFloat64:
-				tA, loadErrA := loadFullTensorTyped[float64](e, tensorAName, metaA)
-				if loadErrA != nil {
-					operationError = loadErrA
-					break
-				}
-				scalarVal, parseErr := strconv.ParseFloat(query.ScalarOperand, 64)
-				if parseErr != nil {
-					operationError = fmt.Errorf("failed to parse scalar operand '%s' as float64: %w", query.ScalarOperand, parseErr)
-					break
-				}
-				resTensor, opErr := AddScalarToTensor[float64](tA, scalarVal)
-				if opErr != nil {
-					operationError = opErr
-					break
-				}
-				resTensor.Name = query.OutputTensorName
-				finalResultTensor = resTensor
-			case DataTypeInt32:
-				tA, loadErrA := loadFullTensorTyped[int32](e, tensorAName, metaA)
-				if loadErrA != nil {
-					operationError = loadErrA
-					break
-				}
-				scalarVal, parseErr := strconv.ParseInt(query.ScalarOperand, 10, 32)
-				if parseErr != nil {
-					operationError = fmt.Errorf("failed to parse scalar operand '%s' as int32: %w", query.ScalarOperand, parseErr)
-					break
-				}
-				resTensor, opErr := AddScalarToTensor[int32](tA, int32(scalarVal))
-				if opErr != nil {
-					operationError = opErr
-					break
-				}
-				resTensor.Name = query.OutputTensorName
-				finalResultTensor = resTensor
-			case DataTypeInt64:
-				tA, loadErrA := loadFullTensorTyped[int64](e, tensorAName, metaA)
-				if loadErrA != nil {
-					operationError = loadErrA
-					break
-				}
-				scalarVal, parseErr := strconv.ParseInt(query.ScalarOperand, 10, 64)
-				if parseErr != nil {
-					operationError = fmt.Errorf("failed to parse scalar operand '%s' as int64: %w", query.ScalarOperand, parseErr)
-					break
-				}
-				resTensor, opErr := AddScalarToTensor[int64](tA, scalarVal)
-				if opErr != nil {
-					operationError = opErr
-					break
-				}
-				resTensor.Name = query.OutputTensorName
-				finalResultTensor = resTensor
-			default:
-				operationError = fmt.Errorf("unsupported data type for ADD_SCALAR operation: %s", metaA.DataType)
-			}
-		default:
-			return nil, fmt.Errorf("unsupported mathematical operator: %s", query.MathOperator)
-		}
-		if operationError != nil {
-			return nil, operationError
-		}
-		if finalResultTensor != nil {
-			var resultMetadata *TensorMetadata
-			switch rt := finalResultTensor.(type) {
-			case *Tensor[float32]:
-				if err := SaveTensor(e.storage, rt); err != nil {
-					return nil, fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
-				}
-				resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
-			case *Tensor[float64]:
-				if err := SaveTensor(e.storage, rt); err != nil {
-					return nil, fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
-				}
-				resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
-			case *Tensor[int32]:
-				if err := SaveTensor(e.storage, rt); err != nil {
-					return nil, fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
-				}
-				resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
-			case *Tensor[int64]:
-				if err := SaveTensor(e.storage, rt); err != nil {
-					return nil, fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
-				}
-				resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
-			default:
-				return nil, fmt.Errorf("unknown type for result tensor, cannot save or index")
-			}
-			if resultMetadata != nil {
-				e.storage.AddTensorToIndex(resultMetadata)
-			}
-			return fmt.Sprintf("Tensor '%s' created successfully from operation %s", query.OutputTensorName, query.MathOperator), nil
-		}
-		return nil, fmt.Errorf("math operation did not produce a result tensor")
+		return e.executeMathOperation(query)
 
 	case ListTensorsQuery:
 		tensorNames := e.storage.QueryIndex(query.FilterDataType, query.FilterNumDimensions)
@@ -923,7 +1331,377 @@ func (e *Executor) Execute(query *Query) (interface{}, error) {
 		}
 		return results, nil
 
+	case DescribeTensorQuery:
+		tensorName := query.TensorNames[0]
+		metadata, err := e.storage.LoadTensorMetadata(tensorName)
+		if err != nil {
+			return nil, fmt.Errorf("tensor '%s' not found for describe: %w", tensorName, err)
+		}
+		elementSize, err := GetElementSize(metadata.DataType)
+		if err != nil {
+			return nil, err
+		}
+		totalElements := tNilaiTotalElemen(metadata.Shape)
+		return &TensorDescription{
+			Name:          metadata.Name,
+			Shape:         metadata.Shape,
+			DataType:      metadata.DataType,
+			Strides:       metadata.Strides,
+			NumDimensions: len(metadata.Shape),
+			TotalElements: totalElements,
+			DataSizeBytes: totalElements * elementSize,
+		}, nil
+
+	case CountQuery:
+		// Hanya file .meta yang dibaca; jumlah elemen dihitung dari shape tanpa membuka file .data.
+		tensorName := query.TensorNames[0]
+		metadata, err := e.storage.LoadTensorMetadata(tensorName)
+		if err != nil {
+			return nil, fmt.Errorf("tensor '%s' not found for count: %w", tensorName, err)
+		}
+		return tNilaiTotalElemen(metadata.Shape), nil
+
+	case ValidateTensorQuery:
+		return e.executeValidate(query)
+
+	case StatsTensorQuery:
+		return e.executeStats(query)
+
+	case DropTensorQuery:
+		tensorName := query.TensorNames[0]
+		metadata, err := e.storage.LoadTensorMetadata(tensorName)
+		if err != nil {
+			return nil, fmt.Errorf("tensor '%s' not found for drop: %w", tensorName, err)
+		}
+		// Handle yang masih terbuka harus dilepas sebelum file dihapus (di Windows file yang
+		// masih di-mmap tidak dapat dihapus).
+		e.mmapsMux.Lock()
+		e.releaseHandleLocked(tensorName)
+		e.mmapsMux.Unlock()
+		if err := e.storage.DeleteTensorFiles(tensorName); err != nil {
+			// DeleteTensorFiles menghapus .meta lebih dulu; jika itu sudah terjadi, tensor tidak lagi ada
+			// walaupun file lain gagal dihapus, jadi indeks tetap harus mengikutinya.
+			if _, errMeta := e.storage.LoadTensorMetadata(tensorName); errors.Is(errMeta, fs.ErrNotExist) {
+				e.storage.RemoveTensorFromIndex(metadata)
+			}
+			return nil, fmt.Errorf("failed to drop tensor '%s': %w", tensorName, err)
+		}
+		e.storage.RemoveTensorFromIndex(metadata)
+		return fmt.Sprintf("Tensor %s dropped", tensorName), nil
+
+	case UpdateElementQuery:
+		metadata, err := e.storage.LoadTensorMetadata(query.TensorNames[0])
+		if err != nil {
+			return nil, fmt.Errorf("tensor '%s' not found for update: %w", query.TensorNames[0], err)
+		}
+		if metadata.DataType == DataTypeBool {
+			if query, err = normalizeBoolQuery(query); err != nil {
+				return nil, err
+			}
+		}
+		return e.executeUpdateElement(query, metadata)
+
+	case RenameTensorQuery:
+		oldName, newName := query.TensorNames[0], query.TensorNames[1]
+		metadata, err := e.storage.LoadTensorMetadata(oldName)
+		if err != nil {
+			return nil, fmt.Errorf("tensor '%s' not found for rename: %w", oldName, err)
+		}
+		if _, err := e.storage.LoadTensorMetadata(newName); err == nil {
+			return nil, fmt.Errorf("cannot rename tensor '%s': tensor '%s' already exists", oldName, newName)
+		}
+		e.mmapsMux.Lock()
+		e.releaseHandleLocked(oldName)
+		e.mmapsMux.Unlock()
+		if err := e.storage.RenameTensorFiles(oldName, newName); err != nil {
+			return nil, fmt.Errorf("failed to rename tensor '%s' to '%s': %w", oldName, newName, err)
+		}
+		e.storage.RemoveTensorFromIndex(metadata)
+		renamedMetadata := *metadata
+		renamedMetadata.Name = newName
+		e.storage.AddTensorToIndex(&renamedMetadata)
+		return fmt.Sprintf("Tensor %s renamed to %s", oldName, newName), nil
+
+	case ExportShardsQuery:
+		return e.executeExportShards(query)
+
+	case ImportShardsQuery:
+		return e.executeImportShards(query)
+
 	default:
 		return nil, fmt.Errorf("unsupported query type: %s", query.Type)
 	}
 }
+
+// insertElementCountError menyusun pesan error ketika jumlah nilai INSERT tidak sesuai shape tensor.
+// Tensor skalar dan VALUES kosong mendapat pesan tersendiri agar penyebabnya langsung terlihat.
+func insertElementCountError(source string, provided int, metadata *TensorMetadata, expected int) error {
+	if len(metadata.Shape) == 0 {
+		return fmt.Errorf("scalar tensor '%s' requires exactly one value, but %s data provides %d", metadata.Name, source, provided)
+	}
+	if provided == 0 {
+		return fmt.Errorf("%s data provides no values, but tensor '%s' of shape %v requires %d elements", source, metadata.Name, metadata.Shape, expected)
+	}
+	return fmt.Errorf("%s data provides %d elements, but tensor '%s' of shape %v requires %d elements",
+		source, provided, metadata.Name, metadata.Shape, expected)
+}
+
+// parseInsertValue mengurai satu nilai INSERT sebagai tipe T dengan parseScalarOperand, lalu menulis
+// ulang error-nya agar menyebut indeks nilai. Nilai integer di luar jangkauan tipe mendapat pesan
+// seragam yang menyebut nilai, indeks, tipe data, dan jangkauan yang valid. Tipe unsigned menolak
+// nilai negatif.
+func parseInsertValue[T Numeric](sVal string, index int) (T, error) {
+	v, err := parseScalarOperand[T](sVal)
+	if err == nil {
+		return v, nil
+	}
+	var zero T
+	dataType, errType := GetDataTypeString[T]()
+	if errType != nil {
+		return zero, errType
+	}
+	elementSize, errSize := GetElementSize(dataType)
+	if errSize != nil {
+		return zero, errSize
+	}
+	bitSize := elementSize * 8
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		return zero, err
+	}
+
+	switch any(zero).(type) {
+	case float32, float64:
+	case uint8, uint32, uint64:
+		if strings.HasPrefix(strings.TrimSpace(sVal), "-") {
+			return zero, fmt.Errorf("negative value '%s' at index %d is not allowed for unsigned type %s", sVal, index, dataType)
+		}
+		if errors.Is(numErr, strconv.ErrRange) {
+			return zero, fmt.Errorf("value '%s' at index %d is out of range for %s: valid range is [0, %d]", sVal, index, dataType, uint64(math.MaxUint64)>>(64-bitSize))
+		}
+	default:
+		if errors.Is(numErr, strconv.ErrRange) {
+			minVal, maxVal := int64(math.MinInt64)>>(64-bitSize), int64(math.MaxInt64)>>(64-bitSize)
+			return zero, fmt.Errorf("value '%s' at index %d is out of range for %s: valid range is [%d, %d]", sVal, index, dataType, minVal, maxVal)
+		}
+	}
+	return zero, fmt.Errorf("error parsing '%s' at index %d as %s: %w", sVal, index, dataType, numErr)
+}
+
+// EachTensorMetadata meneruskan iterasi metadata terfilter ke Storage (lihat Storage.EachTensorMetadata).
+func (e *Executor) EachTensorMetadata(filterDataType string, filterNumDimensions int, fn func(meta *TensorMetadata) error) error {
+	return e.storage.EachTensorMetadata(filterDataType, filterNumDimensions, fn)
+}
+
+// LoadTensorMetadata meneruskan ke Storage.LoadTensorMetadata tanpa membuka file data.
+func (e *Executor) LoadTensorMetadata(name string) (*TensorMetadata, error) {
+	return e.storage.LoadTensorMetadata(name)
+}
+
+// IndexedTensorMetadata meneruskan ke Storage.IndexedTensorMetadata.
+func (e *Executor) IndexedTensorMetadata(name string) (*TensorMetadata, bool) {
+	return e.storage.IndexedTensorMetadata(name)
+}
+
+// MetadataModTime meneruskan ke Storage.MetadataModTime.
+func (e *Executor) MetadataModTime(name string) (time.Time, error) {
+	return e.storage.MetadataModTime(name)
+}
+
+// TensorFileSize meneruskan ke Storage.TensorFileSize.
+func (e *Executor) TensorFileSize(name string) (int64, error) {
+	return e.storage.TensorFileSize(name)
+}
+
+// createTensorFromSelect menjalankan SELECT (dengan slice opsional) dan menyimpan hasilnya
+// sebagai tensor baru. Shape diambil dari slice dan tipe data dari tensor sumber.
+func (e *Executor) createTensorFromSelect(tensorName string, sourceQuery *Query) (interface{}, error) {
+	if sourceQuery.Type != SelectTensorQuery || len(sourceQuery.TensorNames) == 0 {
+		return nil, fmt.Errorf("CREATE TENSOR '%s' FROM requires a SELECT source query", tensorName)
+	}
+	sourceName := sourceQuery.TensorNames[0]
+	metadata, err := e.storage.LoadTensorMetadata(sourceName)
+	if err != nil {
+		return nil, fmt.Errorf("source tensor '%s' not found for CREATE TENSOR '%s': %w", sourceName, tensorName, err)
+	}
+	var ranges [][2]int
+	if len(sourceQuery.Slices) > 0 {
+		ranges = sourceQuery.Slices[0]
+	}
+	var steps []int
+	if len(sourceQuery.SliceSteps) > 0 {
+		steps = sourceQuery.SliceSteps[0]
+	}
+
+	var resultTensor interface{}
+	switch metadata.DataType {
+	case DataTypeFloat32:
+		resultTensor, err = materializeSliceTyped[float32](e, sourceName, metadata, ranges, steps, tensorName)
+	case DataTypeFloat64:
+		resultTensor, err = materializeSliceTyped[float64](e, sourceName, metadata, ranges, steps, tensorName)
+	case DataTypeInt32:
+		resultTensor, err = materializeSliceTyped[int32](e, sourceName, metadata, ranges, steps, tensorName)
+	case DataTypeInt64:
+		resultTensor, err = materializeSliceTyped[int64](e, sourceName, metadata, ranges, steps, tensorName)
+	case DataTypeInt8:
+		resultTensor, err = materializeSliceTyped[int8](e, sourceName, metadata, ranges, steps, tensorName)
+	case DataTypeInt16:
+		resultTensor, err = materializeSliceTyped[int16](e, sourceName, metadata, ranges, steps, tensorName)
+	case DataTypeUint8, DataTypeBool:
+		resultTensor, err = materializeSliceTyped[uint8](e, sourceName, metadata, ranges, steps, tensorName)
+	case DataTypeUint32:
+		resultTensor, err = materializeSliceTyped[uint32](e, sourceName, metadata, ranges, steps, tensorName)
+	case DataTypeUint64:
+		resultTensor, err = materializeSliceTyped[uint64](e, sourceName, metadata, ranges, steps, tensorName)
+	default:
+		return nil, fmt.Errorf("unsupported data type for CREATE TENSOR ... FROM SELECT on tensor %s: %s", sourceName, metadata.DataType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := e.saveResultTensor(resultTensor); err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Tensor %s created from SELECT on %s with type %s", tensorName, sourceName, metadata.DataType), nil
+}
+
+// createTensorTyped membuat tensor baru bertipe T dan menyimpannya. Jika query.ScalarOperand terisi
+// (CREATE TENSOR ... FILL value), setiap elemen diisi nilai tersebut, yang diurai sesuai tipe T, dan
+// nilai-nilai itu juga dicatat ke stats jika stats tidak nil. query.Identity (CREATE IDENTITY) mengisi
+// diagonal tensor persegi dengan 1, dan query.RangeStep (CREATE RANGE) menghasilkan tensor 1-D berisi
+// deret aritmetika (lihat parseRangeSpec).
+func createTensorTyped[T Numeric](e *Executor, tensorName string, query *Query, stats *RunningStats) (*TensorMetadata, error) {
+	shape := query.Shape
+	var rangeStart, rangeStep T
+	if query.RangeStep != "" {
+		count, start, step, err := parseRangeSpec[T](tensorName, query)
+		if err != nil {
+			return nil, err
+		}
+		shape, rangeStart, rangeStep = []int{count}, start, step
+	}
+	tensorInstance, err := NewTensor[T](tensorName, shape, query.DataType)
+	if err != nil {
+		return nil, err
+	}
+	if query.RangeStep != "" {
+		for i := range tensorInstance.Data {
+			tensorInstance.Data[i] = rangeStart + T(i)*rangeStep
+		}
+	}
+	if query.ScalarOperand != "" {
+		value, err := parseScalarOperand[T](query.ScalarOperand)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FILL value for tensor '%s': %w", tensorName, err)
+		}
+		for i := range tensorInstance.Data {
+			tensorInstance.Data[i] = value
+			if stats != nil {
+				stats.add(float64(value))
+			}
+		}
+	}
+	if query.Random {
+		fillRandom(tensorInstance.Data, rand.New(rand.NewSource(*query.Seed)), query.DataType == DataTypeBool)
+	}
+	if query.Identity {
+		n := query.Shape[0]
+		for i := 0; i < n; i++ {
+			tensorInstance.Data[i*n+i] = 1
+		}
+	}
+	if err := SaveNewTensor(e.storage, tensorInstance); err != nil {
+		return nil, err
+	}
+	return &TensorMetadata{Name: tensorInstance.Name, Shape: tensorInstance.Shape, DataType: tensorInstance.DataType, Strides: tensorInstance.Strides}, nil
+}
+
+// randomIntBound adalah batas atas eksklusif nilai integer CREATE RANDOM; nilainya muat di semua tipe
+// integer, termasuk int8.
+const randomIntBound = 100
+
+// fillRandom mengisi data dengan nilai pseudo-acak dari rng: [0, 1) untuk float, [0, randomIntBound)
+// untuk integer, dan 0 atau 1 untuk bool.
+func fillRandom[T Numeric](data []T, rng *rand.Rand, isBool bool) {
+	var zero T
+	for i := range data {
+		switch any(zero).(type) {
+		case float32:
+			data[i] = T(rng.Float32())
+		case float64:
+			data[i] = T(rng.Float64())
+		default:
+			if isBool {
+				data[i] = T(rng.Intn(2))
+			} else {
+				data[i] = T(rng.Intn(randomIntBound))
+			}
+		}
+	}
+}
+
+// parseRangeSpec mengurai FROM, TO, dan STEP CREATE RANGE sesuai tipe T lalu menghitung jumlah elemen
+// ceil((to-from)/step). Rentang bersifat setengah terbuka [from, to), jadi from = to menghasilkan tensor
+// kosong. Langkah nol, atau langkah yang arahnya menjauhi to, menghasilkan error.
+func parseRangeSpec[T Numeric](tensorName string, query *Query) (int, T, T, error) {
+	var zero T
+	start, err := parseScalarOperand[T](query.RangeFrom)
+	if err != nil {
+		return 0, zero, zero, fmt.Errorf("invalid FROM value for range '%s': %w", tensorName, err)
+	}
+	stop, err := parseScalarOperand[T](query.RangeTo)
+	if err != nil {
+		return 0, zero, zero, fmt.Errorf("invalid TO value for range '%s': %w", tensorName, err)
+	}
+	step, err := parseScalarOperand[T](query.RangeStep)
+	if err != nil {
+		return 0, zero, zero, fmt.Errorf("invalid STEP value for range '%s': %w", tensorName, err)
+	}
+	if step == 0 {
+		return 0, zero, zero, fmt.Errorf("range step for '%s' must not be zero", tensorName)
+	}
+	if (start < stop && step < 0) || (start > stop && step > 0) {
+		return 0, zero, zero, fmt.Errorf("range step %s for '%s' moves away from %s toward %s", query.RangeStep, tensorName, query.RangeFrom, query.RangeTo)
+	}
+	count := math.Ceil((float64(stop) - float64(start)) / float64(step))
+	if math.IsNaN(count) || count >= float64(math.MaxInt32) {
+		return 0, zero, zero, fmt.Errorf("range from %s to %s with step %s for '%s' has too many elements", query.RangeFrom, query.RangeTo, query.RangeStep, tensorName)
+	}
+	return int(count), start, step, nil
+}
+
+// materializeSliceTyped memuat tensor sumber dan menyalin slice-nya (dengan langkah steps, nil berarti 1)
+// ke tensor baru bernama newName. ranges kosong berarti seluruh tensor.
+func materializeSliceTyped[T Numeric](e *Executor, sourceName string, metadata *TensorMetadata, ranges [][2]int, steps []int, newName string) (*Tensor[T], error) {
+	source, err := loadFullTensorTyped[T](e, sourceName, metadata)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranges) == 0 {
+		result, err := NewTensor[T](newName, source.Shape, source.DataType)
+		if err != nil {
+			return nil, err
+		}
+		if err := result.SetData(source.Data); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	ranges, err = ResolveSliceRanges(ranges, source.Shape)
+	if err != nil {
+		return nil, fmt.Errorf("failed to slice %s: %w", sourceName, err)
+	}
+	slicedData, err := source.GetSliceStep(ranges, steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to slice %s: %w", sourceName, err)
+	}
+	result, err := NewTensor[T](newName, sliceShape(ranges, steps), source.DataType)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.SetData(slicedData); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package tensor
+
+import "fmt"
+
+// executeAppend menambahkan data di sepanjang satu sumbu (default sumbu 0) dari tensor yang ada.
+// Untuk sumbu selain sumbu terdepan, data baru harus diselipkan di antara baris yang ada,
+// sehingga tensor dimuat, disusun ulang ke shape baru, lalu disimpan kembali.
+func (e *Executor) executeAppend(query *Query, metadata *TensorMetadata) (interface{}, error) {
+	axis := 0
+	if query.Axis != nil {
+		axis = *query.Axis
+	}
+	if len(metadata.Shape) == 0 {
+		return nil, fmt.Errorf("cannot append to scalar tensor '%s'", metadata.Name)
+	}
+	if axis < 0 || axis >= len(metadata.Shape) {
+		return nil, fmt.Errorf("append axis %d out of range for tensor '%s' with %d dimension(s)", axis, metadata.Name, len(metadata.Shape))
+	}
+
+	var newShape []int
+	var err error
+	switch metadata.DataType {
+	case DataTypeFloat32:
+		newShape, err = appendAlongAxisTyped[float32](e, query, metadata, axis)
+	case DataTypeFloat64:
+		newShape, err = appendAlongAxisTyped[float64](e, query, metadata, axis)
+	case DataTypeInt32:
+		newShape, err = appendAlongAxisTyped[int32](e, query, metadata, axis)
+	case DataTypeInt64:
+		newShape, err = appendAlongAxisTyped[int64](e, query, metadata, axis)
+	case DataTypeInt8:
+		newShape, err = appendAlongAxisTyped[int8](e, query, metadata, axis)
+	case DataTypeInt16:
+		newShape, err = appendAlongAxisTyped[int16](e, query, metadata, axis)
+	case DataTypeUint8, DataTypeBool:
+		newShape, err = appendAlongAxisTyped[uint8](e, query, metadata, axis)
+	case DataTypeUint32:
+		newShape, err = appendAlongAxisTyped[uint32](e, query, metadata, axis)
+	case DataTypeUint64:
+		newShape, err = appendAlongAxisTyped[uint64](e, query, metadata, axis)
+	default:
+		return nil, fmt.Errorf("unsupported data type '%s' for append into tensor '%s'", metadata.DataType, metadata.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Data appended to %s along axis %d, new shape %v", metadata.Name, axis, newShape), nil
+}
+
+// appendAlongAxisTyped menyusun ulang data lama dan data baru ke shape yang diperbesar di sumbu axis.
+// Data baru ditafsirkan dalam urutan row-major dari potongan berbentuk shape dengan dimensi axis = k.
+func appendAlongAxisTyped[T Numeric](e *Executor, query *Query, metadata *TensorMetadata, axis int) ([]int, error) {
+	newData, err := appendValuesTyped[T](query, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	otherElements := 1
+	for i, dim := range metadata.Shape {
+		if i != axis {
+			otherElements *= dim
+		}
+	}
+	if otherElements == 0 || len(newData) == 0 || len(newData)%otherElements != 0 {
+		return nil, fmt.Errorf("appended data provides %d elements, must be a non-zero multiple of %d (product of the other dimensions of shape %v)",
+			len(newData), otherElements, metadata.Shape)
+	}
+	added := len(newData) / otherElements
+
+	existing, err := loadFullTensorTyped[T](e, metadata.Name, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	inner := 1
+	for _, dim := range metadata.Shape[axis+1:] {
+		inner *= dim
+	}
+	outer := 1
+	for _, dim := range metadata.Shape[:axis] {
+		outer *= dim
+	}
+	oldBlock := metadata.Shape[axis] * inner
+	newBlock := added * inner
+
+	combined := make([]T, 0, len(existing.Data)+len(newData))
+	for o := 0; o < outer; o++ {
+		combined = append(combined, existing.Data[o*oldBlock:(o+1)*oldBlock]...)
+		combined = append(combined, newData[o*newBlock:(o+1)*newBlock]...)
+	}
+
+	newShape := append([]int{}, metadata.Shape...)
+	newShape[axis] += added
+	result, err := NewTensor[T](metadata.Name, newShape, metadata.DataType)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.SetData(combined); err != nil {
+		return nil, err
+	}
+	if err := SaveTensor(e.storage, result); err != nil {
+		return nil, fmt.Errorf("failed to save appended tensor '%s': %w", metadata.Name, err)
+	}
+	return newShape, nil
+}
+
+// appendValuesTyped mengambil data yang akan ditambahkan dari RawData (client) atau Data (string kueri).
+func appendValuesTyped[T Numeric](query *Query, metadata *TensorMetadata) ([]T, error) {
+	if len(query.RawData) > 0 {
+		elementSize, err := GetElementSize(metadata.DataType)
+		if err != nil {
+			return nil, err
+		}
+		if len(query.RawData)%elementSize != 0 {
+			return nil, fmt.Errorf("raw data size (%d) is not a multiple of element size (%d) for data type %s", len(query.RawData), elementSize, metadata.DataType)
+		}
+		values := make([]T, len(query.RawData)/elementSize)
+		decodeChunk(query.RawData, values)
+		return values, nil
+	}
+
+	values := make([]T, len(query.Data))
+	for i, sVal := range query.Data {
+		v, err := parseInsertValue[T](sVal, i)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in APPEND: %w", err)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,78 @@
+package tensor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseBoolLiteral mengubah literal bool kueri (true/false/1/0, tanpa membedakan huruf besar-kecil)
+// menjadi nilai penyimpanan "1" atau "0".
+func parseBoolLiteral(sVal string, index int) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(sVal)) {
+	case "true", "1":
+		return "1", nil
+	case "false", "0":
+		return "0", nil
+	default:
+		return "", fmt.Errorf("invalid bool value '%s' at index %d: expected true, false, 1 or 0", sVal, index)
+	}
+}
+
+// normalizeBoolQuery mengembalikan salinan query dengan semua nilai INSERT/UPDATE diganti "1"/"0"
+// sehingga jalur uint8 dapat menulisnya apa adanya. Query milik pemanggil tidak diubah.
+func normalizeBoolQuery(query *Query) (*Query, error) {
+	normalized := *query
+	if query.Data != nil {
+		normalized.Data = make([]string, len(query.Data))
+		for i, sVal := range query.Data {
+			value, err := parseBoolLiteral(sVal, i)
+			if err != nil {
+				return nil, err
+			}
+			normalized.Data[i] = value
+		}
+	}
+	if query.Sparse != nil {
+		normalized.Sparse = append(query.Sparse[:0:0], query.Sparse...)
+		for i := range normalized.Sparse {
+			value, err := parseBoolLiteral(normalized.Sparse[i].Value, i)
+			if err != nil {
+				return nil, err
+			}
+			normalized.Sparse[i].Value = value
+		}
+	}
+	if query.ScalarOperand != "" {
+		value, err := parseBoolLiteral(query.ScalarOperand, 0)
+		if err != nil {
+			return nil, err
+		}
+		normalized.ScalarOperand = value
+	}
+	return &normalized, nil
+}
+
+// validateBoolBytes memastikan data mentah tensor bool hanya berisi byte 0 atau 1.
+func validateBoolBytes(raw []byte) error {
+	for i, b := range raw {
+		if b > 1 {
+			return fmt.Errorf("invalid bool byte %d at index %d: expected 0 or 1", b, i)
+		}
+	}
+	return nil
+}
+
+// formatBoolLeaves mengganti setiap elemen uint8 pada hasil FormatMultidimensional dengan bool.
+func formatBoolLeaves(formatted interface{}) interface{} {
+	switch v := formatted.(type) {
+	case uint8:
+		return v != 0
+	case []interface{}:
+		for i := range v {
+			v[i] = formatBoolLeaves(v[i])
+		}
+		return v
+	default:
+		return formatted
+	}
+}
@@ -0,0 +1,114 @@
+package tensor
+
+import "fmt"
+
+// executeSliceInsert menulis query.Data ke sub-region tensor yang ditentukan query.Slices[0]
+// langsung pada file data melalui mmap. Elemen di luar slice tidak disentuh.
+func (e *Executor) executeSliceInsert(query *Query, metadata *TensorMetadata) (interface{}, error) {
+	ranges := query.Slices[0]
+	if len(ranges) != len(metadata.Shape) {
+		return nil, fmt.Errorf("slice %v has %d dimension(s), tensor '%s' has %d", ranges, len(ranges), metadata.Name, len(metadata.Shape))
+	}
+	// Batas negatif dan terbuka diresolusi sekali di sini sehingga pesan hasil memuat rentang absolut.
+	ranges, err := ResolveSliceRanges(ranges, metadata.Shape)
+	if err != nil {
+		return nil, err
+	}
+	var written int
+	switch metadata.DataType {
+	case DataTypeFloat32:
+		written, err = sliceInsertTyped[float32](e, query, metadata, ranges)
+	case DataTypeFloat64:
+		written, err = sliceInsertTyped[float64](e, query, metadata, ranges)
+	case DataTypeInt32:
+		written, err = sliceInsertTyped[int32](e, query, metadata, ranges)
+	case DataTypeInt64:
+		written, err = sliceInsertTyped[int64](e, query, metadata, ranges)
+	case DataTypeInt8:
+		written, err = sliceInsertTyped[int8](e, query, metadata, ranges)
+	case DataTypeInt16:
+		written, err = sliceInsertTyped[int16](e, query, metadata, ranges)
+	case DataTypeUint8, DataTypeBool:
+		written, err = sliceInsertTyped[uint8](e, query, metadata, ranges)
+	case DataTypeUint32:
+		written, err = sliceInsertTyped[uint32](e, query, metadata, ranges)
+	case DataTypeUint64:
+		written, err = sliceInsertTyped[uint64](e, query, metadata, ranges)
+	default:
+		return nil, fmt.Errorf("unsupported data type '%s' for slice insert into tensor '%s'", metadata.DataType, metadata.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Data inserted into %s%v (%d elements set)", metadata.Name, ranges, written), nil
+}
+
+// sliceInsertTyped menulis query.Data sebagai T ke rentang absolut ranges (sudah diresolusi) tensor metadata.
+func sliceInsertTyped[T Numeric](e *Executor, query *Query, metadata *TensorMetadata, ranges [][2]int) (int, error) {
+	sliceShape := make([]int, len(ranges))
+	sliceElements := 1
+	for i, r := range ranges {
+		sliceShape[i] = r[1] - r[0]
+		sliceElements *= sliceShape[i]
+	}
+	if len(query.Data) != sliceElements {
+		return 0, fmt.Errorf("insert provides %d values, but slice %v of tensor '%s' (shape %v) requires %d elements", len(query.Data), ranges, metadata.Name, sliceShape, sliceElements)
+	}
+	if sliceElements == 0 {
+		return 0, nil
+	}
+
+	values := make([]T, sliceElements)
+	for i, sVal := range query.Data {
+		value, err := parseInsertValue[T](sVal, i)
+		if err != nil {
+			return 0, err
+		}
+		values[i] = value
+	}
+
+	elementSize, err := GetElementSize(metadata.DataType)
+	if err != nil {
+		return 0, err
+	}
+	// Slice tidak boleh ditulis ke blob dedup yang mungkin dipakai tensor lain.
+	if metadata, err = e.storage.detachBlob(metadata); err != nil {
+		return 0, err
+	}
+	file, mmapInstance, err := e.storage.OpenFileAndMmap(metadata.Name, tNilaiTotalElemen(metadata.Shape), elementSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open/mmap file for %s: %w", metadata.Name, err)
+	}
+	defer file.Close()
+	defer mmapInstance.Unmap()
+	// Elemen slice tersebar di file (satu baris per stride), bukan dibaca dari awal hingga akhir.
+	e.storage.AdviseMmap(mmapInstance, AccessRandom)
+
+	// Iterasi indeks multidimensi di dalam slice dalam urutan row-major; nilai sumber ke-i ditulis
+	// pada offset berstride elemen ke-i slice.
+	indices := make([]int, len(ranges))
+	for i, r := range ranges {
+		indices[i] = r[0]
+	}
+	for i := 0; i < sliceElements; i++ {
+		offset := 0
+		for dim, idx := range indices {
+			offset += idx * metadata.Strides[dim]
+		}
+		encodeChunk(values[i:i+1], mmapInstance[offset*elementSize:(offset+1)*elementSize])
+		for dim := len(indices) - 1; dim >= 0; dim-- {
+			indices[dim]++
+			if indices[dim] < ranges[dim][1] {
+				break
+			}
+			indices[dim] = ranges[dim][0]
+		}
+	}
+	if err := e.storage.flushMmap(mmapInstance, file, metadata.Name); err != nil {
+		return 0, err
+	}
+	if err := e.storage.refreshChecksum(metadata, mmapInstance); err != nil {
+		return 0, err
+	}
+	return sliceElements, nil
+}
@@ -0,0 +1,897 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// mathOperatorSpec mendeskripsikan kebutuhan input sebuah operator matematika.
+type mathOperatorSpec struct {
+	numInputs   int      // Jumlah tensor input yang dibutuhkan
+	needsScalar bool     // Apakah operator membutuhkan ScalarOperand
+	dataTypes   []string // Tipe data input yang didukung
+	reduction   bool     // Reduksi dijalankan secara streaming atas mmap (lihat reduceTyped)
+	inPlace     bool     // Operator dapat menulis hasil langsung ke tensor input (IN PLACE)
+	withIndices bool     // Operator juga menghasilkan tensor indeks int64 (IndicesTensorName)
+	variadic    bool     // numInputs adalah jumlah minimum; nama input boleh berupa pola glob (mis. sample_*)
+	segmentIDs  bool     // Input terakhir adalah tensor id segmen integer dengan tipe datanya sendiri
+	binaryOp    string   // Nama operasi di registry binaryOps yang menjalankan operator (lihat ApplyBinaryOp)
+}
+
+// mathOperators adalah satu-satunya tempat yang mendeklarasikan operator matematika beserta
+// tipe data input yang didukungnya. Executor memvalidasi kueri terhadap tabel ini sebelum
+// dispatch, sehingga tabel ini sekaligus menjadi dokumentasi matriks operator/tipe data.
+var mathOperators = map[string]mathOperatorSpec{
+	"ADD_TENSORS":      {numInputs: 2, dataTypes: numericDataTypes, binaryOp: "ADD"},
+	"MULTIPLY_TENSORS": {numInputs: 2, dataTypes: numericDataTypes},
+	"DIVIDE_TENSORS":   {numInputs: 2, dataTypes: numericDataTypes},
+	"MATMUL_TENSORS":   {numInputs: 2, dataTypes: numericDataTypes},
+	"INTERLEAVE":       {numInputs: 2, dataTypes: numericDataTypes},
+	"CONCAT":           {numInputs: 2, dataTypes: numericDataTypes},
+	"SEGMENT_SUM":      {numInputs: 2, dataTypes: numericDataTypes, segmentIDs: true},
+	"ADD_SCALAR":       {numInputs: 1, needsScalar: true, dataTypes: numericDataTypes, inPlace: true},
+	"MUL_SCALAR":       {numInputs: 1, needsScalar: true, dataTypes: numericDataTypes},
+	"SUB_SCALAR":       {numInputs: 1, needsScalar: true, dataTypes: numericDataTypes},
+	"SQRT":             {numInputs: 1, dataTypes: floatDataTypes},
+	"BITWISE_NOT":      {numInputs: 1, dataTypes: integerDataTypes},
+	"SHIFT_LEFT":       {numInputs: 1, needsScalar: true, dataTypes: integerDataTypes},
+	"SHIFT_RIGHT":      {numInputs: 1, needsScalar: true, dataTypes: integerDataTypes},
+	"PDIST":            {numInputs: 1, dataTypes: floatDataTypes},
+	"CLIP_NORM":        {numInputs: 1, needsScalar: true, dataTypes: floatDataTypes},
+	"COV":              {numInputs: 1, dataTypes: numericDataTypes},
+	"CORR":             {numInputs: 1, dataTypes: numericDataTypes},
+	"SUM":              {numInputs: 1, dataTypes: numericDataTypes, reduction: true},
+	"MEAN":             {numInputs: 1, dataTypes: numericDataTypes, reduction: true},
+	"NANSUM":           {numInputs: 1, dataTypes: floatDataTypes, reduction: true},
+	"NANMEAN":          {numInputs: 1, dataTypes: floatDataTypes, reduction: true},
+	"MAX":              {numInputs: 1, dataTypes: numericDataTypes},
+	"MIN":              {numInputs: 1, dataTypes: numericDataTypes},
+	"TOPK":             {numInputs: 1, needsScalar: true, dataTypes: numericDataTypes, withIndices: true},
+	"MOVING_AVG":       {numInputs: 1, needsScalar: true, dataTypes: numericDataTypes},
+	"BATCH":            {numInputs: 1, dataTypes: numericDataTypes, variadic: true},
+	"NORMALIZE":        {numInputs: 1, dataTypes: numericDataTypes},
+	"ONEHOT":           {numInputs: 1, needsScalar: true, dataTypes: integerDataTypes},
+	"REPLACE_VALUE":    {numInputs: 1, needsScalar: true, dataTypes: numericDataTypes, inPlace: true},
+	"REINTERPRET":      {numInputs: 1, dataTypes: numericDataTypes},
+	"TRANSPOSE":        {numInputs: 1, dataTypes: numericDataTypes},
+	"RESHAPE":          {numInputs: 1, dataTypes: numericDataTypes},
+}
+
+// lookupMathOperator mengembalikan spesifikasi operator dari tabel mathOperators, atau spesifikasi
+// operasi biner dua input jika operator adalah nama operasi yang didaftarkan dengan RegisterBinaryOp.
+func lookupMathOperator(operator string) (mathOperatorSpec, bool) {
+	if spec, ok := mathOperators[operator]; ok {
+		return spec, true
+	}
+	if dataTypes := binaryOpDataTypes(operator); dataTypes != nil {
+		return mathOperatorSpec{numInputs: 2, dataTypes: dataTypes, binaryOp: operator}, true
+	}
+	return mathOperatorSpec{}, false
+}
+
+// ValidateMathOperatorDataType memeriksa apakah operator matematika mendukung tipe data input.
+// Error yang dikembalikan seragam untuk semua operator: "operation X does not support dtype Y".
+func ValidateMathOperatorDataType(operator, dataType string) error {
+	spec, ok := lookupMathOperator(operator)
+	if !ok {
+		return fmt.Errorf("unsupported mathematical operator: %s", operator)
+	}
+	for _, dt := range spec.dataTypes {
+		if dt == dataType {
+			return nil
+		}
+	}
+	return fmt.Errorf("operation %s does not support dtype %s", operator, dataType)
+}
+
+func (e *Executor) executeMathOperation(query *Query) (interface{}, error) {
+	spec, ok := lookupMathOperator(query.MathOperator)
+	if !ok {
+		return nil, fmt.Errorf("unsupported mathematical operator: %s", query.MathOperator)
+	}
+	if query.InPlace && !spec.inPlace {
+		return nil, fmt.Errorf("operation %s cannot be executed in place", query.MathOperator)
+	}
+
+	if !query.InPlace {
+		outputNames := []string{query.OutputTensorName}
+		if spec.withIndices {
+			if query.IndicesTensorName == "" {
+				return nil, fmt.Errorf("%s operation requires an output tensor name for indices", query.MathOperator)
+			}
+			if query.IndicesTensorName == query.OutputTensorName {
+				return nil, fmt.Errorf("%s operation requires distinct output tensor names for values and indices", query.MathOperator)
+			}
+			outputNames = append(outputNames, query.IndicesTensorName)
+		}
+		for _, outputName := range outputNames {
+			_, errOutputCheck := e.storage.LoadTensorMetadata(outputName)
+			if errOutputCheck == nil {
+				return nil, fmt.Errorf("output tensor '%s' already exists. Math operations require a new output tensor name", outputName)
+			}
+			if !os.IsNotExist(errors.Unwrap(errOutputCheck)) && errOutputCheck != nil && !strings.Contains(errOutputCheck.Error(), "failed to read metadata") {
+				return nil, fmt.Errorf("error checking existing output tensor '%s': %w", outputName, errOutputCheck)
+			}
+		}
+	}
+
+	// Pola nama diekspansi ke slice lokal; Query milik pemanggil tidak diubah. Setelah ini nama input
+	// dibaca dari metadata yang dimuat, bukan dari query.InputTensorNames.
+	inputNames := query.InputTensorNames
+	if spec.variadic {
+		expanded, err := e.expandTensorNamePatterns(inputNames)
+		if err != nil {
+			return nil, err
+		}
+		inputNames = expanded
+		if len(inputNames) < spec.numInputs {
+			return nil, fmt.Errorf("%s operation requires at least %d input tensor(s), got %d", query.MathOperator, spec.numInputs, len(inputNames))
+		}
+	} else if len(inputNames) != spec.numInputs {
+		return nil, fmt.Errorf("%s operation requires %d input tensor(s), got %d", query.MathOperator, spec.numInputs, len(inputNames))
+	}
+	if spec.needsScalar && query.ScalarOperand == "" {
+		return nil, fmt.Errorf("%s operation requires a scalar operand", query.MathOperator)
+	}
+
+	inputs := make([]*TensorMetadata, len(inputNames))
+	for i, name := range inputNames {
+		meta, err := e.storage.LoadTensorMetadata(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load metadata for tensor '%s': %w", name, err)
+		}
+		if spec.segmentIDs && i == len(inputNames)-1 {
+			if !isIntegerDataType(meta.DataType) {
+				return nil, fmt.Errorf("%s requires an integer segment id tensor, but '%s' has dtype %s", query.MathOperator, name, meta.DataType)
+			}
+		} else if i > 0 && meta.DataType != inputs[0].DataType {
+			return nil, fmt.Errorf("data types of %s (%s) and %s (%s) do not match for %s",
+				inputs[0].Name, inputs[0].DataType, name, meta.DataType, query.MathOperator)
+		}
+		inputs[i] = meta
+	}
+
+	dataType := inputs[0].DataType
+	if err := ValidateMathOperatorDataType(query.MathOperator, dataType); err != nil {
+		return nil, err
+	}
+
+	if query.InPlace {
+		var inPlaceErr error
+		switch dataType {
+		case DataTypeFloat32:
+			inPlaceErr = inPlaceOperationTyped[float32](e, query, inputs[0])
+		case DataTypeFloat64:
+			inPlaceErr = inPlaceOperationTyped[float64](e, query, inputs[0])
+		case DataTypeInt32:
+			inPlaceErr = inPlaceOperationTyped[int32](e, query, inputs[0])
+		case DataTypeInt64:
+			inPlaceErr = inPlaceOperationTyped[int64](e, query, inputs[0])
+		case DataTypeInt8:
+			inPlaceErr = inPlaceOperationTyped[int8](e, query, inputs[0])
+		case DataTypeInt16:
+			inPlaceErr = inPlaceOperationTyped[int16](e, query, inputs[0])
+		case DataTypeUint8:
+			inPlaceErr = inPlaceOperationTyped[uint8](e, query, inputs[0])
+		case DataTypeUint32:
+			inPlaceErr = inPlaceOperationTyped[uint32](e, query, inputs[0])
+		case DataTypeUint64:
+			inPlaceErr = inPlaceOperationTyped[uint64](e, query, inputs[0])
+		default:
+			inPlaceErr = fmt.Errorf("operation %s does not support dtype %s", query.MathOperator, dataType)
+		}
+		if inPlaceErr != nil {
+			return nil, inPlaceErr
+		}
+		return fmt.Sprintf("Tensor '%s' updated in place by operation %s", inputs[0].Name, query.MathOperator), nil
+	}
+
+	var resultTensor interface{}
+	var operationError error
+	switch dataType {
+	case DataTypeFloat32:
+		resultTensor, operationError = mathOperationTyped[float32](e, query, inputs)
+	case DataTypeFloat64:
+		resultTensor, operationError = mathOperationTyped[float64](e, query, inputs)
+	case DataTypeInt32:
+		resultTensor, operationError = mathOperationTyped[int32](e, query, inputs)
+	case DataTypeInt64:
+		resultTensor, operationError = mathOperationTyped[int64](e, query, inputs)
+	case DataTypeInt8:
+		resultTensor, operationError = mathOperationTyped[int8](e, query, inputs)
+	case DataTypeInt16:
+		resultTensor, operationError = mathOperationTyped[int16](e, query, inputs)
+	case DataTypeUint8:
+		resultTensor, operationError = mathOperationTyped[uint8](e, query, inputs)
+	case DataTypeUint32:
+		resultTensor, operationError = mathOperationTyped[uint32](e, query, inputs)
+	case DataTypeUint64:
+		resultTensor, operationError = mathOperationTyped[uint64](e, query, inputs)
+	default:
+		operationError = fmt.Errorf("operation %s does not support dtype %s", query.MathOperator, dataType)
+	}
+	if operationError != nil {
+		return nil, operationError
+	}
+	if resultTensor == nil {
+		return nil, fmt.Errorf("math operation did not produce a result tensor")
+	}
+	if results, ok := resultTensor.([]interface{}); ok {
+		outputNames := []string{query.OutputTensorName, query.IndicesTensorName}
+		for i, rt := range results {
+			if err := e.saveResultTensor(rt); err != nil {
+				// Output yang sudah tersimpan dihapus lagi agar operasi yang gagal tidak meninggalkan
+				// tensor nilai tanpa tensor indeksnya.
+				for _, saved := range outputNames[:i] {
+					if meta, errMeta := e.storage.LoadTensorMetadata(saved); errMeta == nil {
+						e.storage.RemoveTensorFromIndex(meta)
+					}
+					e.storage.DeleteTensorFiles(saved)
+				}
+				return nil, err
+			}
+		}
+		return fmt.Sprintf("Tensors '%s' and '%s' created successfully from operation %s", query.OutputTensorName, query.IndicesTensorName, query.MathOperator), nil
+	}
+	if err := e.saveResultTensor(resultTensor); err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Tensor '%s' created successfully from operation %s", query.OutputTensorName, query.MathOperator), nil
+}
+
+// mathOperationTyped memuat tensor input sebagai T dan menjalankan operator.
+// Tipe data input sudah divalidasi oleh executeMathOperation.
+func mathOperationTyped[T Numeric](e *Executor, query *Query, metas []*TensorMetadata) (interface{}, error) {
+	spec, _ := lookupMathOperator(query.MathOperator)
+	if spec.reduction {
+		return reduceTyped[T](e, query, metas[0])
+	}
+
+	inputs := make([]*Tensor[T], len(metas))
+	for i, meta := range metas {
+		if spec.segmentIDs && i == len(metas)-1 {
+			// Id segmen dimuat terpisah sesuai tipe datanya sendiri (lihat loadSegmentIDs).
+			continue
+		}
+		var err error
+		inputs[i], err = loadFullTensorTyped[T](e, meta.Name, meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result *Tensor[T]
+	var err error
+	switch query.MathOperator {
+	case "ADD_TENSORS":
+		if query.Broadcast {
+			result, err = AddTensorsBroadcast(inputs[0], inputs[1])
+		} else {
+			result, err = ApplyBinaryOp(spec.binaryOp, inputs[0], inputs[1])
+		}
+	case "MULTIPLY_TENSORS":
+		result, err = MultiplyTensors(inputs[0], inputs[1])
+	case "DIVIDE_TENSORS":
+		result, err = DivideTensors(inputs[0], inputs[1])
+	case "MATMUL_TENSORS":
+		result, err = MatMul(inputs[0], inputs[1])
+	case "SEGMENT_SUM":
+		segments, loadErr := loadSegmentIDs(e, metas[1].Name, metas[1])
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		result, err = SegmentSum(inputs[0], segments)
+	case "INTERLEAVE":
+		axis := 0
+		if query.Axis != nil {
+			axis = *query.Axis
+		}
+		result, err = Interleave(inputs[0], inputs[1], axis)
+	case "CONCAT":
+		if query.Axis == nil {
+			return nil, fmt.Errorf("CONCAT operation requires an axis")
+		}
+		result, err = Concat(inputs[0], inputs[1], *query.Axis)
+	case "ADD_SCALAR":
+		scalar, parseErr := parseScalarOperand[T](query.ScalarOperand)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		result, err = AddScalarToTensor(inputs[0], scalar)
+	case "MUL_SCALAR":
+		scalar, parseErr := parseScalarOperand[T](query.ScalarOperand)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		result, err = MultiplyTensorByScalar(inputs[0], scalar)
+	case "SUB_SCALAR":
+		scalar, parseErr := parseScalarOperand[T](query.ScalarOperand)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		result, err = SubtractScalarFromTensor(inputs[0], scalar)
+	case "SQRT":
+		result, err = SqrtTensor(inputs[0])
+	case "BITWISE_NOT":
+		result, err = BitwiseNotTensor(inputs[0])
+	case "SHIFT_LEFT", "SHIFT_RIGHT":
+		amount, parseErr := strconv.Atoi(query.ScalarOperand)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%s requires an integer shift amount, got '%s'", query.MathOperator, query.ScalarOperand)
+		}
+		result, err = ShiftTensor(inputs[0], amount, query.MathOperator == "SHIFT_LEFT")
+	case "PDIST":
+		result, err = PairwiseDistances(inputs[0])
+	case "CLIP_NORM":
+		maxNorm, parseErr := strconv.ParseFloat(query.ScalarOperand, 64)
+		if parseErr != nil {
+			return nil, fmt.Errorf("CLIP_NORM requires a numeric MAX, got '%s'", query.ScalarOperand)
+		}
+		result, err = ClipByNorm(inputs[0], maxNorm)
+	case "BATCH":
+		result, err = StackTensors(inputs)
+	case "TRANSPOSE":
+		result, err = Transpose(inputs[0], query.Perm)
+	case "RESHAPE":
+		result, err = Reshape(inputs[0], query.Shape)
+	case "REPLACE_VALUE":
+		match, replacement, tolerance, parseErr := parseReplaceOperands[T](query, inputs[0].DataType)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		result, err = ReplaceValue(inputs[0], match, replacement, tolerance)
+	case "MAX", "MIN":
+		input, axis := inputs[0], 0
+		if query.Axis != nil {
+			axis = *query.Axis
+		} else {
+			// Tanpa AXIS, reduksi dilakukan atas seluruh elemen dan hasilnya tensor skalar.
+			input = &Tensor[T]{Name: input.Name, Shape: []int{len(input.Data)}, Data: input.Data, DataType: input.DataType, Strides: []int{1}}
+		}
+		if query.MathOperator == "MAX" {
+			result, err = MaxAlongAxis(input, axis)
+		} else {
+			result, err = MinAlongAxis(input, axis)
+		}
+	case "MOVING_AVG":
+		return movingAverageTyped(inputs[0], query)
+	case "NORMALIZE":
+		return normalizeTyped(inputs[0], query)
+	case "COV", "CORR":
+		return covarianceTyped(inputs[0], query)
+	case "ONEHOT":
+		return oneHotTyped(inputs[0], query)
+	case "REINTERPRET":
+		return reinterpretTyped(inputs[0], query)
+	case "TOPK":
+		k, parseErr := strconv.Atoi(query.ScalarOperand)
+		if parseErr != nil || k <= 0 {
+			return nil, fmt.Errorf("TOPK requires a positive integer K, got '%s'", query.ScalarOperand)
+		}
+		values, indices, topErr := TopK(inputs[0], k)
+		if topErr != nil {
+			return nil, topErr
+		}
+		values.Name = query.OutputTensorName
+		indices.Name = query.IndicesTensorName
+		return []interface{}{values, indices}, nil
+	default:
+		if spec.binaryOp == "" {
+			return nil, fmt.Errorf("unsupported mathematical operator: %s", query.MathOperator)
+		}
+		result, err = ApplyBinaryOp(spec.binaryOp, inputs[0], inputs[1])
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Name = query.OutputTensorName
+	return result, nil
+}
+
+// loadSegmentIDs memuat tensor id segmen integer apa pun sebagai []int64.
+func loadSegmentIDs(e *Executor, tensorName string, metadata *TensorMetadata) ([]int64, error) {
+	switch metadata.DataType {
+	case DataTypeInt32:
+		return loadAsInt64[int32](e, tensorName, metadata)
+	case DataTypeInt64:
+		return loadAsInt64[int64](e, tensorName, metadata)
+	case DataTypeInt8:
+		return loadAsInt64[int8](e, tensorName, metadata)
+	case DataTypeInt16:
+		return loadAsInt64[int16](e, tensorName, metadata)
+	case DataTypeUint8:
+		return loadAsInt64[uint8](e, tensorName, metadata)
+	case DataTypeUint32:
+		return loadAsInt64[uint32](e, tensorName, metadata)
+	case DataTypeUint64:
+		return loadAsInt64[uint64](e, tensorName, metadata)
+	default:
+		return nil, fmt.Errorf("segment id tensor '%s' must be an integer tensor, got dtype %s", tensorName, metadata.DataType)
+	}
+}
+
+func loadAsInt64[S Numeric](e *Executor, tensorName string, metadata *TensorMetadata) ([]int64, error) {
+	t, err := loadFullTensorTyped[S](e, tensorName, metadata)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]int64, len(t.Data))
+	for i, v := range t.Data {
+		values[i] = int64(v)
+	}
+	return values, nil
+}
+
+// expandTensorNamePatterns mengganti setiap nama yang mengandung karakter glob (*, ?, [) dengan
+// semua tensor yang cocok, diurutkan secara leksikografis. Nama biasa dipertahankan apa adanya.
+// Pola yang tidak cocok dengan tensor mana pun dianggap error agar batch tidak diam-diam kosong.
+func (e *Executor) expandTensorNamePatterns(names []string) ([]string, error) {
+	var allNames []string
+	expanded := make([]string, 0, len(names))
+	for _, name := range names {
+		if !strings.ContainsAny(name, "*?[") {
+			expanded = append(expanded, name)
+			continue
+		}
+		if allNames == nil {
+			allNames = e.storage.QueryIndex("", -1)
+		}
+		var matched []string
+		for _, candidate := range allNames {
+			ok, err := path.Match(name, candidate)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tensor name pattern '%s': %w", name, err)
+			}
+			if ok {
+				matched = append(matched, candidate)
+			}
+		}
+		if len(matched) == 0 {
+			return nil, fmt.Errorf("tensor name pattern '%s' matched no tensors", name)
+		}
+		sort.Strings(matched)
+		expanded = append(expanded, matched...)
+	}
+	return expanded, nil
+}
+
+// inPlaceOperationTyped menjalankan operator IN PLACE langsung pada mmap file data tensor input.
+func inPlaceOperationTyped[T Numeric](e *Executor, query *Query, metadata *TensorMetadata) error {
+	switch query.MathOperator {
+	case "ADD_SCALAR":
+		scalar, err := parseScalarOperand[T](query.ScalarOperand)
+		if err != nil {
+			return err
+		}
+		return applyInPlaceTyped(e, metadata.Name, metadata, func(chunk []T) {
+			for i := range chunk {
+				chunk[i] += scalar
+			}
+		})
+	case "REPLACE_VALUE":
+		match, replacement, tolerance, err := parseReplaceOperands[T](query, metadata.DataType)
+		if err != nil {
+			return err
+		}
+		return applyInPlaceTyped(e, metadata.Name, metadata, func(chunk []T) {
+			replaceInSlice(chunk, match, replacement, tolerance)
+		})
+	default:
+		return fmt.Errorf("operation %s cannot be executed in place", query.MathOperator)
+	}
+}
+
+// applyInPlaceTyped menerapkan fn ke setiap elemen tensor langsung melalui mmap file datanya,
+// per jendela, tanpa membuat tensor output maupun memuat seluruh data ke memori.
+func applyInPlaceTyped[T Numeric](e *Executor, tensorName string, metadata *TensorMetadata, fn func(chunk []T)) error {
+	totalElements := tNilaiTotalElemen(metadata.Shape)
+	elementSize, err := GetElementSize(metadata.DataType)
+	if err != nil {
+		return fmt.Errorf("applyInPlaceTyped: %w", err)
+	}
+
+	// Blob dedup bersama disalin lebih dulu agar tensor lain yang merujuknya tidak ikut berubah.
+	if metadata, err = e.storage.detachBlob(metadata); err != nil {
+		return err
+	}
+	file, mmapInstance, err := e.storage.OpenFileAndMmap(tensorName, totalElements, elementSize)
+	if err != nil {
+		return fmt.Errorf("applyInPlaceTyped: failed to open/mmap file for %s: %w", tensorName, err)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+	if mmapInstance == nil {
+		return nil // Tensor kosong, tidak ada yang diubah
+	}
+	defer mmapInstance.Unmap()
+
+	e.storage.AdviseMmap(mmapInstance, AccessSequential)
+
+	chunk := make([]T, reduceChunkElements)
+	for start := 0; start < totalElements; start += reduceChunkElements {
+		n := reduceChunkElements
+		if start+n > totalElements {
+			n = totalElements - start
+		}
+		window := mmapInstance[start*elementSize : (start+n)*elementSize]
+		decodeChunk(window, chunk[:n])
+		fn(chunk[:n])
+		encodeChunk(chunk[:n], window)
+	}
+	if err := e.storage.flushMmap(mmapInstance, file, tensorName); err != nil {
+		return err
+	}
+	return e.storage.refreshChecksum(metadata, mmapInstance)
+}
+
+// parseReplaceOperands mengurai nilai yang dicari (MatchValue), nilai pengganti (ScalarOperand),
+// dan toleransi opsional REPLACE_VALUE. Toleransi hanya berlaku untuk tipe float.
+func parseReplaceOperands[T Numeric](query *Query, dataType string) (T, T, float64, error) {
+	var zero T
+	match, err := parseScalarOperand[T](query.MatchValue)
+	if err != nil {
+		return zero, zero, 0, fmt.Errorf("invalid value to replace: %w", err)
+	}
+	replacement, err := parseScalarOperand[T](query.ScalarOperand)
+	if err != nil {
+		return zero, zero, 0, fmt.Errorf("invalid replacement value: %w", err)
+	}
+	tolerance := 0.0
+	if query.Tolerance != "" {
+		if dataType != DataTypeFloat32 && dataType != DataTypeFloat64 {
+			return zero, zero, 0, fmt.Errorf("REPLACE_VALUE tolerance is only supported for float data types, got %s", dataType)
+		}
+		tolerance, err = strconv.ParseFloat(query.Tolerance, 64)
+		if err != nil || tolerance < 0 {
+			return zero, zero, 0, fmt.Errorf("REPLACE_VALUE requires a non-negative tolerance, got '%s'", query.Tolerance)
+		}
+	}
+	return match, replacement, tolerance, nil
+}
+
+// movingAverageTyped menjalankan MOVING_AVG dengan ScalarOperand sebagai ukuran jendela dan Axis
+// (default 0) sebagai sumbu. Input float32 menghasilkan float32; tipe lain menghasilkan float64.
+func movingAverageTyped[T Numeric](input *Tensor[T], query *Query) (interface{}, error) {
+	window, err := strconv.Atoi(query.ScalarOperand)
+	if err != nil {
+		return nil, fmt.Errorf("MOVING_AVG requires an integer window, got '%s'", query.ScalarOperand)
+	}
+	axis := 0
+	if query.Axis != nil {
+		axis = *query.Axis
+	}
+	if input.DataType == DataTypeFloat32 {
+		result, err := MovingAverage[T, float32](input, window, axis, DataTypeFloat32)
+		if err != nil {
+			return nil, err
+		}
+		result.Name = query.OutputTensorName
+		return result, nil
+	}
+	result, err := MovingAverage[T, float64](input, window, axis, DataTypeFloat64)
+	if err != nil {
+		return nil, err
+	}
+	result.Name = query.OutputTensorName
+	return result, nil
+}
+
+// covarianceTyped menjalankan COV atau CORR. Seperti MOVING_AVG, input float32 menghasilkan float32
+// dan tipe lain menghasilkan float64.
+func covarianceTyped[T Numeric](input *Tensor[T], query *Query) (interface{}, error) {
+	correlation := query.MathOperator == "CORR"
+	if input.DataType == DataTypeFloat32 {
+		var result *Tensor[float32]
+		var err error
+		if correlation {
+			result, err = Correlation[T, float32](input, DataTypeFloat32)
+		} else {
+			result, err = Covariance[T, float32](input, DataTypeFloat32)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Name = query.OutputTensorName
+		return result, nil
+	}
+	var result *Tensor[float64]
+	var err error
+	if correlation {
+		result, err = Correlation[T, float64](input, DataTypeFloat64)
+	} else {
+		result, err = Covariance[T, float64](input, DataTypeFloat64)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.Name = query.OutputTensorName
+	return result, nil
+}
+
+// normalizeTyped menjalankan NORMALIZE dengan rentang RangeMin/RangeMax (default [0, 1]) dan sumbu opsional.
+// Seperti MOVING_AVG, input float32 menghasilkan float32 dan tipe lain menghasilkan float64.
+func normalizeTyped[T Numeric](input *Tensor[T], query *Query) (interface{}, error) {
+	lo, hi := 0.0, 1.0
+	var err error
+	if query.RangeMin != "" {
+		if lo, err = strconv.ParseFloat(query.RangeMin, 64); err != nil {
+			return nil, fmt.Errorf("NORMALIZE requires a numeric MIN, got '%s'", query.RangeMin)
+		}
+	}
+	if query.RangeMax != "" {
+		if hi, err = strconv.ParseFloat(query.RangeMax, 64); err != nil {
+			return nil, fmt.Errorf("NORMALIZE requires a numeric MAX, got '%s'", query.RangeMax)
+		}
+	}
+	axis := -1
+	if query.Axis != nil {
+		axis = *query.Axis
+	}
+	if input.DataType == DataTypeFloat32 {
+		result, err := Normalize[T, float32](input, lo, hi, axis, DataTypeFloat32)
+		if err != nil {
+			return nil, err
+		}
+		result.Name = query.OutputTensorName
+		return result, nil
+	}
+	result, err := Normalize[T, float64](input, lo, hi, axis, DataTypeFloat64)
+	if err != nil {
+		return nil, err
+	}
+	result.Name = query.OutputTensorName
+	return result, nil
+}
+
+// oneHotTyped menjalankan ONEHOT dengan jumlah kelas dari ScalarOperand. Tipe data output diambil dari
+// query.DataType (default float32).
+func oneHotTyped[T Numeric](labels *Tensor[T], query *Query) (interface{}, error) {
+	classes, err := strconv.Atoi(query.ScalarOperand)
+	if err != nil || classes <= 0 {
+		return nil, fmt.Errorf("ONEHOT requires a positive integer number of classes, got '%s'", query.ScalarOperand)
+	}
+	outputDataType := query.DataType
+	if outputDataType == "" {
+		outputDataType = DataTypeFloat32
+	}
+
+	var result interface{}
+	switch outputDataType {
+	case DataTypeFloat32:
+		t, err := OneHot[T, float32](labels, classes, outputDataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		result = t
+	case DataTypeFloat64:
+		t, err := OneHot[T, float64](labels, classes, outputDataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		result = t
+	case DataTypeInt32:
+		t, err := OneHot[T, int32](labels, classes, outputDataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		result = t
+	case DataTypeInt64:
+		t, err := OneHot[T, int64](labels, classes, outputDataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		result = t
+	case DataTypeInt8:
+		t, err := OneHot[T, int8](labels, classes, outputDataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		result = t
+	case DataTypeInt16:
+		t, err := OneHot[T, int16](labels, classes, outputDataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		result = t
+	case DataTypeUint8:
+		t, err := OneHot[T, uint8](labels, classes, outputDataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		result = t
+	case DataTypeUint32:
+		t, err := OneHot[T, uint32](labels, classes, outputDataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		result = t
+	case DataTypeUint64:
+		t, err := OneHot[T, uint64](labels, classes, outputDataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		result = t
+	default:
+		return nil, fmt.Errorf("unsupported output data type '%s' for ONEHOT", outputDataType)
+	}
+	return result, nil
+}
+
+// reinterpretTyped menjalankan REINTERPRET ke tipe data target query.DataType.
+func reinterpretTyped[T Numeric](input *Tensor[T], query *Query) (interface{}, error) {
+	switch query.DataType {
+	case DataTypeFloat32:
+		t, err := Reinterpret[T, float32](input, query.DataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		return t, nil
+	case DataTypeFloat64:
+		t, err := Reinterpret[T, float64](input, query.DataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		return t, nil
+	case DataTypeInt32:
+		t, err := Reinterpret[T, int32](input, query.DataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		return t, nil
+	case DataTypeInt64:
+		t, err := Reinterpret[T, int64](input, query.DataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		return t, nil
+	case DataTypeInt8:
+		t, err := Reinterpret[T, int8](input, query.DataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		return t, nil
+	case DataTypeInt16:
+		t, err := Reinterpret[T, int16](input, query.DataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		return t, nil
+	case DataTypeUint8:
+		t, err := Reinterpret[T, uint8](input, query.DataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		return t, nil
+	case DataTypeUint32:
+		t, err := Reinterpret[T, uint32](input, query.DataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		return t, nil
+	case DataTypeUint64:
+		t, err := Reinterpret[T, uint64](input, query.DataType)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = query.OutputTensorName
+		return t, nil
+	case "":
+		return nil, fmt.Errorf("REINTERPRET requires a target data type")
+	default:
+		return nil, fmt.Errorf("unsupported target data type '%s' for REINTERPRET", query.DataType)
+	}
+}
+
+// parseScalarOperand mengurai operand skalar sesuai tipe T dengan lebar bit yang tepat.
+func parseScalarOperand[T Numeric](operand string) (T, error) {
+	var zero T
+	dataType, err := GetDataTypeString[T]()
+	if err != nil {
+		return zero, err
+	}
+	elementSize, err := GetElementSize(dataType)
+	if err != nil {
+		return zero, err
+	}
+	bitSize := elementSize * 8
+
+	switch any(zero).(type) {
+	case float32, float64:
+		v, parseErr := strconv.ParseFloat(operand, bitSize)
+		if parseErr != nil {
+			return zero, fmt.Errorf("failed to parse scalar operand '%s' as %s: %w", operand, dataType, parseErr)
+		}
+		return T(v), nil
+	case uint8, uint32, uint64:
+		v, parseErr := strconv.ParseUint(operand, 10, bitSize)
+		if parseErr != nil {
+			return zero, fmt.Errorf("failed to parse scalar operand '%s' as %s: %w", operand, dataType, parseErr)
+		}
+		return T(v), nil
+	default:
+		v, parseErr := strconv.ParseInt(operand, 10, bitSize)
+		if parseErr != nil {
+			return zero, fmt.Errorf("failed to parse scalar operand '%s' as %s: %w", operand, dataType, parseErr)
+		}
+		return T(v), nil
+	}
+}
+
+// saveResultTensor menyimpan tensor hasil (dalam bentuk *Tensor[T] apa pun) dan mendaftarkannya ke indeks.
+func (e *Executor) saveResultTensor(resultTensor interface{}) error {
+	var resultMetadata *TensorMetadata
+	switch rt := resultTensor.(type) {
+	case *Tensor[float32]:
+		if err := SaveNewTensor(e.storage, rt); err != nil {
+			return fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
+		}
+		resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
+	case *Tensor[float64]:
+		if err := SaveNewTensor(e.storage, rt); err != nil {
+			return fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
+		}
+		resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
+	case *Tensor[int32]:
+		if err := SaveNewTensor(e.storage, rt); err != nil {
+			return fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
+		}
+		resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
+	case *Tensor[int64]:
+		if err := SaveNewTensor(e.storage, rt); err != nil {
+			return fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
+		}
+		resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
+	case *Tensor[int8]:
+		if err := SaveNewTensor(e.storage, rt); err != nil {
+			return fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
+		}
+		resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
+	case *Tensor[int16]:
+		if err := SaveNewTensor(e.storage, rt); err != nil {
+			return fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
+		}
+		resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
+	case *Tensor[uint8]:
+		if err := SaveNewTensor(e.storage, rt); err != nil {
+			return fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
+		}
+		resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
+	case *Tensor[uint32]:
+		if err := SaveNewTensor(e.storage, rt); err != nil {
+			return fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
+		}
+		resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
+	case *Tensor[uint64]:
+		if err := SaveNewTensor(e.storage, rt); err != nil {
+			return fmt.Errorf("failed to save result tensor '%s': %w", rt.Name, err)
+		}
+		resultMetadata = &TensorMetadata{Name: rt.Name, Shape: rt.Shape, DataType: rt.DataType, Strides: rt.Strides}
+	default:
+		return fmt.Errorf("unknown type for result tensor, cannot save or index")
+	}
+	e.storage.AddTensorToIndex(resultMetadata)
+	return nil
+}
@@ -0,0 +1,317 @@
+package tensor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// reduceChunkElements adalah jumlah elemen yang didekode per jendela saat reduksi streaming.
+// Memori heap yang dipakai reduksi dibatasi oleh nilai ini, bukan oleh ukuran tensor.
+const reduceChunkElements = 64 * 1024
+
+// reduceTyped menjalankan reduksi SUM, MEAN, NANSUM, atau NANMEAN.
+// Tanpa Axis, reduksi penuh dilakukan secara streaming atas mmap per jendela tanpa memuat seluruh
+// tensor; dengan Axis, tensor dimuat dan direduksi di sepanjang sumbu tersebut (sumbu itu dihapus dari shape).
+// Penjumlahan dilakukan dalam akumulator lebar (lihat sumAccumulator). SUM/NANSUM menghasilkan tipe
+// yang sama dengan input dan ditolak jika jumlahnya tidak muat; MEAN/NANMEAN selalu menghasilkan float64.
+// Varian NAN* melewati nilai NaN: NANSUM dari semua-NaN adalah 0, NANMEAN dari semua-NaN adalah NaN.
+func reduceTyped[T Numeric](e *Executor, query *Query, metadata *TensorMetadata) (interface{}, error) {
+	skipNaN := query.MathOperator == "NANSUM" || query.MathOperator == "NANMEAN"
+
+	var accs []sumAccumulator
+	var resultShape []int
+	if query.Axis == nil {
+		acc, err := streamSumTyped[T](e, metadata.Name, metadata, skipNaN)
+		if err != nil {
+			return nil, err
+		}
+		accs, resultShape = []sumAccumulator{acc}, []int{}
+	} else {
+		input, err := loadFullTensorTyped[T](e, metadata.Name, metadata)
+		if err != nil {
+			return nil, err
+		}
+		accs, resultShape, err = sumAlongAxis(input, *query.Axis, skipNaN)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch query.MathOperator {
+	case "SUM", "NANSUM":
+		result, err := NewTensor[T](query.OutputTensorName, resultShape, metadata.DataType)
+		if err != nil {
+			return nil, err
+		}
+		sums, err := accumulatedSums[T](accs, metadata.Name, metadata.DataType)
+		if err != nil {
+			return nil, err
+		}
+		if err := result.SetData(sums); err != nil {
+			return nil, err
+		}
+		return result, nil
+	case "MEAN", "NANMEAN":
+		means := make([]float64, len(accs))
+		for i, acc := range accs {
+			if acc.count == 0 {
+				if !skipNaN {
+					return nil, fmt.Errorf("cannot compute MEAN of empty tensor %s", metadata.Name)
+				}
+				means[i] = math.NaN()
+				continue
+			}
+			means[i] = acc.float / float64(acc.count)
+		}
+		result, err := NewTensor[float64](query.OutputTensorName, resultShape, DataTypeFloat64)
+		if err != nil {
+			return nil, err
+		}
+		if err := result.SetData(means); err != nil {
+			return nil, err
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported reduction operator: %s", query.MathOperator)
+	}
+}
+
+// sumKind menentukan akumulator lebar yang dipakai untuk menjumlahkan elemen bertipe T.
+type sumKind int
+
+const (
+	sumFloat sumKind = iota
+	sumSigned
+	sumUnsigned
+)
+
+// sumKindOf mengembalikan sumKind untuk tipe elemen T.
+func sumKindOf[T Numeric]() sumKind {
+	switch any(T(0)).(type) {
+	case int8, int16, int32, int64:
+		return sumSigned
+	case uint8, uint32, uint64:
+		return sumUnsigned
+	default:
+		return sumFloat
+	}
+}
+
+// sumAccumulator menjumlahkan elemen dalam tipe lebar agar tipe sempit (mis. int8 atau uint8) tidak
+// berbalik nilai dan float32 tidak kehilangan presisi pada tensor besar. float selalu diisi dan dipakai
+// untuk MEAN; signed/unsigned adalah jumlah integer eksak untuk SUM dengan penanda overflow.
+type sumAccumulator struct {
+	float    float64
+	signed   int64
+	unsigned uint64
+	count    int
+	overflow bool
+}
+
+// addSum menambahkan v ke acc sesuai kind.
+func addSum[T Numeric](acc *sumAccumulator, v T, kind sumKind) {
+	acc.count++
+	acc.float += float64(v)
+	switch kind {
+	case sumSigned:
+		x := int64(v)
+		sum := acc.signed + x
+		if (x > 0 && sum < acc.signed) || (x < 0 && sum > acc.signed) {
+			acc.overflow = true
+		}
+		acc.signed = sum
+	case sumUnsigned:
+		sum := acc.unsigned + uint64(v)
+		if sum < acc.unsigned {
+			acc.overflow = true
+		}
+		acc.unsigned = sum
+	}
+}
+
+// accumulatedSums mengubah akumulator menjadi jumlah bertipe T. Jumlah integer yang melampaui
+// rentang int64/uint64 atau tidak muat dalam T menghasilkan error alih-alih nilai yang berbalik.
+func accumulatedSums[T Numeric](accs []sumAccumulator, tensorName, dataType string) ([]T, error) {
+	kind := sumKindOf[T]()
+	sums := make([]T, len(accs))
+	for i, acc := range accs {
+		var sum T
+		fits := !acc.overflow
+		switch kind {
+		case sumSigned:
+			sum = T(acc.signed)
+			fits = fits && int64(sum) == acc.signed
+		case sumUnsigned:
+			sum = T(acc.unsigned)
+			fits = fits && uint64(sum) == acc.unsigned
+		default:
+			sum = T(acc.float)
+		}
+		if !fits {
+			return nil, fmt.Errorf("SUM of tensor %s overflows %s", tensorName, dataType)
+		}
+		sums[i] = sum
+	}
+	return sums, nil
+}
+
+// sumAlongAxis menjumlahkan tensor di sepanjang axis dan mengembalikan akumulator per posisi hasil
+// (jumlah serta banyaknya elemen yang dijumlahkan) beserta shape hasil (shape input tanpa axis).
+func sumAlongAxis[T Numeric](t *Tensor[T], axis int, skipNaN bool) ([]sumAccumulator, []int, error) {
+	if axis < 0 || axis >= len(t.Shape) {
+		return nil, nil, fmt.Errorf("axis %d out of range: valid axes are [0, %d) for tensor with %d dimension(s)", axis, len(t.Shape), len(t.Shape))
+	}
+	outer := 1
+	for _, dim := range t.Shape[:axis] {
+		outer *= dim
+	}
+	inner := 1
+	for _, dim := range t.Shape[axis+1:] {
+		inner *= dim
+	}
+	n := t.Shape[axis]
+	resultShape := append(append([]int{}, t.Shape[:axis]...), t.Shape[axis+1:]...)
+
+	accs := make([]sumAccumulator, outer*inner)
+	if len(t.Data) == 0 {
+		return accs, resultShape, nil
+	}
+	kind := sumKindOf[T]()
+	for o := 0; o < outer; o++ {
+		for j := 0; j < n; j++ {
+			base := (o*n + j) * inner
+			for i := 0; i < inner; i++ {
+				v := t.Data[base+i]
+				if skipNaN && math.IsNaN(float64(v)) {
+					continue
+				}
+				addSum(&accs[o*inner+i], v, kind)
+			}
+		}
+	}
+	return accs, resultShape, nil
+}
+
+// streamSumTyped menjumlahkan seluruh elemen tensor langsung dari file yang di-mmap ke dalam satu
+// akumulator lebar (NaN tidak dihitung jika skipNaN), dengan hasil yang sama seperti sumAlongAxis
+// setelah pemuatan penuh.
+func streamSumTyped[T Numeric](e *Executor, tensorName string, metadata *TensorMetadata, skipNaN bool) (sumAccumulator, error) {
+	var acc sumAccumulator
+	totalElements := tNilaiTotalElemen(metadata.Shape)
+	elementSize, err := GetElementSize(metadata.DataType)
+	if err != nil {
+		return acc, fmt.Errorf("streamSumTyped: %w", err)
+	}
+
+	file, mmapInstance, err := e.storage.OpenFileAndMmap(tensorName, totalElements, elementSize)
+	if err != nil {
+		return acc, fmt.Errorf("streamSumTyped: failed to open/mmap file for %s: %w", tensorName, err)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+	if mmapInstance == nil {
+		return acc, nil // Tensor kosong
+	}
+	defer mmapInstance.Unmap()
+
+	e.storage.AdviseMmap(mmapInstance, AccessSequential)
+
+	kind := sumKindOf[T]()
+	chunk := make([]T, reduceChunkElements)
+	for start := 0; start < totalElements; start += reduceChunkElements {
+		n := reduceChunkElements
+		if start+n > totalElements {
+			n = totalElements - start
+		}
+		decodeChunk(mmapInstance[start*elementSize:(start+n)*elementSize], chunk[:n])
+		for _, v := range chunk[:n] {
+			if skipNaN && math.IsNaN(float64(v)) {
+				continue
+			}
+			addSum(&acc, v, kind)
+		}
+	}
+	return acc, nil
+}
+
+// decodeChunk mendekode byte little-endian ke dst. Panjang src harus len(dst) * ukuran elemen T.
+func decodeChunk[T Numeric](src []byte, dst []T) {
+	switch d := any(dst).(type) {
+	case []float32:
+		for i := range d {
+			d[i] = math.Float32frombits(binary.LittleEndian.Uint32(src[i*4:]))
+		}
+	case []float64:
+		for i := range d {
+			d[i] = math.Float64frombits(binary.LittleEndian.Uint64(src[i*8:]))
+		}
+	case []int8:
+		for i := range d {
+			d[i] = int8(src[i])
+		}
+	case []int16:
+		for i := range d {
+			d[i] = int16(binary.LittleEndian.Uint16(src[i*2:]))
+		}
+	case []int32:
+		for i := range d {
+			d[i] = int32(binary.LittleEndian.Uint32(src[i*4:]))
+		}
+	case []int64:
+		for i := range d {
+			d[i] = int64(binary.LittleEndian.Uint64(src[i*8:]))
+		}
+	case []uint8:
+		copy(d, src[:len(d)])
+	case []uint32:
+		for i := range d {
+			d[i] = binary.LittleEndian.Uint32(src[i*4:])
+		}
+	case []uint64:
+		for i := range d {
+			d[i] = binary.LittleEndian.Uint64(src[i*8:])
+		}
+	}
+}
+
+// encodeChunk adalah kebalikan decodeChunk: menulis src sebagai byte little-endian ke dst.
+func encodeChunk[T Numeric](src []T, dst []byte) {
+	switch s := any(src).(type) {
+	case []float32:
+		for i, v := range s {
+			binary.LittleEndian.PutUint32(dst[i*4:], math.Float32bits(v))
+		}
+	case []float64:
+		for i, v := range s {
+			binary.LittleEndian.PutUint64(dst[i*8:], math.Float64bits(v))
+		}
+	case []int8:
+		for i, v := range s {
+			dst[i] = byte(v)
+		}
+	case []int16:
+		for i, v := range s {
+			binary.LittleEndian.PutUint16(dst[i*2:], uint16(v))
+		}
+	case []int32:
+		for i, v := range s {
+			binary.LittleEndian.PutUint32(dst[i*4:], uint32(v))
+		}
+	case []int64:
+		for i, v := range s {
+			binary.LittleEndian.PutUint64(dst[i*8:], uint64(v))
+		}
+	case []uint8:
+		copy(dst, s)
+	case []uint32:
+		for i, v := range s {
+			binary.LittleEndian.PutUint32(dst[i*4:], v)
+		}
+	case []uint64:
+		for i, v := range s {
+			binary.LittleEndian.PutUint64(dst[i*8:], v)
+		}
+	}
+}
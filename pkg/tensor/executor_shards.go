@@ -0,0 +1,179 @@
+package tensor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ShardIndex adalah isi file indeks JSON yang ditulis EXPORT TENSOR ... TO SHARDS. Setiap shard
+// menyimpan data row-major mentah (little-endian) untuk rentang [Start, End) pada axis pertama.
+type ShardIndex struct {
+	Name     string            `json:"name"`
+	DataType string            `json:"dtype"`
+	Shape    []int             `json:"shape"`
+	Shards   []ShardIndexEntry `json:"shards"`
+}
+
+// ShardIndexEntry menjelaskan satu file shard. File relatif terhadap direktori file indeks.
+type ShardIndexEntry struct {
+	File  string `json:"file"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Shape []int  `json:"shape"`
+}
+
+// shardIndexPath mengembalikan path file indeks untuk prefix shard.
+func shardIndexPath(prefix string) string {
+	return prefix + ".index.json"
+}
+
+// shardFileName mengembalikan nama file shard ke-i dari count untuk prefix.
+func shardFileName(prefix string, i, count int) string {
+	return fmt.Sprintf("%s-%05d-of-%05d.bin", filepath.Base(prefix), i, count)
+}
+
+// executeExportShards membagi data tensor sepanjang axis pertama menjadi query.ShardCount file shard
+// yang ukurannya berselisih paling banyak satu baris, lalu menulis file indeks JSON. Indeks ditulis
+// terakhir sehingga keberadaannya menandakan semua shard sudah lengkap.
+func (e *Executor) executeExportShards(query *Query) (interface{}, error) {
+	tensorName := query.TensorNames[0]
+	metadata, err := e.storage.LoadTensorMetadata(tensorName)
+	if err != nil {
+		return nil, fmt.Errorf("tensor '%s' not found for export: %w", tensorName, err)
+	}
+	if len(metadata.Shape) == 0 {
+		return nil, fmt.Errorf("cannot export scalar tensor '%s' to shards: sharding requires at least one axis", tensorName)
+	}
+	count, rows := query.ShardCount, metadata.Shape[0]
+	if count <= 0 || count > rows {
+		return nil, fmt.Errorf("shard count %d is invalid for tensor '%s': must be between 1 and the leading dimension %d", count, tensorName, rows)
+	}
+	elementSize, err := GetElementSize(metadata.DataType)
+	if err != nil {
+		return nil, err
+	}
+	rowBytes := tNilaiTotalElemen(metadata.Shape[1:]) * elementSize
+
+	file, mmapInstance, err := e.storage.OpenFileAndMmap(tensorName, tNilaiTotalElemen(metadata.Shape), elementSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open/mmap file for %s: %w", tensorName, err)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+	if mmapInstance != nil {
+		defer mmapInstance.Unmap()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(query.ShardPrefix), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create shard directory for prefix %s: %w", query.ShardPrefix, err)
+	}
+	index := ShardIndex{Name: metadata.Name, DataType: metadata.DataType, Shape: metadata.Shape, Shards: make([]ShardIndexEntry, count)}
+	start := 0
+	for i := 0; i < count; i++ {
+		end := start + rows/count
+		if i < rows%count {
+			end++
+		}
+		entry := ShardIndexEntry{
+			File:  shardFileName(query.ShardPrefix, i, count),
+			Start: start,
+			End:   end,
+			Shape: append([]int{end - start}, metadata.Shape[1:]...),
+		}
+		shardPath := filepath.Join(filepath.Dir(query.ShardPrefix), entry.File)
+		var data []byte
+		if rowBytes > 0 {
+			data = mmapInstance[start*rowBytes : end*rowBytes]
+		}
+		if err := e.storage.writeExternalFile(shardPath, data); err != nil {
+			return nil, err
+		}
+		index.Shards[i] = entry
+		start = end
+	}
+
+	indexBytes, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode shard index for tensor '%s': %w", tensorName, err)
+	}
+	if err := e.storage.writeExternalFile(shardIndexPath(query.ShardPrefix), indexBytes); err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Tensor %s exported to %d shards at %s", tensorName, count, query.ShardPrefix), nil
+}
+
+// executeImportShards membaca indeks shard untuk query.ShardPrefix, memvalidasi bahwa shard-shardnya
+// menutup axis pertama tanpa celah dengan ukuran file yang sesuai, lalu membuat tensor baru berisi
+// gabungan datanya. Nama tensor diambil dari INTO jika ada, selain itu dari indeks.
+func (e *Executor) executeImportShards(query *Query) (interface{}, error) {
+	indexPath := shardIndexPath(query.ShardPrefix)
+	indexBytes, err := os.ReadFile(indexPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read shard index %s: %w", indexPath, err)
+	}
+	var index ShardIndex
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return nil, fmt.Errorf("invalid shard index %s: %w", indexPath, err)
+	}
+	tensorName := index.Name
+	if len(query.TensorNames) > 0 {
+		tensorName = query.TensorNames[0]
+	} else if !IsValidTensorName(tensorName) {
+		return nil, fmt.Errorf("invalid shard index %s: tensor name %q is not a valid identifier", indexPath, tensorName)
+	}
+	elementSize, err := GetElementSize(index.DataType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shard index %s: %w", indexPath, err)
+	}
+	if len(index.Shape) == 0 || len(index.Shards) == 0 {
+		return nil, fmt.Errorf("invalid shard index %s: shape %v with %d shards", indexPath, index.Shape, len(index.Shards))
+	}
+	for _, dim := range index.Shape {
+		// checkedElementCount berhenti pada dimensi nol, jadi dimensi negatif setelahnya diperiksa di sini.
+		if dim < 0 {
+			return nil, fmt.Errorf("invalid shard index %s: invalid dimension size %d in shape %v", indexPath, dim, index.Shape)
+		}
+	}
+	if _, err := checkedElementCount(index.Shape, elementSize); err != nil {
+		return nil, fmt.Errorf("invalid shard index %s: %w", indexPath, err)
+	}
+	rowBytes := tNilaiTotalElemen(index.Shape[1:]) * elementSize
+
+	// raw tumbuh mengikuti isi file shard yang benar-benar dibaca; kapasitas tidak dialokasikan dari
+	// shape di indeks karena indeks tidak tepercaya dan dapat mengklaim ukuran yang sangat besar.
+	var raw []byte
+	expectedStart := 0
+	for i, shard := range index.Shards {
+		if shard.Start != expectedStart || shard.End < shard.Start || !ShapesEqual(shard.Shape, append([]int{shard.End - shard.Start}, index.Shape[1:]...)) {
+			return nil, fmt.Errorf("invalid shard index %s: shard %d covers [%d:%d] with shape %v, expected to start at %d with trailing shape %v",
+				indexPath, i, shard.Start, shard.End, shard.Shape, expectedStart, index.Shape[1:])
+		}
+		shardPath := filepath.Join(filepath.Dir(query.ShardPrefix), shard.File)
+		data, err := os.ReadFile(shardPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read shard %d: %w", i, err)
+		}
+		if expectedBytes := (shard.End - shard.Start) * rowBytes; len(data) != expectedBytes {
+			return nil, fmt.Errorf("shard file %s has %d bytes, expected %d for shape %v", shardPath, len(data), expectedBytes, shard.Shape)
+		}
+		raw = append(raw, data...)
+		expectedStart = shard.End
+	}
+	if expectedStart != index.Shape[0] {
+		return nil, fmt.Errorf("invalid shard index %s: shards cover %d of %d rows", indexPath, expectedStart, index.Shape[0])
+	}
+
+	if _, err := e.execute(&Query{Type: CreateTensorQuery, TensorNames: []string{tensorName}, Shape: index.Shape, DataType: index.DataType}); err != nil {
+		return nil, err
+	}
+	if len(raw) > 0 {
+		if _, err := e.execute(&Query{Type: InsertTensorQuery, TensorNames: []string{tensorName}, RawData: raw}); err != nil {
+			e.execute(&Query{Type: DropTensorQuery, TensorNames: []string{tensorName}})
+			return nil, fmt.Errorf("failed to import shards into tensor '%s': %w", tensorName, err)
+		}
+	}
+	return fmt.Sprintf("Tensor %s imported from %d shards at %s", tensorName, len(index.Shards), query.ShardPrefix), nil
+}
@@ -0,0 +1,71 @@
+package tensor
+
+import "fmt"
+
+// executeSparseInsert mengganti seluruh data tensor dengan data nol, lalu mengisi koordinat
+// yang disebut pada INSERT ... SPARSE. Koordinat divalidasi terhadap shape sebelum apa pun disimpan.
+func (e *Executor) executeSparseInsert(query *Query, metadata *TensorMetadata) (interface{}, error) {
+	var err error
+	switch metadata.DataType {
+	case DataTypeFloat32:
+		err = sparseInsertTyped[float32](e, query, metadata)
+	case DataTypeFloat64:
+		err = sparseInsertTyped[float64](e, query, metadata)
+	case DataTypeInt32:
+		err = sparseInsertTyped[int32](e, query, metadata)
+	case DataTypeInt64:
+		err = sparseInsertTyped[int64](e, query, metadata)
+	case DataTypeInt8:
+		err = sparseInsertTyped[int8](e, query, metadata)
+	case DataTypeInt16:
+		err = sparseInsertTyped[int16](e, query, metadata)
+	case DataTypeUint8, DataTypeBool:
+		err = sparseInsertTyped[uint8](e, query, metadata)
+	case DataTypeUint32:
+		err = sparseInsertTyped[uint32](e, query, metadata)
+	case DataTypeUint64:
+		err = sparseInsertTyped[uint64](e, query, metadata)
+	default:
+		return nil, fmt.Errorf("unsupported data type '%s' for sparse insert into tensor '%s'", metadata.DataType, metadata.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Sparse data inserted into %s (%d elements set)", metadata.Name, len(query.Sparse)), nil
+}
+
+func sparseInsertTyped[T Numeric](e *Executor, query *Query, metadata *TensorMetadata) error {
+	t, err := NewTensor[T](metadata.Name, metadata.Shape, metadata.DataType)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[int]bool, len(query.Sparse))
+	for _, entry := range query.Sparse {
+		if len(entry.Coordinate) != len(t.Shape) {
+			return fmt.Errorf("sparse coordinate %v has %d dimension(s), tensor '%s' has %d", entry.Coordinate, len(entry.Coordinate), metadata.Name, len(t.Shape))
+		}
+		offset := 0
+		for i, idx := range entry.Coordinate {
+			if idx < 0 || idx >= t.Shape[i] {
+				return fmt.Errorf("sparse coordinate %v out of bounds for tensor '%s' with shape %v", entry.Coordinate, metadata.Name, t.Shape)
+			}
+			offset += idx * t.Strides[i]
+		}
+		if seen[offset] {
+			return fmt.Errorf("duplicate sparse coordinate %v for tensor '%s'", entry.Coordinate, metadata.Name)
+		}
+		seen[offset] = true
+
+		value, err := parseInsertValue[T](entry.Value, offset)
+		if err != nil {
+			return err
+		}
+		t.Data[offset] = value
+	}
+
+	if err := SaveTensor(e.storage, t); err != nil {
+		return fmt.Errorf("failed to save sparse insert into '%s': %w", metadata.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package tensor
+
+import "fmt"
+
+// RunningStats adalah rata-rata dan variansi berjalan seluruh nilai yang pernah ditulis ke tensor
+// yang dibuat dengan TRACK_STATS, diperbarui per nilai dengan algoritma Welford dan disimpan di file
+// sidecar name.stats. M2 adalah jumlah kuadrat selisih nilai terhadap rata-rata. Semua jalur penulisan
+// (INSERT, INSERT ke slice, UPDATE, operasi IN PLACE) diperlakukan sama: setiap nilai yang ditulis
+// menjadi sampel baru dan nilai yang ditimpanya tidak dikeluarkan, sehingga statistik menggambarkan
+// aliran nilai yang pernah masuk, bukan isi tensor saat ini.
+type RunningStats struct {
+	Count int64   `json:"count"`
+	Mean  float64 `json:"mean"`
+	M2    float64 `json:"m2"`
+}
+
+// add memasukkan satu nilai ke statistik berjalan (satu langkah Welford).
+func (rs *RunningStats) add(x float64) {
+	rs.Count++
+	delta := x - rs.Mean
+	rs.Mean += delta / float64(rs.Count)
+	rs.M2 += delta * (x - rs.Mean)
+}
+
+// TensorStats adalah hasil STATS TENSOR. Variance adalah variansi populasi (M2 / Count); keduanya
+// bernilai 0 sebelum ada data yang ditulis.
+type TensorStats struct {
+	Name     string
+	Count    int64
+	Mean     float64
+	Variance float64
+}
+
+// executeStats mengembalikan statistik berjalan tensor yang dibuat dengan TRACK_STATS.
+func (e *Executor) executeStats(query *Query) (interface{}, error) {
+	tensorName := query.TensorNames[0]
+	if _, err := e.storage.LoadTensorMetadata(tensorName); err != nil {
+		return nil, fmt.Errorf("tensor '%s' not found for stats: %w", tensorName, err)
+	}
+	stats, err := e.storage.loadRunningStats(tensorName)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		return nil, fmt.Errorf("tensor '%s' does not track statistics: create it with TRACK_STATS", tensorName)
+	}
+	result := &TensorStats{Name: tensorName, Count: stats.Count, Mean: stats.Mean}
+	if stats.Count > 0 {
+		result.Variance = stats.M2 / float64(stats.Count)
+	}
+	return result, nil
+}
+
+// writtenTensorName mengembalikan nama tensor yang elemennya ditulis oleh INSERT, UPDATE, atau operasi
+// matematika IN PLACE, atau "" untuk kueri lain yang tidak memperbarui statistik berjalan.
+func writtenTensorName(query *Query) string {
+	switch {
+	case query.Type == InsertTensorQuery || query.Type == UpdateElementQuery:
+		return query.TensorNames[0]
+	case query.Type == MathOperationQuery && query.InPlace:
+		return query.InputTensorNames[0]
+	}
+	return ""
+}
+
+// updateRunningStats memperbarui statistik berjalan tensorName setelah kueri tulis berhasil. Tensor
+// tanpa TRACK_STATS dilewati. Nilai yang dimasukkan ditentukan oleh writtenValuesTyped.
+func (e *Executor) updateRunningStats(query *Query, tensorName string) error {
+	stats, err := e.storage.loadRunningStats(tensorName)
+	if err != nil || stats == nil {
+		return err
+	}
+	metadata, err := e.storage.LoadTensorMetadata(tensorName)
+	if err != nil {
+		return err
+	}
+	var values []float64
+	switch metadata.DataType {
+	case DataTypeFloat32:
+		values, err = writtenValuesTyped[float32](e, query, metadata)
+	case DataTypeFloat64:
+		values, err = writtenValuesTyped[float64](e, query, metadata)
+	case DataTypeInt32:
+		values, err = writtenValuesTyped[int32](e, query, metadata)
+	case DataTypeInt64:
+		values, err = writtenValuesTyped[int64](e, query, metadata)
+	case DataTypeInt8:
+		values, err = writtenValuesTyped[int8](e, query, metadata)
+	case DataTypeInt16:
+		values, err = writtenValuesTyped[int16](e, query, metadata)
+	case DataTypeUint8, DataTypeBool:
+		values, err = writtenValuesTyped[uint8](e, query, metadata)
+	case DataTypeUint32:
+		values, err = writtenValuesTyped[uint32](e, query, metadata)
+	case DataTypeUint64:
+		values, err = writtenValuesTyped[uint64](e, query, metadata)
+	default:
+		return fmt.Errorf("unsupported data type '%s' for running stats of tensor '%s'", metadata.DataType, tensorName)
+	}
+	if err != nil {
+		return err
+	}
+	for _, v := range values {
+		stats.add(v)
+	}
+	return e.storage.writeRunningStats(tensorName, stats)
+}
+
+// writtenValuesTyped mengembalikan nilai yang baru ditulis kueri sebagai float64: nilai elemen pada
+// UPDATE, data yang ditambahkan (APPEND) atau ditulis ke slice, dan seluruh isi tensor untuk INSERT yang
+// mengganti semua data (termasuk nol pada INSERT ... SPARSE) maupun operasi IN PLACE yang menulis ulang
+// setiap elemen.
+func writtenValuesTyped[T Numeric](e *Executor, query *Query, metadata *TensorMetadata) ([]float64, error) {
+	if metadata.DataType == DataTypeBool {
+		var err error
+		if query, err = normalizeBoolQuery(query); err != nil {
+			return nil, err
+		}
+	}
+	var values []T
+	if query.Type == UpdateElementQuery {
+		value, err := parseInsertValue[T](query.ScalarOperand, 0)
+		if err != nil {
+			return nil, err
+		}
+		values = []T{value}
+	} else if query.Type == InsertTensorQuery && (query.Append || (len(query.Slices) > 0 && len(query.Slices[0]) > 0)) {
+		var err error
+		if values, err = appendValuesTyped[T](query, metadata); err != nil {
+			return nil, err
+		}
+	} else {
+		_, raw, err := e.ReadTensorRaw(metadata.Name)
+		if err != nil {
+			return nil, err
+		}
+		elementSize, err := GetElementSize(metadata.DataType)
+		if err != nil {
+			return nil, err
+		}
+		values = make([]T, len(raw)/elementSize)
+		decodeChunk(raw, values)
+	}
+	result := make([]float64, len(values))
+	for i, v := range values {
+		result[i] = float64(v)
+	}
+	return result, nil
+}
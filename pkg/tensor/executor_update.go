@@ -0,0 +1,76 @@
+package tensor
+
+import "fmt"
+
+// executeUpdateElement menulis satu elemen tensor pada koordinat query.Coordinate langsung ke file
+// data melalui mmap, tanpa memuat maupun menulis ulang elemen lain. Checksum di .meta tetap dihitung
+// ulang atas seluruh data (lihat refreshChecksum), jadi UPDATE pada tensor besar tidak murah.
+func (e *Executor) executeUpdateElement(query *Query, metadata *TensorMetadata) (interface{}, error) {
+	var err error
+	switch metadata.DataType {
+	case DataTypeFloat32:
+		err = updateElementTyped[float32](e, query, metadata)
+	case DataTypeFloat64:
+		err = updateElementTyped[float64](e, query, metadata)
+	case DataTypeInt32:
+		err = updateElementTyped[int32](e, query, metadata)
+	case DataTypeInt64:
+		err = updateElementTyped[int64](e, query, metadata)
+	case DataTypeInt8:
+		err = updateElementTyped[int8](e, query, metadata)
+	case DataTypeInt16:
+		err = updateElementTyped[int16](e, query, metadata)
+	case DataTypeUint8, DataTypeBool:
+		err = updateElementTyped[uint8](e, query, metadata)
+	case DataTypeUint32:
+		err = updateElementTyped[uint32](e, query, metadata)
+	case DataTypeUint64:
+		err = updateElementTyped[uint64](e, query, metadata)
+	default:
+		return nil, fmt.Errorf("unsupported data type '%s' for update of tensor '%s'", metadata.DataType, metadata.Name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("Element %v of %s updated", query.Coordinate, metadata.Name), nil
+}
+
+func updateElementTyped[T Numeric](e *Executor, query *Query, metadata *TensorMetadata) error {
+	if len(query.Coordinate) != len(metadata.Shape) {
+		return fmt.Errorf("coordinate %v has %d dimension(s), tensor '%s' has %d", query.Coordinate, len(query.Coordinate), metadata.Name, len(metadata.Shape))
+	}
+	offset := 0
+	for i, idx := range query.Coordinate {
+		if idx < 0 || idx >= metadata.Shape[i] {
+			return fmt.Errorf("index %d out of range for dimension %d of tensor '%s': valid range is [0, %d)", idx, i, metadata.Name, metadata.Shape[i])
+		}
+		offset += idx * metadata.Strides[i]
+	}
+	value, err := parseInsertValue[T](query.ScalarOperand, offset)
+	if err != nil {
+		return err
+	}
+
+	elementSize, err := GetElementSize(metadata.DataType)
+	if err != nil {
+		return err
+	}
+	// Salin blob dedup bersama (copy-on-write) sebelum elemen ditulis.
+	if metadata, err = e.storage.detachBlob(metadata); err != nil {
+		return err
+	}
+	file, mmapInstance, err := e.storage.OpenFileAndMmap(metadata.Name, tNilaiTotalElemen(metadata.Shape), elementSize)
+	if err != nil {
+		return fmt.Errorf("failed to open/mmap file for %s: %w", metadata.Name, err)
+	}
+	defer file.Close()
+	defer mmapInstance.Unmap()
+	// Hanya satu elemen yang disentuh; read-ahead tidak berguna.
+	e.storage.AdviseMmap(mmapInstance, AccessRandom)
+
+	encodeChunk([]T{value}, mmapInstance[offset*elementSize:(offset+1)*elementSize])
+	if err := e.storage.flushMmap(mmapInstance, file, metadata.Name); err != nil {
+		return err
+	}
+	return e.storage.refreshChecksum(metadata, mmapInstance)
+}
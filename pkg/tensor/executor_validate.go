@@ -0,0 +1,115 @@
+package tensor
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math"
+)
+
+// ValidationReport adalah hasil VALIDATE TENSOR: ringkasan pemeriksaan kualitas data tensor dalam satu
+// lintasan atas file datanya.
+type ValidationReport struct {
+	Name             string
+	DataType         string
+	Shape            []int
+	ExpectedElements int   // Dihitung dari shape
+	ActualElements   int   // Jumlah elemen utuh di file .data
+	ExpectedBytes    int64 // ExpectedElements dikali ukuran elemen
+	FileSizeBytes    int64 // 0 jika file .data tidak ada
+	SizeMatches      bool
+	ChecksumMatches  bool // true juga untuk metadata tanpa checksum; false jika ukuran file tidak cocok
+	NaNCount         int  // Selalu 0 untuk tipe integer
+	InfCount         int  // Selalu 0 untuk tipe integer
+	Min              float64
+	Max              float64 // Min/Max mengabaikan NaN; NaN jika tidak ada elemen yang bukan NaN
+	Valid            bool    // Ukuran dan checksum cocok serta tidak ada NaN/Inf
+}
+
+// executeValidate memindai file data tensor melalui mmap read-only. Berbeda dengan pemuatan biasa,
+// ukuran file yang tidak cocok dengan shape dilaporkan alih-alih menjadi error, dan elemen utuh yang
+// ada tetap dipindai.
+func (e *Executor) executeValidate(query *Query) (interface{}, error) {
+	tensorName := query.TensorNames[0]
+	metadata, err := e.storage.LoadTensorMetadata(tensorName)
+	if err != nil {
+		return nil, fmt.Errorf("tensor '%s' not found for validate: %w", tensorName, err)
+	}
+	elementSize, err := GetElementSize(metadata.DataType)
+	if err != nil {
+		return nil, err
+	}
+	report := &ValidationReport{
+		Name:             metadata.Name,
+		DataType:         metadata.DataType,
+		Shape:            metadata.Shape,
+		ExpectedElements: tNilaiTotalElemen(metadata.Shape),
+		Min:              math.NaN(),
+		Max:              math.NaN(),
+	}
+	report.ExpectedBytes = int64(report.ExpectedElements) * int64(elementSize)
+
+	data, release, err := e.storage.mapDataFileReadOnly(tensorName)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	report.FileSizeBytes = int64(len(data))
+	report.ActualElements = len(data) / elementSize
+	report.SizeMatches = report.FileSizeBytes == report.ExpectedBytes
+	report.ChecksumMatches = report.SizeMatches && (metadata.Checksum == nil || crc32.ChecksumIEEE(data) == *metadata.Checksum)
+
+	data = data[:report.ActualElements*elementSize]
+	switch metadata.DataType {
+	case DataTypeFloat32:
+		scanValuesTyped[float32](data, elementSize, report)
+	case DataTypeFloat64:
+		scanValuesTyped[float64](data, elementSize, report)
+	case DataTypeInt32:
+		scanValuesTyped[int32](data, elementSize, report)
+	case DataTypeInt64:
+		scanValuesTyped[int64](data, elementSize, report)
+	case DataTypeInt8:
+		scanValuesTyped[int8](data, elementSize, report)
+	case DataTypeInt16:
+		scanValuesTyped[int16](data, elementSize, report)
+	case DataTypeUint8, DataTypeBool:
+		scanValuesTyped[uint8](data, elementSize, report)
+	case DataTypeUint32:
+		scanValuesTyped[uint32](data, elementSize, report)
+	case DataTypeUint64:
+		scanValuesTyped[uint64](data, elementSize, report)
+	default:
+		return nil, fmt.Errorf("unsupported data type '%s' for validate of tensor '%s'", metadata.DataType, tensorName)
+	}
+	report.Valid = report.SizeMatches && report.ChecksumMatches && report.NaNCount == 0 && report.InfCount == 0
+	return report, nil
+}
+
+// scanValuesTyped mendekode data per jendela reduceChunkElements dan memperbarui hitungan NaN/Inf
+// serta Min/Max pada report.
+func scanValuesTyped[T Numeric](data []byte, elementSize int, report *ValidationReport) {
+	chunk := make([]T, reduceChunkElements)
+	for start := 0; start < len(data); start += len(chunk) * elementSize {
+		n := (len(data) - start) / elementSize
+		if n > len(chunk) {
+			n = len(chunk)
+		}
+		decodeChunk(data[start:start+n*elementSize], chunk[:n])
+		for _, raw := range chunk[:n] {
+			v := float64(raw)
+			if math.IsNaN(v) {
+				report.NaNCount++
+				continue
+			}
+			if math.IsInf(v, 0) {
+				report.InfCount++
+			}
+			if math.IsNaN(report.Min) || v < report.Min {
+				report.Min = v
+			}
+			if math.IsNaN(report.Max) || v > report.Max {
+				report.Max = v
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package tensor
+
+import "github.com/edsrzf/mmap-go"
+
+// AccessPattern adalah petunjuk pola akses yang diteruskan ke kernel melalui madvise
+// setelah file data dipetakan ke memori.
+type AccessPattern int
+
+const (
+	// AccessNormal tidak memberikan petunjuk apa pun (perilaku default OS).
+	AccessNormal AccessPattern = iota
+	// AccessSequential cocok untuk pembacaan seluruh tensor (SELECT/GET DATA penuh).
+	AccessSequential
+	// AccessRandom cocok untuk akses elemen tunggal atau slice yang tersebar.
+	AccessRandom
+)
+
+// StorageOption mengonfigurasi Storage saat dibuat oleh NewStorage.
+type StorageOption func(*Storage)
+
+// WithMmapAdvice mengaktifkan pemanggilan madvise pada setiap mmap yang dibuat oleh Storage.
+// Pola akses dipilih per operasi oleh pemanggil (lihat Storage.AdviseMmap).
+// Pada platform non-Unix opsi ini tidak berpengaruh.
+func WithMmapAdvice() StorageOption {
+	return func(s *Storage) {
+		s.mmapAdvice = true
+	}
+}
+
+// MmapAdvisor meneruskan petunjuk pola akses region mmap ke OS. Storage memakai madvise secara default;
+// implementasi lain dapat disuntikkan dengan WithMmapAdvisor (mis. untuk mencatat panggilan).
+type MmapAdvisor interface {
+	Advise(b []byte, pattern AccessPattern) error
+}
+
+// osMmapAdvisor adalah MmapAdvisor default yang memanggil madvise sistem operasi.
+type osMmapAdvisor struct{}
+
+func (osMmapAdvisor) Advise(b []byte, pattern AccessPattern) error {
+	return madvise(b, pattern)
+}
+
+// WithMmapAdvisor mengganti MmapAdvisor dan mengaktifkan petunjuk pola akses seperti WithMmapAdvice.
+func WithMmapAdvisor(advisor MmapAdvisor) StorageOption {
+	return func(s *Storage) {
+		s.mmapAdvice = true
+		s.advisor = advisor
+	}
+}
+
+// AdviseMmap memberikan petunjuk pola akses untuk region mmap jika opsi WithMmapAdvice aktif.
+// Kegagalan madvise tidak fatal bagi pemanggil karena hanya memengaruhi prefetch OS.
+func (s *Storage) AdviseMmap(m mmap.MMap, pattern AccessPattern) error {
+	if !s.mmapAdvice || len(m) == 0 || pattern == AccessNormal {
+		return nil
+	}
+	return s.advisor.Advise(m, pattern)
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package tensor
+
+// madvise tidak tersedia di platform ini; petunjuk akses diabaikan.
+func madvise(b []byte, pattern AccessPattern) error {
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package tensor
+
+import "golang.org/x/sys/unix"
+
+func madvise(b []byte, pattern AccessPattern) error {
+	advice := unix.MADV_NORMAL
+	switch pattern {
+	case AccessSequential:
+		advice = unix.MADV_SEQUENTIAL
+	case AccessRandom:
+		advice = unix.MADV_RANDOM
+	}
+	return unix.Madvise(b, advice)
+}
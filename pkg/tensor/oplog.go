@@ -0,0 +1,67 @@
+package tensor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// OpLogFileName adalah nama file log operasi di dalam direktori data.
+const OpLogFileName = "oplog"
+
+// ErrOplogWrite dikembalikan Execute jika kueri tulis berhasil dijalankan tetapi gagal dicatat ke log
+// operasi. Perubahan sudah diterapkan dan hasil yang dikembalikan bersama error ini valid; hanya log
+// yang tertinggal, sehingga ReplayLog tidak akan membuat ulang perubahan tersebut.
+var ErrOplogWrite = errors.New("failed to record query in oplog")
+
+// OpLogEntry adalah satu baris log operasi: waktu eksekusi dan kueri yang berhasil dijalankan.
+// Setiap baris disimpan sebagai JSON agar data biner INSERT (RawData) ikut terekam apa adanya.
+type OpLogEntry struct {
+	Time  time.Time `json:"time"`
+	Query *Query    `json:"query"`
+}
+
+// WithOpLog mengaktifkan log operasi append-only. Setiap kueri yang mengubah state
+// (CREATE, INSERT, UPDATE, DROP, RENAME, IMPORT, operasi matematika) dicatat ke file OpLogFileName di direktori data
+// setelah berhasil dieksekusi, sehingga state dapat dibangun ulang dengan Client.ReplayLog.
+func WithOpLog() StorageOption {
+	return func(s *Storage) {
+		s.opLog = true
+	}
+}
+
+// isMutatingQuery melaporkan apakah kueri mengubah state dan karenanya perlu dicatat.
+func isMutatingQuery(query *Query) bool {
+	switch query.Type {
+	case CreateTensorQuery, InsertTensorQuery, UpdateElementQuery, DropTensorQuery, RenameTensorQuery, MathOperationQuery, ImportShardsQuery:
+		return true
+	default:
+		return false
+	}
+}
+
+// appendOpLog menambahkan kueri ke log operasi jika opsi WithOpLog aktif.
+func (s *Storage) appendOpLog(query *Query) error {
+	if !s.opLog || !isMutatingQuery(query) {
+		return nil
+	}
+	line, err := json.Marshal(OpLogEntry{Time: time.Now().UTC(), Query: query})
+	if err != nil {
+		return fmt.Errorf("failed to encode oplog entry: %w", err)
+	}
+
+	s.opLogMu.Lock()
+	defer s.opLogMu.Unlock()
+	f, err := os.OpenFile(filepath.Join(s.dataDir, OpLogFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open oplog: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append oplog entry: %w", err)
+	}
+	return s.syncFile(f)
+}
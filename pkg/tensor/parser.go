@@ -11,17 +11,207 @@ import (
 // TIDAK ADA LAGI DEKLARASI QueryType atau konstanta QueryType DI SINI.
 // Kita akan menggunakan yang dari tensor.go
 
+// scalarOperandRegex adalah pola numerik yang valid untuk operand skalar:
+// tanda opsional, bagian desimal, dan eksponen opsional (mis. -1.5, +2, .5, 1e-3).
+var scalarOperandRegex = regexp.MustCompile(`^[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)(?:[eE][+-]?[0-9]+)?$`)
+
+// paramTokenRegex cocok dengan parameter bernama seperti :lr yang nilainya baru diberikan saat eksekusi
+// (lihat Executor.ExecuteWithParams).
+var paramTokenRegex = regexp.MustCompile(`^:([a-zA-Z_][a-zA-Z0-9_]*)$`)
+
+// tensorNameRegex mencocokkan nama tensor yang valid, sama dengan nama yang diterima kueri.
+var tensorNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// IsValidTensorName melaporkan apakah name adalah nama tensor yang valid menurut parser. Nama yang
+// berasal dari luar (file indeks shard, request jaringan) harus diperiksa dengan fungsi ini sebelum
+// dipakai, karena nama tensor dipakai langsung sebagai nama file di direktori data.
+func IsValidTensorName(name string) bool {
+	return tensorNameRegex.MatchString(name)
+}
+
+// validateScalarOperand menolak operand skalar yang bukan angka atau parameter bernama dengan error
+// yang jelas, sebelum executor mencoba mengurainya sesuai tipe data tensor.
+func validateScalarOperand(operand string) error {
+	if !scalarOperandRegex.MatchString(operand) && !paramTokenRegex.MatchString(operand) {
+		return fmt.Errorf("invalid scalar operand '%s': expected a number such as -1.5 or 1e-3", operand)
+	}
+	return nil
+}
+
+// sepEscapeReplacer menerjemahkan escape pada klausa SEP '...' di INSERT, mis. '\t' menjadi tab.
+var sepEscapeReplacer = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\'`, "'", `\\`, `\`)
+
 // Parser adalah struct untuk memparsing kueri.
 type Parser struct{}
 
-// Parse memparsing string kueri menjadi struct Query.
+// ParseError adalah error parser yang menunjuk bagian kueri yang bermasalah, mis. untuk ditandai oleh
+// editor kueri. Error() menghasilkan pesan yang sama dengan error parser biasa, sehingga pemanggil yang
+// hanya memakai pesan tidak terpengaruh; gunakan errors.As untuk membaca posisinya.
+type ParseError struct {
+	Pos    int    // Offset byte Text di dalam kueri asli; -1 jika tidak diketahui
+	Clause string // Klausa yang gagal: "shape", "type", "slice", "values", atau "where"
+	Text   string // Substring kueri yang bermasalah
+	Msg    string
+	Err    error // Penyebab (mis. error strconv), boleh nil
+}
+
+func (e *ParseError) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Msg
+	case e.Msg == "":
+		return e.Err.Error()
+	default:
+		return e.Msg + ": " + e.Err.Error()
+	}
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError membuat ParseError untuk text, dengan posisi kemunculan pertama text di query mulai
+// dari offset from. Posisi -1 jika text tidak ditemukan.
+func newParseError(query string, from int, clause, text, msg string, err error) *ParseError {
+	pos := -1
+	if from >= 0 && from <= len(query) {
+		if i := strings.Index(query[from:], text); i >= 0 {
+			pos = from + i
+		}
+	}
+	return &ParseError{Pos: pos, Clause: clause, Text: text, Msg: msg, Err: err}
+}
+
+// withOffset menggeser Pos ParseError di dalam err sebesar offset, mis. dari posisi relatif terhadap isi
+// slice menjadi posisi di kueri utuh. Error lain dikembalikan apa adanya.
+func withOffset(err error, offset int) error {
+	var pe *ParseError
+	if errors.As(err, &pe) && pe.Pos >= 0 {
+		pe.Pos += offset
+	}
+	return err
+}
+
+// relocate mencari ulang posisi Text ParseError di dalam err pada query mulai dari offset from. Dipakai
+// jika error berasal dari potongan kueri yang sudah disusun ulang sehingga posisi relatifnya tidak lagi
+// berlaku; hasilnya perkiraan (kemunculan pertama Text).
+func relocate(err error, query string, from int) error {
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		pe.Pos = newParseError(query, from, pe.Clause, pe.Text, "", nil).Pos
+	}
+	return err
+}
+
+// Validate memparsing kueri tanpa mengembalikan Query, untuk editor kueri yang hanya perlu memeriksa
+// sintaks. Setiap kegagalan dikembalikan sebagai *ParseError; error tanpa informasi posisi dibungkus
+// dengan Pos -1.
+func (p *Parser) Validate(query string) *ParseError {
+	_, err := p.Parse(query)
+	if err == nil {
+		return nil
+	}
+	var pe *ParseError
+	if errors.As(err, &pe) {
+		if pe.Error() != err.Error() {
+			// Error dibungkus dengan konteks tambahan: pertahankan posisi, tetapi pakai pesan lengkapnya.
+			return &ParseError{Pos: pe.Pos, Clause: pe.Clause, Text: pe.Text, Msg: err.Error()}
+		}
+		return pe
+	}
+	return &ParseError{Pos: -1, Msg: err.Error()}
+}
+
+// Parse memparsing string kueri menjadi struct Query. Error pada klausa shape, type, slice, values,
+// dan where berupa *ParseError yang menyertakan posisinya di query. Komentar "-- ..." hingga akhir
+// baris diabaikan, dan parameter bernama (:name) pada operand skalar dicatat di Query.Params.
 func (p *Parser) Parse(query string) (*Query, error) {
-	queryOriginalCase := strings.TrimSpace(query)
+	query = stripComments(query)
+	trimmed := strings.TrimSpace(query)
+	q, err := p.parse(trimmed)
+	if err != nil {
+		// parse menghitung posisi pada kueri yang sudah di-trim.
+		return nil, withOffset(err, strings.Index(query, trimmed))
+	}
+	for _, operand := range paramOperands(q) {
+		if paramTokenRegex.MatchString(*operand) {
+			q.Params = append(q.Params, *operand)
+		}
+	}
+	return q, nil
+}
+
+// stripComments mengganti komentar baris "-- ..." di luar string berkutip tunggal dengan spasi, sehingga
+// offset karakter lainnya (dan posisi ParseError) tidak berubah. Seperti di MySQL, "--" harus diikuti
+// spasi atau akhir baris agar operand seperti --1 tetap dilaporkan sebagai operand yang tidak valid.
+func stripComments(query string) string {
+	if !strings.Contains(query, "--") {
+		return query
+	}
+	b := []byte(query)
+	inQuote := false
+	for i := 0; i < len(b); i++ {
+		switch {
+		case inQuote && b[i] == '\\':
+			i++ // Lewati karakter yang di-escape, mis. \' di dalam SEP '...'
+		case b[i] == '\'':
+			inQuote = !inQuote
+		case !inQuote && isCommentStart(b, i):
+			for ; i < len(b) && b[i] != '\n'; i++ {
+				b[i] = ' '
+			}
+		}
+	}
+	return string(b)
+}
+
+func isCommentStart(b []byte, i int) bool {
+	if b[i] != '-' || i+1 >= len(b) || b[i+1] != '-' {
+		return false
+	}
+	return i+2 == len(b) || b[i+2] == ' ' || b[i+2] == '\t' || b[i+2] == '\r' || b[i+2] == '\n'
+}
+
+// paramOperands mengembalikan pointer ke field operand kueri yang boleh berisi parameter bernama.
+func paramOperands(q *Query) []*string {
+	return []*string{&q.ScalarOperand, &q.MatchValue, &q.Tolerance, &q.RangeMin, &q.RangeMax, &q.RangeFrom, &q.RangeTo, &q.RangeStep}
+}
+
+func (p *Parser) parse(queryOriginalCase string) (*Query, error) {
 	queryLower := strings.ToLower(queryOriginalCase)
 
 	// Regex untuk operasi matematika (contoh untuk ADD)
-	addTensorRegex := regexp.MustCompile(`(?i)^ADD\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
-	addScalarRegex := regexp.MustCompile(`(?i)^ADD\s+SCALAR\s+([0-9\.eE+-]+)\s+TO\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	addTensorRegex := regexp.MustCompile(`(?i)^ADD\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)(\s+BROADCAST)?\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	multiplyTensorRegex := regexp.MustCompile(`(?i)^MULTIPLY\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	divideTensorRegex := regexp.MustCompile(`(?i)^DIVIDE\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	matMulRegex := regexp.MustCompile(`(?i)^MATMUL\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	interleaveRegex := regexp.MustCompile(`(?i)^INTERLEAVE\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)(?:\s+AXIS\s+(\d+))?\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	integerDivisionRegex := regexp.MustCompile(`(?i)^(MOD|FLOORDIV)\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	bitwiseRegex := regexp.MustCompile(`(?i)^BITWISE\s+(AND|OR|XOR)\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	bitwiseNotRegex := regexp.MustCompile(`(?i)^BITWISE\s+NOT\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	shiftRegex := regexp.MustCompile(`(?i)^SHIFT\s+(LEFT|RIGHT)\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+BY\s+(\S+)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	binaryOpRegex := regexp.MustCompile(`(?i)^OP\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	concatRegex := regexp.MustCompile(`(?i)^CONCAT\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WITH\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+ALONG\s+AXIS\s+(\d+)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	segmentSumRegex := regexp.MustCompile(`(?i)^SEGMENT_SUM\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+SEGMENTS\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	addScalarRegex := regexp.MustCompile(`(?i)^ADD\s+SCALAR\s+(\S+)\s+TO\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	mulScalarRegex := regexp.MustCompile(`(?i)^MULTIPLY\s+SCALAR\s+(\S+)\s+TO\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	subScalarRegex := regexp.MustCompile(`(?i)^SUBTRACT\s+SCALAR\s+(\S+)\s+FROM\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	addScalarInPlaceRegex := regexp.MustCompile(`(?i)^ADD\s+SCALAR\s+(\S+)\s+TO\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+IN\s+PLACE$`)
+	sqrtRegex := regexp.MustCompile(`(?i)^SQRT\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	pdistRegex := regexp.MustCompile(`(?i)^PDIST\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	clipNormRegex := regexp.MustCompile(`(?i)^CLIP_NORM\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+MAX\s+(\S+)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	covRegex := regexp.MustCompile(`(?i)^(COV|CORR)\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	topKRegex := regexp.MustCompile(`(?i)^TOPK\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+K\s+(\d+)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+AND\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	movingAvgRegex := regexp.MustCompile(`(?i)^MOVING_AVG\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+WINDOW\s+(\d+)(?:\s+AXIS\s+(\d+))?\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	normalizeRegex := regexp.MustCompile(`(?i)^NORMALIZE\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)(?:\s+MIN\s+(\S+)\s+MAX\s+(\S+))?(?:\s+AXIS\s+(\d+))?\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	oneHotRegex := regexp.MustCompile(`(?i)^ONEHOT\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+CLASSES\s+(\d+)(?:\s+TYPE\s+([a-zA-Z0-9_]+))?\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	replaceValueRegex := regexp.MustCompile(`(?i)^REPLACE\s+VALUE\s+(\S+)\s+WITH\s+(\S+)(?:\s+TOLERANCE\s+(\S+))?\s+IN\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+(?:INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)|(IN\s+PLACE))$`)
+	reinterpretRegex := regexp.MustCompile(`(?i)^REINTERPRET\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+AS\s+([a-zA-Z0-9_]+)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	transposeRegex := regexp.MustCompile(`(?i)^TRANSPOSE\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)(?:\s+PERM\s+(\d+(?:\s*,\s*\d+)*))?\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	reshapeRegex := regexp.MustCompile(`(?i)^RESHAPE\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+TO\s+(\d+(?:\s*,\s*\d+)*)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	batchRegex := regexp.MustCompile(`(?i)^BATCH\s+TENSORS\s+(.+?)\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+	// Reduksi: "SUM TENSOR a [ALONG] AXIS n INTO c"; tanpa AXIS hasilnya tensor skalar (shape []).
+	reduceRegex := regexp.MustCompile(`(?i)^(SUM|MEAN|NANSUM|NANMEAN|MAX|MIN)\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)(?:\s+(?:ALONG\s+)?AXIS\s+(\d+))?\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
 
 	matchesAddTensor := addTensorRegex.FindStringSubmatch(queryOriginalCase)
 	if matchesAddTensor != nil {
@@ -29,12 +219,140 @@ func (p *Parser) Parse(query string) (*Query, error) {
 			Type:             MathOperationQuery, // Menggunakan konstanta dari tensor.go
 			MathOperator:     "ADD_TENSORS",
 			InputTensorNames: []string{matchesAddTensor[1], matchesAddTensor[2]},
-			OutputTensorName: matchesAddTensor[3],
+			OutputTensorName: matchesAddTensor[4],
+			Broadcast:        matchesAddTensor[3] != "",
+		}, nil
+	}
+
+	matchesMultiplyTensor := multiplyTensorRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesMultiplyTensor != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "MULTIPLY_TENSORS",
+			InputTensorNames: []string{matchesMultiplyTensor[1], matchesMultiplyTensor[2]},
+			OutputTensorName: matchesMultiplyTensor[3],
+		}, nil
+	}
+
+	matchesDivideTensor := divideTensorRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesDivideTensor != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "DIVIDE_TENSORS",
+			InputTensorNames: []string{matchesDivideTensor[1], matchesDivideTensor[2]},
+			OutputTensorName: matchesDivideTensor[3],
+		}, nil
+	}
+
+	matchesMatMul := matMulRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesMatMul != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "MATMUL_TENSORS",
+			InputTensorNames: []string{matchesMatMul[1], matchesMatMul[2]},
+			OutputTensorName: matchesMatMul[3],
+		}, nil
+	}
+
+	matchesInterleave := interleaveRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesInterleave != nil {
+		q := &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "INTERLEAVE",
+			InputTensorNames: []string{matchesInterleave[1], matchesInterleave[2]},
+			OutputTensorName: matchesInterleave[4],
+		}
+		if matchesInterleave[3] != "" {
+			axis, err := strconv.Atoi(matchesInterleave[3])
+			if err != nil {
+				return nil, fmt.Errorf("invalid INTERLEAVE axis '%s': %w", matchesInterleave[3], err)
+			}
+			q.Axis = &axis
+		}
+		return q, nil
+	}
+
+	matchesConcat := concatRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesConcat != nil {
+		axis, err := strconv.Atoi(matchesConcat[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid CONCAT axis '%s': %w", matchesConcat[3], err)
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "CONCAT",
+			InputTensorNames: []string{matchesConcat[1], matchesConcat[2]},
+			OutputTensorName: matchesConcat[4],
+			Axis:             &axis,
+		}, nil
+	}
+
+	// BITWISE AND/OR/XOR dijalankan oleh operasi biner terdaftar BITWISE_AND, BITWISE_OR, dan BITWISE_XOR.
+	matchesBitwise := bitwiseRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesBitwise != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "BITWISE_" + strings.ToUpper(matchesBitwise[1]),
+			InputTensorNames: []string{matchesBitwise[2], matchesBitwise[3]},
+			OutputTensorName: matchesBitwise[4],
+		}, nil
+	}
+
+	matchesBitwiseNot := bitwiseNotRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesBitwiseNot != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "BITWISE_NOT",
+			InputTensorNames: []string{matchesBitwiseNot[1]},
+			OutputTensorName: matchesBitwiseNot[2],
+		}, nil
+	}
+
+	// Jumlah geser divalidasi executor terhadap lebar bit tipe tensor (lihat ShiftTensor).
+	matchesShift := shiftRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesShift != nil {
+		if err := validateScalarOperand(matchesShift[3]); err != nil {
+			return nil, err
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "SHIFT_" + strings.ToUpper(matchesShift[1]),
+			InputTensorNames: []string{matchesShift[2]},
+			ScalarOperand:    matchesShift[3],
+			OutputTensorName: matchesShift[4],
+		}, nil
+	}
+
+	// OP name memanggil operasi biner yang didaftarkan dengan RegisterBinaryOp; nama divalidasi executor.
+	// MOD dan FLOORDIV adalah bentuk singkat untuk OP MOD dan OP FLOORDIV.
+	matchesBinaryOp := binaryOpRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesBinaryOp == nil {
+		matchesBinaryOp = integerDivisionRegex.FindStringSubmatch(queryOriginalCase)
+	}
+	if matchesBinaryOp != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     strings.ToUpper(matchesBinaryOp[1]),
+			InputTensorNames: []string{matchesBinaryOp[2], matchesBinaryOp[3]},
+			OutputTensorName: matchesBinaryOp[4],
+		}, nil
+	}
+
+	matchesSegmentSum := segmentSumRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesSegmentSum != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "SEGMENT_SUM",
+			InputTensorNames: []string{matchesSegmentSum[1], matchesSegmentSum[2]},
+			OutputTensorName: matchesSegmentSum[3],
 		}, nil
 	}
 
 	matchesAddScalar := addScalarRegex.FindStringSubmatch(queryOriginalCase)
 	if matchesAddScalar != nil {
+		if err := validateScalarOperand(matchesAddScalar[1]); err != nil {
+			return nil, err
+		}
 		return &Query{
 			Type:             MathOperationQuery, // Menggunakan konstanta dari tensor.go
 			MathOperator:     "ADD_SCALAR",
@@ -44,6 +362,271 @@ func (p *Parser) Parse(query string) (*Query, error) {
 		}, nil
 	}
 
+	matchesMulScalar := mulScalarRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesMulScalar != nil {
+		if err := validateScalarOperand(matchesMulScalar[1]); err != nil {
+			return nil, err
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "MUL_SCALAR",
+			InputTensorNames: []string{matchesMulScalar[2]},
+			ScalarOperand:    matchesMulScalar[1],
+			OutputTensorName: matchesMulScalar[3],
+		}, nil
+	}
+
+	matchesSubScalar := subScalarRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesSubScalar != nil {
+		if err := validateScalarOperand(matchesSubScalar[1]); err != nil {
+			return nil, err
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "SUB_SCALAR",
+			InputTensorNames: []string{matchesSubScalar[2]},
+			ScalarOperand:    matchesSubScalar[1],
+			OutputTensorName: matchesSubScalar[3],
+		}, nil
+	}
+
+	matchesAddScalarInPlace := addScalarInPlaceRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesAddScalarInPlace != nil {
+		if err := validateScalarOperand(matchesAddScalarInPlace[1]); err != nil {
+			return nil, err
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "ADD_SCALAR",
+			InputTensorNames: []string{matchesAddScalarInPlace[2]},
+			ScalarOperand:    matchesAddScalarInPlace[1],
+			InPlace:          true,
+		}, nil
+	}
+
+	matchesSqrt := sqrtRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesSqrt != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "SQRT",
+			InputTensorNames: []string{matchesSqrt[1]},
+			OutputTensorName: matchesSqrt[2],
+		}, nil
+	}
+
+	matchesPdist := pdistRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesPdist != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "PDIST",
+			InputTensorNames: []string{matchesPdist[1]},
+			OutputTensorName: matchesPdist[2],
+		}, nil
+	}
+
+	matchesClipNorm := clipNormRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesClipNorm != nil {
+		if err := validateScalarOperand(matchesClipNorm[2]); err != nil {
+			return nil, err
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "CLIP_NORM",
+			InputTensorNames: []string{matchesClipNorm[1]},
+			ScalarOperand:    matchesClipNorm[2],
+			OutputTensorName: matchesClipNorm[3],
+		}, nil
+	}
+
+	matchesCov := covRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesCov != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     strings.ToUpper(matchesCov[1]),
+			InputTensorNames: []string{matchesCov[2]},
+			OutputTensorName: matchesCov[3],
+		}, nil
+	}
+
+	matchesTopK := topKRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesTopK != nil {
+		return &Query{
+			Type:              MathOperationQuery,
+			MathOperator:      "TOPK",
+			InputTensorNames:  []string{matchesTopK[1]},
+			ScalarOperand:     matchesTopK[2],
+			OutputTensorName:  matchesTopK[3],
+			IndicesTensorName: matchesTopK[4],
+		}, nil
+	}
+
+	matchesMovingAvg := movingAvgRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesMovingAvg != nil {
+		q := &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "MOVING_AVG",
+			InputTensorNames: []string{matchesMovingAvg[1]},
+			ScalarOperand:    matchesMovingAvg[2],
+			OutputTensorName: matchesMovingAvg[4],
+		}
+		if matchesMovingAvg[3] != "" {
+			axis, err := strconv.Atoi(matchesMovingAvg[3])
+			if err != nil {
+				return nil, fmt.Errorf("invalid MOVING_AVG axis '%s': %w", matchesMovingAvg[3], err)
+			}
+			q.Axis = &axis
+		}
+		return q, nil
+	}
+
+	matchesNormalize := normalizeRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesNormalize != nil {
+		q := &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "NORMALIZE",
+			InputTensorNames: []string{matchesNormalize[1]},
+			RangeMin:         matchesNormalize[2],
+			RangeMax:         matchesNormalize[3],
+			OutputTensorName: matchesNormalize[5],
+		}
+		for _, bound := range []string{q.RangeMin, q.RangeMax} {
+			if bound == "" {
+				continue
+			}
+			if err := validateScalarOperand(bound); err != nil {
+				return nil, err
+			}
+		}
+		if matchesNormalize[4] != "" {
+			axis, err := strconv.Atoi(matchesNormalize[4])
+			if err != nil {
+				return nil, fmt.Errorf("invalid NORMALIZE axis '%s': %w", matchesNormalize[4], err)
+			}
+			q.Axis = &axis
+		}
+		return q, nil
+	}
+
+	matchesOneHot := oneHotRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesOneHot != nil {
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "ONEHOT",
+			InputTensorNames: []string{matchesOneHot[1]},
+			ScalarOperand:    matchesOneHot[2],
+			DataType:         strings.ToLower(matchesOneHot[3]),
+			OutputTensorName: matchesOneHot[4],
+		}, nil
+	}
+
+	matchesReplace := replaceValueRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesReplace != nil {
+		for _, operand := range []string{matchesReplace[1], matchesReplace[2], matchesReplace[3]} {
+			if operand == "" {
+				continue
+			}
+			if err := validateScalarOperand(operand); err != nil {
+				return nil, err
+			}
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "REPLACE_VALUE",
+			InputTensorNames: []string{matchesReplace[4]},
+			MatchValue:       matchesReplace[1],
+			ScalarOperand:    matchesReplace[2],
+			Tolerance:        matchesReplace[3],
+			OutputTensorName: matchesReplace[5],
+			InPlace:          matchesReplace[6] != "",
+		}, nil
+	}
+
+	matchesReinterpret := reinterpretRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesReinterpret != nil {
+		targetDataType := strings.ToLower(matchesReinterpret[2])
+		if _, err := GetElementSize(targetDataType); err != nil {
+			return nil, fmt.Errorf("invalid data type '%s' in REINTERPRET: %w", matchesReinterpret[2], err)
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "REINTERPRET",
+			InputTensorNames: []string{matchesReinterpret[1]},
+			DataType:         targetDataType,
+			OutputTensorName: matchesReinterpret[3],
+		}, nil
+	}
+
+	matchesTranspose := transposeRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesTranspose != nil {
+		q := &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "TRANSPOSE",
+			InputTensorNames: []string{matchesTranspose[1]},
+			OutputTensorName: matchesTranspose[3],
+		}
+		if matchesTranspose[2] != "" {
+			for _, part := range strings.Split(matchesTranspose[2], ",") {
+				axis, err := strconv.Atoi(strings.TrimSpace(part))
+				if err != nil {
+					return nil, fmt.Errorf("invalid TRANSPOSE axis '%s': %w", part, err)
+				}
+				q.Perm = append(q.Perm, axis)
+			}
+		}
+		return q, nil
+	}
+
+	matchesReshape := reshapeRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesReshape != nil {
+		shape, err := ParseShape(matchesReshape[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid RESHAPE target shape: %w", err)
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "RESHAPE",
+			InputTensorNames: []string{matchesReshape[1]},
+			Shape:            shape,
+			OutputTensorName: matchesReshape[3],
+		}, nil
+	}
+
+	matchesBatch := batchRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesBatch != nil {
+		var inputNames []string
+		for _, name := range strings.Split(matchesBatch[1], ",") {
+			name = strings.TrimSpace(name)
+			if !batchInputNameRegex.MatchString(name) {
+				return nil, fmt.Errorf("invalid tensor name or pattern '%s' in BATCH TENSORS", name)
+			}
+			inputNames = append(inputNames, name)
+		}
+		return &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     "BATCH",
+			InputTensorNames: inputNames,
+			OutputTensorName: matchesBatch[2],
+		}, nil
+	}
+
+	matchesReduce := reduceRegex.FindStringSubmatch(queryOriginalCase)
+	if matchesReduce != nil {
+		q := &Query{
+			Type:             MathOperationQuery,
+			MathOperator:     strings.ToUpper(matchesReduce[1]),
+			InputTensorNames: []string{matchesReduce[2]},
+			OutputTensorName: matchesReduce[4],
+		}
+		if matchesReduce[3] != "" {
+			axis, err := strconv.Atoi(matchesReduce[3])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s axis '%s': %w", q.MathOperator, matchesReduce[3], err)
+			}
+			q.Axis = &axis
+		}
+		return q, nil
+	}
+
 	partsOriginal := strings.Fields(queryOriginalCase)
 	partsLower := strings.Fields(queryLower)
 
@@ -60,7 +643,8 @@ func (p *Parser) Parse(query string) (*Query, error) {
 		}
 
 		whereClause := ""
-		if idx := strings.Index(queryLower, " where "); idx != -1 {
+		idx := strings.Index(queryLower, " where ")
+		if idx != -1 {
 			whereClause = strings.TrimSpace(queryOriginalCase[idx+len(" where "):])
 		}
 
@@ -74,7 +658,7 @@ func (p *Parser) Parse(query string) (*Query, error) {
 				if _, err := GetElementSize(dt); err == nil {
 					q.FilterDataType = dt
 				} else {
-					return nil, fmt.Errorf("invalid data type in WHERE clause: '%s'", dataTypeMatches[1])
+					return nil, newParseError(queryOriginalCase, idx, "where", dataTypeMatches[1], fmt.Sprintf("invalid data type in WHERE clause: '%s'", dataTypeMatches[1]), nil)
 				}
 			}
 
@@ -82,10 +666,10 @@ func (p *Parser) Parse(query string) (*Query, error) {
 			if len(numDimMatches) == 2 {
 				numDim, err := strconv.Atoi(numDimMatches[1])
 				if err != nil {
-					return nil, fmt.Errorf("invalid number for NUM_DIMENSIONS: '%s'", numDimMatches[1])
+					return nil, newParseError(queryOriginalCase, idx, "where", numDimMatches[1], fmt.Sprintf("invalid number for NUM_DIMENSIONS: '%s'", numDimMatches[1]), nil)
 				}
 				if numDim < 0 {
-					return nil, fmt.Errorf("NUM_DIMENSIONS cannot be negative: %d", numDim)
+					return nil, newParseError(queryOriginalCase, idx, "where", numDimMatches[1], fmt.Sprintf("NUM_DIMENSIONS cannot be negative: %d", numDim), nil)
 				}
 				q.FilterNumDimensions = numDim
 			}
@@ -94,17 +678,262 @@ func (p *Parser) Parse(query string) (*Query, error) {
 	}
 
 	switch partsLower[0] {
+	case "drop":
+		dropRegex := regexp.MustCompile(`(?i)^DROP\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+		m := dropRegex.FindStringSubmatch(queryOriginalCase)
+		if m == nil {
+			return nil, errors.New("invalid DROP syntax: expected 'DROP TENSOR name'")
+		}
+		return &Query{
+			Type:        DropTensorQuery,
+			TensorNames: []string{m[1]},
+		}, nil
+
+	case "update":
+		updateRegex := regexp.MustCompile(`(?i)^UPDATE\s+([a-zA-Z_][a-zA-Z0-9_]*)\s*\[\s*(\d+(?:\s*,\s*\d+)*)?\s*\]\s*=\s*(\S+)$`)
+		m := updateRegex.FindStringSubmatch(queryOriginalCase)
+		if m == nil {
+			return nil, errors.New("invalid UPDATE syntax: expected 'UPDATE name[i,j,...] = value'")
+		}
+		// Literal true/false diteruskan apa adanya untuk tensor bool; executor memvalidasinya.
+		if lower := strings.ToLower(m[3]); lower != "true" && lower != "false" {
+			if err := validateScalarOperand(m[3]); err != nil {
+				return nil, err
+			}
+		}
+		coordinate := []int{}
+		if m[2] != "" {
+			for _, part := range strings.Split(m[2], ",") {
+				idx, err := strconv.Atoi(strings.TrimSpace(part))
+				if err != nil {
+					return nil, fmt.Errorf("invalid UPDATE coordinate '%s': %w", part, err)
+				}
+				coordinate = append(coordinate, idx)
+			}
+		}
+		return &Query{
+			Type:          UpdateElementQuery,
+			TensorNames:   []string{m[1]},
+			Coordinate:    coordinate,
+			ScalarOperand: m[3],
+		}, nil
+
+	case "describe":
+		describeRegex := regexp.MustCompile(`(?i)^DESCRIBE\s+(?:TENSOR\s+)?([a-zA-Z_][a-zA-Z0-9_]*)$`)
+		m := describeRegex.FindStringSubmatch(queryOriginalCase)
+		if m == nil {
+			return nil, errors.New("invalid DESCRIBE syntax: expected 'DESCRIBE [TENSOR] name'")
+		}
+		return &Query{
+			Type:        DescribeTensorQuery,
+			TensorNames: []string{m[1]},
+		}, nil
+
+	case "count":
+		countRegex := regexp.MustCompile(`(?i)^COUNT\s+(?:TENSOR\s+)?([a-zA-Z_][a-zA-Z0-9_]*)$`)
+		m := countRegex.FindStringSubmatch(queryOriginalCase)
+		if m == nil {
+			return nil, errors.New("invalid COUNT syntax: expected 'COUNT [TENSOR] name'")
+		}
+		return &Query{
+			Type:        CountQuery,
+			TensorNames: []string{m[1]},
+		}, nil
+
+	case "validate":
+		validateRegex := regexp.MustCompile(`(?i)^VALIDATE\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+		m := validateRegex.FindStringSubmatch(queryOriginalCase)
+		if m == nil {
+			return nil, errors.New("invalid VALIDATE syntax: expected 'VALIDATE TENSOR name'")
+		}
+		return &Query{
+			Type:        ValidateTensorQuery,
+			TensorNames: []string{m[1]},
+		}, nil
+
+	case "stats":
+		statsRegex := regexp.MustCompile(`(?i)^STATS\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+		m := statsRegex.FindStringSubmatch(queryOriginalCase)
+		if m == nil {
+			return nil, errors.New("invalid STATS syntax: expected 'STATS TENSOR name'")
+		}
+		return &Query{
+			Type:        StatsTensorQuery,
+			TensorNames: []string{m[1]},
+		}, nil
+
+	case "rename":
+		renameRegex := regexp.MustCompile(`(?i)^RENAME\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+TO\s+([a-zA-Z_][a-zA-Z0-9_]*)$`)
+		m := renameRegex.FindStringSubmatch(queryOriginalCase)
+		if m == nil {
+			return nil, errors.New("invalid RENAME syntax: expected 'RENAME TENSOR old_name TO new_name'")
+		}
+		return &Query{
+			Type:        RenameTensorQuery,
+			TensorNames: []string{m[1], m[2]},
+		}, nil
+
+	case "export":
+		exportRegex := regexp.MustCompile(`(?i)^EXPORT\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+TO\s+SHARDS\s+'([^']+)'\s+COUNT\s+(\d+)$`)
+		m := exportRegex.FindStringSubmatch(queryOriginalCase)
+		if m == nil {
+			return nil, errors.New("invalid EXPORT syntax: expected 'EXPORT TENSOR name TO SHARDS 'prefix' COUNT n'")
+		}
+		count, err := strconv.Atoi(m[3])
+		if err != nil || count <= 0 {
+			return nil, fmt.Errorf("invalid shard COUNT '%s': must be a positive integer", m[3])
+		}
+		return &Query{
+			Type:        ExportShardsQuery,
+			TensorNames: []string{m[1]},
+			ShardPrefix: m[2],
+			ShardCount:  count,
+		}, nil
+
+	case "import":
+		importRegex := regexp.MustCompile(`(?i)^IMPORT\s+FROM\s+SHARDS\s+'([^']+)'(?:\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*))?$`)
+		m := importRegex.FindStringSubmatch(queryOriginalCase)
+		if m == nil {
+			return nil, errors.New("invalid IMPORT syntax: expected 'IMPORT FROM SHARDS 'prefix' [INTO name]'")
+		}
+		q := &Query{Type: ImportShardsQuery, ShardPrefix: m[1]}
+		if m[2] != "" {
+			q.TensorNames = []string{m[2]}
+		}
+		return q, nil
+
 	case "create":
+		createFromSelectRegex := regexp.MustCompile(`(?i)^CREATE\s+TENSOR\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+FROM\s+(SELECT\s+.+)$`)
+		if m := createFromSelectRegex.FindStringSubmatch(queryOriginalCase); m != nil {
+			selectStr := m[2]
+			selectParts := strings.Fields(selectStr)
+			// Bentuk singkat "SELECT src [slice]" diperluas menjadi "SELECT src FROM src [slice]".
+			if len(selectParts) < 3 || strings.ToLower(selectParts[2]) != "from" {
+				selectStr = fmt.Sprintf("SELECT %s FROM %s", selectParts[1], strings.Join(selectParts[1:], " "))
+			}
+			sourceQuery, err := p.Parse(selectStr)
+			if err != nil {
+				// selectStr bisa berupa bentuk yang diperluas, jadi posisi dicari ulang di kueri utuh.
+				return nil, fmt.Errorf("invalid SELECT in CREATE TENSOR ... FROM: %w", relocate(err, queryOriginalCase, len(queryOriginalCase)-len(m[2])))
+			}
+			if sourceQuery.Type != SelectTensorQuery {
+				return nil, fmt.Errorf("CREATE TENSOR ... FROM expects a SELECT query, got '%s'", m[2])
+			}
+			return &Query{
+				Type:        CreateTensorQuery,
+				TensorNames: []string{m[1]},
+				SourceQuery: sourceQuery,
+			}, nil
+		}
+		if len(partsLower) > 1 && partsLower[1] == "identity" {
+			identityRegex := regexp.MustCompile(`(?i)^CREATE\s+IDENTITY\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+(-?\d+)(?:\s+TYPE\s+([a-zA-Z0-9_]+))?$`)
+			m := identityRegex.FindStringSubmatch(queryOriginalCase)
+			if m == nil {
+				return nil, errors.New("invalid CREATE IDENTITY syntax: expected 'CREATE IDENTITY name n [TYPE datatype]'")
+			}
+			n, err := strconv.Atoi(m[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid identity size '%s': %w", m[2], err)
+			}
+			if n <= 0 {
+				return nil, fmt.Errorf("identity size must be positive, got %d", n)
+			}
+			dataType := DataTypeFloat64
+			if m[3] != "" {
+				dataType = strings.ToLower(m[3])
+				if _, err := GetElementSize(dataType); err != nil {
+					return nil, fmt.Errorf("invalid data type '%s' in CREATE IDENTITY: %w", m[3], err)
+				}
+			}
+			return &Query{
+				Type:        CreateTensorQuery,
+				TensorNames: []string{m[1]},
+				Shape:       []int{n, n},
+				DataType:    dataType,
+				Identity:    true,
+			}, nil
+		}
+		if len(partsLower) > 1 && partsLower[1] == "random" {
+			randomRegex := regexp.MustCompile(`(?i)^CREATE\s+RANDOM\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+(.+?)(?:\s+TYPE\s+([a-zA-Z0-9_]+))?(?:\s+SEED\s+(\S+))?$`)
+			m := randomRegex.FindStringSubmatch(queryOriginalCase)
+			if m == nil {
+				return nil, errors.New("invalid CREATE RANDOM syntax: expected 'CREATE RANDOM name shape [TYPE datatype] [SEED n]'")
+			}
+			shape, err := ParseShape(m[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid shape '%s' in CREATE RANDOM: %w", m[2], err)
+			}
+			dataType := DataTypeFloat64
+			if m[3] != "" {
+				dataType = strings.ToLower(m[3])
+				if _, err := GetElementSize(dataType); err != nil {
+					return nil, fmt.Errorf("invalid data type '%s' in CREATE RANDOM: %w", m[3], err)
+				}
+			}
+			q := &Query{
+				Type:        CreateTensorQuery,
+				TensorNames: []string{m[1]},
+				Shape:       shape,
+				DataType:    dataType,
+				Random:      true,
+			}
+			if m[4] != "" {
+				seed, err := strconv.ParseInt(m[4], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid SEED '%s' in CREATE RANDOM: %w", m[4], err)
+				}
+				q.Seed = &seed
+			}
+			return q, nil
+		}
+		if len(partsLower) > 1 && partsLower[1] == "range" {
+			rangeRegex := regexp.MustCompile(`(?i)^CREATE\s+RANGE\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+FROM\s+(\S+)\s+TO\s+(\S+)(?:\s+STEP\s+(\S+))?(?:\s+TYPE\s+([a-zA-Z0-9_]+))?$`)
+			m := rangeRegex.FindStringSubmatch(queryOriginalCase)
+			if m == nil {
+				return nil, errors.New("invalid CREATE RANGE syntax: expected 'CREATE RANGE name FROM start TO stop [STEP step] [TYPE datatype]'")
+			}
+			step := "1"
+			if m[4] != "" {
+				step = m[4]
+			}
+			dataType := DataTypeFloat64
+			if m[5] != "" {
+				dataType = strings.ToLower(m[5])
+				if _, err := GetElementSize(dataType); err != nil {
+					return nil, fmt.Errorf("invalid data type '%s' in CREATE RANGE: %w", m[5], err)
+				}
+			}
+			return &Query{
+				Type:        CreateTensorQuery,
+				TensorNames: []string{m[1]},
+				DataType:    dataType,
+				RangeFrom:   m[2],
+				RangeTo:     m[3],
+				RangeStep:   step,
+			}, nil
+		}
 		if len(partsLower) < 3 || partsLower[1] != "tensor" {
-			return nil, errors.New("invalid CREATE TENSOR syntax: expected 'CREATE TENSOR name shape [TYPE datatype]' or 'CREATE TENSOR name TYPE datatype'")
+			return nil, errors.New("invalid CREATE TENSOR syntax: expected 'CREATE TENSOR name shape [TYPE datatype] [FILL value] [TRACK_STATS]' or 'CREATE TENSOR name TYPE datatype'")
 		}
 		tensorName := partsOriginal[2]
-		var shape []int
 
 		remainingPartsOriginal := []string{}
 		if len(partsOriginal) > 3 {
 			remainingPartsOriginal = partsOriginal[3:]
 		}
+		trackStats := false
+		if n := len(remainingPartsOriginal); n > 0 && strings.EqualFold(remainingPartsOriginal[n-1], "TRACK_STATS") {
+			trackStats = true
+			remainingPartsOriginal = remainingPartsOriginal[:n-1]
+		}
+		// FILL value mengisi setiap elemen tensor baru; nilai diurai sesuai tipe data oleh executor.
+		fillValue := ""
+		if n := len(remainingPartsOriginal); n > 0 && strings.EqualFold(remainingPartsOriginal[n-1], "FILL") {
+			return nil, errors.New("missing value after FILL keyword")
+		} else if n > 1 && strings.EqualFold(remainingPartsOriginal[n-2], "FILL") {
+			fillValue = remainingPartsOriginal[n-1]
+			remainingPartsOriginal = remainingPartsOriginal[:n-2]
+		}
 		remainingStrOriginal := strings.Join(remainingPartsOriginal, " ")
 
 		shapeStr := ""
@@ -121,36 +950,17 @@ func (p *Parser) Parse(query string) (*Query, error) {
 			shapeStr = strings.TrimSpace(matches[1])
 		}
 
-		if shapeStr == "" {
-			shape = []int{}
-		} else {
-			shapeStrNoSpaces := strings.ReplaceAll(shapeStr, " ", "")
-			shapeDimsStr := strings.Split(shapeStrNoSpaces, ",")
-			if len(shapeDimsStr) == 0 || (len(shapeDimsStr) == 1 && strings.TrimSpace(shapeDimsStr[0]) == "") {
-				shape = []int{}
-			} else {
-				shape = make([]int, len(shapeDimsStr))
-				for i, dStr := range shapeDimsStr {
-					trimmedDStr := strings.TrimSpace(dStr)
-					if trimmedDStr == "" {
-						return nil, fmt.Errorf("invalid dimension: empty string found in shape '%s'", shapeStr)
-					}
-					dim, err := strconv.Atoi(trimmedDStr)
-					if err != nil {
-						return nil, fmt.Errorf("invalid dimension '%s' in shape '%s': %w", trimmedDStr, shapeStr, err)
-					}
-					if dim < 0 {
-						return nil, fmt.Errorf("invalid dimension '%s' in shape '%s': must be non-negative", trimmedDStr, shapeStr)
-					}
-					shape[i] = dim
-				}
-			}
+		// Posisi dicari setelah nama tensor agar shape/tipe tidak tertukar dengan bagian nama.
+		nameEnd := strings.Index(queryOriginalCase, tensorName) + len(tensorName)
+		shape, err := ParseShape(shapeStr)
+		if err != nil {
+			return nil, newParseError(queryOriginalCase, nameEnd, "shape", shapeStr, "", err)
 		}
 
 		if matches != nil && matches[2] != "" {
 			dt := strings.ToLower(strings.TrimSpace(matches[2]))
 			if _, err := GetElementSize(dt); err != nil { // GetElementSize dari tensor.go
-				return nil, fmt.Errorf("invalid data type '%s' in CREATE TENSOR: %w", dt, err)
+				return nil, newParseError(queryOriginalCase, nameEnd, "type", strings.TrimSpace(matches[2]), fmt.Sprintf("invalid data type '%s' in CREATE TENSOR", dt), err)
 			}
 			dataType = dt
 		} else if matches == nil && strings.Contains(strings.ToLower(remainingStrOriginal), "type") {
@@ -162,7 +972,7 @@ func (p *Parser) Parse(query string) (*Query, error) {
 						dataType = potentialTypeStr
 						shape = []int{}
 					} else {
-						return nil, fmt.Errorf("invalid data type '%s' found after TYPE keyword", potentialTypeStr)
+						return nil, newParseError(queryOriginalCase, nameEnd, "type", strings.TrimSpace(remainingStrOriginal[typeIdx+len("type"):]), fmt.Sprintf("invalid data type '%s' found after TYPE keyword", potentialTypeStr), nil)
 					}
 				} else {
 					return nil, errors.New("missing data type after TYPE keyword")
@@ -171,36 +981,90 @@ func (p *Parser) Parse(query string) (*Query, error) {
 		}
 
 		return &Query{
-			Type:        CreateTensorQuery, // Menggunakan konstanta dari tensor.go
-			TensorNames: []string{tensorName},
-			Shape:       shape,
-			DataType:    dataType,
+			Type:          CreateTensorQuery, // Menggunakan konstanta dari tensor.go
+			TensorNames:   []string{tensorName},
+			Shape:         shape,
+			DataType:      dataType,
+			TrackStats:    trackStats,
+			ScalarOperand: fillValue,
 		}, nil
 
 	case "insert":
-		if len(partsLower) < 5 || partsLower[1] != "into" || partsLower[3] != "values" {
-			return nil, errors.New("invalid INSERT INTO syntax: expected 'INSERT INTO name VALUES (...)'")
+		sparseRegex := regexp.MustCompile(`(?i)^INSERT\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+SPARSE\s+(.+)$`)
+		if m := sparseRegex.FindStringSubmatch(queryOriginalCase); m != nil {
+			entries, err := parseSparseEntries(m[2])
+			if err != nil {
+				return nil, err
+			}
+			return &Query{
+				Type:        InsertTensorQuery,
+				TensorNames: []string{m[1]},
+				Sparse:      entries,
+			}, nil
+		}
+		appendRegex := regexp.MustCompile(`(?i)^INSERT\s+INTO\s+\S+\s+APPEND(?:\s+AXIS\s+(\d+))?\s+VALUES\b`)
+		appendMatches := appendRegex.FindStringSubmatch(queryOriginalCase)
+		// Bentuk "INSERT INTO name[0:1,0:2] VALUES (...)" hanya menulis ke sub-region tensor.
+		sliceRegex := regexp.MustCompile(`(?i)^INSERT\s+INTO\s+([a-zA-Z_][a-zA-Z0-9_]*)\s*\[([^\]]*)\]\s*VALUES\b`)
+		sliceMatches := sliceRegex.FindStringSubmatch(queryOriginalCase)
+		sliceMatchIndexes := sliceRegex.FindStringSubmatchIndex(queryOriginalCase)
+		if appendMatches == nil && sliceMatches == nil && (len(partsLower) < 5 || partsLower[1] != "into" || partsLower[3] != "values") {
+			return nil, errors.New("invalid INSERT INTO syntax: expected 'INSERT INTO name [APPEND [AXIS n]] VALUES (...)' or 'INSERT INTO name[slice] VALUES (...)'")
 		}
 		tensorName := partsOriginal[2]
+		var insertSlices [][][2]int
+		if sliceMatches != nil {
+			tensorName = sliceMatches[1]
+			ranges, steps, err := parseSliceRanges(sliceMatches[2], "INSERT")
+			if err != nil {
+				return nil, withOffset(err, sliceMatchIndexes[4])
+			}
+			if steps != nil {
+				return nil, errors.New("invalid INSERT INTO syntax: slice step is not supported for INSERT")
+			}
+			if len(ranges) == 0 {
+				return nil, errors.New("invalid INSERT INTO syntax: slice must specify at least one range")
+			}
+			insertSlices = [][][2]int{ranges}
+		}
+		var appendAxis *int
+		if appendMatches != nil && appendMatches[1] != "" {
+			axis, err := strconv.Atoi(appendMatches[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid APPEND axis '%s': %w", appendMatches[1], err)
+			}
+			appendAxis = &axis
+		}
 
 		tempQueryLower := strings.ToLower(queryOriginalCase)
 		valuesMatchIndex := strings.Index(tempQueryLower, "values")
 		if valuesMatchIndex == -1 {
 			valuesMatchIndex = strings.Index(queryOriginalCase, "VALUES")
 			if valuesMatchIndex == -1 {
-				return nil, errors.New("invalid INSERT INTO syntax: 'VALUES' keyword not found")
+				return nil, &ParseError{Pos: -1, Clause: "values", Msg: "invalid INSERT INTO syntax: 'VALUES' keyword not found"}
 			}
 		}
 
 		openParenIndex := strings.Index(queryOriginalCase[valuesMatchIndex:], "(")
 		if openParenIndex == -1 {
-			return nil, errors.New("invalid INSERT INTO syntax: '(' not found after 'VALUES'")
+			return nil, &ParseError{Pos: valuesMatchIndex, Clause: "values", Text: queryOriginalCase[valuesMatchIndex:], Msg: "invalid INSERT INTO syntax: '(' not found after 'VALUES'"}
 		}
 		openParenIndex += valuesMatchIndex
 
 		closeParenIndex := strings.LastIndex(queryOriginalCase, ")")
 		if closeParenIndex == -1 || closeParenIndex < openParenIndex {
-			return nil, errors.New("invalid INSERT INTO syntax: ')' not found or misplaced for 'VALUES'")
+			return nil, &ParseError{Pos: openParenIndex, Clause: "values", Text: queryOriginalCase[openParenIndex:], Msg: "invalid INSERT INTO syntax: ')' not found or misplaced for 'VALUES'"}
+		}
+
+		separator := ","
+		sepClause := strings.TrimSpace(queryOriginalCase[valuesMatchIndex+len("values") : openParenIndex])
+		if sepClause != "" {
+			sepRegex := regexp.MustCompile(`(?i)^SEP\s+'((?:\\.|[^'\\])+)'$`)
+			sepMatches := sepRegex.FindStringSubmatch(sepClause)
+			if sepMatches == nil {
+				return nil, newParseError(queryOriginalCase, valuesMatchIndex, "values", sepClause, fmt.Sprintf("invalid INSERT INTO syntax: expected 'VALUES [SEP 'separator'] (...)', got '%s'", sepClause), nil)
+			}
+			separator = sepEscapeReplacer.Replace(sepMatches[1])
 		}
 
 		valuesContent := strings.TrimSpace(queryOriginalCase[openParenIndex+1 : closeParenIndex])
@@ -209,7 +1073,13 @@ func (p *Parser) Parse(query string) (*Query, error) {
 		if valuesContent == "" {
 			dataToInsert = []string{}
 		} else {
-			dataStrValues := strings.Split(valuesContent, ",")
+			var dataStrValues []string
+			if strings.TrimSpace(separator) == "" {
+				// Pemisah whitespace (spasi/tab): deretan pemisah berurutan dianggap satu.
+				dataStrValues = strings.FieldsFunc(valuesContent, func(r rune) bool { return strings.ContainsRune(separator, r) })
+			} else {
+				dataStrValues = strings.Split(valuesContent, separator)
+			}
 			dataToInsert = make([]string, len(dataStrValues))
 			for i, dStr := range dataStrValues {
 				dataToInsert[i] = strings.TrimSpace(dStr)
@@ -220,6 +1090,9 @@ func (p *Parser) Parse(query string) (*Query, error) {
 			Type:        InsertTensorQuery, // Menggunakan konstanta dari tensor.go
 			TensorNames: []string{tensorName},
 			Data:        dataToInsert,
+			Append:      appendMatches != nil,
+			Axis:        appendAxis,
+			Slices:      insertSlices,
 		}, nil
 
 	case "select":
@@ -238,40 +1111,25 @@ func (p *Parser) Parse(query string) (*Query, error) {
 		}
 
 		var parsedSlices [][2]int
+		var sliceSteps [][]int
 		if sliceStr != "" {
-			sliceContent := strings.TrimPrefix(sliceStr, "[")
-			sliceContent = strings.TrimSuffix(sliceContent, "]")
-			if sliceContent == "" {
-				parsedSlices = nil
-			} else {
-				sliceParts := strings.Split(sliceContent, ",")
-				parsedSlices = make([][2]int, len(sliceParts))
-				for i, s := range sliceParts {
-					s = strings.TrimSpace(s)
-					bounds := strings.Split(s, ":")
-					if len(bounds) != 2 {
-						return nil, fmt.Errorf("invalid slice format '%s' for SELECT", s)
-					}
-					startStr, endStr := strings.TrimSpace(bounds[0]), strings.TrimSpace(bounds[1])
-					start, err := strconv.Atoi(startStr)
-					if err != nil {
-						return nil, fmt.Errorf("invalid slice start '%s': %w", startStr, err)
-					}
-					end, err := strconv.Atoi(endStr)
-					if err != nil {
-						return nil, fmt.Errorf("invalid slice end '%s': %w", endStr, err)
-					}
-					if start < 0 || end < start {
-						return nil, fmt.Errorf("invalid slice range [%d:%d]", start, end)
-					}
-					parsedSlices[i] = [2]int{start, end}
-				}
+			// Isi slice diambil dari kueri asli (bukan hasil gabungan Fields) agar posisi error tepat.
+			openIdx, closeIdx := strings.Index(queryOriginalCase, "["), strings.LastIndex(queryOriginalCase, "]")
+			var steps []int
+			var err error
+			parsedSlices, steps, err = parseSliceRanges(queryOriginalCase[openIdx+1:closeIdx], "SELECT")
+			if err != nil {
+				return nil, withOffset(err, openIdx+1)
+			}
+			if steps != nil {
+				sliceSteps = [][]int{steps}
 			}
 		}
 		return &Query{
 			Type:        SelectTensorQuery, // Menggunakan konstanta dari tensor.go
 			TensorNames: []string{sourceTensorName},
 			Slices:      [][][2]int{parsedSlices},
+			SliceSteps:  sliceSteps,
 		}, nil
 
 	case "get":
@@ -321,42 +1179,31 @@ func (p *Parser) Parse(query string) (*Query, error) {
 		}
 		tensorNames := make([]string, 0, len(allMatches))
 		slices := make([][][2]int, 0, len(allMatches))
-		for _, match := range allMatches {
+		var sliceSteps [][]int
+		for i, match := range allMatches {
 			tensorName := strings.TrimSpace(match[1])
 			tensorNames = append(tensorNames, tensorName)
 			var currentTensorSlice [][2]int
+			var currentTensorSteps []int
 			if len(match) > 2 && match[2] != "" {
 				sliceContentWithBrackets := strings.TrimSpace(match[2])
 				sliceContent := strings.TrimPrefix(sliceContentWithBrackets, "[")
 				sliceContent = strings.TrimSuffix(sliceContent, "]")
-				if sliceContent == "" {
-					currentTensorSlice = nil
-				} else {
-					slicePartsStr := strings.Split(sliceContent, ",")
-					currentTensorSlice = make([][2]int, len(slicePartsStr))
-					for j, sPart := range slicePartsStr {
-						sPart = strings.TrimSpace(sPart)
-						bounds := strings.Split(sPart, ":")
-						if len(bounds) != 2 {
-							return nil, fmt.Errorf("invalid slice format '%s' for tensor '%s'", sPart, tensorName)
-						}
-						startStr, endStr := strings.TrimSpace(bounds[0]), strings.TrimSpace(bounds[1])
-						start, err := strconv.Atoi(startStr)
-						if err != nil {
-							return nil, fmt.Errorf("invalid slice start '%s' for tensor '%s': %w", startStr, tensorName, err)
-						}
-						end, err := strconv.Atoi(endStr)
-						if err != nil {
-							return nil, fmt.Errorf("invalid slice end '%s' for tensor '%s': %w", endStr, tensorName, err)
-						}
-						if start < 0 || end < start {
-							return nil, fmt.Errorf("invalid slice range [%d:%d] for tensor '%s'", start, end, tensorName)
-						}
-						currentTensorSlice[j] = [2]int{start, end}
-					}
+				var err error
+				currentTensorSlice, currentTensorSteps, err = parseSliceRanges(sliceContent, fmt.Sprintf("tensor '%s'", tensorName))
+				if err != nil {
+					// Definisi tensor disusun ulang dari Fields, jadi posisi dicari ulang mulai dari nama tensor.
+					return nil, relocate(err, queryOriginalCase, strings.Index(queryOriginalCase, tensorName))
 				}
 			}
 			slices = append(slices, currentTensorSlice)
+			// SliceSteps hanya dibuat jika ada tensor yang memakai langkah; tensor lain mendapat nil.
+			if currentTensorSteps != nil {
+				if sliceSteps == nil {
+					sliceSteps = make([][]int, len(allMatches))
+				}
+				sliceSteps[i] = currentTensorSteps
+			}
 		}
 		if len(tensorNames) == 0 {
 			return nil, errors.New("no valid tensor names found for GET DATA (after regex match)")
@@ -365,9 +1212,105 @@ func (p *Parser) Parse(query string) (*Query, error) {
 			Type:        GetDataTensorQuery, // Menggunakan konstanta dari tensor.go
 			TensorNames: tensorNames,
 			Slices:      slices,
+			SliceSteps:  sliceSteps,
 			BatchSize:   batchSize,
 		}, nil
 
 	}
 	return nil, fmt.Errorf("unsupported query type or malformed query near: '%s'", partsLower[0])
 }
+
+// batchInputNameRegex mencocokkan satu nama input BATCH TENSORS: nama tensor biasa atau pola glob
+// dengan * dan ? (mis. sample_*), yang diekspansi oleh executor.
+var batchInputNameRegex = regexp.MustCompile(`^[a-zA-Z_*?][a-zA-Z0-9_*?]*$`)
+
+// sparseEntryRegex mencocokkan satu pasangan "(i,j,...)=nilai" di awal string, diikuti koma atau akhir string.
+var sparseEntryRegex = regexp.MustCompile(`^\s*\(([^)]*)\)\s*=\s*([^,\s]+)\s*(?:,|$)`)
+
+// parseSliceRanges mengurai isi slice tanpa kurung siku, mis. "0:1, 0:2", menjadi rentang [start, end).
+// Batas boleh negatif (dihitung dari akhir dimensi) atau dihilangkan: start kosong berarti 0 dan end
+// kosong berarti SliceEnd, mis. "-1:, :" untuk baris terakhir. Komponen ketiga opsional adalah langkah
+// positif, mis. "0:6:2"; steps bernilai nil jika tidak ada dimensi yang memakai langkah, dan jika ada,
+// dimensi tanpa langkah mendapat 1. Isi kosong menghasilkan nil (seluruh tensor). context dipakai dalam
+// pesan error (mis. "SELECT"). Error berupa *ParseError dengan Text rentang yang salah dan Pos relatif
+// terhadap sliceContent.
+func parseSliceRanges(sliceContent string, context string) ([][2]int, []int, error) {
+	if strings.TrimSpace(sliceContent) == "" {
+		return nil, nil, nil
+	}
+	sliceParts := strings.Split(sliceContent, ",")
+	parsedSlices := make([][2]int, len(sliceParts))
+	var steps []int
+	offset := 0
+	for i, rawPart := range sliceParts {
+		s := strings.TrimSpace(rawPart)
+		pos := offset + strings.Index(rawPart, s)
+		offset += len(rawPart) + len(",")
+		sliceError := func(msg string, err error) error {
+			return &ParseError{Pos: pos, Clause: "slice", Text: s, Msg: msg, Err: err}
+		}
+		bounds := strings.Split(s, ":")
+		if len(bounds) != 2 && len(bounds) != 3 {
+			return nil, nil, sliceError(fmt.Sprintf("invalid slice format '%s' for %s", s, context), nil)
+		}
+		startStr, endStr := strings.TrimSpace(bounds[0]), strings.TrimSpace(bounds[1])
+		start, end := 0, SliceEnd
+		var err error
+		if startStr != "" {
+			if start, err = strconv.Atoi(startStr); err != nil {
+				return nil, nil, sliceError(fmt.Sprintf("invalid slice start '%s' for %s", startStr, context), err)
+			}
+		}
+		if endStr != "" {
+			if end, err = strconv.Atoi(endStr); err != nil {
+				return nil, nil, sliceError(fmt.Sprintf("invalid slice end '%s' for %s", endStr, context), err)
+			}
+		}
+		// Batas negatif bergantung pada ukuran dimensi sehingga baru divalidasi saat eksekusi
+		// (ResolveSliceRanges); di sini hanya rentang non-negatif yang jelas terbalik yang ditolak.
+		if start >= 0 && end >= 0 && end < start {
+			return nil, nil, sliceError(fmt.Sprintf("invalid slice range [%d:%d] for %s", start, end, context), nil)
+		}
+		parsedSlices[i] = [2]int{start, end}
+		if len(bounds) == 3 && strings.TrimSpace(bounds[2]) != "" {
+			stepStr := strings.TrimSpace(bounds[2])
+			step, err := strconv.Atoi(stepStr)
+			if err != nil || step <= 0 {
+				return nil, nil, sliceError(fmt.Sprintf("invalid slice step '%s' for %s: must be a positive integer", stepStr, context), err)
+			}
+			if steps == nil {
+				steps = make([]int, len(sliceParts))
+				for j := range steps {
+					steps[j] = 1
+				}
+			}
+			steps[i] = step
+		}
+	}
+	return parsedSlices, steps, nil
+}
+
+// parseSparseEntries mengurai daftar "(0,0)=1.0, (1,2)=3.0" untuk INSERT ... SPARSE.
+func parseSparseEntries(content string) ([]SparseEntry, error) {
+	var entries []SparseEntry
+	rest := strings.TrimSpace(content)
+	for rest != "" {
+		m := sparseEntryRegex.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, fmt.Errorf("invalid SPARSE entry near '%s': expected '(i,j,...)=value'", rest)
+		}
+		coordinate, err := ParseShape(m[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid SPARSE coordinate '(%s)': %w", m[1], err)
+		}
+		if err := validateScalarOperand(m[2]); err != nil {
+			return nil, err
+		}
+		entries = append(entries, SparseEntry{Coordinate: coordinate, Value: m[2]})
+		rest = strings.TrimSpace(rest[len(m[0]):])
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("INSERT ... SPARSE requires at least one '(i,j,...)=value' entry")
+	}
+	return entries, nil
+}
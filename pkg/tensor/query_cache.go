@@ -0,0 +1,151 @@
+package tensor
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// WithQueryCache mengaktifkan cache LRU hasil kueri baca deterministik (SELECT dan GET DATA) dengan
+// paling banyak maxEntries entri. Entri yang melibatkan sebuah tensor dibuang setiap kali tensor itu
+// ditulis, dihapus, atau diganti namanya melalui Execute. Hasil ter-cache dibagi antar pemanggil sehingga
+// harus diperlakukan read-only; penulisan langsung lewat mmap (GetTensorMmap) tidak terdeteksi.
+// Nilai 0 atau negatif menonaktifkan cache (default).
+func WithQueryCache(maxEntries int) ExecutorOption {
+	return func(e *Executor) {
+		if maxEntries <= 0 {
+			e.queryCache = nil
+			return
+		}
+		e.queryCache = newQueryCache(maxEntries)
+	}
+}
+
+// QueryCacheStats adalah statistik cache hasil kueri.
+type QueryCacheStats struct {
+	Hits    int
+	Misses  int
+	Entries int
+}
+
+// QueryCacheStats mengembalikan statistik cache hasil kueri; nilai nol jika cache tidak aktif.
+func (e *Executor) QueryCacheStats() QueryCacheStats {
+	if e.queryCache == nil {
+		return QueryCacheStats{}
+	}
+	return e.queryCache.stats()
+}
+
+type queryCacheEntry struct {
+	key     string
+	tensors []string
+	result  interface{}
+}
+
+// queryCache menyimpan hasil kueri per fingerprint dengan urutan LRU. generation dinaikkan pada setiap
+// invalidasi; hasil yang dihitung sebelum invalidasi tidak disimpan agar data usang tidak masuk cache.
+type queryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	generation uint64
+	lru        *list.List               // Entri paling baru dipakai di depan
+	entries    map[string]*list.Element // Fingerprint -> elemen di lru
+	hits       int
+	misses     int
+}
+
+func newQueryCache(maxEntries int) *queryCache {
+	return &queryCache{
+		maxEntries: maxEntries,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// queryFingerprint mengembalikan kunci cache untuk kueri baca deterministik; ok false untuk kueri
+// yang tidak boleh di-cache.
+func queryFingerprint(query *Query) (key string, ok bool) {
+	switch query.Type {
+	case SelectTensorQuery, GetDataTensorQuery:
+		return fmt.Sprintf("%s|%q|%v|%v|%d", query.Type, query.TensorNames, query.Slices, query.SliceSteps, query.BatchSize), true
+	default:
+		return "", false
+	}
+}
+
+// mutatedTensorNames mengembalikan semua nama tensor yang dapat diubah oleh kueri tulis.
+func mutatedTensorNames(query *Query) []string {
+	names := append([]string{}, query.TensorNames...)
+	names = append(names, query.InputTensorNames...)
+	if query.OutputTensorName != "" {
+		names = append(names, query.OutputTensorName)
+	}
+	if query.IndicesTensorName != "" {
+		names = append(names, query.IndicesTensorName)
+	}
+	return names
+}
+
+// lookup mengembalikan hasil ter-cache untuk key beserta generation saat ini, yang harus diteruskan ke
+// store agar hasil yang dihitung bersamaan dengan penulisan tidak disimpan.
+func (c *queryCache) lookup(key string) (interface{}, bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(elem)
+		c.hits++
+		return elem.Value.(*queryCacheEntry).result, true, c.generation
+	}
+	c.misses++
+	return nil, false, c.generation
+}
+
+func (c *queryCache) store(key string, generation uint64, tensors []string, result interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(&queryCacheEntry{key: key, tensors: tensors, result: result})
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*queryCacheEntry).key)
+	}
+}
+
+// invalidate membuang setiap entri yang melibatkan salah satu tensorNames.
+func (c *queryCache) invalidate(tensorNames []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*queryCacheEntry)
+		if tensorsOverlap(entry.tensors, tensorNames) {
+			c.lru.Remove(elem)
+			delete(c.entries, entry.key)
+		}
+		elem = next
+	}
+}
+
+func (c *queryCache) stats() QueryCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return QueryCacheStats{Hits: c.hits, Misses: c.misses, Entries: c.lru.Len()}
+}
+
+func tensorsOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
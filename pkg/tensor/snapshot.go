@@ -0,0 +1,177 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// snapshotAttempts adalah jumlah percobaan menyalin satu tensor yang sedang ditulis bersamaan sebelum
+// Snapshot menyerah.
+const snapshotAttempts = 3
+
+// errSnapshotChanged menandai tensor yang berubah selama disalin sehingga salinannya tidak konsisten.
+var errSnapshotChanged = errors.New("tensor changed while being copied")
+
+// Snapshot menyalin semua tensor (file .meta, .data, .stats, dan blob dedup) ke destDir yang belum ada,
+// menghasilkan direktori data yang dapat dibuka sebagai Storage terpisah dengan NewStorage, misalnya
+// sebagai replika baca. Salinan dibangun di direktori sementara di samping destDir lalu diganti nama
+// menjadi destDir, sehingga destDir tidak pernah terlihat setengah jadi. File yang selalu diganti lewat
+// rename (.meta, .stats, blob) dibuat sebagai hard link jika memungkinkan; file .data disalin karena
+// UPDATE, INSERT ke slice, dan operasi IN PLACE menulisnya langsung lewat mmap. Selama direktori data
+// dibaca dan disalin, kueri tulis melalui Executor.Execute ditahan (lihat writeBarrier), sehingga
+// snapshot mencerminkan satu titik waktu untuk semua tensor, termasuk RENAME dan DROP yang bersamaan.
+// Tulisan langsung melalui GetTensorMmap tidak ditahan; tensor yang berubah dengan cara itu tetap
+// dideteksi lewat checksum dan disalin ulang. Log operasi tidak ikut disalin.
+func (s *Storage) Snapshot(destDir string) error {
+	if _, err := os.Stat(destDir); err == nil {
+		return fmt.Errorf("snapshot destination %s already exists", destDir)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to check snapshot destination %s: %w", destDir, err)
+	}
+	parent := filepath.Dir(destDir)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot parent directory %s: %w", parent, err)
+	}
+	tmpDir, err := os.MkdirTemp(parent, filepath.Base(destDir)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	s.writeBarrier.Lock()
+	err = s.snapshotInto(tmpDir)
+	s.writeBarrier.Unlock()
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		return err
+	}
+	if err := os.Chmod(tmpDir, 0755); err != nil {
+		os.RemoveAll(tmpDir)
+		return fmt.Errorf("failed to set permissions on snapshot directory: %w", err)
+	}
+	if err := os.Rename(tmpDir, destDir); err != nil {
+		os.RemoveAll(tmpDir)
+		return fmt.Errorf("failed to publish snapshot %s: %w", destDir, err)
+	}
+	if s.durability >= DurabilityFullSync {
+		if err := s.syncer.SyncDir(parent); err != nil {
+			return fmt.Errorf("failed to sync snapshot parent directory %s: %w", parent, err)
+		}
+	}
+	return nil
+}
+
+// snapshotInto menyalin setiap tensor di direktori data ke dir.
+func (s *Storage) snapshotInto(dir string) error {
+	entries, err := os.ReadDir(s.dataDir)
+	if err != nil {
+		return fmt.Errorf("failed to read data directory %s: %w", s.dataDir, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".meta") {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".meta")
+		err := errSnapshotChanged
+		for attempt := 0; attempt < snapshotAttempts && errors.Is(err, errSnapshotChanged); attempt++ {
+			err = s.snapshotTensor(name, dir)
+		}
+		if errors.Is(err, errSnapshotChanged) {
+			return fmt.Errorf("failed to snapshot tensor %s: %w after %d attempts", name, err, snapshotAttempts)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if s.durability >= DurabilityFullSync {
+		if err := s.syncer.SyncDir(dir); err != nil {
+			return fmt.Errorf("failed to sync snapshot directory %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// snapshotTensor menyalin satu tensor ke dir. File .meta di-link lebih dulu lalu metadata dibaca dari
+// salinannya, sehingga data yang disalin selalu dibandingkan dengan versi metadata yang sama dengan yang
+// tersimpan di snapshot. errSnapshotChanged dikembalikan jika data tidak cocok dengan checksum metadata.
+func (s *Storage) snapshotTensor(name, dir string) error {
+	metaDst := filepath.Join(dir, name+".meta")
+	os.Remove(metaDst) // Sisa percobaan sebelumnya
+	if err := s.linkOrCopyFile(filepath.Join(s.dataDir, name+".meta"), metaDst); err != nil {
+		if os.IsNotExist(err) {
+			return nil // Tensor dihapus sejak direktori data dibaca
+		}
+		return fmt.Errorf("failed to copy metadata for tensor %s: %w", name, err)
+	}
+	metadata, err := s.loadTensorMetadataInternal(metaDst)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot tensor %s: %w", name, err)
+	}
+
+	if metadata.DataRef != "" {
+		blobDst := filepath.Join(dir, BlobDirName, metadata.DataRef+".data")
+		if _, err := os.Stat(blobDst); os.IsNotExist(err) {
+			if err := os.MkdirAll(filepath.Dir(blobDst), 0755); err != nil {
+				return fmt.Errorf("failed to create snapshot blob directory: %w", err)
+			}
+			if err := s.linkOrCopyFile(s.blobPath(metadata.DataRef), blobDst); err != nil {
+				if os.IsNotExist(err) {
+					return errSnapshotChanged // Tensor ditimpa dan blob lamanya sudah dilepas
+				}
+				return fmt.Errorf("failed to copy blob for tensor %s: %w", name, err)
+			}
+		}
+	} else {
+		checksum, err := s.copyFileChecksum(filepath.Join(s.dataDir, name+".data"), filepath.Join(dir, name+".data"))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to copy data for tensor %s: %w", name, err)
+		}
+		if err == nil && metadata.Checksum != nil && checksum != *metadata.Checksum {
+			return errSnapshotChanged
+		}
+	}
+
+	statsDst := filepath.Join(dir, name+".stats")
+	os.Remove(statsDst)
+	if err := s.linkOrCopyFile(filepath.Join(s.dataDir, name+".stats"), statsDst); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to copy stats for tensor %s: %w", name, err)
+	}
+	return nil
+}
+
+// linkOrCopyFile membuat dst sebagai hard link ke src, atau menyalinnya jika link tidak didukung
+// (mis. beda sistem file). Error os.ErrNotExist dari src diteruskan apa adanya.
+func (s *Storage) linkOrCopyFile(src, dst string) error {
+	if err := os.Link(src, dst); err == nil || os.IsNotExist(err) {
+		return err
+	}
+	_, err := s.copyFileChecksum(src, dst)
+	return err
+}
+
+// copyFileChecksum menyalin src ke dst (dibuat baru) dan mengembalikan CRC32 isinya.
+func (s *Storage) copyFileChecksum(src, dst string) (uint32, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	hash := crc32.NewIEEE()
+	_, errCopy := io.Copy(io.MultiWriter(out, hash), in)
+	var errSync error
+	if errCopy == nil {
+		errSync = s.syncFile(out)
+	}
+	errClose := out.Close()
+	if err := errors.Join(errCopy, errSync, errClose); err != nil {
+		return 0, fmt.Errorf("failed to copy %s to %s: %w", src, dst, err)
+	}
+	return hash.Sum32(), nil
+}
@@ -3,14 +3,18 @@ package tensor
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
+	"unsafe"
 
 	"github.com/edsrzf/mmap-go"
 )
@@ -20,19 +24,30 @@ type TensorMetadata struct {
 	Shape    []int
 	DataType string
 	Strides  []int
+	Checksum *uint32 // CRC32 (IEEE) isi file .data; nil untuk metadata lama tanpa baris checksum (tidak diverifikasi)
+	DataRef  string  // Hash blob data bersama pada mode dedup (lihat WithDedup); kosong jika data ada di file .data sendiri
 	// NumDimensions int // Bisa ditambahkan jika ingin disimpan, atau dihitung on-the-fly
 }
 
+// NumDimensions mengembalikan jumlah dimensi tensor seperti yang dipakai indeks untuk filter dimensi.
+// Shape kosong maupun shape [0] dari parser lama dihitung sebagai skalar dengan 0 dimensi.
+func (m *TensorMetadata) NumDimensions() int {
+	if len(m.Shape) == 1 && m.Shape[0] == 0 {
+		return 0
+	}
+	return len(m.Shape)
+}
+
 // InMemoryIndex adalah struktur data untuk indeks metadata tensor dalam memori.
 type InMemoryIndex struct {
 	// Key: DataType (string), Value: set nama tensor (map[tensorName]struct{})
 	ByDataType map[string]map[string]struct{}
 	// Key: NumDimensions (int), Value: set nama tensor (map[tensorName]struct{})
 	ByNumDimensions map[int]map[string]struct{}
-	// Key: tensorName, Value: pointer ke metadata (untuk akses cepat jika sudah dimuat)
-	// Ini opsional dan bisa menambah kompleksitas sinkronisasi.
-	// Untuk saat ini, kita akan fokus pada pencarian nama, lalu muat metadata dari disk.
-	// AllTensorMetadata map[string]*TensorMetadata
+	// Key: tensorName, Value: salinan metadata terakhir yang ditulis, agar pembacaan metadata massal
+	// tidak perlu membuka file .meta. Diperbarui oleh Add, Remove, Rebuild, dan setiap penulisan .meta
+	// untuk tensor yang sudah terindeks (lihat Refresh).
+	AllTensorMetadata map[string]*TensorMetadata
 
 	mu sync.RWMutex // Melindungi akses ke semua peta indeks
 }
@@ -40,10 +55,22 @@ type InMemoryIndex struct {
 // NewInMemoryIndex membuat instance baru dari InMemoryIndex.
 func NewInMemoryIndex() *InMemoryIndex {
 	return &InMemoryIndex{
-		ByDataType:      make(map[string]map[string]struct{}),
-		ByNumDimensions: make(map[int]map[string]struct{}),
-		// AllTensorMetadata: make(map[string]*TensorMetadata),
+		ByDataType:        make(map[string]map[string]struct{}),
+		ByNumDimensions:   make(map[int]map[string]struct{}),
+		AllTensorMetadata: make(map[string]*TensorMetadata),
+	}
+}
+
+// cloneMetadata mengembalikan salinan metadata yang tidak berbagi slice atau pointer dengan aslinya.
+func cloneMetadata(metadata *TensorMetadata) *TensorMetadata {
+	clone := *metadata
+	clone.Shape = append([]int(nil), metadata.Shape...)
+	clone.Strides = append([]int(nil), metadata.Strides...)
+	if metadata.Checksum != nil {
+		checksum := *metadata.Checksum
+		clone.Checksum = &checksum
 	}
+	return &clone
 }
 
 // Add menambahkan atau memperbarui metadata tensor dalam indeks.
@@ -54,16 +81,14 @@ func (idx *InMemoryIndex) Add(metadata *TensorMetadata) {
 	}
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
+	idx.add(metadata)
+}
 
+// add adalah Add tanpa penguncian; pemanggil harus memegang idx.mu.
+func (idx *InMemoryIndex) add(metadata *TensorMetadata) {
 	tensorName := metadata.Name
 	dataType := metadata.DataType
-	numDimensions := len(metadata.Shape)
-	if len(metadata.Shape) == 1 && metadata.Shape[0] == 0 { // Representasi skalar dari parser lama mungkin [0]
-		numDimensions = 0 // Skalar sejati memiliki 0 dimensi
-	}
-	if len(metadata.Shape) == 0 { // Representasi skalar yang lebih baik adalah shape kosong
-		numDimensions = 0
-	}
+	numDimensions := metadata.NumDimensions() // Shape [0] dari parser lama dihitung sebagai skalar
 
 	// Tambahkan ke indeks ByDataType
 	if _, ok := idx.ByDataType[dataType]; !ok {
@@ -77,7 +102,7 @@ func (idx *InMemoryIndex) Add(metadata *TensorMetadata) {
 	}
 	idx.ByNumDimensions[numDimensions][tensorName] = struct{}{}
 
-	// idx.AllTensorMetadata[tensorName] = metadata // Opsional
+	idx.AllTensorMetadata[tensorName] = cloneMetadata(metadata)
 }
 
 // Remove menghapus tensor dari indeks.
@@ -88,16 +113,14 @@ func (idx *InMemoryIndex) Remove(metadata *TensorMetadata) {
 	}
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
+	idx.remove(metadata)
+}
 
+// remove adalah Remove tanpa penguncian; pemanggil harus memegang idx.mu.
+func (idx *InMemoryIndex) remove(metadata *TensorMetadata) {
 	tensorName := metadata.Name
 	dataType := metadata.DataType
-	numDimensions := len(metadata.Shape)
-	if len(metadata.Shape) == 1 && metadata.Shape[0] == 0 {
-		numDimensions = 0
-	}
-	if len(metadata.Shape) == 0 {
-		numDimensions = 0
-	}
+	numDimensions := metadata.NumDimensions()
 
 	if names, ok := idx.ByDataType[dataType]; ok {
 		delete(names, tensorName)
@@ -112,7 +135,36 @@ func (idx *InMemoryIndex) Remove(metadata *TensorMetadata) {
 			delete(idx.ByNumDimensions, numDimensions)
 		}
 	}
-	// delete(idx.AllTensorMetadata, tensorName) // Opsional
+	delete(idx.AllTensorMetadata, tensorName)
+}
+
+// Refresh mengganti entri tensor yang sudah terindeks dengan metadata yang baru ditulis, termasuk
+// memindahkannya jika tipe data atau jumlah dimensinya berubah. Tensor yang belum terindeks dibiarkan,
+// karena pendaftarannya ke indeks menjadi tanggung jawab pembuatnya (lihat Add).
+func (idx *InMemoryIndex) Refresh(metadata *TensorMetadata) {
+	if metadata == nil {
+		return
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	old, ok := idx.AllTensorMetadata[metadata.Name]
+	if !ok {
+		return
+	}
+	idx.remove(old)
+	idx.add(metadata)
+}
+
+// Get mengembalikan salinan metadata tensor name yang tersimpan di indeks, atau false jika name belum
+// terindeks.
+func (idx *InMemoryIndex) Get(name string) (*TensorMetadata, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	metadata, ok := idx.AllTensorMetadata[name]
+	if !ok {
+		return nil, false
+	}
+	return cloneMetadata(metadata), true
 }
 
 // Query mencari nama tensor yang cocok dengan kriteria filter.
@@ -183,7 +235,9 @@ func (idx *InMemoryIndex) Query(filterDataType string, filterNumDimensions int)
 }
 
 // Rebuild membangun ulang seluruh indeks dari file metadata di dataDir.
-// Ini harus dipanggil saat Storage diinisialisasi.
+// Ini harus dipanggil saat Storage diinisialisasi. File .meta yang tidak dapat dibaca dan entri direktori
+// yang gagal ditelusuri dilewati tanpa menghentikan rebuild, sehingga tensor lain tetap terindeks; error
+// per file dikumpulkan dan dikembalikan bersama dengan errors.Join.
 func (idx *InMemoryIndex) Rebuild(dataDir string, storage *Storage) error {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
@@ -191,11 +245,19 @@ func (idx *InMemoryIndex) Rebuild(dataDir string, storage *Storage) error {
 	// Bersihkan indeks yang ada
 	idx.ByDataType = make(map[string]map[string]struct{})
 	idx.ByNumDimensions = make(map[int]map[string]struct{})
-	// idx.AllTensorMetadata = make(map[string]*TensorMetadata)
+	idx.AllTensorMetadata = make(map[string]*TensorMetadata)
 
+	var errs []error
 	err := filepath.WalkDir(dataDir, func(path string, d fs.DirEntry, errWalk error) error {
 		if errWalk != nil {
-			return errWalk // Propagate error dari WalkDir
+			if path == dataDir {
+				return errWalk // Direktori data sendiri tidak dapat dibaca; tidak ada yang bisa diindeks
+			}
+			errs = append(errs, fmt.Errorf("failed to read %s during index rebuild: %w", path, errWalk))
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
 		}
 		if !d.IsDir() && strings.HasSuffix(d.Name(), ".meta") {
 			tensorName := strings.TrimSuffix(d.Name(), ".meta")
@@ -210,13 +272,7 @@ func (idx *InMemoryIndex) Rebuild(dataDir string, storage *Storage) error {
 			if errLoad == nil && metadata != nil {
 				// Hitung NumDimensions di sini jika tidak disimpan di metadata
 				dataType := metadata.DataType
-				numDimensions := len(metadata.Shape)
-				if len(metadata.Shape) == 1 && metadata.Shape[0] == 0 {
-					numDimensions = 0
-				}
-				if len(metadata.Shape) == 0 {
-					numDimensions = 0
-				}
+				numDimensions := metadata.NumDimensions()
 
 				if _, ok := idx.ByDataType[dataType]; !ok {
 					idx.ByDataType[dataType] = make(map[string]struct{})
@@ -227,48 +283,105 @@ func (idx *InMemoryIndex) Rebuild(dataDir string, storage *Storage) error {
 					idx.ByNumDimensions[numDimensions] = make(map[string]struct{})
 				}
 				idx.ByNumDimensions[numDimensions][tensorName] = struct{}{}
-				// idx.AllTensorMetadata[tensorName] = metadata
+				idx.AllTensorMetadata[tensorName] = metadata
 			} else if errLoad != nil {
-				// Log error pemuatan metadata, tapi lanjutkan rebuild
-				fmt.Fprintf(os.Stderr, "Warning: failed to load metadata for %s during index rebuild: %v\n", tensorName, errLoad)
+				// Catat error pemuatan metadata, tapi lanjutkan rebuild
+				errs = append(errs, fmt.Errorf("failed to load metadata for %s during index rebuild: %w", tensorName, errLoad))
 			}
 		}
 		return nil
 	})
-	return err
+	return errors.Join(append(errs, err)...)
 }
 
 type Storage struct {
-	dataDir string
-	index   *InMemoryIndex // Tambahkan field untuk indeks
+	dataDir    string
+	index      *InMemoryIndex // Tambahkan field untuk indeks
+	mmapAdvice bool           // Panggil madvise setelah mmap (lihat WithMmapAdvice)
+	advisor    MmapAdvisor    // Pelaksana petunjuk pola akses mmap (lihat WithMmapAdvisor)
+	opLog      bool           // Catat kueri yang mengubah state ke file oplog (lihat WithOpLog)
+	opLogMu    sync.Mutex
+	durability Durability      // Tingkat flush/fsync setelah menulis (lihat WithDurability)
+	syncer     FileSyncer      // Pelaksana fsync untuk DurabilityFullSync (lihat WithFileSyncer)
+	opts       []StorageOption // Opsi pembuatan, dipakai ulang oleh Reopen
+	dedup      bool            // Simpan data sebagai blob berbasis konten (lihat WithDedup)
+	blobMu     sync.Mutex      // Menyerialkan penulisan dan pelepasan blob dedup
+	blobRefs   *blobRefTable   // Hitungan rujukan blob dedup di memori (lihat blobRefTable)
+	// writeBarrier dipegang bersama (RLock) oleh setiap kueri tulis Executor.Execute dan secara
+	// eksklusif oleh Snapshot, sehingga snapshot melihat direktori data pada satu titik waktu.
+	writeBarrier sync.RWMutex
 }
 
-func NewStorage(dataDir string) (*Storage, error) {
+func NewStorage(dataDir string, opts ...StorageOption) (*Storage, error) {
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create data directory: %v", err)
 	}
 	s := &Storage{
-		dataDir: dataDir,
-		index:   NewInMemoryIndex(), // Buat instance indeks baru
+		dataDir:    dataDir,
+		index:      NewInMemoryIndex(), // Buat instance indeks baru
+		durability: DurabilityFlush,
+		syncer:     osFileSyncer{},
+		advisor:    osMmapAdvisor{},
+		opts:       opts,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	blobRefs, err := loadBlobRefTable(s)
+	if err != nil {
+		return nil, err
+	}
+	s.blobRefs = blobRefs
 	// Bangun ulang indeks saat storage dibuat
 	if err := s.index.Rebuild(dataDir, s); err != nil {
 		// Pertimbangkan apakah error rebuild harus fatal atau hanya warning
-		fmt.Fprintf(os.Stderr, "Warning: failed to rebuild tensor index: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Warning: tensor index rebuilt with errors: %v\n", err)
 	}
 	return s, nil
 }
 
-// Fungsi pembantu internal untuk LoadTensorMetadata agar bisa dipanggil dari Rebuild
-func (s *Storage) loadTensorMetadataInternal(metadataFilePath string) (*TensorMetadata, error) {
-	data, err := os.ReadFile(metadataFilePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read metadata from %s: %w", metadataFilePath, err)
-	}
+// Reopen membuat Storage baru atas direktori data yang sama dengan opsi yang sama, sehingga indeks
+// dibangun ulang dari file di disk seperti saat proses baru membuka database.
+func (s *Storage) Reopen() (*Storage, error) {
+	return NewStorage(s.dataDir, s.opts...)
+}
 
-	// Ekstrak nama tensor dari path file untuk konsistensi, meskipun tidak selalu digunakan di sini
-	// tensorNameFromPath := strings.TrimSuffix(filepath.Base(metadataFilePath), ".meta")
+// metadataFileJSON adalah isi file .meta. JSON dipakai agar nama dan nilai boleh berisi karakter apa pun
+// (mis. titik dua atau baris baru); file lama berformat baris "key:value" tetap dapat dibaca.
+type metadataFileJSON struct {
+	Name     string  `json:"name"`
+	Shape    []int   `json:"shape"`
+	DataType string  `json:"datatype"`
+	Strides  []int   `json:"strides"`
+	Checksum *uint32 `json:"checksum,omitempty"`
+	DataRef  string  `json:"data_ref,omitempty"`
+}
 
+// parseJSONMetadata mengurai file .meta berformat JSON.
+func parseJSONMetadata(data []byte, metadataFilePath string) (*TensorMetadata, error) {
+	var mf metadataFileJSON
+	if err := json.Unmarshal(data, &mf); err != nil {
+		return nil, fmt.Errorf("invalid metadata format in %s: %w", metadataFilePath, err)
+	}
+	if mf.DataType != "" {
+		if _, errDt := GetElementSize(mf.DataType); errDt != nil {
+			return nil, fmt.Errorf("unsupported data type '%s' in metadata: %w", mf.DataType, errDt)
+		}
+	}
+	for _, dim := range mf.Shape {
+		if dim < 0 {
+			return nil, fmt.Errorf("invalid shape %v in metadata: dimension must not be negative", mf.Shape)
+		}
+	}
+	if mf.DataRef != "" && !dataRefRegex.MatchString(mf.DataRef) {
+		return nil, fmt.Errorf("invalid data_ref '%s' in metadata %s", mf.DataRef, metadataFilePath)
+	}
+	return &TensorMetadata{Name: mf.Name, Shape: mf.Shape, DataType: mf.DataType, Strides: mf.Strides, Checksum: mf.Checksum, DataRef: mf.DataRef}, nil
+}
+
+// parseLegacyMetadata mengurai file .meta lama berformat baris "key:value".
+func parseLegacyMetadata(data []byte, metadataFilePath string) (*TensorMetadata, error) {
+	var err error
 	tm := &TensorMetadata{} // Nama akan diisi dari file atau path jika perlu
 	lines := strings.Split(string(data), "\n")
 	for _, line := range lines {
@@ -284,7 +397,7 @@ func (s *Storage) loadTensorMetadataInternal(metadataFilePath string) (*TensorMe
 		case "name":
 			tm.Name = value // Ambil nama dari file metadata
 		case "shape":
-			tm.Shape, err = parseIntSlice(value)
+			tm.Shape, err = ParseShape(value)
 			if err != nil {
 				return nil, fmt.Errorf("invalid shape '%s' in metadata: %w", value, err)
 			}
@@ -294,12 +407,39 @@ func (s *Storage) loadTensorMetadataInternal(metadataFilePath string) (*TensorMe
 				return nil, fmt.Errorf("unsupported data type '%s' in metadata: %w", value, errDt)
 			}
 		case "strides":
-			tm.Strides, err = parseIntSlice(value)
+			tm.Strides, err = ParseShape(value)
 			if err != nil {
 				return nil, fmt.Errorf("invalid strides '%s' in metadata: %w", value, err)
 			}
+		case "checksum":
+			checksum, errSum := strconv.ParseUint(value, 16, 32)
+			if errSum != nil {
+				return nil, fmt.Errorf("invalid checksum '%s' in metadata: %w", value, errSum)
+			}
+			sum := uint32(checksum)
+			tm.Checksum = &sum
 		}
 	}
+	return tm, nil
+}
+
+// Fungsi pembantu internal untuk LoadTensorMetadata agar bisa dipanggil dari Rebuild
+func (s *Storage) loadTensorMetadataInternal(metadataFilePath string) (*TensorMetadata, error) {
+	data, err := os.ReadFile(metadataFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata from %s: %w", metadataFilePath, err)
+	}
+
+	var tm *TensorMetadata
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		tm, err = parseJSONMetadata(data, metadataFilePath)
+	} else {
+		// File .meta lama berformat baris "key:value".
+		tm, err = parseLegacyMetadata(data, metadataFilePath)
+	}
+	if err != nil {
+		return nil, err
+	}
 	if tm.Name == "" { // Jika nama tidak ada di file, coba ambil dari nama file
 		tm.Name = strings.TrimSuffix(filepath.Base(metadataFilePath), ".meta")
 	}
@@ -307,6 +447,10 @@ func (s *Storage) loadTensorMetadataInternal(metadataFilePath string) (*TensorMe
 	if tm.Shape == nil || tm.DataType == "" || tm.Name == "" {
 		return nil, fmt.Errorf("incomplete metadata in %s (name, shape, or datatype missing)", metadataFilePath)
 	}
+	elementSize, _ := GetElementSize(tm.DataType) // Sudah divalidasi saat membaca "datatype"
+	if _, err := checkedElementCount(tm.Shape, elementSize); err != nil {
+		return nil, fmt.Errorf("invalid shape in metadata %s: %w", metadataFilePath, err)
+	}
 	if tm.Strides == nil {
 		// Hitung strides default jika tidak ada di metadata
 		if len(tm.Shape) > 0 {
@@ -344,14 +488,24 @@ func (s *Storage) loadTensorMetadataInternal(metadataFilePath string) (*TensorMe
 // SaveTensor sekarang tidak secara langsung memperbarui indeks.
 // Executor akan bertanggung jawab untuk memanggil fungsi pembaruan indeks setelah SaveTensor berhasil.
 func SaveTensor[T Numeric](s *Storage, t *Tensor[T]) error {
-	metadataFile := filepath.Join(s.dataDir, t.Name+".meta")
+	return saveTensor(s, t, false)
+}
+
+// SaveNewTensor menyimpan tensor yang belum ada. File .meta dipublikasikan tanpa menimpa (lihat
+// publishFile) sehingga dari beberapa pembuat bersamaan dengan nama yang sama hanya satu yang berhasil; yang lain
+// mendapat error "already exists" (membungkus os.ErrExist) tanpa menimpa tensor yang sudah dibuat.
+func SaveNewTensor[T Numeric](s *Storage, t *Tensor[T]) error {
+	return saveTensor(s, t, true)
+}
+
+func saveTensor[T Numeric](s *Storage, t *Tensor[T], exclusive bool) error {
 	dataFile := filepath.Join(s.dataDir, t.Name+".data")
 
 	typeStrT, err := GetDataTypeString[T]()
 	if err != nil {
 		return fmt.Errorf("internal error getting type string for T in SaveTensor: %w", err)
 	}
-	if t.DataType != typeStrT {
+	if storageDataType(t.DataType) != typeStrT {
 		return fmt.Errorf("tensor's DataType string ('%s') does not match generic type T ('%s')", t.DataType, typeStrT)
 	}
 
@@ -386,18 +540,6 @@ func SaveTensor[T Numeric](s *Storage, t *Tensor[T]) error {
 		}
 	}
 
-	metadataContent := fmt.Sprintf("name:%s\nshape:%s\ndatatype:%s\nstrides:%s\n",
-		t.Name, intSliceToString(t.Shape), t.DataType, intSliceToString(t.Strides))
-	if err := os.WriteFile(metadataFile, []byte(metadataContent), 0644); err != nil {
-		return fmt.Errorf("failed to write metadata for %s: %w", t.Name, err)
-	}
-
-	file, err := os.Create(dataFile)
-	if err != nil {
-		return fmt.Errorf("failed to create data file %s: %w", dataFile, err)
-	}
-	defer file.Close()
-
 	elementSize, err := GetElementSize(t.DataType)
 	if err != nil {
 		return fmt.Errorf("cannot save tensor %s: %w", t.Name, err)
@@ -420,40 +562,15 @@ func SaveTensor[T Numeric](s *Storage, t *Tensor[T]) error {
 			numElements = 0
 		}
 	}
-	// Pastikan len(t.Data) konsisten dengan numElements yang dihitung dari shape
-	if len(t.Data) != numElements && numElements > 0 { // Hanya periksa jika numElements > 0
-		// Ini bisa terjadi jika SetData tidak dipanggil atau shape diubah setelah SetData
-		// return fmt.Errorf("data length %d does not match expected elements %d from shape %v for tensor %s", len(t.Data), numElements, t.Shape, t.Name)
-		// Untuk sementara, kita lanjutkan dengan len(t.Data) jika numElements dari shape adalah 0 tapi t.Data tidak
-		// Ini menunjukkan inkonsistensi, tapi kita prioritaskan data yang ada jika shape menyiratkan 0 elemen.
-		if numElements == 0 && len(t.Data) > 0 {
-			// Ini aneh, shape bilang 0 elemen tapi ada data. Sebaiknya error.
-			return fmt.Errorf("inconsistent state: shape %v implies 0 elements but data has length %d for tensor %s", t.Shape, len(t.Data), t.Name)
-		}
-		// Jika numElements > 0 tapi len(t.Data) != numElements, ini juga error.
-		// Sudah ditangani oleh SetData. Di sini kita asumsikan t.Data sudah benar.
-		numElements = len(t.Data) // Gunakan panjang data aktual untuk dataSize
+	// Ukuran file data selalu ditentukan oleh shape, sehingga tensor yang baru dibuat oleh CREATE
+	// (data dari NewTensor berisi nol) menghasilkan file berukuran shape×elementSize yang terisi nol.
+	if len(t.Data) != numElements {
+		return fmt.Errorf("data length %d does not match expected elements %d from shape %v for tensor %s", len(t.Data), numElements, t.Shape, t.Name)
 	}
 
 	dataSize := numElements * elementSize
 
-	if err := file.Truncate(int64(dataSize)); err != nil {
-		// Jangan error jika dataSize adalah 0 (tensor kosong)
-		if dataSize == 0 {
-			return nil // File kosong sudah benar untuk tensor kosong
-		}
-		return fmt.Errorf("failed to truncate data file %s for tensor %s: %w", dataFile, t.Name, err)
-	}
-	if dataSize == 0 {
-		return nil // Tidak ada data untuk ditulis
-	}
-
-	mmapFile, err := mmap.Map(file, mmap.RDWR, 0)
-	if err != nil {
-		return fmt.Errorf("failed to map data file %s for tensor %s: %w", dataFile, t.Name, err)
-	}
-	defer mmapFile.Unmap()
-
+	// Data diserialisasi sebelum metadata ditulis agar checksum-nya dapat disimpan di file .meta.
 	tempBufIter := new(bytes.Buffer)
 	tempBufIter.Grow(dataSize) // Alokasikan buffer dengan ukuran yang benar
 	for _, val := range t.Data {
@@ -466,20 +583,272 @@ func SaveTensor[T Numeric](s *Storage, t *Tensor[T]) error {
 	if len(actualDataBytes) != dataSize {
 		return fmt.Errorf("data size mismatch during save for tensor %s: expected %d bytes, got %d. DataType: %s, NumElements: %d, Shape: %v", t.Name, dataSize, len(actualDataBytes), t.DataType, numElements, t.Shape)
 	}
-	copy(mmapFile, actualDataBytes)
-	if err := mmapFile.Flush(); err != nil {
-		return fmt.Errorf("failed to flush mmap for tensor %s: %w", t.Name, err)
+
+	checksum := crc32.ChecksumIEEE(actualDataBytes)
+	if s.dedup {
+		return s.saveBlobTensor(&TensorMetadata{Name: t.Name, Shape: t.Shape, DataType: t.DataType, Strides: t.Strides, Checksum: &checksum}, actualDataBytes, exclusive)
+	}
+	// Metadata tensor yang ditimpa disimpan untuk rollback. Tensor itu mungkin merujuk blob dedup;
+	// rujukannya dilepas setelah data baru terpasang.
+	var oldMetadata *TensorMetadata
+	if !exclusive {
+		if old, err := s.LoadTensorMetadata(t.Name); err == nil {
+			oldMetadata = old
+		}
+	}
+
+	// Data ditulis ke file sementara lebih dulu, lalu dipasang bersama metadatanya oleh publishTensorData.
+	// Crash sebelum itu hanya meninggalkan file *.tmp* tanpa menyentuh file tensor yang sudah ada.
+	dataTmp, err := s.createTempFile(dataFile)
+	if err != nil {
+		return fmt.Errorf("failed to create data file %s: %w", dataFile, err)
+	}
+	dataTmpPath := dataTmp.Name()
+	errFill := s.fillDataFile(dataTmp, actualDataBytes, t.Name)
+	errClose := dataTmp.Close()
+	if errFill != nil || errClose != nil {
+		os.Remove(dataTmpPath)
+		return fmt.Errorf("failed to write data file for tensor %s: %w", t.Name, errors.Join(errFill, errClose))
+	}
+	return s.publishTensorData(&TensorMetadata{Name: t.Name, Shape: t.Shape, DataType: t.DataType, Strides: t.Strides, Checksum: &checksum}, dataTmpPath, oldMetadata, exclusive)
+}
+
+// publishTensorData memasang file data sementara dataTmpPath yang sudah lengkap (isinya cocok dengan
+// checksum metadata) sebagai file .data tensor. Metadata dipublikasikan lebih dulu (dengan exclusive
+// sekaligus mengklaim nama), lalu file data sementara diganti nama menjadi file .data. Jika penggantian
+// file data gagal, metadata dikembalikan ke oldMetadata (atau dihapus untuk tensor baru). Crash di antara
+// kedua rename dapat meninggalkan metadata baru bersama data lama; checksum di metadata membuat keadaan
+// ini terdeteksi saat pemuatan alih-alih mengembalikan data yang salah. File sementara selalu dihapus
+// pada jalur error.
+func (s *Storage) publishTensorData(metadata *TensorMetadata, dataTmpPath string, oldMetadata *TensorMetadata, exclusive bool) error {
+	dataFile := filepath.Join(s.dataDir, metadata.Name+".data")
+	if err := s.writeMetadataFile(metadata, exclusive); err != nil {
+		os.Remove(dataTmpPath)
+		return err
+	}
+	if err := os.Rename(dataTmpPath, dataFile); err != nil {
+		os.Remove(dataTmpPath)
+		errReplace := fmt.Errorf("failed to replace data file %s for tensor %s: %w", dataFile, metadata.Name, err)
+		if errRollback := s.rollbackMetadata(metadata.Name, oldMetadata); errRollback != nil {
+			return errors.Join(errReplace, errRollback)
+		}
+		return errReplace
+	}
+	if oldMetadata != nil && oldMetadata.DataRef != "" {
+		if err := s.releaseBlob(oldMetadata.DataRef); err != nil {
+			return err
+		}
+	}
+	return s.syncDataDir()
+}
+
+// rollbackMetadata memulihkan file .meta tensor name ke oldMetadata setelah penggantian file data
+// gagal, atau menghapusnya jika tensor baru dibuat (oldMetadata nil).
+func (s *Storage) rollbackMetadata(name string, oldMetadata *TensorMetadata) error {
+	if oldMetadata == nil {
+		metadataFile := filepath.Join(s.dataDir, name+".meta")
+		if err := os.Remove(metadataFile); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to roll back metadata for %s: %w", name, err)
+		}
+		s.blobRefs.remove(name)
+		return s.syncDataDir()
+	}
+	if err := s.writeMetadataFile(oldMetadata, false); err != nil {
+		return fmt.Errorf("failed to roll back metadata for %s: %w", name, err)
+	}
+	return s.syncDataDir()
+}
+
+// fillDataFile mengisi file data kosong dengan data melalui mmap lalu melakukan flush/fsync sesuai
+// tingkat durabilitas.
+func (s *Storage) fillDataFile(file *os.File, data []byte, tensorName string) error {
+	if err := file.Truncate(int64(len(data))); err != nil {
+		return fmt.Errorf("failed to truncate data file %s for tensor %s: %w", file.Name(), tensorName, err)
+	}
+	if len(data) == 0 {
+		// Tidak ada data untuk ditulis, tetapi file kosong tetap harus tahan crash.
+		return s.syncFile(file)
+	}
+	mmapFile, err := mmap.Map(file, mmap.RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("failed to map data file %s for tensor %s: %w", file.Name(), tensorName, err)
+	}
+	copy(mmapFile, data)
+	errFlush := s.flushMmap(mmapFile, file, tensorName)
+	errUnmap := mmapFile.Unmap()
+	return errors.Join(errFlush, errUnmap)
+}
+
+// createTempFile membuat file sementara unik di samping path (mis. name.meta.tmp123) dengan izin 0644.
+func (s *Storage) createTempFile(path string) (*os.File, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Chmod(0644); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
+
+// publishFile memindahkan file sementara tmpPath ke path secara atomik. Dengan exclusive, path yang
+// sudah ada tidak ditimpa: hard link gagal dengan os.ErrExist sehingga dari beberapa pembuat bersamaan
+// hanya satu yang berhasil.
+func publishFile(tmpPath, path string, exclusive bool) error {
+	if !exclusive {
+		return os.Rename(tmpPath, path)
+	}
+	if err := os.Link(tmpPath, path); err != nil {
+		return err
+	}
+	return os.Remove(tmpPath)
+}
+
+// writeMetadataFile menulis file .meta untuk metadata secara atomik melalui file sementara. Dengan
+// exclusive, nama yang sudah ada ditolak ("already exists"); tanpa exclusive, file lama diganti.
+func (s *Storage) writeMetadataFile(metadata *TensorMetadata, exclusive bool) error {
+	metadataFile := filepath.Join(s.dataDir, metadata.Name+".meta")
+	mf := metadataFileJSON{Name: metadata.Name, Shape: metadata.Shape, DataType: metadata.DataType, Strides: metadata.Strides, Checksum: metadata.Checksum, DataRef: metadata.DataRef}
+	// Tulis [] alih-alih null untuk tensor skalar agar shape tetap tercatat.
+	if mf.Shape == nil {
+		mf.Shape = []int{}
+	}
+	if mf.Strides == nil {
+		mf.Strides = []int{}
+	}
+	metadataContent, err := json.Marshal(mf)
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata for %s: %w", metadata.Name, err)
+	}
+	metaFile, err := s.createTempFile(metadataFile)
+	if err != nil {
+		return fmt.Errorf("failed to create metadata for %s: %w", metadata.Name, err)
+	}
+	tmpPath := metaFile.Name()
+	_, errWrite := metaFile.Write(metadataContent)
+	var errSync error
+	if errWrite == nil {
+		errSync = s.syncFile(metaFile)
+	}
+	errClose := metaFile.Close()
+	if errWrite != nil || errSync != nil || errClose != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write metadata for %s: %w", metadata.Name, errors.Join(errWrite, errSync, errClose))
+	}
+	if err := publishFile(tmpPath, metadataFile, exclusive); err != nil {
+		os.Remove(tmpPath)
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("tensor '%s' already exists: %w", metadata.Name, err)
+		}
+		return fmt.Errorf("failed to replace metadata for %s: %w", metadata.Name, err)
+	}
+	s.blobRefs.set(metadata.Name, metadata.DataRef)
+	s.index.Refresh(metadata)
+	return nil
+}
+
+// verifyChecksum membandingkan CRC32 data dengan checksum di metadata. Metadata tanpa checksum
+// (ditulis sebelum checksum diperkenalkan) dianggap belum terverifikasi dan selalu lolos.
+func verifyChecksum(metadata *TensorMetadata, data []byte) error {
+	if metadata.Checksum == nil {
+		return nil
+	}
+	if actual := crc32.ChecksumIEEE(data); actual != *metadata.Checksum {
+		return fmt.Errorf("checksum mismatch for tensor %s: data file is corrupted (expected crc32 %08x, got %08x)", metadata.Name, *metadata.Checksum, actual)
+	}
+	return nil
+}
+
+// refreshChecksum menghitung ulang checksum metadata dari data lalu menulis ulang file .meta. Dipanggil
+// setelah data tensor diubah langsung melalui mmap (UPDATE, INSERT ke slice, operasi IN PLACE).
+// Checksum mencakup seluruh file, sehingga biayanya O(ukuran file) bahkan untuk UPDATE satu elemen
+// yang hanya menulis byte elemen itu (lihat BenchmarkUpdateElement_Large di tests).
+func (s *Storage) refreshChecksum(metadata *TensorMetadata, data []byte) error {
+	checksum := crc32.ChecksumIEEE(data)
+	updated := *metadata
+	updated.Checksum = &checksum
+	if err := s.writeMetadataFile(&updated, false); err != nil {
+		return err
+	}
+	return s.syncDataDir()
+}
+
+// writeExternalFile menulis data ke path di luar direktori data (mis. file shard ekspor), menimpa
+// isinya jika sudah ada, dan melakukan fsync sesuai tingkat durabilitas.
+func (s *Storage) writeExternalFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	_, errWrite := f.Write(data)
+	var errSync error
+	if errWrite == nil {
+		errSync = s.syncFile(f)
+	}
+	errClose := f.Close()
+	if errWrite != nil || errSync != nil || errClose != nil {
+		return fmt.Errorf("failed to write %s: %w", path, errors.Join(errWrite, errSync, errClose))
 	}
 	return nil
 }
 
+// loadRunningStats membaca file sidecar .stats tensor name. Tensor tanpa TRACK_STATS tidak memiliki
+// file tersebut dan menghasilkan nil tanpa error.
+func (s *Storage) loadRunningStats(name string) (*RunningStats, error) {
+	statsFile := filepath.Join(s.dataDir, name+".stats")
+	content, err := os.ReadFile(statsFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read stats file %s: %w", statsFile, err)
+	}
+	var stats RunningStats
+	if err := json.Unmarshal(content, &stats); err != nil {
+		return nil, fmt.Errorf("invalid stats file %s: %w", statsFile, err)
+	}
+	return &stats, nil
+}
+
+// writeRunningStats menulis (atau mengganti) file sidecar .stats tensor name secara atomik melalui
+// file sementara.
+func (s *Storage) writeRunningStats(name string, stats *RunningStats) error {
+	statsFile := filepath.Join(s.dataDir, name+".stats")
+	content, err := json.Marshal(stats)
+	if err != nil {
+		return fmt.Errorf("failed to encode stats for %s: %w", name, err)
+	}
+	f, err := s.createTempFile(statsFile)
+	if err != nil {
+		return fmt.Errorf("failed to create stats file for %s: %w", name, err)
+	}
+	tmpPath := f.Name()
+	_, errWrite := f.Write(content)
+	var errSync error
+	if errWrite == nil {
+		errSync = s.syncFile(f)
+	}
+	errClose := f.Close()
+	if errWrite != nil || errSync != nil || errClose != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write stats for %s: %w", name, errors.Join(errWrite, errSync, errClose))
+	}
+	if err := publishFile(tmpPath, statsFile, false); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace stats for %s: %w", name, err)
+	}
+	return s.syncDataDir()
+}
+
 func (s *Storage) LoadTensorMetadata(name string) (*TensorMetadata, error) {
 	metadataFile := filepath.Join(s.dataDir, name+".meta")
 	return s.loadTensorMetadataInternal(metadataFile) // Gunakan fungsi internal
 }
 
 func (s *Storage) OpenFileAndMmap(name string, expectedTotalElements int, elementSize int) (*os.File, mmap.MMap, error) {
-	dataFile := filepath.Join(s.dataDir, name+".data")
+	dataFile := s.dataFilePath(name)
 	file, err := os.OpenFile(dataFile, os.O_RDWR, 0644) // Buka untuk baca/tulis
 	if err != nil {
 		// Jika file tidak ada DAN kita mengharapkan 0 elemen (tensor kosong baru), ini bukan error.
@@ -504,7 +873,7 @@ func (s *Storage) OpenFileAndMmap(name string, expectedTotalElements int, elemen
 		return nil, nil, fmt.Errorf("failed to stat data file %s: %w", dataFile, err)
 	}
 
-	expectedDataSize := int64(expectedTotalElements * elementSize)
+	expectedDataSize := int64(expectedTotalElements) * int64(elementSize)
 
 	// Jika tensor kosong, ukuran file bisa 0.
 	if expectedTotalElements == 0 {
@@ -561,6 +930,38 @@ func ReadData[T Numeric](mmapFile mmap.MMap, numElements int, dataTypeString str
 	return dataSlice, nil
 }
 
+// hostLittleEndian bernilai true jika urutan byte native host little-endian, sama dengan format file
+// .data, sehingga byte mmap dapat ditafsirkan langsung sebagai []T.
+var hostLittleEndian = func() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}()
+
+// ReadDataUnsafe adalah versi cepat ReadData: pada host little-endian byte mmap ditafsirkan langsung
+// sebagai []T lewat unsafe lalu disalin dalam satu copy, alih-alih didekode per elemen dengan
+// binary.Read. Hasilnya tetap salinan karena mmap dapat di-unmap (mis. diusir dari cache handle)
+// selama tensor hasil masih dipakai. Pada host big-endian fungsi ini sama dengan ReadData.
+func ReadDataUnsafe[T Numeric](mmapFile mmap.MMap, numElements int, dataTypeString string) ([]T, error) {
+	if !hostLittleEndian || numElements == 0 || mmapFile == nil {
+		return ReadData[T](mmapFile, numElements, dataTypeString)
+	}
+	elementSize, err := GetElementSize(dataTypeString)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get element size for type %s in ReadDataUnsafe: %w", dataTypeString, err)
+	}
+	var zero T
+	if int(unsafe.Sizeof(zero)) != elementSize {
+		return nil, fmt.Errorf("element type %T does not match data type %s", zero, dataTypeString)
+	}
+	expectedBytes := numElements * elementSize
+	if len(mmapFile) < expectedBytes {
+		return nil, fmt.Errorf("mmap size %d is less than expected data size %d (%d elements * %d bytes/element) for type %s", len(mmapFile), expectedBytes, numElements, elementSize, dataTypeString)
+	}
+	dataSlice := make([]T, numElements)
+	copy(dataSlice, unsafe.Slice((*T)(unsafe.Pointer(&mmapFile[0])), numElements))
+	return dataSlice, nil
+}
+
 func (s *Storage) GetTensorMmap(name string) (*TensorMetadata, *os.File, mmap.MMap, error) {
 	metadata, err := s.LoadTensorMetadata(name)
 	if err != nil {
@@ -601,6 +1002,68 @@ func (s *Storage) GetTensorMmap(name string) (*TensorMetadata, *os.File, mmap.MM
 	return metadata, file, mmapInstance, nil
 }
 
+// OpenReadOnlyMmap memetakan file .data tensor name dengan mmap read-only tanpa verifikasi checksum.
+// File ditutup segera setelah dipetakan; pemanggil wajib melepas mmap dengan Unmap. Tensor tanpa elemen
+// menghasilkan mmap nil.
+func (s *Storage) OpenReadOnlyMmap(name string) (*TensorMetadata, mmap.MMap, error) {
+	metadata, err := s.LoadTensorMetadata(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("OpenReadOnlyMmap: failed to load metadata for %s: %w", name, err)
+	}
+	elementSize, err := GetElementSize(metadata.DataType)
+	if err != nil {
+		return nil, nil, err
+	}
+	expectedSize := int64(tNilaiTotalElemen(metadata.Shape)) * int64(elementSize)
+	if expectedSize == 0 {
+		return metadata, nil, nil
+	}
+	dataFile := s.dataFilePath(name)
+	file, err := os.Open(dataFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open data file %s: %w", dataFile, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to stat data file %s: %w", dataFile, err)
+	}
+	if info.Size() != expectedSize {
+		return nil, nil, fmt.Errorf("data file size mismatch for %s: expected %d, got %d", name, expectedSize, info.Size())
+	}
+	mmapInstance, err := mmap.Map(file, mmap.RDONLY, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to map data file %s: %w", dataFile, err)
+	}
+	return metadata, mmapInstance, nil
+}
+
+// mapDataFileReadOnly memetakan file .data tensor name apa adanya, tanpa memeriksa ukurannya terhadap
+// shape. File yang tidak ada atau kosong menghasilkan data nil. release wajib dipanggil.
+func (s *Storage) mapDataFileReadOnly(name string) (mmap.MMap, func(), error) {
+	dataFile := s.dataFilePath(name)
+	file, err := os.Open(dataFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, func() {}, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open data file %s: %w", dataFile, err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to stat data file %s: %w", dataFile, err)
+	}
+	if info.Size() == 0 {
+		return nil, func() {}, nil
+	}
+	m, err := mmap.Map(file, mmap.RDONLY, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to map data file %s: %w", dataFile, err)
+	}
+	return m, func() { m.Unmap() }, nil
+}
+
 func intSliceToString(slice []int) string {
 	if slice == nil { // Untuk shape skalar []
 		return ""
@@ -612,32 +1075,297 @@ func intSliceToString(slice []int) string {
 	return strings.Join(parts, ",")
 }
 
-func parseIntSlice(s string) ([]int, error) {
+// ParseShape adalah satu-satunya pengurai string shape, dipakai oleh parser (CREATE TENSOR) dan
+// pemuat metadata (shape dan strides). String kosong berarti skalar ([]int{}), "0" adalah shape [0],
+// spasi di sekitar dimensi diabaikan, dan dimensi kosong (mis. "1," atau ",") maupun negatif ditolak.
+func ParseShape(s string) ([]int, error) {
 	s = strings.TrimSpace(s)
-	if s == "" { // Untuk shape skalar yang disimpan sebagai string kosong
+	if s == "" {
 		return []int{}, nil
 	}
 	parts := strings.Split(s, ",")
 	result := make([]int, len(parts))
-	var err error
 	for i, p := range parts {
 		trimmedPart := strings.TrimSpace(p)
-		if trimmedPart == "" && len(parts) == 1 && s != "0" { // "0" adalah shape yang valid
-			// Jika string asli hanya spasi atau kosong, sudah ditangani di atas.
-			// Ini untuk kasus seperti "," atau "1,"
-			return nil, fmt.Errorf("empty dimension string part in '%s'", s)
+		if trimmedPart == "" {
+			return nil, fmt.Errorf("invalid dimension: empty string found in shape '%s'", s)
 		}
-		if trimmedPart == "" && len(parts) > 1 {
-			return nil, fmt.Errorf("empty dimension string part in '%s'", s)
-		}
-		result[i], err = strconv.Atoi(trimmedPart)
+		dim, err := strconv.Atoi(trimmedPart)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing '%s' as int in shape string '%s': %w", trimmedPart, s, err)
+			return nil, fmt.Errorf("invalid dimension '%s' in shape '%s': %w", trimmedPart, s, err)
+		}
+		if dim < 0 {
+			return nil, fmt.Errorf("invalid dimension '%s' in shape '%s': must be non-negative", trimmedPart, s)
 		}
+		result[i] = dim
 	}
 	return result, nil
 }
 
+// MetadataModTime mengembalikan waktu modifikasi terakhir file .meta tensor. Storage tidak menyimpan
+// waktu pembuatan tersendiri; file .meta ditulis saat tensor dibuat dan ditulis ulang setiap kali
+// metadatanya berubah (mis. checksum setelah INSERT), jadi nilai ini adalah waktu perubahan metadata
+// terakhir.
+func (s *Storage) MetadataModTime(name string) (time.Time, error) {
+	info, err := os.Stat(filepath.Join(s.dataDir, name+".meta"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to stat .meta file for tensor %s: %w", name, err)
+	}
+	return info.ModTime(), nil
+}
+
+// TensorFileSize mengembalikan ukuran total file tensor di disk (.meta ditambah .data) dalam byte.
+// Pada mode dedup ukuran blob yang dirujuk ikut dihitung penuh walaupun blob dipakai bersama.
+func (s *Storage) TensorFileSize(name string) (int64, error) {
+	var total int64
+	for _, path := range []string{filepath.Join(s.dataDir, name+".meta"), s.dataFilePath(name)} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to stat %s file for tensor %s: %w", filepath.Ext(path), name, err)
+		}
+		total += info.Size()
+	}
+	return total, nil
+}
+
+// EachTensorMetadata memanggil fn untuk metadata setiap tensor yang cocok dengan filter indeks
+// (filterNumDimensions -1 berarti tanpa filter dimensi). Metadata dimuat satu per satu dari file .meta,
+// sehingga tidak ada daftar metadata lengkap yang dialokasikan. Hanya nama kandidat yang disalin dari
+// indeks agar fn boleh mengubah storage. Iterasi berhenti dan mengembalikan error pertama dari fn.
+func (s *Storage) EachTensorMetadata(filterDataType string, filterNumDimensions int, fn func(meta *TensorMetadata) error) error {
+	for _, name := range s.index.Query(filterDataType, filterNumDimensions) {
+		meta, err := s.LoadTensorMetadata(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not load metadata for tensor '%s' during iteration: %v\n", name, err)
+			continue
+		}
+		if err := fn(meta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteTensorFiles menghapus file .meta, .data, dan .stats tensor dari disk. File .meta dihapus lebih
+// dulu sehingga tensor tidak lagi terlihat walaupun penghapusan .data gagal; file .data yang memang tidak
+// ada (tensor tanpa elemen) dan file .stats yang tidak ada (tensor tanpa TRACK_STATS) bukan error. Blob
+// dedup yang dirujuk tensor hanya dihapus jika tidak ada tensor lain yang masih merujuknya.
+func (s *Storage) DeleteTensorFiles(name string) error {
+	metaFile := filepath.Join(s.dataDir, name+".meta")
+	var dataRef string
+	if metadata, err := s.loadTensorMetadataInternal(metaFile); err == nil {
+		dataRef = metadata.DataRef
+	}
+	if err := os.Remove(metaFile); err != nil {
+		return fmt.Errorf("failed to remove metadata file %s: %w", metaFile, err)
+	}
+	s.blobRefs.remove(name)
+	dataFile := filepath.Join(s.dataDir, name+".data")
+	if err := os.Remove(dataFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove data file %s: %w", dataFile, err)
+	}
+	statsFile := filepath.Join(s.dataDir, name+".stats")
+	if err := os.Remove(statsFile); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stats file %s: %w", statsFile, err)
+	}
+	if dataRef != "" {
+		if err := s.releaseBlob(dataRef); err != nil {
+			return err
+		}
+	}
+	return s.syncDataDir()
+}
+
+// RenameTensorFiles memindahkan file tensor oldName ke newName dan menulis ulang nama di metadata.
+// File .meta baru dipublikasikan tanpa menimpa lebih dulu sehingga nama tujuan yang sudah ada
+// ditolak ("already exists") tanpa menimpa apa pun; setelah .data (dan .stats jika ada) dipindahkan,
+// .meta lama dihapus.
+func (s *Storage) RenameTensorFiles(oldName, newName string) error {
+	oldMetaFile := filepath.Join(s.dataDir, oldName+".meta")
+	newMetaFile := filepath.Join(s.dataDir, newName+".meta")
+	metadata, err := s.loadTensorMetadataInternal(oldMetaFile)
+	if err != nil {
+		return err
+	}
+	// Metadata ditulis ulang dalam format JSON (sekaligus memigrasikan file format lama).
+	metadata.Name = newName
+	if err := s.writeMetadataFile(metadata, true); err != nil {
+		return err
+	}
+
+	oldDataFile := filepath.Join(s.dataDir, oldName+".data")
+	newDataFile := filepath.Join(s.dataDir, newName+".data")
+	if err := os.Rename(oldDataFile, newDataFile); err != nil && !os.IsNotExist(err) {
+		os.Remove(newMetaFile)
+		s.blobRefs.remove(newName)
+		return fmt.Errorf("failed to rename data file %s to %s: %w", oldDataFile, newDataFile, err)
+	}
+	oldStatsFile := filepath.Join(s.dataDir, oldName+".stats")
+	newStatsFile := filepath.Join(s.dataDir, newName+".stats")
+	if err := os.Rename(oldStatsFile, newStatsFile); err != nil && !os.IsNotExist(err) {
+		os.Rename(newDataFile, oldDataFile)
+		os.Remove(newMetaFile)
+		s.blobRefs.remove(newName)
+		return fmt.Errorf("failed to rename stats file %s to %s: %w", oldStatsFile, newStatsFile, err)
+	}
+	if err := os.Remove(oldMetaFile); err != nil {
+		return fmt.Errorf("failed to remove metadata file %s: %w", oldMetaFile, err)
+	}
+	s.blobRefs.remove(oldName)
+	return s.syncDataDir()
+}
+
+// SliceToNewTensor menyalin sub-region ranges (satu rentang [start, end) per dimensi, diresolusi dengan
+// ResolveSliceRanges) dari tensor src ke tensor baru dst langsung antar file: sumber dibaca melalui mmap
+// read-only dan setiap baris slice (rentang dimensi terakhir) disalin sebagai satu blok byte, sehingga
+// tensor sumber tidak pernah dimuat utuh ke memori. dst harus belum ada. Data disalin ke file sementara
+// sehingga dst baru terlihat setelah lengkap; pada mode dedup (WithDedup) data dst disimpan sebagai blob.
+// Seperti operasi file Storage lainnya, dst tidak didaftarkan ke indeks (lihat AddTensorToIndex).
+func (s *Storage) SliceToNewTensor(src, dst string, ranges [][2]int) error {
+	srcMeta, err := s.LoadTensorMetadata(src)
+	if err != nil {
+		return fmt.Errorf("failed to load metadata for source tensor %s: %w", src, err)
+	}
+	if len(ranges) != len(srcMeta.Shape) {
+		return fmt.Errorf("slice ranges length %d does not match tensor dimensions %d", len(ranges), len(srcMeta.Shape))
+	}
+	elementSize, err := GetElementSize(srcMeta.DataType)
+	if err != nil {
+		return err
+	}
+	srcElements := 1
+	for _, dim := range srcMeta.Shape {
+		srcElements *= dim
+	}
+	ranges, err = ResolveSliceRanges(ranges, srcMeta.Shape)
+	if err != nil {
+		return err
+	}
+	dstShape := make([]int, len(ranges))
+	dstElements := 1
+	for i, r := range ranges {
+		dstShape[i] = r[1] - r[0]
+		dstElements *= dstShape[i]
+	}
+	dstStrides := make([]int, len(dstShape))
+	if dstElements > 0 && len(dstShape) > 0 {
+		dstStrides[len(dstShape)-1] = 1
+		for i := len(dstShape) - 2; i >= 0; i-- {
+			dstStrides[i] = dstStrides[i+1] * dstShape[i+1]
+		}
+	}
+
+	// Data disalin ke file sementara lebih dulu agar metadata dst dipublikasikan sekali, lengkap dengan
+	// checksum, melalui jalur yang sama dengan saveTensor (atau sebagai blob pada mode dedup).
+	dstDataFile := filepath.Join(s.dataDir, dst+".data")
+	if s.dedup {
+		dstDataFile = filepath.Join(s.dataDir, BlobDirName, dst+".data")
+		if err := os.MkdirAll(filepath.Dir(dstDataFile), 0755); err != nil {
+			return fmt.Errorf("failed to create blob directory: %w", err)
+		}
+	}
+	dstFile, err := s.createTempFile(dstDataFile)
+	if err != nil {
+		return fmt.Errorf("failed to create data file %s: %w", dstDataFile, err)
+	}
+	tmpPath := dstFile.Name()
+	checksum, ref, errCopy := s.copySliceData(srcMeta, srcElements, dstFile, dst, ranges, dstElements, elementSize)
+	errClose := dstFile.Close()
+	if errCopy != nil || errClose != nil {
+		os.Remove(tmpPath)
+		return errors.Join(errCopy, errClose)
+	}
+
+	dstMeta := &TensorMetadata{Name: dst, Shape: dstShape, DataType: srcMeta.DataType, Strides: dstStrides, Checksum: &checksum}
+	if !s.dedup {
+		return s.publishTensorData(dstMeta, tmpPath, nil, true)
+	}
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	if err := s.publishBlob(tmpPath, ref, dst); err != nil {
+		return err
+	}
+	return s.publishBlobMetadata(dstMeta, ref, "", true)
+}
+
+// copySliceData mengisi file data kosong dstFile (milik tensor dst) hingga berukuran dstElements dengan
+// elemen ranges dari file data sumber, lalu mengembalikan checksum CRC32 data dst serta, pada mode
+// dedup, hash SHA-256-nya sebagai rujukan blob.
+func (s *Storage) copySliceData(srcMeta *TensorMetadata, srcElements int, dstFile *os.File, dst string, ranges [][2]int, dstElements int, elementSize int) (uint32, string, error) {
+	dstDataFile := dstFile.Name()
+	if dstElements == 0 {
+		return crc32.ChecksumIEEE(nil), blobRef(nil), s.syncFile(dstFile)
+	}
+	if err := dstFile.Truncate(int64(dstElements * elementSize)); err != nil {
+		return 0, "", fmt.Errorf("failed to truncate data file %s for tensor %s: %w", dstDataFile, dst, err)
+	}
+
+	srcDataFile := s.dataFilePath(srcMeta.Name)
+	srcFile, err := os.Open(srcDataFile)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to open data file %s: %w", srcDataFile, err)
+	}
+	defer srcFile.Close()
+	info, err := srcFile.Stat()
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to stat data file %s: %w", srcDataFile, err)
+	}
+	if expected := int64(srcElements) * int64(elementSize); info.Size() != expected {
+		return 0, "", fmt.Errorf("data file size mismatch for %s: expected %d, got %d", srcMeta.Name, expected, info.Size())
+	}
+	srcMmap, err := mmap.Map(srcFile, mmap.RDONLY, 0)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to map data file %s: %w", srcDataFile, err)
+	}
+	defer srcMmap.Unmap()
+	// Baris slice tersebar di file sumber, jadi read-ahead sekuensial hanya membaca halaman yang dilompati.
+	s.AdviseMmap(srcMmap, AccessRandom)
+	dstMmap, err := mmap.Map(dstFile, mmap.RDWR, 0)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to map data file %s for tensor %s: %w", dstDataFile, dst, err)
+	}
+	defer dstMmap.Unmap()
+
+	// Dimensi terakhir bersebelahan di file sumber (stride 1), jadi satu baris slice adalah satu blok
+	// byte. Indeks dimensi luar diiterasi seperti odometer; offset tujuan cukup bertambah per blok.
+	last := len(ranges) - 1
+	runElements, runStride := 1, 1
+	if last >= 0 {
+		runElements = ranges[last][1] - ranges[last][0]
+		runStride = srcMeta.Strides[last]
+	}
+	indices := make([]int, len(ranges))
+	for i, r := range ranges {
+		indices[i] = r[0]
+	}
+	for dstOffset := 0; dstOffset < dstElements; dstOffset += runElements {
+		srcOffset := 0
+		for i, idx := range indices {
+			srcOffset += idx * srcMeta.Strides[i]
+		}
+		if runStride == 1 {
+			copy(dstMmap[dstOffset*elementSize:(dstOffset+runElements)*elementSize], srcMmap[srcOffset*elementSize:(srcOffset+runElements)*elementSize])
+		} else {
+			for k := 0; k < runElements; k++ {
+				from := (srcOffset + k*runStride) * elementSize
+				copy(dstMmap[(dstOffset+k)*elementSize:(dstOffset+k+1)*elementSize], srcMmap[from:from+elementSize])
+			}
+		}
+		for i := last - 1; i >= 0; i-- {
+			indices[i]++
+			if indices[i] < ranges[i][1] {
+				break
+			}
+			indices[i] = ranges[i][0]
+		}
+	}
+	var ref string
+	if s.dedup {
+		ref = blobRef(dstMmap)
+	}
+	return crc32.ChecksumIEEE(dstMmap), ref, s.flushMmap(dstMmap, dstFile, dst)
+}
+
 // Metode untuk mengakses indeks dari Storage
 func (s *Storage) AddTensorToIndex(metadata *TensorMetadata) {
 	s.index.Add(metadata)
@@ -647,6 +1375,12 @@ func (s *Storage) RemoveTensorFromIndex(metadata *TensorMetadata) {
 	s.index.Remove(metadata)
 }
 
+// IndexedTensorMetadata mengembalikan salinan metadata tensor name dari indeks dalam memori tanpa
+// membaca disk, atau false jika name tidak terindeks.
+func (s *Storage) IndexedTensorMetadata(name string) (*TensorMetadata, bool) {
+	return s.index.Get(name)
+}
+
 func (s *Storage) QueryIndex(filterDataType string, filterNumDimensions int) []string {
 	return s.index.Query(filterDataType, filterNumDimensions)
 }
@@ -1,24 +1,61 @@
 package tensor
 
 import (
+	"container/heap"
 	"errors"
 	"fmt"
 	"math"
+	"runtime"
+	"sync"
 )
 
 // Numeric adalah batasan tipe untuk tipe data numerik yang didukung oleh Tensor.
 type Numeric interface {
-	~float32 | ~float64 | ~int32 | ~int64
+	~float32 | ~float64 | ~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint32 | ~uint64
 }
 
 // Supported Data Types (string constants remain useful for metadata and parsing)
 const (
 	DataTypeFloat32 string = "float32"
 	DataTypeFloat64 string = "float64"
+	DataTypeInt8    string = "int8"
+	DataTypeInt16   string = "int16"
 	DataTypeInt32   string = "int32"
 	DataTypeInt64   string = "int64"
+	DataTypeUint8   string = "uint8"
+	DataTypeUint32  string = "uint32"
+	DataTypeUint64  string = "uint64"
+	// DataTypeBool disimpan sebagai uint8 (0 = false, 1 = true); Tensor[uint8] membawa tipe ini.
+	DataTypeBool string = "bool"
 )
 
+// Kelompok tipe data yang digunakan untuk mendeklarasikan dukungan tipe data per operasi.
+var (
+	floatDataTypes    = []string{DataTypeFloat32, DataTypeFloat64}
+	unsignedDataTypes = []string{DataTypeUint8, DataTypeUint32, DataTypeUint64}
+	integerDataTypes  = append([]string{DataTypeInt8, DataTypeInt16, DataTypeInt32, DataTypeInt64}, unsignedDataTypes...)
+	numericDataTypes  = append(append([]string{}, floatDataTypes...), integerDataTypes...)
+)
+
+// isIntegerDataType melaporkan apakah dataType termasuk integerDataTypes.
+func isIntegerDataType(dataType string) bool {
+	for _, dt := range integerDataTypes {
+		if dt == dataType {
+			return true
+		}
+	}
+	return false
+}
+
+// storageDataType mengembalikan tipe data penyimpanan untuk dataType. Tipe bool disimpan sebagai
+// uint8; tipe lain disimpan apa adanya.
+func storageDataType(dataType string) string {
+	if dataType == DataTypeBool {
+		return DataTypeUint8
+	}
+	return dataType
+}
+
 // GetElementSize mengembalikan ukuran dalam byte dari satu elemen tipe data yang diberikan.
 func GetElementSize(dataType string) (int, error) {
 	switch dataType {
@@ -26,10 +63,20 @@ func GetElementSize(dataType string) (int, error) {
 		return 4, nil
 	case DataTypeFloat64:
 		return 8, nil
+	case DataTypeInt8:
+		return 1, nil
+	case DataTypeInt16:
+		return 2, nil
 	case DataTypeInt32:
 		return 4, nil
 	case DataTypeInt64:
 		return 8, nil
+	case DataTypeUint8, DataTypeBool:
+		return 1, nil
+	case DataTypeUint32:
+		return 4, nil
+	case DataTypeUint64:
+		return 8, nil
 	default:
 		return 0, fmt.Errorf("unsupported data type string: %s", dataType)
 	}
@@ -43,10 +90,20 @@ func GetDataTypeString[T Numeric]() (string, error) {
 		return DataTypeFloat32, nil
 	case float64:
 		return DataTypeFloat64, nil
+	case int8:
+		return DataTypeInt8, nil
+	case int16:
+		return DataTypeInt16, nil
 	case int32:
 		return DataTypeInt32, nil
 	case int64:
 		return DataTypeInt64, nil
+	case uint8:
+		return DataTypeUint8, nil
+	case uint32:
+		return DataTypeUint32, nil
+	case uint64:
+		return DataTypeUint64, nil
 	default:
 		// Ini seharusnya tidak terjadi jika T dibatasi oleh Numeric
 		return "", fmt.Errorf("unsupported generic type: %T", zero)
@@ -93,7 +150,7 @@ func NewTensor[T Numeric](name string, shape []int, dataTypeString string) (*Ten
 	if err != nil {
 		return nil, fmt.Errorf("internal error getting type string for T: %w", err)
 	}
-	if typeStrT != dataTypeString {
+	if typeStrT != storageDataType(dataTypeString) {
 		return nil, fmt.Errorf("type parameter T (%s) does not match dataTypeString (%s)", typeStrT, dataTypeString)
 	}
 
@@ -103,21 +160,13 @@ func NewTensor[T Numeric](name string, shape []int, dataTypeString string) (*Ten
 		}
 	}
 
-	totalElements := 1
-	hasZeroDim := false
-	if len(shape) > 0 {
-		for _, dim := range shape {
-			if dim == 0 {
-				hasZeroDim = true
-				break
-			}
-			totalElements *= dim
-		}
-		if hasZeroDim {
-			totalElements = 0
-		}
-	} else {
-		totalElements = 1
+	elementSize, err := GetElementSize(dataTypeString)
+	if err != nil {
+		return nil, err
+	}
+	totalElements, err := checkedElementCount(shape, elementSize)
+	if err != nil {
+		return nil, err
 	}
 
 	dataSlice := make([]T, totalElements)
@@ -172,7 +221,87 @@ func (t *Tensor[T]) SetData(data []T) error {
 	return nil
 }
 
+// SliceEnd menandai batas akhir slice yang dihilangkan (mis. "1:"), yang berarti sampai akhir dimensi.
+const SliceEnd = math.MaxInt
+
+// ResolveSliceRanges mengubah rentang slice relatif menjadi rentang absolut terhadap shape: batas
+// negatif dihitung dari akhir dimensi (-1 adalah elemen terakhir) dan SliceEnd menjadi ukuran dimensi.
+// Karena bergantung pada shape, resolusi dilakukan saat eksekusi, bukan oleh parser. Rentang yang
+// masih di luar dimensi setelah resolusi menghasilkan error. Rentang di luar jumlah dimensi shape
+// dibiarkan apa adanya; pemeriksaan jumlah dimensi tetap menjadi tanggung jawab pemanggil.
+func ResolveSliceRanges(ranges [][2]int, shape []int) ([][2]int, error) {
+	resolved := make([][2]int, len(ranges))
+	for i, r := range ranges {
+		if i >= len(shape) {
+			resolved[i] = r
+			continue
+		}
+		start, end := r[0], r[1]
+		if start < 0 {
+			start += shape[i]
+		}
+		if end == SliceEnd {
+			end = shape[i]
+		} else if end < 0 {
+			end += shape[i]
+		}
+		if start < 0 || end > shape[i] || start > end {
+			return nil, fmt.Errorf("invalid slice range %s for dimension %d with size %d", formatSliceRange(r), i, shape[i])
+		}
+		resolved[i] = [2]int{start, end}
+	}
+	return resolved, nil
+}
+
+// formatSliceRange menulis rentang seperti yang ditulis pada kueri, mis. "[-1:]" untuk [-1, SliceEnd].
+func formatSliceRange(r [2]int) string {
+	if r[1] == SliceEnd {
+		return fmt.Sprintf("[%d:]", r[0])
+	}
+	return fmt.Sprintf("[%d:%d]", r[0], r[1])
+}
+
+// sliceShape menghitung shape hasil slice dari rentang absolut ranges dengan langkah steps (nil berarti
+// langkah 1 di semua dimensi): ceil((end-start)/step) per dimensi.
+func sliceShape(ranges [][2]int, steps []int) []int {
+	shape := make([]int, len(ranges))
+	for i, r := range ranges {
+		step := 1
+		if steps != nil {
+			step = steps[i]
+		}
+		// Bukan (end-start+step-1)/step: penjumlahan itu overflow untuk langkah mendekati MaxInt.
+		if r[1] > r[0] {
+			shape[i] = (r[1]-r[0]-1)/step + 1
+		}
+	}
+	return shape
+}
+
+// GetSlice menyalin elemen dalam ranges ke buffer baru dalam urutan row-major. Batas negatif dan
+// SliceEnd diresolusi terhadap shape tensor (lihat ResolveSliceRanges).
 func (t *Tensor[T]) GetSlice(ranges [][2]int) ([]T, error) {
+	return t.GetSliceStep(ranges, nil)
+}
+
+// GetSliceStep seperti GetSlice, tetapi dimensi ke-i hanya mengambil setiap elemen ke-steps[i] mulai dari
+// start, sehingga ukurannya ceil((end-start)/step). steps nil berarti langkah 1 di semua dimensi; langkah
+// nol atau negatif menghasilkan error.
+func (t *Tensor[T]) GetSliceStep(ranges [][2]int, steps []int) ([]T, error) {
+	if steps != nil {
+		if len(steps) != len(ranges) {
+			return nil, fmt.Errorf("slice steps length %d does not match slice ranges length %d", len(steps), len(ranges))
+		}
+		for i, step := range steps {
+			if step <= 0 {
+				return nil, fmt.Errorf("invalid slice step %d for dimension %d: must be a positive integer", step, i)
+			}
+		}
+	}
+	ranges, err := ResolveSliceRanges(ranges, t.Shape)
+	if err != nil {
+		return nil, err
+	}
 	if t.getTotalElements() == 0 && (len(ranges) > 0 && len(ranges[0]) > 0 && ranges[0][1]-ranges[0][0] > 0) {
 		isSliceEmpty := true
 		for _, r := range ranges {
@@ -193,7 +322,6 @@ func (t *Tensor[T]) GetSlice(ranges [][2]int) ([]T, error) {
 		}
 	}
 
-	newSliceShape := make([]int, len(ranges))
 	for i, r := range ranges {
 		currentDimSize := 0
 		if len(t.Shape) == 0 && i == 0 {
@@ -207,8 +335,8 @@ func (t *Tensor[T]) GetSlice(ranges [][2]int) ([]T, error) {
 		if r[0] < 0 || r[1] > currentDimSize || r[0] > r[1] {
 			return nil, fmt.Errorf("invalid slice range [%d:%d] for dimension %d with size %d", r[0], r[1], i, currentDimSize)
 		}
-		newSliceShape[i] = r[1] - r[0]
 	}
+	newSliceShape := sliceShape(ranges, steps)
 
 	resultSize := 1
 	hasZeroDimInSlice := false
@@ -262,7 +390,11 @@ mainLoop:
 			break mainLoop
 		}
 		for i := len(currentIterIndices) - 1; i >= 0; i-- {
-			currentIterIndices[i]++
+			if steps != nil {
+				currentIterIndices[i] += steps[i]
+			} else {
+				currentIterIndices[i]++
+			}
 			if currentIterIndices[i] < ranges[i][1] {
 				break
 			}
@@ -277,6 +409,11 @@ mainLoop:
 }
 
 func (t *Tensor[T]) GetDataForInference(ranges [][][2]int, batchSize int) ([]TensorDataWithMetadata[T], error) {
+	return t.getDataForInference(ranges, nil, batchSize)
+}
+
+// getDataForInference seperti GetDataForInference dengan langkah slice opsional steps[0] (lihat GetSliceStep).
+func (t *Tensor[T]) getDataForInference(ranges [][][2]int, steps [][]int, batchSize int) ([]TensorDataWithMetadata[T], error) {
 	var dataToProcess []T
 	var currentShape []int
 	var currentStrides []int
@@ -288,14 +425,21 @@ func (t *Tensor[T]) GetDataForInference(ranges [][][2]int, batchSize int) ([]Ten
 				return nil, fmt.Errorf("slice ranges length %d does not match tensor dimensions %d for tensor %s", len(ranges[0]), len(t.Shape), t.Name)
 			}
 		}
-		dataToProcess, err = t.GetSlice(ranges[0])
+		// GetSlice menyalin slice (termasuk yang tidak kontigu) ke buffer baru dalam urutan row-major,
+		// sehingga strides hasil dihitung ulang sebagai strides kontigu dan batching datar di bawah aman.
+		resolvedRanges, err := ResolveSliceRanges(ranges[0], t.Shape)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get slice for tensor %s: %w", t.Name, err)
 		}
-		currentShape = make([]int, len(ranges[0]))
-		for i, r := range ranges[0] {
-			currentShape[i] = r[1] - r[0]
+		var resolvedSteps []int
+		if len(steps) > 0 {
+			resolvedSteps = steps[0]
 		}
+		dataToProcess, err = t.GetSliceStep(resolvedRanges, resolvedSteps)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get slice for tensor %s: %w", t.Name, err)
+		}
+		currentShape = sliceShape(resolvedRanges, resolvedSteps)
 		currentStrides = make([]int, len(currentShape))
 		if len(currentShape) > 0 {
 			if tNilaiTotalElemen(currentShape) > 0 {
@@ -456,6 +600,33 @@ func formatRecursiveCore[T Numeric](data []T, currentShape []int, currentOffset
 	return result
 }
 
+// checkedElementCount menghitung jumlah elemen shape dan memastikan jumlah elemen maupun ukuran datanya
+// dalam byte (elemen × elementSize) tidak melampaui int.
+//
+// Asumsi platform: shape, stride, offset elemen, dan offset byte memakai int di seluruh paket. Pada
+// platform 64-bit int berukuran 64 bit, sehingga tensor yang ukuran datanya muat di int64 (dan di ruang
+// alamat mmap) dapat diiris di offset mana pun tanpa overflow: setiap stride dan offset selalu lebih
+// kecil dari jumlah elemen yang diperiksa di sini. Pada platform 32-bit batasnya 2^31-1 byte data.
+func checkedElementCount(shape []int, elementSize int) (int, error) {
+	total := 1
+	for _, dim := range shape {
+		if dim == 0 {
+			return 0, nil
+		}
+		if dim < 0 {
+			return 0, fmt.Errorf("invalid dimension size %d in shape %v", dim, shape)
+		}
+		if total > math.MaxInt/dim {
+			return 0, fmt.Errorf("shape %v has too many elements: element count overflows int", shape)
+		}
+		total *= dim
+	}
+	if elementSize > 0 && total > math.MaxInt/elementSize {
+		return 0, fmt.Errorf("shape %v is too large: data size of %d elements x %d bytes overflows int", shape, total, elementSize)
+	}
+	return total, nil
+}
+
 func tNilaiTotalElemen(shape []int) int {
 	if len(shape) == 0 {
 		return 1
@@ -554,6 +725,42 @@ func ShapesEqual(s1, s2 []int) bool {
 	return true
 }
 
+// parallelElementThreshold adalah jumlah elemen minimum sebelum operasi elemen per elemen dibagi ke
+// beberapa goroutine; di bawahnya overhead goroutine lebih besar daripada keuntungannya.
+const parallelElementThreshold = 1 << 20
+
+// parallelFor menjalankan fn atas rentang [0, n) yang dipecah menjadi potongan terpisah, satu per CPU,
+// jika n mencapai parallelElementThreshold, dan langsung sebagai fn(0, n) jika tidak. fn hanya boleh
+// menulis ke indeks di dalam potongannya sendiri sehingga tidak dibutuhkan penguncian. Panic di salah
+// satu goroutine diteruskan ke pemanggil setelah semua potongan selesai.
+func parallelFor(n int, fn func(start, end int)) {
+	workers := runtime.NumCPU()
+	if n < parallelElementThreshold || workers < 2 {
+		fn(0, n)
+		return
+	}
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	var panicOnce sync.Once
+	var panicValue interface{}
+	for start := 0; start < n; start += chunk {
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panicOnce.Do(func() { panicValue = r })
+				}
+			}()
+			fn(start, end)
+		}(start, min(start+chunk, n))
+	}
+	wg.Wait()
+	if panicValue != nil {
+		panic(panicValue)
+	}
+}
+
 func AddTensors[T Numeric](t1, t2 *Tensor[T]) (*Tensor[T], error) {
 	if !ShapesEqual(t1.Shape, t2.Shape) {
 		return nil, fmt.Errorf("bentuk tensor tidak sama: %v dan %v (broadcasting belum diimplementasikan)", t1.Shape, t2.Shape)
@@ -570,12 +777,128 @@ func AddTensors[T Numeric](t1, t2 *Tensor[T]) (*Tensor[T], error) {
 		return resultTensor, nil
 	}
 
+	resultData := make([]T, len(t1.Data))
+	parallelFor(len(resultData), func(start, end int) {
+		for i := start; i < end; i++ {
+			resultData[i] = t1.Data[i] + t2.Data[i]
+		}
+	})
+
+	resultTensor, err := NewTensor[T]("temp_add_result", t1.Shape, t1.DataType)
+	if err != nil {
+		return nil, err
+	}
+	err = resultTensor.SetData(resultData)
+	if err != nil {
+		return nil, err
+	}
+	return resultTensor, nil
+}
+
+// AddTensorsBroadcast menjumlahkan t1 dan t2 dengan broadcasting ala NumPy: shape disejajarkan dari
+// dimensi terakhir dan setiap pasangan dimensi harus sama atau salah satunya 1 (dimensi yang tidak ada
+// dianggap 1). Misalnya vektor [n] ditambahkan ke setiap baris matriks [m, n].
+func AddTensorsBroadcast[T Numeric](t1, t2 *Tensor[T]) (*Tensor[T], error) {
+	if t1.DataType != t2.DataType {
+		return nil, fmt.Errorf("tipe data tensor tidak sama: %s dan %s", t1.DataType, t2.DataType)
+	}
+	shape, err := broadcastShape(t1.Shape, t2.Shape)
+	if err != nil {
+		return nil, err
+	}
+	result, err := NewTensor[T]("temp_add_result", shape, t1.DataType)
+	if err != nil {
+		return nil, err
+	}
+	strides1 := broadcastStrides(t1.Shape, shape)
+	strides2 := broadcastStrides(t2.Shape, shape)
+	// Koordinat hasil dinaikkan seperti odometer; offset sumber mengikuti dengan stride broadcast,
+	// yang bernilai 0 pada dimensi yang diulang.
+	coord := make([]int, len(shape))
+	off1, off2 := 0, 0
+	for i := range result.Data {
+		result.Data[i] = t1.Data[off1] + t2.Data[off2]
+		for d := len(shape) - 1; d >= 0; d-- {
+			coord[d]++
+			off1 += strides1[d]
+			off2 += strides2[d]
+			if coord[d] < shape[d] {
+				break
+			}
+			off1 -= strides1[d] * shape[d]
+			off2 -= strides2[d] * shape[d]
+			coord[d] = 0
+		}
+	}
+	return result, nil
+}
+
+// broadcastShape mengembalikan shape hasil broadcasting a dan b, atau error jika ada pasangan dimensi
+// yang berbeda dan tidak satu pun bernilai 1.
+func broadcastShape(a, b []int) ([]int, error) {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	shape := make([]int, n)
+	for i := 1; i <= n; i++ {
+		da, db := 1, 1
+		if i <= len(a) {
+			da = a[len(a)-i]
+		}
+		if i <= len(b) {
+			db = b[len(b)-i]
+		}
+		switch {
+		case da == db || db == 1:
+			shape[n-i] = da
+		case da == 1:
+			shape[n-i] = db
+		default:
+			return nil, fmt.Errorf("shapes %v and %v cannot be broadcast: dimension %d is %d and %d", a, b, n-i, da, db)
+		}
+	}
+	return shape, nil
+}
+
+// broadcastStrides mengembalikan stride row-major tensor berbentuk shape untuk setiap dimensi shape
+// hasil out. Dimensi yang di-broadcast (berukuran 1 atau tidak ada) memiliki stride 0.
+func broadcastStrides(shape, out []int) []int {
+	strides := make([]int, len(out))
+	stride := 1
+	for i := 1; i <= len(shape); i++ {
+		dim := shape[len(shape)-i]
+		if dim != 1 {
+			strides[len(out)-i] = stride
+		}
+		stride *= dim
+	}
+	return strides
+}
+
+// MultiplyTensors mengalikan dua tensor elemen per elemen (perkalian Hadamard).
+func MultiplyTensors[T Numeric](t1, t2 *Tensor[T]) (*Tensor[T], error) {
+	if !ShapesEqual(t1.Shape, t2.Shape) {
+		return nil, fmt.Errorf("bentuk tensor tidak sama: %v dan %v (broadcasting belum diimplementasikan)", t1.Shape, t2.Shape)
+	}
+	if t1.DataType != t2.DataType {
+		return nil, fmt.Errorf("tipe data tensor tidak sama: %s dan %s", t1.DataType, t2.DataType)
+	}
+
+	if t1.getTotalElements() == 0 {
+		resultTensor, err := NewTensor[T]("temp_multiply_result", t1.Shape, t1.DataType)
+		if err != nil {
+			return nil, err
+		}
+		return resultTensor, nil
+	}
+
 	resultData := make([]T, len(t1.Data))
 	for i := range t1.Data {
-		resultData[i] = t1.Data[i] + t2.Data[i]
+		resultData[i] = t1.Data[i] * t2.Data[i]
 	}
 
-	resultTensor, err := NewTensor[T]("temp_add_result", t1.Shape, t1.DataType)
+	resultTensor, err := NewTensor[T]("temp_multiply_result", t1.Shape, t1.DataType)
 	if err != nil {
 		return nil, err
 	}
@@ -586,6 +909,69 @@ func AddTensors[T Numeric](t1, t2 *Tensor[T]) (*Tensor[T], error) {
 	return resultTensor, nil
 }
 
+// DivideTensors membagi t1 dengan t2 elemen per elemen. Untuk tipe integer, pembagi nol menghasilkan
+// error yang menyebut indeks datar elemennya; untuk tipe float hasil IEEE (Inf/NaN) disimpan apa adanya.
+func DivideTensors[T Numeric](t1, t2 *Tensor[T]) (*Tensor[T], error) {
+	if !ShapesEqual(t1.Shape, t2.Shape) {
+		return nil, fmt.Errorf("bentuk tensor tidak sama: %v dan %v (broadcasting belum diimplementasikan)", t1.Shape, t2.Shape)
+	}
+	if t1.DataType != t2.DataType {
+		return nil, fmt.Errorf("tipe data tensor tidak sama: %s dan %s", t1.DataType, t2.DataType)
+	}
+
+	resultTensor, err := NewTensor[T]("temp_divide_result", t1.Shape, t1.DataType)
+	if err != nil {
+		return nil, err
+	}
+	if t1.getTotalElements() == 0 {
+		return resultTensor, nil
+	}
+
+	isInteger := isIntegerDataType(t1.DataType)
+	resultData := make([]T, len(t1.Data))
+	for i := range t1.Data {
+		if isInteger && t2.Data[i] == 0 {
+			return nil, fmt.Errorf("integer division by zero at flat index %d", i)
+		}
+		resultData[i] = t1.Data[i] / t2.Data[i]
+	}
+	if err := resultTensor.SetData(resultData); err != nil {
+		return nil, err
+	}
+	return resultTensor, nil
+}
+
+// MatMul mengalikan dua matriks 2-D: a berbentuk [m, n] dan b berbentuk [n, p] menghasilkan [m, p].
+func MatMul[T Numeric](a, b *Tensor[T]) (*Tensor[T], error) {
+	if len(a.Shape) != 2 || len(b.Shape) != 2 {
+		return nil, fmt.Errorf("matmul requires 2-D tensors, got shapes %v and %v", a.Shape, b.Shape)
+	}
+	if a.DataType != b.DataType {
+		return nil, fmt.Errorf("tipe data tensor tidak sama: %s dan %s", a.DataType, b.DataType)
+	}
+	m, n, p := a.Shape[0], a.Shape[1], b.Shape[1]
+	if b.Shape[0] != n {
+		return nil, fmt.Errorf("matmul inner dimensions do not match: %v x %v (a.Shape[1]=%d, b.Shape[0]=%d)", a.Shape, b.Shape, n, b.Shape[0])
+	}
+
+	resultTensor, err := NewTensor[T]("temp_matmul_result", []int{m, p}, a.DataType)
+	if err != nil {
+		return nil, err
+	}
+	// Urutan i-k-j membaca baris b secara berurutan sehingga ramah cache.
+	for i := 0; i < m; i++ {
+		row := resultTensor.Data[i*p : (i+1)*p]
+		for k := 0; k < n; k++ {
+			aik := a.Data[i*n+k]
+			bRow := b.Data[k*p : (k+1)*p]
+			for j := range row {
+				row[j] += aik * bRow[j]
+			}
+		}
+	}
+	return resultTensor, nil
+}
+
 func AddScalarToTensor[T Numeric](t *Tensor[T], scalar T) (*Tensor[T], error) {
 	if t.getTotalElements() == 0 {
 		resultTensor, err := NewTensor[T]("temp_add_scalar_result", t.Shape, t.DataType)
@@ -595,12 +981,40 @@ func AddScalarToTensor[T Numeric](t *Tensor[T], scalar T) (*Tensor[T], error) {
 		return resultTensor, nil
 	}
 
+	resultData := make([]T, len(t.Data))
+	parallelFor(len(resultData), func(start, end int) {
+		for i := start; i < end; i++ {
+			resultData[i] = t.Data[i] + scalar
+		}
+	})
+
+	resultTensor, err := NewTensor[T]("temp_add_scalar_result", t.Shape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	err = resultTensor.SetData(resultData)
+	if err != nil {
+		return nil, err
+	}
+	return resultTensor, nil
+}
+
+// MultiplyTensorByScalar mengalikan setiap elemen tensor dengan skalar.
+func MultiplyTensorByScalar[T Numeric](t *Tensor[T], scalar T) (*Tensor[T], error) {
+	if t.getTotalElements() == 0 {
+		resultTensor, err := NewTensor[T]("temp_mul_scalar_result", t.Shape, t.DataType)
+		if err != nil {
+			return nil, err
+		}
+		return resultTensor, nil
+	}
+
 	resultData := make([]T, len(t.Data))
 	for i := range t.Data {
-		resultData[i] = t.Data[i] + scalar
+		resultData[i] = t.Data[i] * scalar
 	}
 
-	resultTensor, err := NewTensor[T]("temp_add_scalar_result", t.Shape, t.DataType)
+	resultTensor, err := NewTensor[T]("temp_mul_scalar_result", t.Shape, t.DataType)
 	if err != nil {
 		return nil, err
 	}
@@ -611,34 +1025,904 @@ func AddScalarToTensor[T Numeric](t *Tensor[T], scalar T) (*Tensor[T], error) {
 	return resultTensor, nil
 }
 
-// QueryType merepresentasikan tipe kueri.
-type QueryType string
+// SubtractScalarFromTensor mengurangkan skalar dari setiap elemen tensor.
+func SubtractScalarFromTensor[T Numeric](t *Tensor[T], scalar T) (*Tensor[T], error) {
+	if t.getTotalElements() == 0 {
+		resultTensor, err := NewTensor[T]("temp_sub_scalar_result", t.Shape, t.DataType)
+		if err != nil {
+			return nil, err
+		}
+		return resultTensor, nil
+	}
 
-const (
-	CreateTensorQuery  QueryType = "create_tensor" // Diubah untuk menghindari konflik dengan const DataType
-	InsertTensorQuery  QueryType = "insert_tensor"
-	SelectTensorQuery  QueryType = "select_tensor"
-	GetDataTensorQuery QueryType = "get_data_tensor"
-	MathOperationQuery QueryType = "math_operation"
-	ListTensorsQuery   QueryType = "list_tensors"
-)
+	resultData := make([]T, len(t.Data))
+	for i := range t.Data {
+		resultData[i] = t.Data[i] - scalar
+	}
 
-// Query merepresentasikan kueri yang sudah diparsing.
-type Query struct {
-	Type        QueryType
-	TensorNames []string
-	Shape       []int
-	DataType    string   // Tipe data untuk CREATE TENSOR
-	Data        []string // Data untuk INSERT dari string kueri
-	RawData     []byte   // Data biner untuk INSERT dari client (OPTIMASI)
-	Slices      [][][2]int
-	BatchSize   int
+	resultTensor, err := NewTensor[T]("temp_sub_scalar_result", t.Shape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	err = resultTensor.SetData(resultData)
+	if err != nil {
+		return nil, err
+	}
+	return resultTensor, nil
+}
+
+// SqrtTensor menghitung akar kuadrat setiap elemen tensor.
+// Operasi ini hanya didaftarkan untuk tipe float (lihat mathOperators di executor).
+func SqrtTensor[T Numeric](t *Tensor[T]) (*Tensor[T], error) {
+	resultTensor, err := NewTensor[T]("temp_sqrt_result", t.Shape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	if t.getTotalElements() == 0 {
+		return resultTensor, nil
+	}
+
+	resultData := make([]T, len(t.Data))
+	for i, v := range t.Data {
+		resultData[i] = T(math.Sqrt(float64(v)))
+	}
+	if err := resultTensor.SetData(resultData); err != nil {
+		return nil, err
+	}
+	return resultTensor, nil
+}
+
+// BitwiseNotTensor membalik setiap bit elemen tensor (komplemen dua untuk tipe bertanda, sehingga
+// NOT 5 = -6). Operasi ini hanya didaftarkan untuk tipe integer (lihat mathOperators di executor).
+func BitwiseNotTensor[T Numeric](t *Tensor[T]) (*Tensor[T], error) {
+	if !isIntegerDataType(t.DataType) {
+		return nil, fmt.Errorf("bitwise NOT requires an integer tensor, got dtype %s", t.DataType)
+	}
+	resultTensor, err := NewTensor[T]("temp_bitwise_not_result", t.Shape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range t.Data {
+		// Konversi ke uint64 memperluas tanda untuk tipe bertanda; konversi balik memotong ke lebar T.
+		resultTensor.Data[i] = T(^uint64(v))
+	}
+	return resultTensor, nil
+}
+
+// ShiftTensor menggeser bit setiap elemen tensor sebanyak amount, ke kiri jika left bernilai true dan ke
+// kanan jika tidak. Geser kanan bersifat aritmetika untuk tipe bertanda (tanda dipertahankan, -8 >> 1 = -4)
+// dan logis untuk tipe unsigned; bit yang tergeser keluar dibuang. amount harus dalam [0, lebar bit tipe).
+func ShiftTensor[T Numeric](t *Tensor[T], amount int, left bool) (*Tensor[T], error) {
+	if !isIntegerDataType(t.DataType) {
+		return nil, fmt.Errorf("bit shift requires an integer tensor, got dtype %s", t.DataType)
+	}
+	elementSize, err := GetElementSize(t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	if bits := elementSize * 8; amount < 0 || amount >= bits {
+		return nil, fmt.Errorf("shift amount %d is out of range for dtype %s: must be between 0 and %d", amount, t.DataType, bits-1)
+	}
+	resultTensor, err := NewTensor[T]("temp_shift_result", t.Shape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	signed := T(0)-1 < 0
+	for i, v := range t.Data {
+		switch {
+		case left:
+			resultTensor.Data[i] = T(uint64(v) << amount)
+		case signed:
+			resultTensor.Data[i] = T(int64(v) >> amount)
+		default:
+			resultTensor.Data[i] = T(uint64(v) >> amount)
+		}
+	}
+	return resultTensor, nil
+}
+
+// ClipByNorm menskalakan seluruh tensor dengan maxNorm/norm jika norma L2-nya melebihi maxNorm,
+// sehingga norma hasil tepat maxNorm; tensor dengan norma <= maxNorm disalin tanpa perubahan.
+// Norma dihitung dalam float64 dengan akumulasi berskala seperti math.Hypot (kuadrat setiap elemen
+// dibagi dulu dengan nilai absolut terbesar sejauh ini), sehingga elemen sangat besar atau sangat kecil
+// tidak overflow ke +Inf atau underflow ke nol saat dikuadratkan.
+func ClipByNorm[T Numeric](t *Tensor[T], maxNorm float64) (*Tensor[T], error) {
+	if maxNorm < 0 || math.IsNaN(maxNorm) {
+		return nil, fmt.Errorf("clip norm MAX must be a non-negative number, got %v", maxNorm)
+	}
+	result, err := NewTensor[T]("temp_clip_norm_result", t.Shape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	// norm = maxAbs * sqrt(ssq), dengan ssq jumlah (|v| / maxAbs)^2.
+	maxAbs, ssq := 0.0, 1.0
+	for _, v := range t.Data {
+		x := math.Abs(float64(v))
+		if x == 0 {
+			continue
+		}
+		if x > maxAbs {
+			r := maxAbs / x
+			ssq = 1 + ssq*r*r
+			maxAbs = x
+		} else {
+			r := x / maxAbs
+			ssq += r * r
+		}
+	}
+	norm := maxAbs * math.Sqrt(ssq)
+	if norm <= maxNorm {
+		copy(result.Data, t.Data)
+		return result, nil
+	}
+	scale := maxNorm / norm
+	for i, v := range t.Data {
+		result.Data[i] = T(float64(v) * scale)
+	}
+	return result, nil
+}
+
+// PairwiseDistances menghitung matriks jarak Euclidean [n, n] antar baris tensor 2-D [n, d].
+// Elemen (i, j) hasil adalah ||row_i - row_j||; matriks simetris dengan diagonal nol. Akumulasi
+// dilakukan dalam float64 dan elemen dibaca melalui strides tensor.
+func PairwiseDistances[T Numeric](t *Tensor[T]) (*Tensor[T], error) {
+	if len(t.Shape) != 2 {
+		return nil, fmt.Errorf("pairwise distance requires a 2-D tensor, got shape %v", t.Shape)
+	}
+	n, d := t.Shape[0], t.Shape[1]
+	rowStride, colStride := d, 1
+	if len(t.Strides) == 2 {
+		rowStride, colStride = t.Strides[0], t.Strides[1]
+	}
+
+	result, err := NewTensor[T]("temp_pdist_result", []int{n, n}, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			sumSq := 0.0
+			for k := 0; k < d; k++ {
+				diff := float64(t.Data[i*rowStride+k*colStride]) - float64(t.Data[j*rowStride+k*colStride])
+				sumSq += diff * diff
+			}
+			dist := T(math.Sqrt(sumSq))
+			result.Data[i*n+j] = dist
+			result.Data[j*n+i] = dist
+		}
+	}
+	return result, nil
+}
+
+// Covariance menghitung matriks kovarians sampel [d, d] antar kolom (fitur) tensor 2-D [n, d] bertipe
+// R (float). Kolom dipusatkan ke rata-ratanya lalu dibagi n-1, sama seperti numpy.cov(rowvar=False).
+// Minimal dibutuhkan dua baris.
+func Covariance[T Numeric, R Numeric](t *Tensor[T], resultDataType string) (*Tensor[R], error) {
+	cov, d, err := covarianceMatrix(t)
+	if err != nil {
+		return nil, err
+	}
+	result, err := NewTensor[R]("temp_cov_result", []int{d, d}, resultDataType)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range cov {
+		result.Data[i] = R(v)
+	}
+	return result, nil
+}
+
+// Correlation menghitung matriks korelasi Pearson [d, d] antar kolom tensor 2-D [n, d] bertipe R (float).
+// Kolom dengan varians nol tidak memiliki korelasi yang terdefinisi: baris dan kolomnya bernilai NaN
+// (termasuk diagonalnya), sama seperti numpy.corrcoef.
+func Correlation[T Numeric, R Numeric](t *Tensor[T], resultDataType string) (*Tensor[R], error) {
+	cov, d, err := covarianceMatrix(t)
+	if err != nil {
+		return nil, err
+	}
+	result, err := NewTensor[R]("temp_corr_result", []int{d, d}, resultDataType)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+			varI, varJ := cov[i*d+i], cov[j*d+j]
+			switch {
+			case varI == 0 || varJ == 0:
+				result.Data[i*d+j] = R(math.NaN())
+			case i == j:
+				result.Data[i*d+j] = 1
+			default:
+				result.Data[i*d+j] = R(cov[i*d+j] / math.Sqrt(varI*varJ))
+			}
+		}
+	}
+	return result, nil
+}
+
+// covarianceMatrix menghitung matriks kovarians sampel (float64, row-major [d, d]) antar kolom tensor 2-D.
+func covarianceMatrix[T Numeric](t *Tensor[T]) ([]float64, int, error) {
+	if len(t.Shape) != 2 {
+		return nil, 0, fmt.Errorf("covariance requires a 2-D tensor, got shape %v", t.Shape)
+	}
+	n, d := t.Shape[0], t.Shape[1]
+	if n < 2 {
+		return nil, 0, fmt.Errorf("covariance requires at least 2 rows, got %d", n)
+	}
+	rowStride, colStride := d, 1
+	if len(t.Strides) == 2 {
+		rowStride, colStride = t.Strides[0], t.Strides[1]
+	}
+
+	means := make([]float64, d)
+	for r := 0; r < n; r++ {
+		for c := 0; c < d; c++ {
+			means[c] += float64(t.Data[r*rowStride+c*colStride])
+		}
+	}
+	for c := range means {
+		means[c] /= float64(n)
+	}
+
+	cov := make([]float64, d*d)
+	centered := make([]float64, d)
+	for r := 0; r < n; r++ {
+		for c := 0; c < d; c++ {
+			centered[c] = float64(t.Data[r*rowStride+c*colStride]) - means[c]
+		}
+		for i := 0; i < d; i++ {
+			for j := i; j < d; j++ {
+				cov[i*d+j] += centered[i] * centered[j]
+			}
+		}
+	}
+	for i := 0; i < d; i++ {
+		for j := i; j < d; j++ {
+			cov[i*d+j] /= float64(n - 1)
+			cov[j*d+i] = cov[i*d+j]
+		}
+	}
+	return cov, d, nil
+}
+
+// MovingAverage menghitung rata-rata bergerak trailing dengan jendela window di sepanjang sumbu axis.
+// Hanya jendela penuh yang dihasilkan ("valid"), sehingga panjang sumbu hasil adalah n-window+1:
+// elemen ke-j hasil adalah rata-rata elemen j..j+window-1 input. Akumulasi dilakukan dalam float64
+// lalu dikonversi ke tipe hasil R (float), sehingga input integer dipromosikan ke float. Jumlah setiap
+// jendela dihitung ulang (O(n*window)) alih-alih digeser, agar nilai besar atau Inf yang sudah keluar
+// dari jendela tidak merusak rata-rata jendela berikutnya.
+func MovingAverage[T Numeric, R Numeric](t *Tensor[T], window int, axis int, resultDataType string) (*Tensor[R], error) {
+	if axis < 0 || axis >= len(t.Shape) {
+		return nil, fmt.Errorf("axis %d out of range for tensor with %d dimension(s)", axis, len(t.Shape))
+	}
+	n := t.Shape[axis]
+	if window <= 0 || window > n {
+		return nil, fmt.Errorf("window %d must be between 1 and the axis length %d", window, n)
+	}
+
+	outer := 1
+	for _, dim := range t.Shape[:axis] {
+		outer *= dim
+	}
+	inner := 1
+	for _, dim := range t.Shape[axis+1:] {
+		inner *= dim
+	}
+	outN := n - window + 1
+	resultShape := append([]int{}, t.Shape...)
+	resultShape[axis] = outN
+
+	result, err := NewTensor[R]("temp_moving_avg_result", resultShape, resultDataType)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		return result, nil
+	}
+
+	for o := 0; o < outer; o++ {
+		inBase := o * n * inner
+		outBase := o * outN * inner
+		for i := 0; i < inner; i++ {
+			for j := 0; j < outN; j++ {
+				var sum float64
+				for k := j; k < j+window; k++ {
+					sum += float64(t.Data[inBase+k*inner+i])
+				}
+				result.Data[outBase+j*inner+i] = R(sum / float64(window))
+			}
+		}
+	}
+	return result, nil
+}
+
+// Normalize menskalakan ulang data ke rentang [lo, hi] secara min-max menggunakan min/max data itu sendiri.
+// Dengan axis < 0, min/max diambil dari seluruh tensor; dengan axis >= 0, setiap irisan 1-D di sepanjang
+// sumbu axis dinormalisasi dengan min/max-nya sendiri. Data konstan (min == max) menghasilkan lo untuk
+// semua elemen, seperti MinMaxScaler pada umumnya. Hasil bertipe float R; input integer dipromosikan.
+func Normalize[T Numeric, R Numeric](t *Tensor[T], lo, hi float64, axis int, resultDataType string) (*Tensor[R], error) {
+	if lo >= hi {
+		return nil, fmt.Errorf("normalize range min %v must be less than max %v", lo, hi)
+	}
+	result, err := NewTensor[R]("temp_normalize_result", t.Shape, resultDataType)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Data) == 0 {
+		return result, nil
+	}
+
+	// Tanpa sumbu, seluruh tensor diperlakukan sebagai satu irisan sepanjang n elemen.
+	n, outer, inner := len(t.Data), 1, 1
+	if axis >= 0 {
+		if axis >= len(t.Shape) {
+			return nil, fmt.Errorf("axis %d out of range for tensor with %d dimension(s)", axis, len(t.Shape))
+		}
+		n = t.Shape[axis]
+		for _, dim := range t.Shape[:axis] {
+			outer *= dim
+		}
+		for _, dim := range t.Shape[axis+1:] {
+			inner *= dim
+		}
+	}
+
+	for o := 0; o < outer; o++ {
+		base := o * n * inner
+		for i := 0; i < inner; i++ {
+			minVal, maxVal := float64(t.Data[base+i]), float64(t.Data[base+i])
+			for j := 1; j < n; j++ {
+				v := float64(t.Data[base+j*inner+i])
+				if v < minVal {
+					minVal = v
+				}
+				if v > maxVal {
+					maxVal = v
+				}
+			}
+			dataRange := maxVal - minVal
+			for j := 0; j < n; j++ {
+				idx := base + j*inner + i
+				if dataRange == 0 {
+					result.Data[idx] = R(lo)
+					continue
+				}
+				result.Data[idx] = R(lo + (float64(t.Data[idx])-minVal)/dataRange*(hi-lo))
+			}
+		}
+	}
+	return result, nil
+}
+
+// OneHot mengubah tensor 1-D berisi indeks kelas menjadi tensor [n, classes] bertipe R yang bernilai 1
+// pada kolom kelas setiap baris dan 0 di tempat lain. Semua label harus berada di [0, classes).
+func OneHot[T Numeric, R Numeric](labels *Tensor[T], classes int, resultDataType string) (*Tensor[R], error) {
+	if len(labels.Shape) != 1 {
+		return nil, fmt.Errorf("one-hot requires a 1-D label tensor, got shape %v", labels.Shape)
+	}
+	if classes <= 0 {
+		return nil, fmt.Errorf("number of classes must be positive, got %d", classes)
+	}
+	for i, label := range labels.Data {
+		// Label sudah dipastikan tidak negatif, sehingga perbandingan sebagai uint64 tepat untuk label uint64
+		// di atas MaxInt64 yang akan berbalik negatif bila dikonversi ke int64.
+		if label < 0 || uint64(label) >= uint64(classes) {
+			return nil, fmt.Errorf("label %v at index %d is out of range [0, %d)", label, i, classes)
+		}
+	}
+
+	result, err := NewTensor[R]("temp_onehot_result", []int{len(labels.Data), classes}, resultDataType)
+	if err != nil {
+		return nil, err
+	}
+	for i, label := range labels.Data {
+		result.Data[i*classes+int(label)] = 1
+	}
+	return result, nil
+}
+
+// ReplaceValue mengganti setiap elemen yang sama dengan match (dalam toleransi |v-match| <= tolerance)
+// dengan replacement dan mengembalikan tensor baru. Toleransi 0 berarti harus persis sama.
+func ReplaceValue[T Numeric](t *Tensor[T], match, replacement T, tolerance float64) (*Tensor[T], error) {
+	resultTensor, err := NewTensor[T]("temp_replace_result", t.Shape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	copy(resultTensor.Data, t.Data)
+	replaceInSlice(resultTensor.Data, match, replacement, tolerance)
+	return resultTensor, nil
+}
+
+// replaceInSlice mengganti elemen data yang cocok dengan match secara in-place dan mengembalikan jumlahnya.
+func replaceInSlice[T Numeric](data []T, match, replacement T, tolerance float64) int {
+	replaced := 0
+	for i, v := range data {
+		if v == match || (tolerance > 0 && math.Abs(float64(v)-float64(match)) <= tolerance) {
+			data[i] = replacement
+			replaced++
+		}
+	}
+	return replaced
+}
+
+// Reinterpret menafsirkan ulang byte mentah (little-endian) tensor sebagai tipe data R berukuran elemen
+// sama tanpa mengonversi nilai, mis. pola bit IEEE float32 dibaca sebagai int32. Berbeda dengan konversi
+// nilai, hasilnya berbagi representasi biner yang persis sama dengan input (data disalin).
+func Reinterpret[T Numeric, R Numeric](t *Tensor[T], resultDataType string) (*Tensor[R], error) {
+	srcSize, err := GetElementSize(t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	dstSize, err := GetElementSize(resultDataType)
+	if err != nil {
+		return nil, err
+	}
+	if srcSize != dstSize {
+		return nil, fmt.Errorf("cannot reinterpret %s (%d bytes) as %s (%d bytes): element sizes differ", t.DataType, srcSize, resultDataType, dstSize)
+	}
+
+	result, err := NewTensor[R]("temp_reinterpret_result", t.Shape, resultDataType)
+	if err != nil {
+		return nil, err
+	}
+	raw := make([]byte, len(t.Data)*srcSize)
+	encodeChunk(t.Data, raw)
+	decodeChunk(raw, result.Data)
+	return result, nil
+}
+
+// Transpose mempermutasi axis tensor: axis ke-i hasil adalah axis perm[i] input, sehingga [2,3] dengan
+// perm [1,0] menjadi [3,2]. Data disusun ulang secara fisik ke tata letak row-major shape baru.
+// perm nil (atau kosong) berarti urutan axis dibalik.
+func Transpose[T Numeric](t *Tensor[T], perm []int) (*Tensor[T], error) {
+	ndim := len(t.Shape)
+	if len(perm) == 0 {
+		perm = make([]int, ndim)
+		for i := range perm {
+			perm[i] = ndim - 1 - i
+		}
+	}
+	if len(perm) != ndim {
+		return nil, fmt.Errorf("permutation %v has %d entries, but tensor has %d dimension(s)", perm, len(perm), ndim)
+	}
+	seen := make([]bool, ndim)
+	for _, axis := range perm {
+		if axis < 0 || axis >= ndim {
+			return nil, fmt.Errorf("permutation %v contains axis %d out of range [0, %d)", perm, axis, ndim)
+		}
+		if seen[axis] {
+			return nil, fmt.Errorf("permutation %v contains duplicate axis %d", perm, axis)
+		}
+		seen[axis] = true
+	}
+
+	srcStrides := make([]int, ndim)
+	stride := 1
+	for d := ndim - 1; d >= 0; d-- {
+		srcStrides[d] = stride
+		stride *= t.Shape[d]
+	}
+	resultShape := make([]int, ndim)
+	permutedStrides := make([]int, ndim)
+	for i, axis := range perm {
+		resultShape[i] = t.Shape[axis]
+		permutedStrides[i] = srcStrides[axis]
+	}
+	result, err := NewTensor[T]("temp_transpose_result", resultShape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Iterasi indeks multi-dimensi hasil dalam urutan row-major sambil melacak offset sumbernya.
+	index := make([]int, ndim)
+	srcOffset := 0
+	for dst := range result.Data {
+		result.Data[dst] = t.Data[srcOffset]
+		for d := ndim - 1; d >= 0; d-- {
+			index[d]++
+			srcOffset += permutedStrides[d]
+			if index[d] < resultShape[d] {
+				break
+			}
+			srcOffset -= index[d] * permutedStrides[d]
+			index[d] = 0
+		}
+	}
+	return result, nil
+}
+
+// Reshape mengembalikan tensor dengan shape baru yang berbagi slice Data yang sama dengan t (tanpa
+// menyalin nilai). Jumlah elemen newShape harus sama dengan jumlah elemen t; strides dihitung ulang.
+func Reshape[T Numeric](t *Tensor[T], newShape []int) (*Tensor[T], error) {
+	newCount, err := checkedElementCount(newShape, 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reshape target: %w", err)
+	}
+	oldCount := tNilaiTotalElemen(t.Shape)
+	if (oldCount == 0) != (newCount == 0) {
+		return nil, fmt.Errorf("cannot reshape tensor of shape %v (%d elements) to %v (%d elements): a shape with a zero dimension can only be reshaped to another shape with a zero dimension", t.Shape, oldCount, newShape, newCount)
+	}
+	if oldCount != newCount {
+		return nil, fmt.Errorf("cannot reshape tensor of shape %v (%d elements) to %v (%d elements): element counts differ", t.Shape, oldCount, newShape, newCount)
+	}
+
+	// Sama seperti NewTensor: tensor tanpa elemen memiliki strides nol.
+	strides := make([]int, len(newShape))
+	if newCount > 0 {
+		stride := 1
+		for d := len(newShape) - 1; d >= 0; d-- {
+			strides[d] = stride
+			stride *= newShape[d]
+		}
+	}
+	return &Tensor[T]{
+		Name:     t.Name,
+		Shape:    append([]int(nil), newShape...),
+		Strides:  strides,
+		Data:     t.Data,
+		DataType: t.DataType,
+	}, nil
+}
+
+// SumAlongAxis menjumlahkan tensor di sepanjang axis sehingga dimensi tersebut hilang dari shape,
+// mis. [2,3] pada axis 0 menjadi [3]. Strides hasil dihitung ulang oleh NewTensor.
+func SumAlongAxis[T Numeric](t *Tensor[T], axis int) (*Tensor[T], error) {
+	accs, resultShape, err := sumAlongAxis(t, axis, false)
+	if err != nil {
+		return nil, err
+	}
+	sums, err := accumulatedSums[T](accs, t.Name, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	result, err := NewTensor[T]("temp_sum_axis_result", resultShape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.SetData(sums); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MaxAlongAxis mengambil nilai maksimum di sepanjang axis; dimensi axis hilang dari shape hasil.
+// Tensor tanpa elemen ditolak karena tidak ada elemen identitas max yang tetap berada dalam T.
+func MaxAlongAxis[T Numeric](t *Tensor[T], axis int) (*Tensor[T], error) {
+	return extremeAlongAxis(t, axis, func(candidate, current T) bool { return candidate > current })
+}
+
+// MinAlongAxis mengambil nilai minimum di sepanjang axis; dimensi axis hilang dari shape hasil.
+// Tensor tanpa elemen ditolak karena tidak ada elemen identitas min yang tetap berada dalam T.
+func MinAlongAxis[T Numeric](t *Tensor[T], axis int) (*Tensor[T], error) {
+	return extremeAlongAxis(t, axis, func(candidate, current T) bool { return candidate < current })
+}
+
+// extremeAlongAxis mereduksi tensor di sepanjang axis dengan mempertahankan elemen yang better
+// dibanding nilai saat ini. Nilai awal tiap posisi adalah elemen pertama irisannya.
+func extremeAlongAxis[T Numeric](t *Tensor[T], axis int, better func(candidate, current T) bool) (*Tensor[T], error) {
+	if axis < 0 || axis >= len(t.Shape) {
+		return nil, fmt.Errorf("axis %d out of range: valid axes are [0, %d) for tensor with %d dimension(s)", axis, len(t.Shape), len(t.Shape))
+	}
+	if len(t.Data) == 0 {
+		return nil, fmt.Errorf("cannot reduce empty tensor (shape %v) along axis %d: max/min has no identity element", t.Shape, axis)
+	}
+	outer := 1
+	for _, dim := range t.Shape[:axis] {
+		outer *= dim
+	}
+	inner := 1
+	for _, dim := range t.Shape[axis+1:] {
+		inner *= dim
+	}
+	n := t.Shape[axis]
+	resultShape := append(append([]int{}, t.Shape[:axis]...), t.Shape[axis+1:]...)
+
+	result, err := NewTensor[T]("temp_extreme_result", resultShape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	for o := 0; o < outer; o++ {
+		base := o * n * inner
+		copy(result.Data[o*inner:(o+1)*inner], t.Data[base:base+inner])
+		for j := 1; j < n; j++ {
+			for i := 0; i < inner; i++ {
+				if v := t.Data[base+j*inner+i]; better(v, result.Data[o*inner+i]) {
+					result.Data[o*inner+i] = v
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// StackTensors menumpuk tensor-tensor berbentuk sama menjadi satu tensor dengan dimensi batch baru
+// di depan: N tensor berbentuk S menghasilkan tensor berbentuk [N, S...] dengan urutan sesuai input.
+func StackTensors[T Numeric](tensors []*Tensor[T]) (*Tensor[T], error) {
+	if len(tensors) == 0 {
+		return nil, fmt.Errorf("stack requires at least one tensor")
+	}
+	first := tensors[0]
+	for _, t := range tensors[1:] {
+		if !ShapesEqual(t.Shape, first.Shape) {
+			return nil, fmt.Errorf("shape %v of tensor '%s' does not match shape %v of tensor '%s'", t.Shape, t.Name, first.Shape, first.Name)
+		}
+		if t.DataType != first.DataType {
+			return nil, fmt.Errorf("data type %s of tensor '%s' does not match data type %s of tensor '%s'", t.DataType, t.Name, first.DataType, first.Name)
+		}
+	}
+
+	resultShape := append([]int{len(tensors)}, first.Shape...)
+	resultTensor, err := NewTensor[T]("temp_stack_result", resultShape, first.DataType)
+	if err != nil {
+		return nil, err
+	}
+	resultData := make([]T, 0, len(tensors)*len(first.Data))
+	for _, t := range tensors {
+		resultData = append(resultData, t.Data...)
+	}
+	if err := resultTensor.SetData(resultData); err != nil {
+		return nil, err
+	}
+	return resultTensor, nil
+}
+
+// Interleave menyelang-nyeling irisan a dan b di sepanjang axis (a[0], b[0], a[1], b[1], ...).
+// Kedua tensor harus berbentuk sama; dimensi axis pada hasil menjadi dua kali lipat.
+func Interleave[T Numeric](a, b *Tensor[T], axis int) (*Tensor[T], error) {
+	if !ShapesEqual(a.Shape, b.Shape) {
+		return nil, fmt.Errorf("shape %v of tensor '%s' does not match shape %v of tensor '%s'", b.Shape, b.Name, a.Shape, a.Name)
+	}
+	if axis < 0 || axis >= len(a.Shape) {
+		return nil, fmt.Errorf("axis %d is out of range for tensor with %d dimension(s)", axis, len(a.Shape))
+	}
+
+	n := a.Shape[axis]
+	outer := tNilaiTotalElemen(a.Shape[:axis])
+	inner := tNilaiTotalElemen(a.Shape[axis+1:])
+	resultShape := append([]int{}, a.Shape...)
+	resultShape[axis] = 2 * n
+	result, err := NewTensor[T]("temp_interleave_result", resultShape, a.DataType)
+	if err != nil {
+		return nil, err
+	}
+	// Irisan ke-j dari a menempati posisi 2j pada axis hasil, irisan ke-j dari b posisi 2j+1.
+	for o := 0; o < outer; o++ {
+		for j := 0; j < n; j++ {
+			src := (o*n + j) * inner
+			dst := (o*2*n + 2*j) * inner
+			copy(result.Data[dst:dst+inner], a.Data[src:src+inner])
+			copy(result.Data[dst+inner:dst+2*inner], b.Data[src:src+inner])
+		}
+	}
+	return result, nil
+}
+
+// Concat menggabungkan a dan b di sepanjang axis: semua dimensi selain axis harus sama, dan dimensi
+// axis pada hasil adalah jumlah dimensi axis keduanya.
+func Concat[T Numeric](a, b *Tensor[T], axis int) (*Tensor[T], error) {
+	if len(a.Shape) != len(b.Shape) {
+		return nil, fmt.Errorf("cannot concat tensor '%s' with %d dimension(s) and tensor '%s' with %d dimension(s)", a.Name, len(a.Shape), b.Name, len(b.Shape))
+	}
+	if axis < 0 || axis >= len(a.Shape) {
+		return nil, fmt.Errorf("axis %d is out of range for tensor with %d dimension(s)", axis, len(a.Shape))
+	}
+	for i := range a.Shape {
+		if i != axis && a.Shape[i] != b.Shape[i] {
+			return nil, fmt.Errorf("shape %v of tensor '%s' does not match shape %v of tensor '%s' outside concat axis %d", b.Shape, b.Name, a.Shape, a.Name, axis)
+		}
+	}
+
+	outer := tNilaiTotalElemen(a.Shape[:axis])
+	inner := tNilaiTotalElemen(a.Shape[axis+1:])
+	blockA, blockB := a.Shape[axis]*inner, b.Shape[axis]*inner
+	resultShape := append([]int{}, a.Shape...)
+	resultShape[axis] = a.Shape[axis] + b.Shape[axis]
+	result, err := NewTensor[T]("temp_concat_result", resultShape, a.DataType)
+	if err != nil {
+		return nil, err
+	}
+	// Untuk setiap indeks di depan axis, blok a lalu blok b disalin berurutan ke hasil.
+	for o := 0; o < outer; o++ {
+		dst := o * (blockA + blockB)
+		copy(result.Data[dst:dst+blockA], a.Data[o*blockA:(o+1)*blockA])
+		copy(result.Data[dst+blockA:dst+blockA+blockB], b.Data[o*blockB:(o+1)*blockB])
+	}
+	return result, nil
+}
+
+// SegmentSum menjumlahkan baris-baris t (irisan pada axis pertama) per segmen. segments[i] adalah id
+// segmen baris ke-i; id harus mencakup 0..k-1 tanpa celah dan hasilnya berbentuk [k, t.Shape[1:]...].
+func SegmentSum[T Numeric](t *Tensor[T], segments []int64) (*Tensor[T], error) {
+	if len(t.Shape) == 0 {
+		return nil, fmt.Errorf("segment sum requires a tensor with at least one dimension")
+	}
+	rows := t.Shape[0]
+	if len(segments) != rows {
+		return nil, fmt.Errorf("segment ids have %d entries, but tensor '%s' has %d rows", len(segments), t.Name, rows)
+	}
+	numSegments := 0
+	for i, id := range segments {
+		if id < 0 {
+			return nil, fmt.Errorf("segment id %d at index %d is negative", id, i)
+		}
+		// Id yang kontigu dari 0 selalu lebih kecil dari jumlah baris; menolaknya di sini mencegah
+		// alokasi raksasa (atau panic) untuk id besar sebelum pemeriksaan kontiguitas.
+		if id >= int64(rows) {
+			return nil, fmt.Errorf("segment id %d at index %d must be less than the number of rows (%d)", id, i, rows)
+		}
+		if int(id) >= numSegments {
+			numSegments = int(id) + 1
+		}
+	}
+	seen := make([]bool, numSegments)
+	for _, id := range segments {
+		seen[id] = true
+	}
+	for id, ok := range seen {
+		if !ok {
+			return nil, fmt.Errorf("segment ids must be contiguous from 0, but id %d is missing (max id %d)", id, numSegments-1)
+		}
+	}
+
+	rowSize := tNilaiTotalElemen(t.Shape[1:])
+	resultShape := append([]int{numSegments}, t.Shape[1:]...)
+	result, err := NewTensor[T]("temp_segment_sum_result", resultShape, t.DataType)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range segments {
+		src := t.Data[i*rowSize : (i+1)*rowSize]
+		dst := result.Data[int(id)*rowSize : (int(id)+1)*rowSize]
+		for j, v := range src {
+			dst[j] += v
+		}
+	}
+	return result, nil
+}
+
+// TopK mengembalikan K nilai terbesar (urutan menurun) beserta indeks datarnya sebagai tensor int64.
+// Menggunakan min-heap berukuran K sehingga hanya sebagian data yang diurutkan. Jika K melebihi
+// jumlah elemen, seluruh elemen dikembalikan. Nilai yang sama diurutkan berdasarkan indeks terkecil.
+func TopK[T Numeric](t *Tensor[T], k int) (*Tensor[T], *Tensor[int64], error) {
+	if k <= 0 {
+		return nil, nil, fmt.Errorf("k must be positive, got %d", k)
+	}
+	if k > len(t.Data) {
+		k = len(t.Data)
+	}
+
+	h := &topKHeap[T]{}
+	for i, v := range t.Data {
+		candidate := topKItem[T]{value: v, index: i}
+		if h.Len() < k {
+			heap.Push(h, candidate)
+		} else if h.less(h.items[0], candidate) {
+			h.items[0] = candidate
+			heap.Fix(h, 0)
+		}
+	}
+
+	values := make([]T, h.Len())
+	indices := make([]int64, h.Len())
+	for i := len(values) - 1; i >= 0; i-- {
+		item := heap.Pop(h).(topKItem[T])
+		values[i] = item.value
+		indices[i] = int64(item.index)
+	}
+
+	valuesTensor, err := NewTensor[T]("temp_topk_values", []int{len(values)}, t.DataType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := valuesTensor.SetData(values); err != nil {
+		return nil, nil, err
+	}
+	indicesTensor, err := NewTensor[int64]("temp_topk_indices", []int{len(indices)}, DataTypeInt64)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := indicesTensor.SetData(indices); err != nil {
+		return nil, nil, err
+	}
+	return valuesTensor, indicesTensor, nil
+}
+
+type topKItem[T Numeric] struct {
+	value T
+	index int
+}
+
+// topKHeap adalah min-heap: akar adalah kandidat "terlemah" (nilai terkecil, lalu indeks terbesar).
+type topKHeap[T Numeric] struct {
+	items []topKItem[T]
+}
+
+// less melaporkan apakah a lebih lemah dari b dalam urutan TOPK.
+func (h *topKHeap[T]) less(a, b topKItem[T]) bool {
+	if a.value != b.value {
+		return a.value < b.value
+	}
+	return a.index > b.index
+}
+
+func (h *topKHeap[T]) Len() int           { return len(h.items) }
+func (h *topKHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *topKHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *topKHeap[T]) Push(x any)         { h.items = append(h.items, x.(topKItem[T])) }
+func (h *topKHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// QueryType merepresentasikan tipe kueri.
+type QueryType string
+
+const (
+	CreateTensorQuery   QueryType = "create_tensor" // Diubah untuk menghindari konflik dengan const DataType
+	InsertTensorQuery   QueryType = "insert_tensor"
+	SelectTensorQuery   QueryType = "select_tensor"
+	GetDataTensorQuery  QueryType = "get_data_tensor"
+	MathOperationQuery  QueryType = "math_operation"
+	ListTensorsQuery    QueryType = "list_tensors"
+	DropTensorQuery     QueryType = "drop_tensor"
+	RenameTensorQuery   QueryType = "rename_tensor"
+	UpdateElementQuery  QueryType = "update_element"
+	ExportShardsQuery   QueryType = "export_shards"
+	ImportShardsQuery   QueryType = "import_shards"
+	DescribeTensorQuery QueryType = "describe_tensor"
+	StatsTensorQuery    QueryType = "stats_tensor"
+	CountQuery          QueryType = "count_tensor"
+	ValidateTensorQuery QueryType = "validate_tensor"
+)
+
+// SparseEntry adalah satu pasangan koordinat=nilai pada INSERT ... SPARSE.
+type SparseEntry struct {
+	Coordinate []int
+	Value      string
+}
+
+// Query merepresentasikan kueri yang sudah diparsing.
+type Query struct {
+	Type        QueryType
+	TensorNames []string
+	Shape       []int         // Shape untuk CREATE TENSOR dan shape target RESHAPE
+	DataType    string        // Tipe data untuk CREATE TENSOR
+	TrackStats  bool          // CREATE TENSOR ... TRACK_STATS: lacak rata-rata/variansi berjalan pada setiap INSERT, UPDATE, dan operasi IN PLACE
+	Identity    bool          // CREATE IDENTITY: tensor persegi Shape dengan 1 pada diagonal dan 0 di tempat lain
+	Random      bool          // CREATE RANDOM: isi tensor dengan nilai pseudo-acak (lihat Seed)
+	Seed        *int64        // Seed CREATE RANDOM; nil berarti seed berbasis waktu yang dilaporkan di hasil CREATE
+	Data        []string      // Data untuk INSERT dari string kueri
+	RawData     []byte        // Data biner untuk INSERT dari client (OPTIMASI)
+	Append      bool          // INSERT ... APPEND: tambahkan data di sepanjang Axis (default 0)
+	Sparse      []SparseEntry // INSERT ... SPARSE: pasangan koordinat=nilai, elemen lain bernilai nol
+	Coordinate  []int         // UPDATE: koordinat elemen yang diubah (nilainya di ScalarOperand)
+	Slices      [][][2]int
+	SliceSteps  [][]int // Langkah slice per tensor dan dimensi, sejajar dengan Slices; nil berarti langkah 1
+	BatchSize   int
+
+	SourceQuery *Query // Kueri SELECT sumber untuk CREATE TENSOR ... FROM SELECT
+
+	ShardPrefix string // Prefix path file shard untuk EXPORT TENSOR ... TO SHARDS dan IMPORT FROM SHARDS
+	ShardCount  int    // Jumlah shard untuk EXPORT TENSOR ... TO SHARDS
 
-	MathOperator     string
-	InputTensorNames []string
-	OutputTensorName string
-	ScalarOperand    string
-	Axis             *int
+	MathOperator      string
+	InputTensorNames  []string
+	OutputTensorName  string
+	IndicesTensorName string // Tensor output kedua berisi indeks (TOPK)
+	ScalarOperand     string // Operand skalar operasi matematika; juga nilai UPDATE dan CREATE TENSOR ... FILL
+	MatchValue        string // Nilai yang dicari oleh REPLACE VALUE (ScalarOperand berisi penggantinya)
+	Tolerance         string // Toleransi opsional REPLACE VALUE untuk tipe float (kosong = persis sama)
+	RangeMin          string // Batas bawah rentang target NORMALIZE (kosong = 0)
+	RangeMax          string // Batas atas rentang target NORMALIZE (kosong = 1)
+	RangeFrom         string // Nilai awal CREATE RANGE (inklusif)
+	RangeTo           string // Nilai akhir CREATE RANGE (eksklusif)
+	RangeStep         string // Langkah CREATE RANGE; tidak kosong menandai kueri CREATE RANGE
+	Axis              *int
+	Broadcast         bool     // ADD TENSOR ... BROADCAST: shape input boleh berbeda selama dapat di-broadcast
+	Perm              []int    // Permutasi axis TRANSPOSE (nil = urutan axis dibalik)
+	InPlace           bool     // Operasi menulis hasil langsung ke data tensor input (tanpa tensor output)
+	Params            []string // Parameter bernama (:name) pada operand yang harus diisi lewat ExecuteWithParams
 
 	FilterDataType      string
 	FilterNumDimensions int
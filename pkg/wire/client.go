@@ -0,0 +1,188 @@
+package wire
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/sciefylab/tensordb/pkg/tensor"
+)
+
+// Client mengirim request protokol wire melalui satu koneksi. Metodenya mengikuti client.Client
+// in-process; request dari beberapa goroutine diserialkan karena protokol bersifat request/response.
+type Client struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	mu     sync.Mutex
+}
+
+func NewClient(conn net.Conn) *Client {
+	return &Client{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// roundTrip mengirim request dan menunggu response-nya. Response StatusError dikembalikan sebagai error.
+func (c *Client) roundTrip(request *message) (*message, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := writeMessage(c.conn, request); err != nil {
+		return nil, err
+	}
+	response, err := readMessage(c.reader)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca response dari server: %w", err)
+	}
+	if response.Op == StatusError {
+		return nil, errors.New(response.Text)
+	}
+	return response, nil
+}
+
+func (c *Client) CreateTensor(name string, shape []int, dataType string) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	if _, err := tensor.GetElementSize(dataType); err != nil {
+		return fmt.Errorf("tipe data tidak valid '%s': %w", dataType, err)
+	}
+	_, err := c.roundTrip(&message{Op: OpCreate, Name: name, DataType: dataType, Shape: shape})
+	return err
+}
+
+// DropTensor menghapus tensor di server beserta file-filenya.
+func (c *Client) DropTensor(name string) error {
+	if name == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	_, err := c.roundTrip(&message{Op: OpDrop, Name: name})
+	return err
+}
+
+// Execute menjalankan kueri string di server dan mengembalikan hasilnya dalam bentuk teks.
+func (c *Client) Execute(query string) (string, error) {
+	response, err := c.roundTrip(&message{Op: OpExecute, Text: query})
+	if err != nil {
+		return "", err
+	}
+	return response.Text, nil
+}
+
+func (c *Client) InsertFloat32Data(tensorName string, data []float32) error {
+	return insertData(c, tensorName, data)
+}
+
+func (c *Client) InsertFloat64Data(tensorName string, data []float64) error {
+	return insertData(c, tensorName, data)
+}
+
+func (c *Client) InsertInt32Data(tensorName string, data []int32) error {
+	return insertData(c, tensorName, data)
+}
+
+func (c *Client) InsertInt64Data(tensorName string, data []int64) error {
+	return insertData(c, tensorName, data)
+}
+
+func (c *Client) InsertInt8Data(tensorName string, data []int8) error {
+	return insertData(c, tensorName, data)
+}
+
+func (c *Client) InsertInt16Data(tensorName string, data []int16) error {
+	return insertData(c, tensorName, data)
+}
+
+func (c *Client) InsertUint8Data(tensorName string, data []uint8) error {
+	return insertData(c, tensorName, data)
+}
+
+func (c *Client) InsertUint32Data(tensorName string, data []uint32) error {
+	return insertData(c, tensorName, data)
+}
+
+func (c *Client) InsertUint64Data(tensorName string, data []uint64) error {
+	return insertData(c, tensorName, data)
+}
+
+// insertData mengirim data sebagai byte mentah little-endian dalam satu frame OpInsert.
+func insertData[T tensor.Numeric](c *Client, tensorName string, data []T) error {
+	if tensorName == "" {
+		return fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	buf := new(bytes.Buffer)
+	if err := binary.Write(buf, binary.LittleEndian, data); err != nil {
+		return fmt.Errorf("gagal serialisasi data ke bytes: %w", err)
+	}
+	_, err := c.roundTrip(&message{Op: OpInsert, Name: tensorName, Data: buf.Bytes()})
+	return err
+}
+
+func (c *Client) LoadTensorFloat32(tensorName string) (*tensor.Tensor[float32], error) {
+	return loadTensor[float32](c, tensorName, tensor.DataTypeFloat32)
+}
+
+func (c *Client) LoadTensorFloat64(tensorName string) (*tensor.Tensor[float64], error) {
+	return loadTensor[float64](c, tensorName, tensor.DataTypeFloat64)
+}
+
+func (c *Client) LoadTensorInt32(tensorName string) (*tensor.Tensor[int32], error) {
+	return loadTensor[int32](c, tensorName, tensor.DataTypeInt32)
+}
+
+func (c *Client) LoadTensorInt64(tensorName string) (*tensor.Tensor[int64], error) {
+	return loadTensor[int64](c, tensorName, tensor.DataTypeInt64)
+}
+
+func (c *Client) LoadTensorInt8(tensorName string) (*tensor.Tensor[int8], error) {
+	return loadTensor[int8](c, tensorName, tensor.DataTypeInt8)
+}
+
+func (c *Client) LoadTensorInt16(tensorName string) (*tensor.Tensor[int16], error) {
+	return loadTensor[int16](c, tensorName, tensor.DataTypeInt16)
+}
+
+func (c *Client) LoadTensorUint8(tensorName string) (*tensor.Tensor[uint8], error) {
+	return loadTensor[uint8](c, tensorName, tensor.DataTypeUint8)
+}
+
+func (c *Client) LoadTensorUint32(tensorName string) (*tensor.Tensor[uint32], error) {
+	return loadTensor[uint32](c, tensorName, tensor.DataTypeUint32)
+}
+
+func (c *Client) LoadTensorUint64(tensorName string) (*tensor.Tensor[uint64], error) {
+	return loadTensor[uint64](c, tensorName, tensor.DataTypeUint64)
+}
+
+// loadTensor mengambil seluruh tensor dengan OpSelect dan menyusunnya kembali sebagai *Tensor[T].
+func loadTensor[T tensor.Numeric](c *Client, tensorName string, expectedDataType string) (*tensor.Tensor[T], error) {
+	if tensorName == "" {
+		return nil, fmt.Errorf("nama tensor tidak boleh kosong")
+	}
+	response, err := c.roundTrip(&message{Op: OpSelect, Name: tensorName})
+	if err != nil {
+		return nil, err
+	}
+	if response.DataType != expectedDataType {
+		return nil, fmt.Errorf("tipe data tensor aktual ('%s') tidak cocok dengan tipe yang diminta ('%s') untuk tensor '%s'", response.DataType, expectedDataType, tensorName)
+	}
+	loadedTensor, err := tensor.NewTensor[T](response.Name, response.Shape, response.DataType)
+	if err != nil {
+		return nil, err
+	}
+	if expectedBytes := binary.Size(loadedTensor.Data); len(response.Data) != expectedBytes {
+		return nil, fmt.Errorf("ukuran data tensor '%s' dari server (%d byte) tidak sesuai shape %v (%d byte)", tensorName, len(response.Data), response.Shape, expectedBytes)
+	}
+	if err := binary.Read(bytes.NewReader(response.Data), binary.LittleEndian, loadedTensor.Data); err != nil {
+		return nil, fmt.Errorf("gagal mendekode data tensor '%s': %w", tensorName, err)
+	}
+	return loadedTensor, nil
+}
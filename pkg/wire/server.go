@@ -0,0 +1,123 @@
+package wire
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/sciefylab/tensordb/pkg/tensor"
+)
+
+// Server melayani request protokol wire dengan menjalankannya pada satu Executor.
+type Server struct {
+	executor *tensor.Executor
+	parser   *tensor.Parser
+}
+
+func NewServer(executor *tensor.Executor) *Server {
+	return &Server{
+		executor: executor,
+		parser:   &tensor.Parser{},
+	}
+}
+
+// Serve menerima koneksi dari listener dan melayani setiap koneksi di goroutine tersendiri.
+// Serve kembali dengan nil setelah listener ditutup.
+func (s *Server) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go s.ServeConn(conn)
+	}
+}
+
+// ServeConn melayani request pada satu koneksi secara berurutan hingga klien menutup koneksi.
+// Error eksekusi dan response yang melebihi MaxFrameSize dikirim ke klien sebagai response StatusError;
+// hanya error I/O atau frame rusak yang menghentikan koneksi.
+func (s *Server) ServeConn(conn net.Conn) error {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	for {
+		request, err := readMessage(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		err = writeMessage(writer, s.handle(request))
+		if errors.Is(err, errFrameTooLarge) {
+			// writeMessage memeriksa ukuran sebelum menulis apa pun, jadi error masih dapat dikirim.
+			err = writeMessage(writer, errorResponse(fmt.Errorf("response %w", err)))
+		}
+		if err != nil {
+			return err
+		}
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("failed to flush response: %w", err)
+		}
+	}
+}
+
+// handle menjalankan satu request dan menyusun response-nya.
+func (s *Server) handle(request *message) *message {
+	var result interface{}
+	var err error
+	switch request.Op {
+	case OpCreate, OpInsert, OpSelect, OpDrop:
+		// Nama dipakai sebagai nama file di direktori data; tolak nama seperti "../x".
+		if !tensor.IsValidTensorName(request.Name) {
+			return errorResponse(fmt.Errorf("invalid tensor name %q", request.Name))
+		}
+	}
+	switch request.Op {
+	case OpCreate:
+		result, err = s.executor.Execute(&tensor.Query{
+			Type:        tensor.CreateTensorQuery,
+			TensorNames: []string{request.Name},
+			Shape:       request.Shape,
+			DataType:    request.DataType,
+		})
+	case OpInsert:
+		result, err = s.executor.Execute(&tensor.Query{
+			Type:        tensor.InsertTensorQuery,
+			TensorNames: []string{request.Name},
+			RawData:     request.Data,
+		})
+	case OpSelect:
+		metadata, raw, readErr := s.executor.ReadTensorRaw(request.Name)
+		if readErr != nil {
+			return errorResponse(readErr)
+		}
+		return &message{Op: StatusOK, Name: metadata.Name, DataType: metadata.DataType, Shape: metadata.Shape, Data: raw}
+	case OpDrop:
+		result, err = s.executor.Execute(&tensor.Query{
+			Type:        tensor.DropTensorQuery,
+			TensorNames: []string{request.Name},
+		})
+	case OpExecute:
+		query, parseErr := s.parser.Parse(request.Text)
+		if parseErr != nil {
+			return errorResponse(parseErr)
+		}
+		result, err = s.executor.Execute(query)
+	default:
+		err = fmt.Errorf("unsupported wire op code %d", request.Op)
+	}
+	if err != nil {
+		return errorResponse(err)
+	}
+	return &message{Op: StatusOK, Name: request.Name, Text: fmt.Sprint(result)}
+}
+
+func errorResponse(err error) *message {
+	return &message{Op: StatusError, Text: err.Error()}
+}
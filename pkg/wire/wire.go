@@ -0,0 +1,172 @@
+// Package wire mengimplementasikan protokol biner ringan berbasis frame dengan awalan panjang
+// untuk mengakses Executor melalui koneksi TCP (atau net.Conn lain) tanpa overhead serialisasi
+// tambahan. Data tensor dikirim sebagai byte mentah little-endian, sama dengan format file .data.
+//
+// Setiap frame terdiri dari panjang payload (uint32 little-endian) diikuti payload:
+//
+//	op        uint8                   kode operasi (request) atau status (response)
+//	name      uint16 panjang + byte   nama tensor
+//	dataType  uint8 panjang + byte    tipe data tensor
+//	text      uint32 panjang + byte   kueri OpExecute; hasil atau pesan error pada response
+//	shape     uint8 jumlah + int64[]  dimensi tensor
+//	data      sisa payload            data tensor mentah
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Kode operasi request.
+const (
+	OpCreate  byte = 1 // Membuat tensor (name, dataType, shape)
+	OpInsert  byte = 2 // Menulis data mentah ke tensor (name, data)
+	OpSelect  byte = 3 // Membaca seluruh tensor (name); response berisi dataType, shape, dan data
+	OpDrop    byte = 4 // Menghapus tensor (name)
+	OpExecute byte = 5 // Menjalankan kueri string (text); response berisi hasil yang diformat
+)
+
+// Status response.
+const (
+	StatusOK    byte = 0
+	StatusError byte = 1
+)
+
+// MaxFrameSize membatasi ukuran payload satu frame agar frame rusak atau berbahaya tidak
+// menyebabkan alokasi memori berlebihan.
+const MaxFrameSize = 1 << 30
+
+// errFrameTooLarge menandai frame yang melebihi MaxFrameSize.
+var errFrameTooLarge = errors.New("exceeds maximum frame size")
+
+// message adalah representasi payload request maupun response.
+type message struct {
+	Op       byte
+	Name     string
+	DataType string
+	Text     string
+	Shape    []int
+	Data     []byte
+}
+
+// writeMessage menyandikan msg sebagai satu frame dan menuliskannya ke w.
+func writeMessage(w io.Writer, msg *message) error {
+	if len(msg.Name) > math.MaxUint16 {
+		return fmt.Errorf("tensor name too long: %d bytes", len(msg.Name))
+	}
+	if len(msg.DataType) > math.MaxUint8 {
+		return fmt.Errorf("data type too long: %d bytes", len(msg.DataType))
+	}
+	if len(msg.Shape) > math.MaxUint8 {
+		return fmt.Errorf("too many dimensions: %d", len(msg.Shape))
+	}
+
+	payloadSize := 1 + 2 + len(msg.Name) + 1 + len(msg.DataType) + 4 + len(msg.Text) + 1 + 8*len(msg.Shape) + len(msg.Data)
+	if payloadSize > MaxFrameSize {
+		return fmt.Errorf("frame of %d bytes %w %d", payloadSize, errFrameTooLarge, MaxFrameSize)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 4+payloadSize))
+	binary.Write(buf, binary.LittleEndian, uint32(payloadSize))
+	buf.WriteByte(msg.Op)
+	binary.Write(buf, binary.LittleEndian, uint16(len(msg.Name)))
+	buf.WriteString(msg.Name)
+	buf.WriteByte(byte(len(msg.DataType)))
+	buf.WriteString(msg.DataType)
+	binary.Write(buf, binary.LittleEndian, uint32(len(msg.Text)))
+	buf.WriteString(msg.Text)
+	buf.WriteByte(byte(len(msg.Shape)))
+	for _, dim := range msg.Shape {
+		binary.Write(buf, binary.LittleEndian, int64(dim))
+	}
+	buf.Write(msg.Data)
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write frame: %w", err)
+	}
+	return nil
+}
+
+// readMessage membaca satu frame dari r. io.EOF dikembalikan apa adanya jika koneksi ditutup
+// tepat di batas frame.
+func readMessage(r io.Reader) (*message, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read frame header: %w", err)
+	}
+	payloadSize := binary.LittleEndian.Uint32(header[:])
+	if payloadSize > MaxFrameSize {
+		return nil, fmt.Errorf("frame of %d bytes %w %d", payloadSize, errFrameTooLarge, MaxFrameSize)
+	}
+	payload := make([]byte, payloadSize)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("failed to read frame payload: %w", err)
+	}
+	return decodeMessage(payload)
+}
+
+// decodeMessage mengurai payload frame. Data pada hasil berbagi memori dengan payload.
+func decodeMessage(payload []byte) (*message, error) {
+	d := &decoder{buf: payload}
+	msg := &message{}
+	msg.Op = d.byte()
+	msg.Name = string(d.bytes(int(d.uint16())))
+	msg.DataType = string(d.bytes(int(d.byte())))
+	msg.Text = string(d.bytes(int(d.uint32())))
+	msg.Shape = make([]int, d.byte())
+	for i := range msg.Shape {
+		dim := int64(binary.LittleEndian.Uint64(d.bytes(8)))
+		if dim < 0 {
+			return nil, fmt.Errorf("malformed frame: negative dimension %d", dim)
+		}
+		msg.Shape[i] = int(dim)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	msg.Data = d.buf[d.pos:]
+	return msg, nil
+}
+
+// decoder membaca field payload secara berurutan dan mencatat error pertama (payload terpotong).
+type decoder struct {
+	buf []byte
+	pos int
+	err error
+}
+
+// bytes mengembalikan n byte berikutnya. Setelah error, field berukuran tetap (maksimal 8 byte)
+// dibaca sebagai nol dan field lain sebagai kosong, sehingga pemanggil cukup memeriksa d.err sekali.
+func (d *decoder) bytes(n int) []byte {
+	if d.err == nil && n > len(d.buf)-d.pos {
+		d.err = fmt.Errorf("malformed frame: need %d bytes at offset %d, only %d left", n, d.pos, len(d.buf)-d.pos)
+	}
+	if d.err != nil {
+		if n <= 8 {
+			return make([]byte, n)
+		}
+		return nil
+	}
+	b := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *decoder) byte() byte {
+	return d.bytes(1)[0]
+}
+
+func (d *decoder) uint16() uint16 {
+	return binary.LittleEndian.Uint16(d.bytes(2))
+}
+
+func (d *decoder) uint32() uint32 {
+	return binary.LittleEndian.Uint32(d.bytes(4))
+}
@@ -1,8 +1,20 @@
 package tests
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/sciefylab/tensordb/pkg/client"
 	"github.com/sciefylab/tensordb/pkg/tensor"
 )
 
@@ -156,3 +168,2204 @@ func TestAPIClientOperations(t *testing.T) {
 		}
 	})
 }
+
+func TestOpLogReplay(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t, tensor.WithOpLog())
+	defer cleanup()
+
+	assertError(t, apiClient.CreateTensor("oplog_a", []int{2, 2}, tensor.DataTypeFloat32), false)
+	assertError(t, apiClient.InsertFloat32Data("oplog_a", []float32{1, 2, 3, 4}), false)
+	_, err := apiClient.AddScalarToTensor(10, "oplog_a", "oplog_b")
+	assertError(t, err, false)
+	// Kueri baca dan kueri yang gagal tidak boleh dicatat.
+	_, err = apiClient.SelectData("oplog_a", nil)
+	assertError(t, err, false)
+	assertError(t, apiClient.CreateTensor("oplog_a", []int{1}, tensor.DataTypeFloat32), true)
+
+	logFile, err := os.Open(filepath.Join(dataDir, tensor.OpLogFileName))
+	if err != nil {
+		t.Fatalf("Gagal membuka oplog: %v", err)
+	}
+	defer logFile.Close()
+
+	var ops []tensor.QueryType
+	scanner := bufio.NewScanner(logFile)
+	for scanner.Scan() {
+		var entry tensor.OpLogEntry
+		assertError(t, json.Unmarshal(scanner.Bytes(), &entry), false)
+		assertTrue(t, !entry.Time.IsZero(), "Entri oplog harus memiliki timestamp")
+		ops = append(ops, entry.Query.Type)
+	}
+	assertEqual(t, ops, []tensor.QueryType{tensor.CreateTensorQuery, tensor.InsertTensorQuery, tensor.MathOperationQuery})
+
+	t.Run("Replay_Reconstructs_State", func(t *testing.T) {
+		_, freshClient, freshCleanup := setupTestClient(t)
+		defer freshCleanup()
+
+		_, errSeek := logFile.Seek(0, 0)
+		assertError(t, errSeek, false)
+		assertError(t, freshClient.ReplayLog(logFile), false)
+
+		a, errA := freshClient.LoadTensorFloat32("oplog_a")
+		assertError(t, errA, false)
+		if errA == nil {
+			assertEqual(t, a.Data, []float32{1, 2, 3, 4})
+		}
+		b, errB := freshClient.LoadTensorFloat32("oplog_b")
+		assertError(t, errB, false)
+		if errB == nil {
+			assertEqual(t, b.Shape, []int{2, 2})
+			assertEqual(t, b.Data, []float32{11, 12, 13, 14})
+		}
+	})
+}
+
+func TestOpLogWriteFailure(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "tensordb_test_oplog_fail_")
+	if err != nil {
+		t.Fatalf("Gagal membuat direktori data sementara: %v", err)
+	}
+	defer os.RemoveAll(dataDir)
+	storage, err := tensor.NewStorage(dataDir, tensor.WithOpLog())
+	if err != nil {
+		t.Fatalf("Gagal membuat storage: %v", err)
+	}
+	executor := tensor.NewExecutor(storage)
+	defer executor.Close()
+
+	// Direktori dengan nama file oplog membuat pembukaan log gagal setelah kueri dijalankan.
+	assertError(t, os.Mkdir(filepath.Join(dataDir, tensor.OpLogFileName), 0755), false)
+	result, err := executor.Execute(&tensor.Query{Type: tensor.CreateTensorQuery, TensorNames: []string{"oplog_fail"}, Shape: []int{2}, DataType: tensor.DataTypeFloat32})
+	assertTrue(t, errors.Is(err, tensor.ErrOplogWrite), "Error harus membungkus ErrOplogWrite, aktual: %v", err)
+	assertTrue(t, result != nil, "Hasil kueri harus tetap dikembalikan bersama ErrOplogWrite")
+	_, err = executor.LoadTensorMetadata("oplog_fail")
+	assertError(t, err, false)
+}
+
+func TestGetDataBatchedNonContiguousSlices(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	grid := make([]int32, 16)
+	for i := range grid {
+		grid[i] = int32(i)
+	}
+	assertError(t, apiClient.CreateTensor("grid_4x4", []int{4, 4}, tensor.DataTypeInt32), false)
+	assertError(t, apiClient.InsertInt32Data("grid_4x4", grid), false)
+
+	cube := make([]int64, 24)
+	for i := range cube {
+		cube[i] = int64(i)
+	}
+	assertError(t, apiClient.CreateTensor("cube_2x3x4", []int{2, 3, 4}, tensor.DataTypeInt64), false)
+	assertError(t, apiClient.InsertInt64Data("cube_2x3x4", cube), false)
+
+	collect := func(t *testing.T, results interface{}) []tensor.TensorDataResult {
+		t.Helper()
+		batches, ok := results.([]tensor.TensorDataResult)
+		assertTrue(t, ok, "Hasil GetData bukan []tensor.TensorDataResult")
+		return batches
+	}
+
+	t.Run("Inner_Columns_2D", func(t *testing.T) {
+		results, err := apiClient.GetData([]string{"grid_4x4"}, [][][2]int{{{0, 2}, {1, 3}}}, 3)
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		batches := collect(t, results)
+		assertEqual(t, len(batches), 2)
+		if len(batches) == 2 {
+			assertEqual(t, batches[0].Data, []int32{1, 2, 5})
+			assertEqual(t, batches[1].Data, []int32{6})
+			for _, b := range batches {
+				assertEqual(t, b.Shape, []int{2, 2})
+				assertEqual(t, b.Strides, []int{2, 1})
+				assertEqual(t, b.TotalElements, 4)
+			}
+		}
+	})
+
+	t.Run("Inner_Block_3D", func(t *testing.T) {
+		results, err := apiClient.GetData([]string{"cube_2x3x4"}, [][][2]int{{{0, 2}, {1, 3}, {1, 3}}}, 3)
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		batches := collect(t, results)
+		var flattened []int64
+		for _, b := range batches {
+			flattened = append(flattened, b.Data.([]int64)...)
+			assertEqual(t, b.Shape, []int{2, 2, 2})
+			assertEqual(t, b.Strides, []int{4, 2, 1})
+		}
+		assertEqual(t, len(batches), 3)
+		// Elemen (i, j, k) = i*12 + j*4 + k untuk j, k di [1:3], urutan row-major.
+		assertEqual(t, flattened, []int64{5, 6, 9, 10, 17, 18, 21, 22})
+	})
+
+	t.Run("Single_Column_Batch_Per_Element", func(t *testing.T) {
+		results, err := apiClient.GetData([]string{"grid_4x4"}, [][][2]int{{{0, 4}, {3, 4}}}, 1)
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		batches := collect(t, results)
+		var flattened []int32
+		for i, b := range batches {
+			flattened = append(flattened, b.Data.([]int32)...)
+			assertEqual(t, b.BatchInfo.CurrentBatchIndex, i)
+			assertEqual(t, b.BatchInfo.NumBatches, 4)
+		}
+		assertEqual(t, flattened, []int32{3, 7, 11, 15})
+	})
+}
+
+func TestCreateFromData(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Bool", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("from_data_bool", []int{3}, []bool{true, false, true}), false)
+		meta, err := apiClient.GetTensorMetadata("from_data_bool")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, meta.DataType, tensor.DataTypeBool)
+		}
+		values, err := apiClient.SelectData("from_data_bool", nil)
+		assertError(t, err, false)
+		assertEqual(t, values, []interface{}{true, false, true})
+	})
+
+	t.Run("Float32_2x3", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("from_data_f32", []int{2, 3}, []float32{1, 2, 3, 4, 5, 6}), false)
+		loaded, err := apiClient.LoadTensorFloat32("from_data_f32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{2, 3})
+			assertEqual(t, loaded.DataType, tensor.DataTypeFloat32)
+			assertEqual(t, loaded.Data, []float32{1, 2, 3, 4, 5, 6})
+		}
+	})
+
+	t.Run("Int64_Scalar", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("from_data_i64", []int{}, []int64{42}), false)
+		loaded, err := apiClient.LoadTensorInt64("from_data_i64")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []int64{42})
+		}
+	})
+
+	t.Run("Unsupported_Type", func(t *testing.T) {
+		err := apiClient.CreateFromData("from_data_bad", []int{2}, []int{1, 2})
+		assertErrorContains(t, err, "tipe data tidak didukung untuk CreateFromData: []int")
+	})
+
+	t.Run("Size_Mismatch_Creates_Nothing", func(t *testing.T) {
+		err := apiClient.CreateFromData("from_data_mismatch", []int{2, 2}, []float64{1, 2, 3})
+		assertErrorContains(t, err, "jumlah data 3 tidak sesuai dengan shape [2 2]")
+		_, errMeta := apiClient.GetTensorMetadata("from_data_mismatch")
+		assertError(t, errMeta, true, "Tensor tidak boleh dibuat jika validasi gagal")
+	})
+}
+
+func TestEachTensorMeta(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateTensor("each_f32_a", []int{2}, tensor.DataTypeFloat32), false)
+	assertError(t, apiClient.CreateTensor("each_f32_b", []int{2, 2}, tensor.DataTypeFloat32), false)
+	assertError(t, apiClient.CreateTensor("each_i64", []int{2}, tensor.DataTypeInt64), false)
+
+	t.Run("Visits_Only_Matching", func(t *testing.T) {
+		visited := map[string][]int{}
+		err := apiClient.EachTensorMeta(tensor.DataTypeFloat32, -1, func(meta *tensor.TensorMetadata) error {
+			assertEqual(t, meta.DataType, tensor.DataTypeFloat32)
+			visited[meta.Name] = meta.Shape
+			return nil
+		})
+		assertError(t, err, false)
+		assertEqual(t, visited, map[string][]int{"each_f32_a": {2}, "each_f32_b": {2, 2}})
+	})
+
+	t.Run("Combined_Filter", func(t *testing.T) {
+		var names []string
+		err := apiClient.EachTensorMeta(tensor.DataTypeFloat32, 2, func(meta *tensor.TensorMetadata) error {
+			names = append(names, meta.Name)
+			return nil
+		})
+		assertError(t, err, false)
+		assertEqual(t, names, []string{"each_f32_b"})
+	})
+
+	t.Run("Stops_On_Callback_Error", func(t *testing.T) {
+		stopErr := fmt.Errorf("berhenti")
+		calls := 0
+		err := apiClient.EachTensorMeta("", -1, func(meta *tensor.TensorMetadata) error {
+			calls++
+			return stopErr
+		})
+		assertEqual(t, err, stopErr)
+		assertEqual(t, calls, 1)
+	})
+
+	t.Run("Callback_May_Drop_Tensors", func(t *testing.T) {
+		err := apiClient.EachTensorMeta(tensor.DataTypeInt64, -1, func(meta *tensor.TensorMetadata) error {
+			return apiClient.DropTensor(meta.Name)
+		})
+		assertError(t, err, false)
+		_, errMeta := apiClient.GetTensorMetadata("each_i64")
+		assertError(t, errMeta, true, "Tensor yang di-drop di dalam callback harus hilang")
+	})
+}
+
+func TestSummary(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	empty, err := apiClient.Summary()
+	assertError(t, err, false)
+	assertEqual(t, empty.TotalTensors, 0)
+	assertEqual(t, empty.TotalBytes, int64(0))
+
+	assertError(t, apiClient.CreateTensor("sum_f32_mat", []int{2, 3}, tensor.DataTypeFloat32), false)
+	assertError(t, apiClient.CreateTensor("sum_f32_scalar", []int{}, tensor.DataTypeFloat32), false)
+	assertError(t, apiClient.CreateTensor("sum_i64_vec", []int{4}, tensor.DataTypeInt64), false)
+	assertError(t, apiClient.CreateTensor("sum_i32_empty", []int{0, 2}, tensor.DataTypeInt32), false)
+
+	var metaBytes int64
+	for _, name := range []string{"sum_f32_mat", "sum_f32_scalar", "sum_i64_vec", "sum_i32_empty"} {
+		info, errStat := os.Stat(filepath.Join(dataDir, name+".meta"))
+		assertError(t, errStat, false)
+		if errStat == nil {
+			metaBytes += info.Size()
+		}
+	}
+
+	summary, err := apiClient.Summary()
+	assertError(t, err, false)
+	assertEqual(t, summary.TotalTensors, 4)
+	assertEqual(t, summary.ByDataType, map[string]int{tensor.DataTypeFloat32: 2, tensor.DataTypeInt64: 1, tensor.DataTypeInt32: 1})
+	assertEqual(t, summary.ByNumDimensions, map[int]int{0: 1, 1: 1, 2: 2})
+	assertEqual(t, summary.TotalElements, int64(6+1+4+0))
+	assertEqual(t, summary.TotalBytes, metaBytes+int64(6*4+1*4+4*8), "Total byte = file .meta + file .data")
+
+	t.Run("Legacy_Zero_Shape_Matches_Index", func(t *testing.T) {
+		assertEqual(t, (&tensor.TensorMetadata{Shape: []int{0}}).NumDimensions(), 0)
+		assertError(t, apiClient.CreateTensor("sum_legacy_zero", []int{0}, tensor.DataTypeFloat64), false)
+		summary, err := apiClient.Summary()
+		assertError(t, err, false)
+		for dims, count := range summary.ByNumDimensions {
+			listed, errList := apiClient.ListTensors("", dims)
+			assertError(t, errList, false)
+			assertEqual(t, len(listed), count, "Summary dan indeks harus sepakat untuk %d dimensi", dims)
+		}
+		assertEqual(t, summary.ByNumDimensions, map[int]int{0: 2, 1: 1, 2: 2})
+	})
+
+	t.Run("Dedup_Blob_Counted_Once", func(t *testing.T) {
+		dedupDir, dedupClient, dedupCleanup := setupTestClient(t, tensor.WithDedup())
+		defer dedupCleanup()
+		data := []float64{1, 2, 3, 4}
+		assertError(t, dedupClient.CreateFromData("sum_dup_a", []int{4}, data), false)
+		assertError(t, dedupClient.CreateFromData("sum_dup_b", []int{2, 2}, data), false)
+		var metaBytes int64
+		for _, name := range []string{"sum_dup_a", "sum_dup_b"} {
+			info, errStat := os.Stat(filepath.Join(dedupDir, name+".meta"))
+			assertError(t, errStat, false)
+			if errStat == nil {
+				metaBytes += info.Size()
+			}
+		}
+		summary, err := dedupClient.Summary()
+		assertError(t, err, false)
+		assertEqual(t, summary.TotalBytes, metaBytes+int64(4*8), "Blob bersama hanya dihitung sekali")
+	})
+}
+
+func TestSelectMatrix64(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("matrix_2x3", []int{2, 3}, []float64{1, 2, 3, 4, 5, 6}), false)
+
+	matrix, err := apiClient.SelectMatrix64("matrix_2x3")
+	assertError(t, err, false)
+	assertEqual(t, matrix, [][]float64{{1, 2, 3}, {4, 5, 6}})
+
+	t.Run("Empty_Rows", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("matrix_0x3", []int{0, 3}, tensor.DataTypeFloat64), false)
+		empty, err := apiClient.SelectMatrix64("matrix_0x3")
+		assertError(t, err, false)
+		assertEqual(t, len(empty), 0)
+	})
+
+	t.Run("Not_2D", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("matrix_vec", []int{3}, []float64{1, 2, 3}), false)
+		_, err := apiClient.SelectMatrix64("matrix_vec")
+		assertErrorContains(t, err, "bukan matriks 2-D")
+	})
+
+	t.Run("Wrong_DataType", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("matrix_f32", []int{1, 2}, []float32{1, 2}), false)
+		_, err := apiClient.SelectMatrix64("matrix_f32")
+		assertErrorContains(t, err, "tidak cocok dengan tipe yang diminta ('float64')")
+	})
+}
+
+// TestGetDataPreservesNameOrder menjalankan GET DATA multi-tensor berulang kali (jalankan dengan -race)
+// dan memastikan urutan hasil serta urutan pesan error selalu sama dengan urutan nama di kueri.
+func TestGetDataPreservesNameOrder(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	var names []string
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("order_t%d", i)
+		// Ukuran berbeda agar waktu muat setiap goroutine bervariasi.
+		data := make([]float32, (8-i)*1000)
+		for j := range data {
+			data[j] = float32(i)
+		}
+		assertError(t, apiClient.CreateFromData(name, []int{len(data)}, data), false)
+		names = append(names, name)
+	}
+	// Urutan kueri sengaja berbeda dari urutan pembuatan.
+	queryNames := []string{names[5], names[0], names[7], names[2], names[6], names[1], names[4], names[3]}
+
+	for iter := 0; iter < 50; iter++ {
+		results, err := apiClient.GetData(queryNames, nil, 0)
+		assertError(t, err, false, "Iterasi %d", iter)
+		if err != nil {
+			return
+		}
+		perTensor, ok := results.([][]tensor.TensorDataResult)
+		assertTrue(t, ok, "Hasil GetData multi-tensor bukan [][]tensor.TensorDataResult")
+		if !ok {
+			return
+		}
+		assertEqual(t, len(perTensor), len(queryNames))
+		for i, batches := range perTensor {
+			if len(batches) == 0 {
+				t.Errorf("Iterasi %d: tidak ada hasil untuk %s", iter, queryNames[i])
+				continue
+			}
+			if batches[0].Name != queryNames[i] {
+				t.Fatalf("Iterasi %d: posisi %d berisi %s, diharapkan %s", iter, i, batches[0].Name, queryNames[i])
+			}
+		}
+	}
+
+	t.Run("Error_Order", func(t *testing.T) {
+		for iter := 0; iter < 20; iter++ {
+			_, err := apiClient.GetData([]string{"order_missing_b", names[0], "order_missing_a"}, nil, 0)
+			assertError(t, err, true)
+			if err == nil {
+				return
+			}
+			idxB := strings.Index(err.Error(), "order_missing_b")
+			idxA := strings.Index(err.Error(), "order_missing_a")
+			assertTrue(t, idxB >= 0 && idxA > idxB, "Error harus mengikuti urutan nama kueri: %v", err)
+		}
+	})
+}
+
+func TestClientDropTensor(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("client_drop", []int{2}, []float32{1, 2}), false)
+	assertError(t, apiClient.DropTensor("client_drop"), false)
+	if _, err := os.Stat(filepath.Join(dataDir, "client_drop.meta")); !os.IsNotExist(err) {
+		t.Errorf("File client_drop.meta seharusnya sudah dihapus, stat error: %v", err)
+	}
+	_, err := apiClient.SelectData("client_drop", nil)
+	assertError(t, err, true)
+	assertErrorContains(t, apiClient.DropTensor("client_drop"), "not found for drop")
+	assertErrorContains(t, apiClient.DropTensor(""), "nama tensor tidak boleh kosong")
+}
+
+func TestRenameTensor(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("rename_old", []int{2, 2}, []int64{1, 2, 3, 4}), false)
+	assertError(t, apiClient.CreateFromData("rename_taken", []int{1}, []int64{9}), false)
+	// Muat sekali agar executor menyimpan handle yang harus dilepas saat rename.
+	_, err := apiClient.SelectData("rename_old", nil)
+	assertError(t, err, false)
+
+	t.Run("Rename_Then_Select", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("RENAME TENSOR rename_old TO rename_new")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Type, tensor.RenameTensorQuery)
+			assertEqual(t, query.TensorNames, []string{"rename_old", "rename_new"})
+		}
+		assertError(t, apiClient.RenameTensor("rename_old", "rename_new"), false)
+
+		loaded, err := apiClient.LoadTensorInt64("rename_new")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Name, "rename_new")
+			assertEqual(t, loaded.Shape, []int{2, 2})
+			assertEqual(t, loaded.Data, []int64{1, 2, 3, 4})
+		}
+		meta, err := os.ReadFile(filepath.Join(dataDir, "rename_new.meta"))
+		assertError(t, err, false)
+		var metaFields map[string]interface{}
+		assertError(t, json.Unmarshal(meta, &metaFields), false)
+		assertEqual(t, metaFields["name"], "rename_new", "Nama di metadata harus ditulis ulang")
+
+		_, err = apiClient.SelectData("rename_old", nil)
+		assertErrorContains(t, err, "tensor 'rename_old' not found")
+		for _, ext := range []string{".meta", ".data"} {
+			if _, statErr := os.Stat(filepath.Join(dataDir, "rename_old"+ext)); !os.IsNotExist(statErr) {
+				t.Errorf("File rename_old%s seharusnya sudah tidak ada, stat error: %v", ext, statErr)
+			}
+		}
+		listed, err := apiClient.ListTensors("", -1)
+		assertError(t, err, false)
+		names := make([]string, 0, len(listed))
+		for _, m := range listed {
+			names = append(names, m.Name)
+		}
+		assertTrue(t, strings.Join(names, ",") == "rename_new,rename_taken" || strings.Join(names, ",") == "rename_taken,rename_new",
+			fmt.Sprintf("Indeks harus berisi rename_new dan rename_taken, didapat %v", names))
+	})
+
+	t.Run("Rename_Errors", func(t *testing.T) {
+		err := apiClient.RenameTensor("rename_new", "rename_taken")
+		assertErrorContains(t, err, "tensor 'rename_taken' already exists")
+		loaded, loadErr := apiClient.LoadTensorInt64("rename_taken")
+		assertError(t, loadErr, false)
+		if loadErr == nil {
+			assertEqual(t, loaded.Data, []int64{9})
+		}
+		err = apiClient.RenameTensor("rename_missing", "rename_other")
+		assertErrorContains(t, err, "tensor 'rename_missing' not found for rename")
+	})
+}
+
+func TestUpdateElement(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("update_i32", []int{2, 3}, []int32{1, 2, 3, 4, 5, 6}), false)
+	// Muat sekali agar mmap ter-cache; pembaruan harus terlihat lewat pemuatan berikutnya.
+	_, err := apiClient.LoadTensorInt32("update_i32")
+	assertError(t, err, false)
+
+	t.Run("Client_Update", func(t *testing.T) {
+		assertError(t, apiClient.UpdateElement("update_i32", []int{1, 2}, -60), false)
+		assertError(t, apiClient.UpdateElement("update_i32", []int{0, 1}, 20), false)
+		loaded, err := apiClient.LoadTensorInt32("update_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []int32{1, 20, 3, 4, 5, -60})
+		}
+	})
+
+	t.Run("Query_Update", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("UPDATE update_i32[1, 0] = 40")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, query.Type, tensor.UpdateElementQuery)
+		assertEqual(t, query.Coordinate, []int{1, 0})
+		assertEqual(t, query.ScalarOperand, "40")
+		assertError(t, apiClient.UpdateElement("update_i32", query.Coordinate, 40), false)
+		loaded, err := apiClient.LoadTensorInt32("update_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []int32{1, 20, 3, 40, 5, -60})
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		err := apiClient.UpdateElement("update_i32", []int{2, 0}, 1)
+		assertErrorContains(t, err, "index 2 out of range for dimension 0 of tensor 'update_i32': valid range is [0, 2)")
+		err = apiClient.UpdateElement("update_i32", []int{0, 3}, 1)
+		assertErrorContains(t, err, "index 3 out of range for dimension 1 of tensor 'update_i32': valid range is [0, 3)")
+		err = apiClient.UpdateElement("update_i32", []int{0}, 1)
+		assertErrorContains(t, err, "coordinate [0] has 1 dimension(s), tensor 'update_i32' has 2")
+		err = apiClient.UpdateElement("update_i32", []int{0, 0}, 2.5)
+		assertErrorContains(t, err, "error parsing '2.5' at index 0 as int32")
+		err = apiClient.UpdateElement("update_i32", []int{0, 0}, 1<<31)
+		assertErrorContains(t, err, "out of range for int32")
+		err = apiClient.UpdateElement("update_missing", []int{0}, 1)
+		assertErrorContains(t, err, "tensor 'update_missing' not found for update")
+		_, err = (&tensor.Parser{}).Parse("UPDATE update_i32[0,0] = abc")
+		assertErrorContains(t, err, "invalid scalar operand 'abc'")
+
+		loaded, loadErr := apiClient.LoadTensorInt32("update_i32")
+		assertError(t, loadErr, false)
+		if loadErr == nil {
+			assertEqual(t, loaded.Data, []int32{1, 20, 3, 40, 5, -60})
+		}
+	})
+}
+
+func TestClientSmallIntegerTypes(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("client_i8", []int{2, 2}, []int8{-5, 0, 5, 127}), false)
+	loaded8, err := apiClient.LoadTensorInt8("client_i8")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, loaded8.Shape, []int{2, 2})
+		assertEqual(t, loaded8.Data, []int8{-5, 0, 5, 127})
+	}
+
+	assertError(t, apiClient.CreateTensor("client_i16", []int{3}, tensor.DataTypeInt16), false)
+	assertError(t, apiClient.InsertInt16Data("client_i16", []int16{-32768, 1, 32767}), false)
+	loaded16, err := apiClient.LoadTensorInt16("client_i16")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, loaded16.Data, []int16{-32768, 1, 32767})
+	}
+
+	_, err = apiClient.LoadTensorInt8("client_i16")
+	assertErrorContains(t, err, "tipe data tensor aktual ('int16') tidak cocok dengan tipe yang diminta ('int8')")
+}
+
+func TestClientUnsignedTypes(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Uint8_No_Sign_Extension", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("client_u8", []int{4}, tensor.DataTypeUint8), false)
+		assertError(t, apiClient.InsertUint8Data("client_u8", []uint8{255, 0, 128, 255}), false)
+		loaded, err := apiClient.LoadTensorUint8("client_u8")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.DataType, tensor.DataTypeUint8)
+			assertEqual(t, loaded.Data, []uint8{255, 0, 128, 255})
+		}
+		_, err = apiClient.Sum("client_u8", "client_u8_sum")
+		assertErrorContains(t, err, "SUM of tensor client_u8 overflows uint8")
+		_, err = apiClient.Mean("client_u8", "client_u8_mean")
+		assertError(t, err, false)
+		mean, err := apiClient.LoadTensorFloat64("client_u8_mean")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, mean.Data, []float64{159.5})
+		}
+	})
+
+	t.Run("Uint32_Uint64_Round_Trip", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("client_u32", []int{2}, []uint32{math.MaxUint32, 1}), false)
+		loaded32, err := apiClient.LoadTensorUint32("client_u32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded32.Data, []uint32{math.MaxUint32, 1})
+		}
+		assertError(t, apiClient.CreateFromData("client_u64", []int{2}, []uint64{math.MaxUint64, 7}), false)
+		loaded64, err := apiClient.LoadTensorUint64("client_u64")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded64.Data, []uint64{math.MaxUint64, 7})
+		}
+	})
+
+	t.Run("String_Insert", func(t *testing.T) {
+		_, executor, cleanupExecutor := setupTest(t)
+		defer cleanupExecutor()
+		parser := &tensor.Parser{}
+		run := func(q string) error {
+			query, err := parser.Parse(q)
+			if err != nil {
+				return err
+			}
+			_, err = executor.Execute(query)
+			return err
+		}
+		assertError(t, run("CREATE TENSOR client_u8 4 TYPE uint8"), false)
+		assertError(t, run("CREATE TENSOR client_u64 2 TYPE uint64"), false)
+		assertError(t, run("INSERT INTO client_u8 VALUES (1, 2, 3, 255)"), false)
+		_, raw, err := executor.ReadTensorRaw("client_u8")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, raw, []byte{1, 2, 3, 255})
+		}
+		assertErrorContains(t, run("INSERT INTO client_u8 VALUES (1, -2, 3, 4)"), "negative value '-2' at index 1 is not allowed for unsigned type uint8")
+		assertErrorContains(t, run("INSERT INTO client_u8 VALUES (1, 2, 3, 256)"), "value '256' at index 3 is out of range for uint8: valid range is [0, 255]")
+		assertErrorContains(t, run("INSERT INTO client_u64 VALUES (18446744073709551616, 0)"), "valid range is [0, 18446744073709551615]")
+	})
+}
+
+func TestAssertClose(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("close_expected", []int{2, 2}, []float64{1.0, 100.0, -2.0, 0.0}), false)
+	assertError(t, apiClient.CreateFromData("close_actual", []int{2, 2}, []float64{1.0005, 100.09, -2.0, 1e-9}), false)
+	assertError(t, apiClient.CreateFromData("close_far", []int{2, 2}, []float64{1.0, 100.0, -2.1, 0.0}), false)
+
+	t.Run("Passing", func(t *testing.T) {
+		// |100.09-100| = 0.09 <= 1e-8 + 1e-3*100; |1.0005-1| = 5e-4 <= 1e-8 + 1e-3*1.
+		assertError(t, apiClient.AssertClose("close_actual", "close_expected", 1e-3, 1e-8), false)
+	})
+
+	t.Run("Failing", func(t *testing.T) {
+		err := apiClient.AssertClose("close_actual", "close_expected", 1e-4, 1e-8)
+		assertErrorContains(t, err, "tidak berdekatan pada indeks [0 0]: 1.0005 vs 1")
+		err = apiClient.AssertClose("close_far", "close_expected", 1e-3, 1e-2)
+		assertErrorContains(t, err, "tidak berdekatan pada indeks [1 0]: -2.1 vs -2")
+	})
+
+	t.Run("Infinities", func(t *testing.T) {
+		inf := math.Inf(1)
+		assertError(t, apiClient.CreateFromData("close_inf_a", []int{3}, []float64{inf, -inf, 1}), false)
+		assertError(t, apiClient.CreateFromData("close_inf_b", []int{3}, []float64{inf, -inf, 1}), false)
+		assertError(t, apiClient.AssertClose("close_inf_a", "close_inf_b", 1e-3, 1e-8), false)
+		assertError(t, apiClient.CreateFromData("close_inf_c", []int{3}, []float64{inf, inf, 1}), false)
+		err := apiClient.AssertClose("close_inf_a", "close_inf_c", 1e-3, 1e-8)
+		assertErrorContains(t, err, "tidak berdekatan pada indeks [1]")
+	})
+
+	t.Run("Integers_Exact", func(t *testing.T) {
+		// 2^53 dan 2^53+1 sama setelah dikonversi ke float64, tetapi harus terdeteksi berbeda.
+		assertError(t, apiClient.CreateFromData("close_i64_a", []int{2}, []int64{1, 1 << 53}), false)
+		assertError(t, apiClient.CreateFromData("close_i64_b", []int{2}, []int64{1, 1<<53 + 1}), false)
+		err := apiClient.AssertClose("close_i64_a", "close_i64_b", 1e-3, 1e-8)
+		assertErrorContains(t, err, "tidak sama pada indeks [1]: 9007199254740992 vs 9007199254740993")
+		assertError(t, apiClient.AssertClose("close_i64_a", "close_i64_a", 0, 0), false)
+	})
+
+	t.Run("Mismatched_Tensors", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("close_other_shape", []int{4}, []float64{1, 100, -2, 0}), false)
+		err := apiClient.AssertClose("close_other_shape", "close_expected", 1e-3, 1e-8)
+		assertErrorContains(t, err, "shape tensor tidak sama")
+		assertError(t, apiClient.CreateFromData("close_f32", []int{2, 2}, []float32{1, 100, -2, 0}), false)
+		err = apiClient.AssertClose("close_f32", "close_expected", 1e-3, 1e-8)
+		assertErrorContains(t, err, "tipe data tensor tidak sama")
+	})
+}
+
+func TestRowIterator(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Float32_All_Rows", func(t *testing.T) {
+		data := []float32{0, 1, 2, 3, 10, 11, 12, 13, 20, 21, 22, 23}
+		assertError(t, apiClient.CreateFromData("rows_f32", []int{3, 4}, data), false)
+		next, closeRows, err := apiClient.RowIteratorFloat32("rows_f32")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		defer closeRows()
+		var rebuilt []float32
+		numRows := 0
+		for {
+			row, ok, err := next()
+			assertError(t, err, false)
+			if !ok || err != nil {
+				break
+			}
+			assertEqual(t, len(row), 4)
+			rebuilt = append(rebuilt, row...)
+			numRows++
+		}
+		assertEqual(t, numRows, 3)
+		assertEqual(t, rebuilt, data)
+		// Setelah baris terakhir, next tetap melaporkan selesai.
+		_, ok, err := next()
+		assertError(t, err, false)
+		assertEqual(t, ok, false)
+	})
+
+	t.Run("Concurrent_Iterators_Same_Tensor", func(t *testing.T) {
+		// Setiap iterator memegang mmap privat, jadi iterator lain, SELECT, dan RENAME atas tensor yang
+		// sama tidak melepas mmap yang sedang dibaca.
+		first, closeFirst, err := apiClient.RowIteratorFloat32("rows_f32")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		defer closeFirst()
+		second, closeSecond, err := apiClient.RowIteratorFloat32("rows_f32")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		defer closeSecond()
+		row, ok, err := first()
+		assertError(t, err, false)
+		assertEqual(t, ok, true)
+		assertEqual(t, row, []float32{0, 1, 2, 3})
+
+		_, err = apiClient.LoadTensorFloat32("rows_f32")
+		assertError(t, err, false)
+		for i := 0; i < 3; i++ {
+			row, ok, err = second()
+			assertError(t, err, false)
+			assertEqual(t, ok, true)
+			assertEqual(t, row[0], float32(10*i))
+		}
+		_, ok, err = second()
+		assertError(t, err, false)
+		assertEqual(t, ok, false)
+
+		assertError(t, apiClient.RenameTensor("rows_f32", "rows_f32_renamed"), false)
+		row, ok, err = first()
+		assertError(t, err, false)
+		assertEqual(t, ok, true)
+		assertEqual(t, row, []float32{10, 11, 12, 13})
+		assertError(t, apiClient.RenameTensor("rows_f32_renamed", "rows_f32"), false)
+		for ok && err == nil {
+			_, ok, err = first() // Habiskan agar mmap dilepas
+		}
+		assertError(t, err, false)
+	})
+
+	t.Run("Empty_Tensor", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("rows_empty", []int{0, 4}, tensor.DataTypeInt64), false)
+		next, closeRows, err := apiClient.RowIteratorInt64("rows_empty")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		defer closeRows()
+		row, ok, err := next()
+		assertError(t, err, false)
+		assertEqual(t, ok, false)
+		assertEqual(t, len(row), 0)
+	})
+
+	t.Run("Type_And_Rank_Mismatch", func(t *testing.T) {
+		_, _, err := apiClient.RowIteratorFloat64("rows_f32")
+		assertErrorContains(t, err, "tidak cocok dengan tipe yang diminta")
+		assertError(t, apiClient.CreateFromData("rows_1d", []int{3}, []float32{1, 2, 3}), false)
+		_, _, err = apiClient.RowIteratorFloat32("rows_1d")
+		assertErrorContains(t, err, "bukan matriks 2-D")
+	})
+
+	t.Run("Early_Close", func(t *testing.T) {
+		// Jumlah pemetaan file data di /proc/self/maps menunjukkan apakah mmap iterator sudah dilepas.
+		dataFile := filepath.Join(dataDir, "rows_f32.data")
+		countMappings := func() int {
+			maps, err := os.ReadFile("/proc/self/maps")
+			if err != nil {
+				t.Skipf("/proc/self/maps tidak tersedia: %v", err)
+			}
+			return strings.Count(string(maps), dataFile)
+		}
+		before := countMappings()
+		next, closeRows, err := apiClient.RowIteratorFloat32("rows_f32")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, countMappings(), before+1)
+		row, ok, err := next()
+		assertError(t, err, false)
+		assertEqual(t, ok, true)
+		assertEqual(t, row, []float32{0, 1, 2, 3})
+		assertError(t, closeRows(), false)
+		assertEqual(t, countMappings(), before, "close harus melepas mmap iterator")
+		assertError(t, closeRows(), false, "close kedua seharusnya no-op")
+		_, ok, err = next()
+		assertErrorContains(t, err, "sudah ditutup")
+		assertEqual(t, ok, false)
+	})
+}
+
+func TestLoadTensorAny(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("any_f32", []int{2, 2}, []float32{1.5, 2.5, 3.5, 4.5}), false)
+	assertError(t, apiClient.CreateFromData("any_i64", []int{3}, []int64{-7, 0, 1 << 40}), false)
+
+	loaded, meta, err := apiClient.LoadTensorAny("any_f32")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, meta.DataType, tensor.DataTypeFloat32)
+		f32, ok := loaded.(*tensor.Tensor[float32])
+		if !ok {
+			t.Fatalf("LoadTensorAny mengembalikan %T, harapan *tensor.Tensor[float32]", loaded)
+		}
+		assertEqual(t, f32.Shape, []int{2, 2})
+		assertEqual(t, f32.Data, []float32{1.5, 2.5, 3.5, 4.5})
+	}
+
+	loaded, meta, err = apiClient.LoadTensorAny("any_i64")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, meta.Shape, []int{3})
+		i64, ok := loaded.(*tensor.Tensor[int64])
+		if !ok {
+			t.Fatalf("LoadTensorAny mengembalikan %T, harapan *tensor.Tensor[int64]", loaded)
+		}
+		assertEqual(t, i64.Data, []int64{-7, 0, 1 << 40})
+	}
+
+	_, _, err = apiClient.LoadTensorAny("any_missing")
+	assertError(t, err, true)
+}
+
+func TestStreamBatches(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	data := make([]int32, 4*5)
+	for i := range data {
+		data[i] = int32(i)
+	}
+	assertError(t, apiClient.CreateFromData("stream_src", []int{4, 5}, data), false)
+
+	// collect menghabiskan iterator dan mengembalikan semua batch yang dihasilkan.
+	collect := func(t *testing.T, slice [][2]int, batchSize int) []tensor.TensorDataResult {
+		t.Helper()
+		next, closeStream, err := apiClient.StreamBatches("stream_src", slice, batchSize)
+		if err != nil {
+			t.Fatalf("StreamBatches gagal: %v", err)
+		}
+		defer closeStream()
+		var batches []tensor.TensorDataResult
+		for {
+			batch, ok, err := next()
+			if err != nil {
+				t.Fatalf("next gagal: %v", err)
+			}
+			if !ok {
+				if batch != nil {
+					t.Errorf("next mengembalikan batch non-nil setelah selesai: %+v", batch)
+				}
+				return batches
+			}
+			batches = append(batches, *batch)
+		}
+	}
+
+	cases := []struct {
+		name      string
+		slice     [][2]int
+		batchSize int
+	}{
+		{"Full_Tensor_Batched", nil, 6},
+		{"Slice_Batched", [][2]int{{1, 4}, {1, 4}}, 4},
+		{"Slice_Exact_Multiple", [][2]int{{0, 2}, {0, 5}}, 5},
+		{"Slice_Without_Batching", [][2]int{{2, 4}, {3, 5}}, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			streamed := collect(t, tc.slice, tc.batchSize)
+			expected, err := apiClient.GetData([]string{"stream_src"}, [][][2]int{tc.slice}, tc.batchSize)
+			assertError(t, err, false)
+			assertEqual(t, streamed, expected.([]tensor.TensorDataResult))
+		})
+	}
+
+	t.Run("Batch_Boundaries", func(t *testing.T) {
+		batches := collect(t, [][2]int{{1, 4}, {1, 4}}, 4)
+		assertEqual(t, len(batches), 3)
+		if len(batches) == 3 {
+			assertEqual(t, batches[0].Data, []int32{6, 7, 8, 11})
+			assertEqual(t, batches[1].Data, []int32{12, 13, 16, 17})
+			assertEqual(t, batches[2].Data, []int32{18})
+			assertEqual(t, batches[2].DataSizeBytes, 4)
+			assertEqual(t, batches[2].BatchInfo.CurrentBatchIndex, 2)
+		}
+	})
+
+	t.Run("Invalid_Slice", func(t *testing.T) {
+		_, _, err := apiClient.StreamBatches("stream_src", [][2]int{{0, 5}, {0, 5}}, 2)
+		assertErrorContains(t, err, "tidak valid untuk dimensi 0")
+	})
+
+	t.Run("Early_Close", func(t *testing.T) {
+		next, closeStream, err := apiClient.StreamBatches("stream_src", nil, 6)
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		batch, ok, err := next()
+		assertError(t, err, false)
+		assertEqual(t, ok, true)
+		assertEqual(t, batch.Data, data[:6])
+		assertError(t, closeStream(), false)
+		assertError(t, closeStream(), false, "close kedua seharusnya no-op")
+		_, ok, err = next()
+		assertErrorContains(t, err, "sudah ditutup")
+		assertEqual(t, ok, false)
+	})
+
+	t.Run("Interleaved_With_Select", func(t *testing.T) {
+		// Tanpa cache handle, setiap SELECT membuka dan menutup handle tensor; mmap stream harus tetap
+		// valid karena dipetakan terpisah.
+		_, executor, cleanupExec := setupTest(t, tensor.WithMaxOpenFiles(0))
+		defer cleanupExec()
+		uncached := client.NewClient(executor)
+		assertError(t, uncached.CreateFromData("stream_sel", []int{4, 5}, data), false)
+
+		next, closeStream, err := uncached.StreamBatches("stream_sel", nil, 5)
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		defer closeStream()
+		var streamed []int32
+		for {
+			_, err := uncached.SelectData("stream_sel", nil)
+			assertError(t, err, false)
+			batch, ok, err := next()
+			assertError(t, err, false)
+			if !ok || err != nil {
+				break
+			}
+			streamed = append(streamed, batch.Data.([]int32)...)
+		}
+		assertEqual(t, streamed, data)
+	})
+}
+
+func TestCSVImportExport(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Float32_Round_Trip", func(t *testing.T) {
+		input := "1.5,2,-3\n0.1, 4e3,5\n"
+		assertError(t, apiClient.ImportCSV("csv_f32", strings.NewReader(input), tensor.DataTypeFloat32), false)
+		loaded, err := apiClient.LoadTensorFloat32("csv_f32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{2, 3})
+			assertEqual(t, loaded.Data, []float32{1.5, 2, -3, 0.1, 4000, 5})
+		}
+
+		var out bytes.Buffer
+		assertError(t, apiClient.ExportCSV("csv_f32", &out), false)
+		assertEqual(t, out.String(), "1.5,2,-3\n0.1,4000,5\n")
+		assertError(t, apiClient.ImportCSV("csv_f32_copy", &out, tensor.DataTypeFloat32), false)
+		assertError(t, apiClient.AssertClose("csv_f32_copy", "csv_f32", 0, 0), false)
+	})
+
+	t.Run("Int64_Round_Trip", func(t *testing.T) {
+		input := "9007199254740993,-1\n0,42\n7,8\n"
+		assertError(t, apiClient.ImportCSV("csv_i64", strings.NewReader(input), tensor.DataTypeInt64), false)
+		var out bytes.Buffer
+		assertError(t, apiClient.ExportCSV("csv_i64", &out), false)
+		assertEqual(t, out.String(), input)
+	})
+
+	t.Run("Ragged_Rows", func(t *testing.T) {
+		err := apiClient.ImportCSV("csv_ragged", strings.NewReader("1,2,3\n4,5,6\n7,8\n"), tensor.DataTypeInt32)
+		assertErrorContains(t, err, "baris CSV 3 memiliki 2 kolom, sedangkan baris 1 memiliki 3 kolom")
+		_, err = apiClient.GetTensorMetadata("csv_ragged")
+		assertError(t, err, true)
+	})
+
+	t.Run("Empty_Input", func(t *testing.T) {
+		err := apiClient.ImportCSV("csv_empty", strings.NewReader(""), tensor.DataTypeFloat32)
+		assertErrorContains(t, err, "CSV untuk tensor 'csv_empty' kosong")
+	})
+
+	t.Run("Invalid_Cell_Removes_Tensor", func(t *testing.T) {
+		err := apiClient.ImportCSV("csv_bad_cell", strings.NewReader("1,2\n3,x\n"), tensor.DataTypeInt32)
+		assertErrorContains(t, err, "gagal menyisipkan data CSV ke tensor 'csv_bad_cell'")
+		_, err = apiClient.GetTensorMetadata("csv_bad_cell")
+		assertError(t, err, true)
+	})
+
+	t.Run("Export_Requires_2D", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("csv_1d", []int{3}, []float64{1, 2, 3}), false)
+		var out bytes.Buffer
+		err := apiClient.ExportCSV("csv_1d", &out)
+		assertErrorContains(t, err, "bukan matriks 2-D")
+	})
+}
+
+func TestExportNPY(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	data := []float32{1, 2, 3, 4.5, -5, 6}
+	assertError(t, apiClient.CreateFromData("npy_f32", []int{2, 3}, data), false)
+	var out bytes.Buffer
+	assertError(t, apiClient.ExportNPY("npy_f32", &out), false)
+	written := out.Bytes()
+
+	// 10 byte preamble + 118 byte header = 128 (kelipatan 64), lalu 6 elemen x 4 byte.
+	assertEqual(t, len(written), 128+24)
+	if len(written) != 152 {
+		return
+	}
+	assertEqual(t, string(written[:8]), "\x93NUMPY\x01\x00")
+	assertEqual(t, binary.LittleEndian.Uint16(written[8:10]), uint16(118))
+	expectedHeader := "{'descr': '<f4', 'fortran_order': False, 'shape': (2, 3), }" + strings.Repeat(" ", 58) + "\n"
+	assertEqual(t, string(written[10:128]), expectedHeader)
+	decoded := make([]float32, len(data))
+	assertError(t, binary.Read(bytes.NewReader(written[128:]), binary.LittleEndian, decoded), false)
+	assertEqual(t, decoded, data)
+
+	t.Run("One_Dimensional_Int64", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("npy_i64", []int{3}, []int64{1, 2, 3}), false)
+		var out bytes.Buffer
+		assertError(t, apiClient.ExportNPY("npy_i64", &out), false)
+		header := out.String()[10:]
+		assertEqual(t, strings.HasPrefix(header, "{'descr': '<i8', 'fortran_order': False, 'shape': (3,), }"), true)
+		assertEqual(t, (out.Len()-24)%64, 0)
+	})
+}
+
+func TestGetTensorMetadataBatch(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateTensor("meta_batch_a", []int{2, 3}, tensor.DataTypeFloat32), false)
+	assertError(t, apiClient.CreateTensor("meta_batch_b", []int{4}, tensor.DataTypeInt64), false)
+
+	found, failed := apiClient.GetTensorMetadataBatch([]string{"meta_batch_a", "meta_missing", "meta_batch_b", "meta_batch_a", ""})
+	assertEqual(t, len(found), 2)
+	assertEqual(t, len(failed), 2)
+	if meta, ok := found["meta_batch_a"]; ok {
+		assertEqual(t, meta.Shape, []int{2, 3})
+		assertEqual(t, meta.DataType, tensor.DataTypeFloat32)
+	} else {
+		t.Errorf("metadata meta_batch_a tidak ditemukan")
+	}
+	if meta, ok := found["meta_batch_b"]; ok {
+		assertEqual(t, meta.Shape, []int{4})
+		assertEqual(t, meta.DataType, tensor.DataTypeInt64)
+	} else {
+		t.Errorf("metadata meta_batch_b tidak ditemukan")
+	}
+	assertErrorContains(t, failed["meta_missing"], "gagal memuat metadata untuk tensor 'meta_missing'")
+	assertErrorContains(t, failed[""], "nama tensor tidak boleh kosong")
+
+	// Metadata dari indeks harus mengikuti penulisan dan rename berikutnya, dan tidak boleh berbagi
+	// data dengan indeks.
+	found["meta_batch_b"].Shape[0] = 99
+	assertError(t, apiClient.InsertInt64Data("meta_batch_b", []int64{1, 2, 3, 4}), false)
+	assertError(t, apiClient.RenameTensor("meta_batch_a", "meta_batch_c"), false)
+	found, failed = apiClient.GetTensorMetadataBatch([]string{"meta_batch_a", "meta_batch_b", "meta_batch_c"})
+	assertEqual(t, len(found), 2)
+	assertErrorContains(t, failed["meta_batch_a"], "gagal memuat metadata untuk tensor 'meta_batch_a'")
+	onDisk, err := apiClient.GetTensorMetadata("meta_batch_b")
+	assertError(t, err, false)
+	if meta, ok := found["meta_batch_b"]; ok && err == nil {
+		assertEqual(t, meta.Shape, []int{4})
+		assertEqual(t, meta.Checksum, onDisk.Checksum)
+	} else {
+		t.Errorf("metadata meta_batch_b tidak ditemukan")
+	}
+	if meta, ok := found["meta_batch_c"]; ok {
+		assertEqual(t, meta.Shape, []int{2, 3})
+	} else {
+		t.Errorf("metadata meta_batch_c tidak ditemukan")
+	}
+}
+
+func TestImportNPY(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Round_Trip_Float32", func(t *testing.T) {
+		data := []float32{1, 2, 3, 4.5, -5, 6}
+		assertError(t, apiClient.CreateFromData("npy_src_f32", []int{2, 3}, data), false)
+		var buf bytes.Buffer
+		assertError(t, apiClient.ExportNPY("npy_src_f32", &buf), false)
+		assertError(t, apiClient.ImportNPY("npy_dst_f32", &buf), false)
+		loaded, err := apiClient.LoadTensorFloat32("npy_dst_f32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{2, 3})
+			assertEqual(t, loaded.DataType, tensor.DataTypeFloat32)
+			assertEqual(t, loaded.Data, data)
+		}
+	})
+
+	t.Run("Round_Trip_Int64_1D", func(t *testing.T) {
+		data := []int64{-1, 1 << 40, 7}
+		assertError(t, apiClient.CreateFromData("npy_src_i64", []int{3}, data), false)
+		var buf bytes.Buffer
+		assertError(t, apiClient.ExportNPY("npy_src_i64", &buf), false)
+		assertError(t, apiClient.ImportNPY("npy_dst_i64", &buf), false)
+		loaded, err := apiClient.LoadTensorInt64("npy_dst_i64")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{3})
+			assertEqual(t, loaded.Data, data)
+		}
+	})
+
+	// npyFile menyusun file .npy versi 1.0 dengan header dict yang diberikan.
+	npyFile := func(header string, data []byte) *bytes.Reader {
+		header += strings.Repeat(" ", (64-(10+len(header)+1)%64)%64) + "\n"
+		buf := []byte("\x93NUMPY\x01\x00")
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(len(header)))
+		buf = append(buf, header...)
+		return bytes.NewReader(append(buf, data...))
+	}
+
+	t.Run("Rejected_Headers", func(t *testing.T) {
+		err := apiClient.ImportNPY("npy_big", npyFile("{'descr': '>f4', 'fortran_order': False, 'shape': (1,), }", make([]byte, 4)))
+		assertErrorContains(t, err, "big-endian '>f4' tidak didukung")
+		err = apiClient.ImportNPY("npy_fortran", npyFile("{'descr': '<f4', 'fortran_order': True, 'shape': (2, 2), }", make([]byte, 16)))
+		assertErrorContains(t, err, "fortran_order=True belum didukung")
+		err = apiClient.ImportNPY("npy_complex", npyFile("{'descr': '<c8', 'fortran_order': False, 'shape': (1,), }", make([]byte, 8)))
+		assertErrorContains(t, err, "dtype .npy '<c8' tidak didukung")
+		err = apiClient.ImportNPY("npy_short", npyFile("{'descr': '<i4', 'fortran_order': False, 'shape': (3,), }", make([]byte, 8)))
+		assertErrorContains(t, err, "ukuran data .npy (8 byte) tidak sesuai shape [3]")
+		_, err = apiClient.GetTensorMetadata("npy_short")
+		assertError(t, err, true)
+		err = apiClient.ImportNPY("npy_no_shape", npyFile("{'descr': '<f4', 'fortran_order': False, }", nil))
+		assertErrorContains(t, err, "kunci 'shape' harus muncul tepat sekali")
+	})
+
+	t.Run("Key_Order_And_Byte_Order_Prefixes", func(t *testing.T) {
+		f32 := make([]byte, 8)
+		binary.LittleEndian.PutUint32(f32, math.Float32bits(1.5))
+		binary.LittleEndian.PutUint32(f32[4:], math.Float32bits(-2))
+		assertError(t, apiClient.ImportNPY("npy_native", npyFile("{'shape': (2,), 'fortran_order': False, 'descr': '=f4'}", f32)), false)
+		loaded, err := apiClient.LoadTensorFloat32("npy_native")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []float32{1.5, -2})
+		}
+		assertError(t, apiClient.ImportNPY("npy_pipe_u8", npyFile("{'fortran_order': False, 'descr': '|u1', 'shape': (3,), }", []byte{1, 2, 3})), false)
+		assertError(t, apiClient.ImportNPY("npy_big_i1", npyFile("{'descr': '>i1', 'fortran_order': False, 'shape': (1,), }", []byte{0xFF})), false)
+		i8, err := apiClient.LoadTensorInt8("npy_big_i1")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, i8.Data, []int8{-1})
+		}
+	})
+}
+
+func TestJSONExportImport(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	data := make([]int32, 2*3*2)
+	for i := range data {
+		data[i] = int32(i*10 - 50)
+	}
+	assertError(t, apiClient.CreateFromData("json_src", []int{2, 3, 2}, data), false)
+
+	t.Run("Round_Trip_3D_Int32", func(t *testing.T) {
+		var buf bytes.Buffer
+		assertError(t, apiClient.ExportJSON("json_src", &buf), false)
+		var doc map[string]interface{}
+		assertError(t, json.Unmarshal(buf.Bytes(), &doc), false)
+		assertEqual(t, doc["datatype"], tensor.DataTypeInt32)
+		assertEqual(t, doc["shape"], []interface{}{float64(2), float64(3), float64(2)})
+		assertEqual(t, doc["strides"], []interface{}{float64(6), float64(2), float64(1)})
+		assertEqual(t, doc["data"].([]interface{})[1].([]interface{})[2], []interface{}{float64(50), float64(60)})
+
+		// Impor ke direktori data lain agar nama tensor yang sama dapat dibuat ulang.
+		_, otherClient, otherCleanup := setupTestClient(t)
+		defer otherCleanup()
+		assertError(t, otherClient.ImportJSON(&buf), false)
+		loaded, err := otherClient.LoadTensorInt32("json_src")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{2, 3, 2})
+			assertEqual(t, loaded.Data, data)
+		}
+	})
+
+	t.Run("Round_Trip_Non_Finite_Float", func(t *testing.T) {
+		floats := []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1.5}
+		assertError(t, apiClient.CreateFromData("json_nonfinite", []int{2, 2}, floats), false)
+		var buf bytes.Buffer
+		assertError(t, apiClient.ExportJSON("json_nonfinite", &buf), false)
+		assertTrue(t, strings.Contains(buf.String(), `"data":[["NaN","Infinity"],["-Infinity",1.5]]`), "JSON aktual: %s", buf.String())
+
+		_, otherClient, otherCleanup := setupTestClient(t)
+		defer otherCleanup()
+		assertError(t, otherClient.ImportJSON(&buf), false)
+		loaded, err := otherClient.LoadTensorFloat64("json_nonfinite")
+		assertError(t, err, false)
+		if err == nil {
+			assertTrue(t, math.IsNaN(loaded.Data[0]), "Elemen 0 harus NaN")
+			assertEqual(t, loaded.Data[1:], floats[1:])
+		}
+
+		input := `{"name":"json_bad","shape":[1],"datatype":"int32","strides":[1],"data":["NaN"]}`
+		err = apiClient.ImportJSON(strings.NewReader(input))
+		assertError(t, err, true, "NaN tidak valid untuk tensor int32")
+		input = `{"name":"json_bad","shape":[1],"datatype":"float32","strides":[1],"data":["nan?"]}`
+		err = apiClient.ImportJSON(strings.NewReader(input))
+		assertErrorContains(t, err, `data[0] harus berupa angka, bool, atau "NaN"/"Infinity"/"-Infinity", bukan string "nan?"`)
+	})
+
+	t.Run("Shape_Mismatch", func(t *testing.T) {
+		input := `{"name":"json_bad","shape":[2,2],"datatype":"int32","strides":[2,1],"data":[[1,2],[3]]}`
+		err := apiClient.ImportJSON(strings.NewReader(input))
+		assertErrorContains(t, err, "data[1] memiliki 1 elemen, sedangkan dimensi 1 berukuran 2")
+		input = `{"name":"json_bad","shape":[2,2],"datatype":"int32","strides":[2,1],"data":[[1,2],[3,[4]]]}`
+		err = apiClient.ImportJSON(strings.NewReader(input))
+		assertErrorContains(t, err, "data[1][1] harus berupa angka atau bool")
+		input = `{"name":"json_bad","shape":[3],"datatype":"int32","strides":[1],"data":[1,2]}`
+		err = apiClient.ImportJSON(strings.NewReader(input))
+		assertErrorContains(t, err, "data memiliki 2 elemen, sedangkan dimensi 0 berukuran 3")
+		if _, statErr := os.Stat(filepath.Join(dataDir, "json_bad.meta")); !os.IsNotExist(statErr) {
+			t.Errorf("tensor json_bad seharusnya tidak dibuat, stat error: %v", statErr)
+		}
+	})
+}
+
+func TestClientReopen(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("reopen_f64", []int{2, 2}, []float64{1.5, -2, 3, 4.25}), false)
+	assertError(t, apiClient.CreateFromData("reopen_i32", []int{3}, []int32{7, 8, 9}), false)
+	assertError(t, apiClient.CreateFromData("reopen_dropped", []int{1}, []int32{1}), false)
+	assertError(t, apiClient.UpdateElement("reopen_f64", []int{1, 0}, 30), false)
+	assertError(t, apiClient.DropTensor("reopen_dropped"), false)
+	// Muat sekali agar ada handle ter-cache yang harus ditutup oleh Reopen.
+	_, err := apiClient.LoadTensorFloat64("reopen_f64")
+	assertError(t, err, false)
+
+	assertError(t, apiClient.Reopen(), false)
+
+	f64, err := apiClient.LoadTensorFloat64("reopen_f64")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, f64.Shape, []int{2, 2})
+		assertEqual(t, f64.Data, []float64{1.5, -2, 30, 4.25})
+	}
+	i32, err := apiClient.LoadTensorInt32("reopen_i32")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, i32.Data, []int32{7, 8, 9})
+	}
+	_, err = apiClient.LoadTensorInt32("reopen_dropped")
+	assertError(t, err, true)
+
+	// Indeks dibangun ulang dari file .meta di disk.
+	metas, err := apiClient.ListTensors(tensor.DataTypeInt32, -1)
+	assertError(t, err, false)
+	var names []string
+	for _, meta := range metas {
+		names = append(names, meta.Name)
+	}
+	assertEqual(t, names, []string{"reopen_i32"})
+}
+
+func TestDescribeTensor(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Populated", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("describe_full", []int{2, 3}, []int64{1, 2, 3, 4, 5, 6}), false)
+		query, err := (&tensor.Parser{}).Parse("DESCRIBE describe_full")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Type, tensor.DescribeTensorQuery)
+			assertEqual(t, query.TensorNames, []string{"describe_full"})
+		}
+		desc, err := apiClient.DescribeTensor("describe_full")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, *desc, tensor.TensorDescription{
+				Name: "describe_full", Shape: []int{2, 3}, DataType: tensor.DataTypeInt64, Strides: []int{3, 1},
+				NumDimensions: 2, TotalElements: 6, DataSizeBytes: 48,
+			})
+		}
+	})
+
+	t.Run("Zero_Dimension", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("describe_empty", []int{2, 0, 3}, tensor.DataTypeFloat32), false)
+		desc, err := apiClient.DescribeTensor("describe_empty")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, desc.Shape, []int{2, 0, 3})
+			assertEqual(t, desc.NumDimensions, 3)
+			assertEqual(t, desc.TotalElements, 0)
+			assertEqual(t, desc.DataSizeBytes, 0)
+		}
+	})
+
+	t.Run("Not_Found", func(t *testing.T) {
+		_, err := apiClient.DescribeTensor("describe_missing")
+		assertErrorContains(t, err, "tensor 'describe_missing' not found for describe")
+	})
+}
+
+func TestSelectView(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("view_f32", []int{2, 3}, []float32{1.5, 2, 3, 4, 5, -6.25}), false)
+
+	t.Run("Reads_Without_Copy", func(t *testing.T) {
+		view, err := apiClient.SelectView("view_f32")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		defer view.Close()
+		assertEqual(t, view.Metadata().Shape, []int{2, 3})
+
+		value, err := view.At([]int{1, 2})
+		assertError(t, err, false)
+		assertEqual(t, value, -6.25)
+
+		data, err := view.Float32Slice()
+		assertError(t, err, false)
+		assertEqual(t, data, []float32{1.5, 2, 3, 4, 5, -6.25})
+
+		// Slice menunjuk ke byte file .data yang sama, bukan salinan.
+		raw, err := os.ReadFile(filepath.Join(dataDir, "view_f32.data"))
+		assertError(t, err, false)
+		if len(data) == 6 && len(raw) == 24 {
+			assertEqual(t, math.Float32bits(data[4]), binary.LittleEndian.Uint32(raw[16:20]))
+		}
+
+		_, err = view.At([]int{2, 0})
+		assertErrorContains(t, err, "di luar batas")
+	})
+
+	t.Run("Reflects_In_Place_Update", func(t *testing.T) {
+		view, err := apiClient.SelectView("view_f32")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		defer view.Close()
+		assertError(t, apiClient.UpdateElement("view_f32", []int{0, 1}, 42), false)
+		value, err := view.At([]int{0, 1})
+		assertError(t, err, false)
+		assertEqual(t, value, 42.0)
+	})
+
+	t.Run("Closed_View", func(t *testing.T) {
+		view, err := apiClient.SelectView("view_f32")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertError(t, view.Close(), false)
+		assertError(t, view.Close(), false, "Close kedua kali harus aman")
+		_, err = view.At([]int{0, 0})
+		assertErrorContains(t, err, "sudah ditutup")
+		_, err = view.Float32Slice()
+		assertErrorContains(t, err, "sudah ditutup")
+	})
+
+	t.Run("Other_Types_And_Errors", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("view_i16", []int{3}, []int16{-7, 0, 300}), false)
+		view, err := apiClient.SelectView("view_i16")
+		assertError(t, err, false)
+		if err == nil {
+			value, err := view.At([]int{0})
+			assertError(t, err, false)
+			assertEqual(t, value, -7.0)
+			_, err = view.Float32Slice()
+			assertErrorContains(t, err, "tidak cocok")
+			assertError(t, view.Close(), false)
+		}
+
+		_, err = apiClient.SelectView("view_missing")
+		assertErrorContains(t, err, "failed to open view of tensor 'view_missing'")
+	})
+}
+
+func TestCountElements(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("count_full", []int{3, 4}, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}), false)
+	query, err := (&tensor.Parser{}).Parse("COUNT count_full")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, query.Type, tensor.CountQuery)
+		assertEqual(t, query.TensorNames, []string{"count_full"})
+	}
+	count, err := apiClient.CountElements("count_full")
+	assertError(t, err, false)
+	assertEqual(t, count, 12)
+
+	t.Run("Data_File_Missing", func(t *testing.T) {
+		assertError(t, os.Remove(filepath.Join(dataDir, "count_full.data")), false)
+		count, err := apiClient.CountElements("count_full")
+		assertError(t, err, false, "COUNT tidak boleh menyentuh file .data")
+		assertEqual(t, count, 12)
+	})
+
+	t.Run("Scalar_And_Zero_Dimension", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("count_scalar", []int{}, tensor.DataTypeInt32), false)
+		count, err := apiClient.CountElements("count_scalar")
+		assertError(t, err, false)
+		assertEqual(t, count, 1)
+		assertError(t, apiClient.CreateTensor("count_empty", []int{5, 0}, tensor.DataTypeInt32), false)
+		count, err = apiClient.CountElements("count_empty")
+		assertError(t, err, false)
+		assertEqual(t, count, 0)
+	})
+
+	t.Run("Not_Found", func(t *testing.T) {
+		_, err := apiClient.CountElements("count_missing")
+		assertErrorContains(t, err, "tensor 'count_missing' not found for count")
+	})
+}
+
+func TestValidateTensor(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	values := []float32{1.5, -2, float32(math.NaN()), 7, float32(math.Inf(1)), 0}
+	assertError(t, apiClient.CreateFromData("validate_f32", []int{2, 3}, values), false)
+
+	t.Run("Reports_NaN_And_Inf", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("VALIDATE TENSOR validate_f32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Type, tensor.ValidateTensorQuery)
+		}
+		report, err := apiClient.ValidateTensor("validate_f32")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, report.ExpectedElements, 6)
+		assertEqual(t, report.ActualElements, 6)
+		assertEqual(t, report.FileSizeBytes, int64(24))
+		assertTrue(t, report.SizeMatches, "Ukuran file harus cocok dengan shape")
+		assertTrue(t, report.ChecksumMatches, "Checksum harus cocok")
+		assertEqual(t, report.NaNCount, 1)
+		assertEqual(t, report.InfCount, 1)
+		assertEqual(t, report.Min, -2.0)
+		assertTrue(t, math.IsInf(report.Max, 1), "Max harus +Inf, didapat %v", report.Max)
+		assertTrue(t, !report.Valid, "Tensor dengan NaN tidak boleh dianggap valid")
+	})
+
+	t.Run("Clean_Integer_Tensor", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("validate_i32", []int{4}, []int32{3, -9, 12, 0}), false)
+		report, err := apiClient.ValidateTensor("validate_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, report.NaNCount, 0)
+			assertEqual(t, report.Min, -9.0)
+			assertEqual(t, report.Max, 12.0)
+			assertTrue(t, report.Valid, "Tensor bersih harus valid")
+		}
+	})
+
+	t.Run("Truncated_Data_File", func(t *testing.T) {
+		dataPath := filepath.Join(dataDir, "validate_i32.data")
+		raw, err := os.ReadFile(dataPath)
+		assertError(t, err, false)
+		assertError(t, os.WriteFile(dataPath, raw[:10], 0644), false)
+		report, err := apiClient.ValidateTensor("validate_i32")
+		assertError(t, err, false, "Ukuran file yang salah harus dilaporkan, bukan menjadi error")
+		if err == nil {
+			assertEqual(t, report.FileSizeBytes, int64(10))
+			assertEqual(t, report.ExpectedBytes, int64(16))
+			assertEqual(t, report.ActualElements, 2)
+			assertTrue(t, !report.SizeMatches && !report.ChecksumMatches && !report.Valid, "Laporan harus menandai ukuran tidak cocok: %+v", report)
+			assertEqual(t, report.Min, -9.0)
+			assertEqual(t, report.Max, 3.0)
+		}
+	})
+
+	t.Run("Not_Found", func(t *testing.T) {
+		_, err := apiClient.ValidateTensor("validate_missing")
+		assertErrorContains(t, err, "tensor 'validate_missing' not found for validate")
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	data := []float32{1, 2, 3, 4, 5, 6}
+	assertError(t, apiClient.CreateFromData("fp_a", []int{2, 3}, data), false)
+	assertError(t, apiClient.CreateFromData("fp_b", []int{2, 3}, data), false)
+
+	fpA, err := apiClient.Fingerprint("fp_a")
+	assertError(t, err, false)
+	assertEqual(t, len(fpA), 64)
+
+	t.Run("Identical_Tensors_Match", func(t *testing.T) {
+		fpB, err := apiClient.Fingerprint("fp_b")
+		assertError(t, err, false)
+		assertEqual(t, fpB, fpA)
+		again, err := apiClient.Fingerprint("fp_a")
+		assertError(t, err, false)
+		assertEqual(t, again, fpA)
+	})
+
+	t.Run("Shape_And_Type_Are_Hashed", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("fp_flat", []int{6}, data), false)
+		fpFlat, err := apiClient.Fingerprint("fp_flat")
+		assertError(t, err, false)
+		assertTrue(t, fpFlat != fpA, "Shape berbeda harus menghasilkan fingerprint berbeda")
+
+		// 1.0 float32 dan 1065353216 int32 memiliki byte yang sama.
+		bits := make([]int32, len(data))
+		for i, v := range data {
+			bits[i] = int32(math.Float32bits(v))
+		}
+		assertError(t, apiClient.CreateFromData("fp_bits", []int{2, 3}, bits), false)
+		fpBits, err := apiClient.Fingerprint("fp_bits")
+		assertError(t, err, false)
+		assertTrue(t, fpBits != fpA, "Tipe data berbeda harus menghasilkan fingerprint berbeda")
+	})
+
+	t.Run("Single_Element_Change", func(t *testing.T) {
+		assertError(t, apiClient.UpdateElement("fp_b", []int{1, 2}, 7), false)
+		fpB, err := apiClient.Fingerprint("fp_b")
+		assertError(t, err, false)
+		assertTrue(t, fpB != fpA, "Perubahan satu elemen harus mengubah fingerprint")
+		assertError(t, apiClient.UpdateElement("fp_b", []int{1, 2}, 6), false)
+		fpB, err = apiClient.Fingerprint("fp_b")
+		assertError(t, err, false)
+		assertEqual(t, fpB, fpA)
+	})
+
+	t.Run("Missing_Tensor", func(t *testing.T) {
+		_, err := apiClient.Fingerprint("fp_missing")
+		assertErrorContains(t, err, "failed to fingerprint tensor 'fp_missing'")
+		_, err = apiClient.Fingerprint("")
+		assertErrorContains(t, err, "nama tensor tidak boleh kosong")
+	})
+}
+
+func TestCreateFilled(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Client_Float32", func(t *testing.T) {
+		assertError(t, apiClient.CreateFilled("filled_f32", []int{2, 2}, tensor.DataTypeFloat32, 1.5), false)
+		data, err := apiClient.SelectData("filled_f32", nil)
+		assertError(t, err, false)
+		row := []interface{}{float32(1.5), float32(1.5)}
+		assertEqual(t, data, []interface{}{row, row})
+	})
+
+	t.Run("Client_Int32", func(t *testing.T) {
+		assertError(t, apiClient.CreateFilled("filled_i32", []int{2, 2}, tensor.DataTypeInt32, -7), false)
+		loaded, err := apiClient.LoadTensorInt32("filled_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{2, 2})
+			assertEqual(t, loaded.Data, []int32{-7, -7, -7, -7})
+		}
+		err = apiClient.CreateFilled("filled_i32_frac", []int{2}, tensor.DataTypeInt32, 1.5)
+		assertErrorContains(t, err, "invalid FILL value for tensor 'filled_i32_frac': failed to parse scalar operand '1.5' as int32")
+		_, statErr := apiClient.GetTensorMetadata("filled_i32_frac")
+		assertError(t, statErr, true, "Tensor dengan FILL tidak valid tidak boleh dibuat")
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		_, executor, cleanupExecutor := setupTest(t)
+		defer cleanupExecutor()
+		parser := &tensor.Parser{}
+		run := func(q string) (interface{}, error) {
+			query, err := parser.Parse(q)
+			if err != nil {
+				return nil, err
+			}
+			return executor.Execute(query)
+		}
+
+		query, err := parser.Parse("CREATE TENSOR ones 3,3 TYPE float32 fill 1.0")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Shape, []int{3, 3})
+			assertEqual(t, query.DataType, tensor.DataTypeFloat32)
+			assertEqual(t, query.ScalarOperand, "1.0")
+		}
+
+		_, err = run("CREATE TENSOR sevens 2,2 TYPE int64 FILL 7 TRACK_STATS")
+		assertError(t, err, false)
+		data, err := run("SELECT sevens FROM sevens")
+		assertError(t, err, false)
+		row := []interface{}{int64(7), int64(7)}
+		assertEqual(t, data, []interface{}{row, row})
+		stats, err := run("STATS TENSOR sevens")
+		assertError(t, err, false)
+		s, ok := stats.(*tensor.TensorStats)
+		assertTrue(t, ok, "Hasil STATS bukan *tensor.TensorStats: %T", stats)
+		if ok {
+			assertEqual(t, s.Count, int64(4))
+			assertEqual(t, s.Mean, 7.0)
+		}
+
+		_, err = run("CREATE TENSOR flags 3 TYPE bool FILL true")
+		assertError(t, err, false)
+		_, raw, err := executor.ReadTensorRaw("flags")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, raw, []byte{1, 1, 1})
+		}
+
+		_, err = run("CREATE TENSOR bad_u8 2 TYPE uint8 FILL 300")
+		assertErrorContains(t, err, "failed to parse scalar operand '300' as uint8")
+		_, err = run("CREATE TENSOR bad_fill 2 TYPE float32 FILL")
+		assertErrorContains(t, err, "missing value after FILL keyword")
+	})
+}
+
+func TestCreateIdentity(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Client", func(t *testing.T) {
+		assertError(t, apiClient.CreateIdentity("eye3", 3, tensor.DataTypeFloat64), false)
+		loaded, err := apiClient.LoadTensorFloat64("eye3")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{3, 3})
+			for i := 0; i < 3; i++ {
+				for j := 0; j < 3; j++ {
+					want := 0.0
+					if i == j {
+						want = 1.0
+					}
+					assertTrue(t, loaded.Data[i*3+j] == want, "Elemen [%d,%d] = %v, diharapkan %v", i, j, loaded.Data[i*3+j], want)
+				}
+			}
+		}
+		assertErrorContains(t, apiClient.CreateIdentity("eye0", 0, tensor.DataTypeFloat64), "harus positif")
+		assertErrorContains(t, apiClient.CreateIdentity("eye_neg", -2, tensor.DataTypeFloat64), "harus positif")
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		_, executor, cleanupExecutor := setupTest(t)
+		defer cleanupExecutor()
+		parser := &tensor.Parser{}
+		run := func(q string) (interface{}, error) {
+			query, err := parser.Parse(q)
+			if err != nil {
+				return nil, err
+			}
+			return executor.Execute(query)
+		}
+
+		_, err := run("CREATE IDENTITY eye2 2 TYPE int32")
+		assertError(t, err, false)
+		data, err := run("SELECT eye2 FROM eye2")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{[]interface{}{int32(1), int32(0)}, []interface{}{int32(0), int32(1)}})
+
+		query, err := parser.Parse("create identity eye4 4")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Shape, []int{4, 4})
+			assertEqual(t, query.DataType, tensor.DataTypeFloat64)
+			assertTrue(t, query.Identity, "Kueri CREATE IDENTITY harus menandai Identity")
+		}
+
+		_, err = run("CREATE IDENTITY eye_zero 0")
+		assertErrorContains(t, err, "identity size must be positive, got 0")
+		_, err = run("CREATE IDENTITY eye_neg -3 TYPE float32")
+		assertErrorContains(t, err, "identity size must be positive, got -3")
+		_, err = run("CREATE IDENTITY eye_bad 3 TYPE complex")
+		assertErrorContains(t, err, "invalid data type 'complex' in CREATE IDENTITY")
+		_, err = run("CREATE IDENTITY eye_missing")
+		assertErrorContains(t, err, "invalid CREATE IDENTITY syntax")
+		_, err = executor.Execute(&tensor.Query{Type: tensor.CreateTensorQuery, TensorNames: []string{"eye_rect"}, Shape: []int{2, 3}, DataType: tensor.DataTypeFloat32, Identity: true})
+		assertErrorContains(t, err, "requires a positive size n")
+	})
+}
+
+func TestCreateRange(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Client_Integer", func(t *testing.T) {
+		assertError(t, apiClient.CreateRange("evens", 0, 10, 2, tensor.DataTypeInt32), false)
+		loaded, err := apiClient.LoadTensorInt32("evens")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{5})
+			assertEqual(t, loaded.Data, []int32{0, 2, 4, 6, 8})
+		}
+		assertError(t, apiClient.CreateRange("countdown", 5, 0, -2, tensor.DataTypeInt64), false)
+		down, err := apiClient.LoadTensorInt64("countdown")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, down.Data, []int64{5, 3, 1})
+		}
+		assertErrorContains(t, apiClient.CreateRange("half_int", 0, 2, 0.5, tensor.DataTypeInt32), "invalid STEP value for range 'half_int'")
+	})
+
+	t.Run("Client_FloatFractionalCount", func(t *testing.T) {
+		// (1 - 0) / 0.3 = 3.33..., jadi jumlah elemen dibulatkan ke atas menjadi 4.
+		assertError(t, apiClient.CreateRange("thirds", 0, 1, 0.3, tensor.DataTypeFloat64), false)
+		loaded, err := apiClient.LoadTensorFloat64("thirds")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{4})
+			for i, want := range []float64{0, 0.3, 0.6, 0.9} {
+				assertTrue(t, math.Abs(loaded.Data[i]-want) < 1e-9, "Elemen %d = %v, diharapkan %v", i, loaded.Data[i], want)
+			}
+		}
+		assertError(t, apiClient.CreateRange("empty_range", 3, 3, 1, tensor.DataTypeFloat32), false)
+		empty, err := apiClient.LoadTensorFloat32("empty_range")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, empty.Shape, []int{0})
+		}
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		_, executor, cleanupExecutor := setupTest(t)
+		defer cleanupExecutor()
+		parser := &tensor.Parser{}
+		run := func(q string) (interface{}, error) {
+			query, err := parser.Parse(q)
+			if err != nil {
+				return nil, err
+			}
+			return executor.Execute(query)
+		}
+
+		_, err := run("CREATE RANGE r FROM 0 TO 10 STEP 2 TYPE int32")
+		assertError(t, err, false)
+		data, err := run("SELECT r FROM r")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{int32(0), int32(2), int32(4), int32(6), int32(8)})
+
+		_, err = run("create range halves from 0 to 2 step 0.5 type float32")
+		assertError(t, err, false)
+		data, err = run("SELECT halves FROM halves")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{float32(0), float32(0.5), float32(1), float32(1.5)})
+
+		query, err := parser.Parse("CREATE RANGE defaults FROM 1 TO 4")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.RangeFrom, "1")
+			assertEqual(t, query.RangeTo, "4")
+			assertEqual(t, query.RangeStep, "1")
+			assertEqual(t, query.RangeMin, "")
+			assertEqual(t, query.RangeMax, "")
+			assertEqual(t, query.DataType, tensor.DataTypeFloat64)
+		}
+
+		_, err = run("CREATE RANGE zero_step FROM 0 TO 10 STEP 0 TYPE int32")
+		assertErrorContains(t, err, "range step for 'zero_step' must not be zero")
+		_, err = run("CREATE RANGE wrong_way FROM 0 TO 10 STEP -1 TYPE int32")
+		assertErrorContains(t, err, "range step -1 for 'wrong_way' moves away from 0 toward 10")
+		_, err = run("CREATE RANGE wrong_way_up FROM 10 TO 0 STEP 2")
+		assertErrorContains(t, err, "moves away from 10 toward 0")
+		_, err = run("CREATE RANGE flags FROM 0 TO 2 TYPE bool")
+		assertErrorContains(t, err, "CREATE RANGE does not support dtype bool")
+		_, err = run("CREATE RANGE missing_to FROM 0 STEP 1")
+		assertErrorContains(t, err, "invalid CREATE RANGE syntax")
+	})
+}
+
+func TestCreateRandom(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Client_SameSeed", func(t *testing.T) {
+		assertError(t, apiClient.CreateRandom("rand_a", []int{2, 3}, tensor.DataTypeFloat32, 42), false)
+		assertError(t, apiClient.CreateRandom("rand_b", []int{2, 3}, tensor.DataTypeFloat32, 42), false)
+		assertError(t, apiClient.CreateRandom("rand_c", []int{2, 3}, tensor.DataTypeFloat32, 7), false)
+		a, errA := apiClient.LoadTensorFloat32("rand_a")
+		b, errB := apiClient.LoadTensorFloat32("rand_b")
+		c, errC := apiClient.LoadTensorFloat32("rand_c")
+		assertError(t, errA, false)
+		assertError(t, errB, false)
+		assertError(t, errC, false)
+		if errA == nil && errB == nil && errC == nil {
+			assertEqual(t, a.Shape, []int{2, 3})
+			assertEqual(t, a.Data, b.Data, "Seed yang sama harus menghasilkan data yang sama")
+			assertTrue(t, fmt.Sprint(a.Data) != fmt.Sprint(c.Data), "Seed berbeda seharusnya menghasilkan data berbeda: %v", a.Data)
+			for i, v := range a.Data {
+				assertTrue(t, v >= 0 && v < 1, "Elemen float %d = %v di luar [0, 1)", i, v)
+			}
+		}
+	})
+
+	t.Run("Client_IntegerRange", func(t *testing.T) {
+		assertError(t, apiClient.CreateRandom("rand_i8", []int{64}, tensor.DataTypeInt8, 3), false)
+		loaded, err := apiClient.LoadTensorInt8("rand_i8")
+		assertError(t, err, false)
+		if err == nil {
+			for i, v := range loaded.Data {
+				assertTrue(t, v >= 0 && v < 100, "Elemen int8 %d = %v di luar [0, 100)", i, v)
+			}
+		}
+		assertError(t, apiClient.CreateRandom("rand_flags", []int{32}, tensor.DataTypeBool, 3), false)
+		flags, err := apiClient.SelectData("rand_flags", nil)
+		assertError(t, err, false)
+		values, ok := flags.([]interface{})
+		assertTrue(t, ok && len(values) == 32, "Hasil SELECT bool tidak terduga: %v", flags)
+		for i, v := range values {
+			_, isBool := v.(bool)
+			assertTrue(t, isBool, "Elemen bool %d = %v (%T) bukan bool", i, v, v)
+		}
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		_, executor, cleanupExecutor := setupTest(t)
+		defer cleanupExecutor()
+		parser := &tensor.Parser{}
+		run := func(q string) (interface{}, error) {
+			query, err := parser.Parse(q)
+			if err != nil {
+				return nil, err
+			}
+			return executor.Execute(query)
+		}
+
+		_, err := run("CREATE RANDOM q1 2,3 TYPE float32 SEED 42")
+		assertError(t, err, false)
+		_, err = run("create random q2 2,3 type float32 seed 42")
+		assertError(t, err, false)
+		first, err := run("SELECT q1 FROM q1")
+		assertError(t, err, false)
+		second, err := run("SELECT q2 FROM q2")
+		assertError(t, err, false)
+		assertEqual(t, first, second)
+
+		// Tanpa SEED executor memilih seed berbasis waktu dan melaporkannya di hasil tanpa mengubah kueri.
+		query, err := parser.Parse("CREATE RANDOM unseeded 4 TYPE int32")
+		assertError(t, err, false)
+		if err == nil {
+			assertTrue(t, query.Random && query.Seed == nil, "Kueri tanpa SEED tidak boleh memiliki seed")
+			assertEqual(t, query.Shape, []int{4})
+			result, err := executor.Execute(query)
+			assertError(t, err, false)
+			assertTrue(t, query.Seed == nil, "Executor tidak boleh menulis seed ke kueri pemanggil")
+			var seed int64
+			_, errScan := fmt.Sscanf(fmt.Sprint(result), "Tensor unseeded created with type int32 using seed %d", &seed)
+			assertError(t, errScan, false, "Hasil seharusnya memuat seed: %v", result)
+			_, err = run(fmt.Sprintf("CREATE RANDOM reseeded 4 TYPE int32 SEED %d", seed))
+			assertError(t, err, false)
+			unseeded, err := run("SELECT unseeded FROM unseeded")
+			assertError(t, err, false)
+			reseeded, err := run("SELECT reseeded FROM reseeded")
+			assertError(t, err, false)
+			assertEqual(t, unseeded, reseeded, "Seed dari hasil harus membuat ulang data yang sama")
+		}
+
+		_, err = run("CREATE RANDOM bad_seed 2 SEED abc")
+		assertErrorContains(t, err, "invalid SEED 'abc' in CREATE RANDOM")
+		_, err = run("CREATE RANDOM bad_type 2 TYPE complex SEED 1")
+		assertErrorContains(t, err, "invalid data type 'complex' in CREATE RANDOM")
+		_, err = run("CREATE RANDOM bad_shape 2,,3 SEED 1")
+		assertErrorContains(t, err, "invalid shape '2,,3' in CREATE RANDOM")
+	})
+
+	t.Run("Unseeded_Replays_From_OpLog", func(t *testing.T) {
+		logDir := t.TempDir()
+		storage, err := tensor.NewStorage(logDir, tensor.WithOpLog())
+		if err != nil {
+			t.Fatalf("Gagal membuat storage: %v", err)
+		}
+		logExecutor := tensor.NewExecutor(storage)
+		logClient := client.NewClient(logExecutor)
+		defer logClient.Close()
+
+		query, err := (&tensor.Parser{}).Parse("CREATE RANDOM logged_rand 8 TYPE float32")
+		assertError(t, err, false)
+		_, err = logExecutor.Execute(query)
+		assertError(t, err, false)
+		assertTrue(t, query.Seed == nil, "Executor tidak boleh menulis seed ke kueri pemanggil")
+
+		logFile, err := os.Open(filepath.Join(logDir, tensor.OpLogFileName))
+		if err != nil {
+			t.Fatalf("Gagal membuka oplog: %v", err)
+		}
+		defer logFile.Close()
+		_, freshClient, freshCleanup := setupTestClient(t)
+		defer freshCleanup()
+		assertError(t, freshClient.ReplayLog(logFile), false)
+
+		original, errOrig := logClient.LoadTensorFloat32("logged_rand")
+		replayed, errReplay := freshClient.LoadTensorFloat32("logged_rand")
+		assertError(t, errOrig, false)
+		assertError(t, errReplay, false)
+		if errOrig == nil && errReplay == nil {
+			assertEqual(t, replayed.Data, original.Data, "Replay oplog harus membuat ulang data acak yang sama")
+		}
+	})
+}
+
+func TestReassembleBatches(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	values := make([]float32, 12)
+	for i := range values {
+		values[i] = float32(i) * 0.5
+	}
+	assertError(t, apiClient.CreateFromData("batched", []int{3, 4}, values), false)
+	original, err := apiClient.LoadTensorFloat32("batched")
+	assertError(t, err, false)
+
+	result, err := apiClient.GetData([]string{"batched"}, nil, 5)
+	assertError(t, err, false)
+	batches, ok := result.([]tensor.TensorDataResult)
+	assertTrue(t, ok, "Hasil GET DATA bukan []tensor.TensorDataResult: %T", result)
+	if !ok || original == nil {
+		return
+	}
+	assertEqual(t, len(batches), 3)
+
+	// Urutan masukan diacak; perakitan mengikuti CurrentBatchIndex.
+	shuffled := []tensor.TensorDataResult{batches[2], batches[0], batches[1]}
+	reassembled, err := client.ReassembleFloat32(shuffled)
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, reassembled.Shape, original.Shape)
+		assertEqual(t, reassembled.Strides, original.Strides)
+		assertEqual(t, reassembled.Data, original.Data)
+	}
+
+	t.Run("Slice", func(t *testing.T) {
+		result, err := apiClient.GetData([]string{"batched"}, [][][2]int{{{1, 3}, {0, 2}}}, 3)
+		assertError(t, err, false)
+		sliceBatches, _ := result.([]tensor.TensorDataResult)
+		sliced, err := client.ReassembleFloat32(sliceBatches)
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, sliced.Shape, []int{2, 2})
+			assertEqual(t, sliced.Data, []float32{2, 2.5, 4, 4.5})
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		next, closeStream, err := apiClient.StreamBatches("batched", nil, 4)
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		defer closeStream()
+		var streamed []tensor.TensorDataResult
+		for {
+			batch, ok, err := next()
+			assertError(t, err, false)
+			if !ok || err != nil {
+				break
+			}
+			streamed = append(streamed, *batch)
+		}
+		fromStream, err := client.ReassembleFloat32(streamed)
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, fromStream.Data, original.Data)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := client.ReassembleFloat32(nil)
+		assertErrorContains(t, err, "tidak ada batch untuk dirakit ulang")
+		_, err = client.ReassembleFloat32(batches[:2])
+		assertErrorContains(t, err, "BatchInfo menyebut 3 batch")
+		_, err = client.ReassembleFloat32([]tensor.TensorDataResult{batches[0], batches[0], batches[2]})
+		assertErrorContains(t, err, "indeks batch 0 tensor 'batched' tidak valid atau duplikat")
+		_, err = client.ReassembleInt32(batches)
+		assertErrorContains(t, err, "tidak cocok dengan tipe yang diminta ('int32')")
+
+		truncated := append([]tensor.TensorDataResult(nil), batches...)
+		truncated[2].Data = truncated[2].Data.([]float32)[:1]
+		_, err = client.ReassembleFloat32(truncated)
+		assertErrorContains(t, err, "jumlah elemen batch tensor 'batched' (11) tidak cocok dengan TotalElements (12)")
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		flags := []bool{true, false, false, true, true}
+		assertError(t, apiClient.CreateFromData("batched_flags", []int{5}, flags), false)
+		result, err := apiClient.GetData([]string{"batched_flags"}, nil, 2)
+		assertError(t, err, false)
+		flagBatches, _ := result.([]tensor.TensorDataResult)
+		_, err = client.ReassembleUint8(flagBatches)
+		assertErrorContains(t, err, "tidak cocok dengan tipe yang diminta ('uint8')")
+		reassembled, err := client.ReassembleBool(flagBatches)
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, reassembled.DataType, tensor.DataTypeBool)
+			assertEqual(t, reassembled.Data, []uint8{1, 0, 0, 1, 1})
+		}
+
+		corrupt := append([]tensor.TensorDataResult(nil), flagBatches...)
+		corrupt[0].Data = []uint8{1, 7}
+		_, err = client.ReassembleBool(corrupt)
+		assertErrorContains(t, err, "bernilai 7, bukan 0 atau 1")
+	})
+}
+
+func TestDedupStorage(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t, tensor.WithDedup())
+	defer cleanup()
+
+	blobs := func() []string {
+		entries, err := os.ReadDir(filepath.Join(dataDir, tensor.BlobDirName))
+		if err != nil && !os.IsNotExist(err) {
+			t.Fatalf("Gagal membaca direktori blob: %v", err)
+		}
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		return names
+	}
+	dataRef := func(name string) string {
+		meta, err := apiClient.GetTensorMetadata(name)
+		assertError(t, err, false)
+		if err != nil {
+			return ""
+		}
+		return meta.DataRef
+	}
+
+	values := []float32{1, 2, 3, 4}
+	assertError(t, apiClient.CreateFromData("dup_a", []int{2, 2}, values), false)
+	assertError(t, apiClient.CreateFromData("dup_b", []int{2, 2}, values), false)
+	assertEqual(t, len(blobs()), 1)
+	refA := dataRef("dup_a")
+	assertTrue(t, refA != "", "Metadata dup_a harus merujuk blob")
+	assertEqual(t, dataRef("dup_b"), refA)
+	assertEqual(t, blobs(), []string{refA + ".data"})
+	metaContent, err := os.ReadFile(filepath.Join(dataDir, "dup_a.meta"))
+	assertError(t, err, false)
+	assertTrue(t, strings.Contains(string(metaContent), `"data_ref":"`+refA+`"`), "File .meta tidak berisi data_ref: %s", metaContent)
+	_, err = os.Stat(filepath.Join(dataDir, "dup_a.data"))
+	assertTrue(t, os.IsNotExist(err), "Mode dedup tidak boleh menulis dup_a.data")
+
+	// Salinan lewat CREATE ... FROM SELECT berbagi blob yang sama.
+	assertError(t, apiClient.CreateFromSelect("dup_copy", "dup_a", nil), false)
+	assertEqual(t, dataRef("dup_copy"), refA)
+	assertEqual(t, len(blobs()), 1)
+
+	// UPDATE menyalin blob (copy-on-write) sehingga tensor lain tidak berubah.
+	assertError(t, apiClient.UpdateElement("dup_copy", []int{0, 0}, 9), false)
+	assertEqual(t, dataRef("dup_copy"), "")
+	copied, err := apiClient.LoadTensorFloat32("dup_copy")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, copied.Data, []float32{9, 2, 3, 4})
+	}
+	original, err := apiClient.LoadTensorFloat32("dup_b")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, original.Data, values)
+	}
+
+	// Blob baru dihapus setelah tensor terakhir yang merujuknya dihapus.
+	assertError(t, apiClient.DropTensor("dup_a"), false)
+	assertEqual(t, blobs(), []string{refA + ".data"})
+	loaded, err := apiClient.LoadTensorFloat32("dup_b")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, loaded.Data, values)
+	}
+	assertError(t, apiClient.DropTensor("dup_b"), false)
+	assertEqual(t, len(blobs()), 0)
+	assertError(t, apiClient.DropTensor("dup_copy"), false)
+}
+
+func TestTensorsSharingData(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "tensordb_dedup_")
+	if err != nil {
+		t.Fatalf("Gagal membuat direktori data sementara: %v", err)
+	}
+	defer os.RemoveAll(dataDir)
+	storage, err := tensor.NewStorage(dataDir, tensor.WithDedup())
+	if err != nil {
+		t.Fatalf("Gagal membuat storage: %v", err)
+	}
+
+	save := func(name string, values []int32) {
+		tt, err := tensor.NewTensor[int32](name, []int{len(values)}, tensor.DataTypeInt32)
+		assertError(t, err, false)
+		if err == nil {
+			copy(tt.Data, values)
+			assertError(t, tensor.SaveTensor(storage, tt), false)
+		}
+	}
+	save("share_a", []int32{1, 2, 3})
+	save("share_b", []int32{1, 2, 3})
+	save("share_c", []int32{1, 2, 3})
+	save("other", []int32{4, 5, 6})
+
+	sharing, err := storage.TensorsSharingData("share_a")
+	assertError(t, err, false)
+	assertEqual(t, sharing, []string{"share_b", "share_c"})
+	sharing, err = storage.TensorsSharingData("share_b")
+	assertError(t, err, false)
+	assertEqual(t, sharing, []string{"share_a", "share_c"})
+	sharing, err = storage.TensorsSharingData("other")
+	assertError(t, err, false)
+	assertEqual(t, sharing, []string{})
+
+	assertError(t, storage.DeleteTensorFiles("share_c"), false)
+	sharing, err = storage.TensorsSharingData("share_a")
+	assertError(t, err, false)
+	assertEqual(t, sharing, []string{"share_b"})
+
+	// Hitungan rujukan mengikuti RENAME dan dibangun ulang dari file .meta saat storage dibuka ulang.
+	assertError(t, storage.RenameTensorFiles("share_b", "share_r"), false)
+	storage, err = storage.Reopen()
+	if err != nil {
+		t.Fatalf("Gagal membuka ulang storage: %v", err)
+	}
+	sharing, err = storage.TensorsSharingData("share_a")
+	assertError(t, err, false)
+	assertEqual(t, sharing, []string{"share_r"})
+	meta, err := storage.LoadTensorMetadata("share_a")
+	if err != nil {
+		t.Fatalf("Gagal memuat metadata share_a: %v", err)
+	}
+	blobFile := filepath.Join(dataDir, tensor.BlobDirName, meta.DataRef+".data")
+	assertError(t, storage.DeleteTensorFiles("share_a"), false)
+	_, err = os.Stat(blobFile)
+	assertError(t, err, false, "Blob masih dirujuk share_r dan tidak boleh dihapus")
+	assertError(t, storage.DeleteTensorFiles("share_r"), false)
+	_, err = os.Stat(blobFile)
+	assertTrue(t, os.IsNotExist(err), "Blob harus dihapus setelah rujukan terakhir dihapus")
+
+	_, err = storage.TensorsSharingData("missing")
+	assertError(t, err, true, "Tensor yang tidak ada harus menghasilkan error")
+}
+
+func TestExportCatalog(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Empty_Store", func(t *testing.T) {
+		var buf bytes.Buffer
+		assertError(t, apiClient.ExportCatalog(&buf), false)
+		assertEqual(t, strings.TrimSpace(buf.String()), "[]")
+	})
+
+	assertError(t, apiClient.CreateFromData("catalog_matrix", []int{2, 3}, []float32{1, 2, 3, 4, 5, 6}), false)
+	assertError(t, apiClient.CreateFromData("catalog_ids", []int{4}, []int64{1, 2, 3, 4}), false)
+	assertError(t, apiClient.CreateTensor("catalog_scalar", []int{}, tensor.DataTypeUint8), false)
+
+	var buf bytes.Buffer
+	assertError(t, apiClient.ExportCatalog(&buf), false)
+	var entries []map[string]interface{}
+	assertError(t, json.Unmarshal(buf.Bytes(), &entries), false, "Katalog harus berupa array JSON")
+	assertEqual(t, len(entries), 3)
+	if len(entries) != 3 {
+		return
+	}
+	// created berasal dari mtime file .meta; periksa formatnya lalu buang agar entri dapat dibandingkan.
+	for _, entry := range entries {
+		created, err := time.Parse(time.RFC3339Nano, fmt.Sprint(entry["created"]))
+		assertError(t, err, false, "created harus berupa waktu RFC 3339")
+		assertTrue(t, time.Since(created) < time.Minute, "created %v seharusnya baru saja", created)
+		delete(entry, "created")
+	}
+	// Entri diurutkan menurut nama; angka JSON didekode sebagai float64.
+	assertEqual(t, entries[0], map[string]interface{}{
+		"name": "catalog_ids", "shape": []interface{}{4.0}, "datatype": tensor.DataTypeInt64,
+		"strides": []interface{}{1.0}, "description": "", "data_size_bytes": 32.0,
+	})
+	assertEqual(t, entries[1], map[string]interface{}{
+		"name": "catalog_matrix", "shape": []interface{}{2.0, 3.0}, "datatype": tensor.DataTypeFloat32,
+		"strides": []interface{}{3.0, 1.0}, "description": "", "data_size_bytes": 24.0,
+	})
+	assertEqual(t, entries[2], map[string]interface{}{
+		"name": "catalog_scalar", "shape": []interface{}{}, "datatype": tensor.DataTypeUint8,
+		"strides": []interface{}{}, "description": "", "data_size_bytes": 1.0,
+	})
+}
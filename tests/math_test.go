@@ -0,0 +1,2175 @@
+package tests
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sciefylab/tensordb/pkg/tensor"
+)
+
+// Asumsikan setupTestClient, assertEqual, assertError, assertErrorContains, assertTrue
+// ada di file test_helpers.go dalam package tests yang sama.
+
+func TestMathOperatorDataTypeRestrictions(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Validate_Table", func(t *testing.T) {
+		assertError(t, tensor.ValidateMathOperatorDataType("ADD_TENSORS", tensor.DataTypeInt32), false)
+		assertError(t, tensor.ValidateMathOperatorDataType("SQRT", tensor.DataTypeFloat64), false)
+
+		err := tensor.ValidateMathOperatorDataType("SQRT", tensor.DataTypeInt64)
+		assertErrorContains(t, err, "operation SQRT does not support dtype int64")
+
+		err = tensor.ValidateMathOperatorDataType("NO_SUCH_OP", tensor.DataTypeFloat32)
+		assertErrorContains(t, err, "unsupported mathematical operator: NO_SUCH_OP")
+	})
+
+	t.Run("Float_Only_Op_Rejects_Int", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("sqrt_in_i32", []int{3}, tensor.DataTypeInt32), false)
+		assertError(t, apiClient.InsertInt32Data("sqrt_in_i32", []int32{1, 4, 9}), false)
+
+		_, err := apiClient.Sqrt("sqrt_in_i32", "sqrt_out_i32")
+		assertErrorContains(t, err, "operation SQRT does not support dtype int32")
+
+		_, errMeta := apiClient.GetTensorMetadata("sqrt_out_i32")
+		assertError(t, errMeta, true, "Tensor output tidak boleh dibuat jika validasi tipe data gagal")
+	})
+
+	t.Run("Float_Only_Op_Accepts_Float", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("sqrt_in_f64", []int{3}, tensor.DataTypeFloat64), false)
+		assertError(t, apiClient.InsertFloat64Data("sqrt_in_f64", []float64{1, 4, 9}), false)
+
+		_, err := apiClient.Sqrt("sqrt_in_f64", "sqrt_out_f64")
+		assertError(t, err, false)
+		loaded, errLoad := apiClient.LoadTensorFloat64("sqrt_out_f64")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, loaded.Data, []float64{1, 2, 3})
+		}
+	})
+}
+
+func TestStreamingReductions(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	// Lebih besar dari satu jendela streaming agar jalur multi-chunk ikut teruji.
+	const n = 200000
+	dataF32 := make([]float32, n)
+	dataI64 := make([]int64, n)
+	for i := range dataF32 {
+		dataF32[i] = float32(i%97) * 0.25
+		dataI64[i] = int64(i)
+	}
+	assertError(t, apiClient.CreateTensor("reduce_f32", []int{400, 500}, tensor.DataTypeFloat32), false)
+	assertError(t, apiClient.InsertFloat32Data("reduce_f32", dataF32), false)
+	assertError(t, apiClient.CreateTensor("reduce_i64", []int{n}, tensor.DataTypeInt64), false)
+	assertError(t, apiClient.InsertInt64Data("reduce_i64", dataI64), false)
+
+	t.Run("Sum_Matches_Full_Load", func(t *testing.T) {
+		full, err := apiClient.LoadTensorFloat32("reduce_f32")
+		assertError(t, err, false)
+		var expected float64
+		if err == nil {
+			for _, v := range full.Data {
+				expected += float64(v)
+			}
+		}
+
+		_, err = apiClient.Sum("reduce_f32", "reduce_f32_sum")
+		assertError(t, err, false)
+		result, errLoad := apiClient.LoadTensorFloat32("reduce_f32_sum")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, result.Shape, []int{})
+			assertEqual(t, result.Data, []float32{float32(expected)})
+		}
+	})
+
+	t.Run("Sum_Int64", func(t *testing.T) {
+		_, err := apiClient.Sum("reduce_i64", "reduce_i64_sum")
+		assertError(t, err, false)
+		result, errLoad := apiClient.LoadTensorInt64("reduce_i64_sum")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, result.Data, []int64{int64(n) * (n - 1) / 2})
+		}
+	})
+
+	t.Run("Mean_Int64_Produces_Float64", func(t *testing.T) {
+		_, err := apiClient.Mean("reduce_i64", "reduce_i64_mean")
+		assertError(t, err, false)
+		result, errLoad := apiClient.LoadTensorFloat64("reduce_i64_mean")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, result.Data, []float64{float64(n-1) / 2})
+		}
+	})
+
+	t.Run("Narrow_Types_Do_Not_Wrap", func(t *testing.T) {
+		data := make([]int8, 10000)
+		for i := range data {
+			data[i] = 100
+		}
+		assertError(t, apiClient.CreateFromData("reduce_i8", []int{100, 100}, data), false)
+		_, err := apiClient.Mean("reduce_i8", "reduce_i8_mean")
+		assertError(t, err, false)
+		result, errLoad := apiClient.LoadTensorFloat64("reduce_i8_mean")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, result.Data, []float64{100})
+		}
+
+		_, err = apiClient.Sum("reduce_i8", "reduce_i8_sum")
+		assertErrorContains(t, err, "SUM of tensor reduce_i8 overflows int8")
+
+		assertError(t, apiClient.CreateFromData("reduce_u64_big", []int{2}, []uint64{math.MaxUint64, 1}), false)
+		_, err = apiClient.Sum("reduce_u64_big", "reduce_u64_big_sum")
+		assertErrorContains(t, err, "SUM of tensor reduce_u64_big overflows uint64")
+	})
+
+	t.Run("Mean_Empty_Tensor", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("reduce_empty", []int{0, 3}, tensor.DataTypeFloat64), false)
+		_, err := apiClient.Mean("reduce_empty", "reduce_empty_mean")
+		assertErrorContains(t, err, "cannot compute MEAN of empty tensor")
+	})
+
+	t.Run("Parse_Reduction", func(t *testing.T) {
+		parser := &tensor.Parser{}
+		query, err := parser.Parse("mean tensor reduce_f32 INTO reduce_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "MEAN")
+			assertEqual(t, query.InputTensorNames, []string{"reduce_f32"})
+			assertEqual(t, query.OutputTensorName, "reduce_out")
+		}
+	})
+}
+
+func TestAddScalarInPlace(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	const n = 150000
+	data := make([]float32, n)
+	for i := range data {
+		data[i] = float32(i % 1000)
+	}
+	assertError(t, apiClient.CreateTensor("inplace_f32", []int{n}, tensor.DataTypeFloat32), false)
+	assertError(t, apiClient.InsertFloat32Data("inplace_f32", data), false)
+
+	t.Run("Matches_Out_Of_Place", func(t *testing.T) {
+		_, err := apiClient.AddScalarToTensor(2.5, "inplace_f32", "inplace_f32_copy")
+		assertError(t, err, false)
+		msg, err := apiClient.AddScalarToTensorInPlace(2.5, "inplace_f32")
+		assertError(t, err, false)
+		assertEqual(t, msg, "Tensor 'inplace_f32' updated in place by operation ADD_SCALAR")
+
+		inPlace, errIn := apiClient.LoadTensorFloat32("inplace_f32")
+		outOfPlace, errOut := apiClient.LoadTensorFloat32("inplace_f32_copy")
+		assertError(t, errIn, false)
+		assertError(t, errOut, false)
+		if errIn == nil && errOut == nil {
+			assertEqual(t, inPlace.Shape, []int{n})
+			assertEqual(t, inPlace.Data, outOfPlace.Data)
+		}
+	})
+
+	t.Run("Parse_In_Place", func(t *testing.T) {
+		parser := &tensor.Parser{}
+		query, err := parser.Parse("ADD SCALAR 3 TO TENSOR inplace_i32 IN PLACE")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "ADD_SCALAR")
+			assertEqual(t, query.InputTensorNames, []string{"inplace_i32"})
+			assertEqual(t, query.ScalarOperand, "3")
+			assertTrue(t, query.InPlace, "InPlace harus true")
+			assertEqual(t, query.OutputTensorName, "")
+		}
+	})
+
+	t.Run("Int32_Via_Query", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("inplace_i32", []int{2, 2}, tensor.DataTypeInt32), false)
+		assertError(t, apiClient.InsertInt32Data("inplace_i32", []int32{1, 2, 3, 4}), false)
+		_, err := apiClient.AddScalarToTensorInPlace(3, "inplace_i32")
+		assertError(t, err, false)
+		loaded, errLoad := apiClient.LoadTensorInt32("inplace_i32")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, loaded.Data, []int32{4, 5, 6, 7})
+		}
+	})
+}
+
+func TestScalarOperandParsing(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+
+	run := func(queryStr string) (interface{}, error) {
+		q, err := parser.Parse(queryStr)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(q)
+	}
+
+	_, err := run("CREATE TENSOR scalar_src_f64 3 TYPE float64")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO scalar_src_f64 VALUES (1, 2, 3)")
+	assertError(t, err, false)
+	_, err = run("CREATE TENSOR scalar_src_i32 3 TYPE int32")
+	assertError(t, err, false)
+
+	validOperands := []string{"-1.5", "1e-3", "+2", ".5", "3.", "2E+2"}
+	for _, operand := range validOperands {
+		for _, form := range []string{"ADD SCALAR %s TO TENSOR t INTO out", "ADD SCALAR %s TO TENSOR t IN PLACE"} {
+			queryStr := fmt.Sprintf(form, operand)
+			query, errParse := parser.Parse(queryStr)
+			assertError(t, errParse, false, "Parsing: %s", queryStr)
+			if errParse == nil {
+				assertEqual(t, query.ScalarOperand, operand, "Operand untuk: %s", queryStr)
+			}
+		}
+	}
+
+	malformedOperands := []string{"--1", "-", "+", "1e", "1.2.3", "e5", "abc"}
+	for _, operand := range malformedOperands {
+		for _, form := range []string{"ADD SCALAR %s TO TENSOR t INTO out", "ADD SCALAR %s TO TENSOR t IN PLACE"} {
+			queryStr := fmt.Sprintf(form, operand)
+			_, errParse := parser.Parse(queryStr)
+			assertErrorContains(t, errParse, fmt.Sprintf("invalid scalar operand '%s'", operand), "Parsing: %s", queryStr)
+		}
+	}
+
+	t.Run("Negative_Float", func(t *testing.T) {
+		_, err := run("ADD SCALAR -1.5 TO TENSOR scalar_src_f64 INTO scalar_neg_out")
+		assertError(t, err, false)
+		loaded, errLoad := run("SELECT scalar_neg_out FROM scalar_neg_out")
+		assertError(t, errLoad, false)
+		assertFormattedEqual(t, loaded, []interface{}{-0.5, 0.5, 1.5})
+	})
+
+	t.Run("Scientific_Float_In_Place", func(t *testing.T) {
+		_, err := run("ADD SCALAR 1e-3 TO TENSOR scalar_src_f64 IN PLACE")
+		assertError(t, err, false)
+		loaded, errLoad := run("SELECT scalar_src_f64 FROM scalar_src_f64")
+		assertError(t, errLoad, false)
+		assertFormattedEqual(t, loaded, []interface{}{1.001, 2.001, 3.001})
+	})
+
+	t.Run("Scientific_Rejected_For_Int", func(t *testing.T) {
+		_, err := run("ADD SCALAR 1e-3 TO TENSOR scalar_src_i32 INTO scalar_sci_i32")
+		assertErrorContains(t, err, "failed to parse scalar operand '1e-3' as int32")
+	})
+}
+
+func TestTopK(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("topk_src", []int{2, 4}, []float32{0.5, 3, -1, 7, 3, 2, 9, 0}), false)
+
+	t.Run("Top3_Values_And_Indices", func(t *testing.T) {
+		_, err := apiClient.TopK("topk_src", 3, "topk_values", "topk_indices")
+		assertError(t, err, false)
+		values, errV := apiClient.LoadTensorFloat32("topk_values")
+		assertError(t, errV, false)
+		indices, errI := apiClient.LoadTensorInt64("topk_indices")
+		assertError(t, errI, false)
+		if errV == nil && errI == nil {
+			assertEqual(t, values.Data, []float32{9, 7, 3})
+			// Nilai 3 muncul di indeks 1 dan 4; indeks terkecil didahulukan.
+			assertEqual(t, indices.Data, []int64{6, 3, 1})
+		}
+	})
+
+	t.Run("K_Larger_Than_Size_Returns_All", func(t *testing.T) {
+		parser := &tensor.Parser{}
+		query, err := parser.Parse("TOPK TENSOR topk_src K 20 INTO topk_all_values AND topk_all_indices")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, query.ScalarOperand, "20")
+		assertEqual(t, query.IndicesTensorName, "topk_all_indices")
+
+		_, err = apiClient.TopK("topk_src", 20, "topk_all_values", "topk_all_indices")
+		assertError(t, err, false)
+		values, errV := apiClient.LoadTensorFloat32("topk_all_values")
+		assertError(t, errV, false)
+		if errV == nil {
+			assertEqual(t, values.Shape, []int{8})
+			assertEqual(t, values.Data, []float32{9, 7, 3, 3, 2, 0.5, 0, -1})
+		}
+	})
+
+	t.Run("Invalid_K", func(t *testing.T) {
+		_, err := apiClient.TopK("topk_src", 0, "topk_zero_values", "topk_zero_indices")
+		assertErrorContains(t, err, "TOPK requires a positive integer K")
+	})
+
+	t.Run("Same_Output_Names_Rejected", func(t *testing.T) {
+		_, err := apiClient.TopK("topk_src", 2, "topk_same", "topk_same")
+		assertErrorContains(t, err, "distinct output tensor names")
+	})
+
+	t.Run("Indices_Save_Failure_Drops_Values", func(t *testing.T) {
+		// Direktori di jalur file data tensor indeks membuat penyimpanannya gagal setelah tensor nilai
+		// tersimpan.
+		assertError(t, os.Mkdir(filepath.Join(dataDir, "topk_fail_indices.data"), 0755), false)
+		_, err := apiClient.TopK("topk_src", 2, "topk_fail_values", "topk_fail_indices")
+		assertErrorContains(t, err, "failed to save result tensor 'topk_fail_indices'")
+		_, err = apiClient.GetTensorMetadata("topk_fail_values")
+		assertError(t, err, true, "Tensor nilai harus dihapus jika tensor indeks gagal disimpan")
+		listed, err := apiClient.ListTensors("", -1)
+		assertError(t, err, false)
+		for _, meta := range listed {
+			assertTrue(t, meta.Name != "topk_fail_values", "Tensor nilai tidak boleh tersisa di indeks")
+		}
+	})
+}
+
+func TestMovingAverage(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("ma_series", []int{6}, []int32{1, 2, 3, 4, 5, 9}), false)
+
+	t.Run("Trailing_Window3_1D", func(t *testing.T) {
+		parser := &tensor.Parser{}
+		query, err := parser.Parse("MOVING_AVG TENSOR ma_series WINDOW 3 AXIS 0 INTO ma_out")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, query.ScalarOperand, "3")
+		assertTrue(t, query.Axis != nil && *query.Axis == 0, "Axis harus 0")
+
+		_, err = apiClient.MovingAverage("ma_series", 3, 0, "ma_out")
+		assertError(t, err, false)
+		result, errLoad := apiClient.LoadTensorFloat64("ma_out")
+		assertError(t, errLoad, false, "Input int32 harus dipromosikan ke float64")
+		if errLoad == nil {
+			assertEqual(t, result.Shape, []int{4})
+			assertEqual(t, result.Data, []float64{2, 3, 4, 6})
+		}
+	})
+
+	t.Run("Inner_Axis_2D_Float32", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("ma_grid", []int{2, 3}, []float32{1, 3, 5, 2, 4, 8}), false)
+		_, err := apiClient.MovingAverage("ma_grid", 2, 1, "ma_grid_out")
+		assertError(t, err, false)
+		result, errLoad := apiClient.LoadTensorFloat32("ma_grid_out")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, result.Shape, []int{2, 2})
+			assertEqual(t, result.Data, []float32{2, 4, 3, 6})
+		}
+	})
+
+	t.Run("Window_Too_Large", func(t *testing.T) {
+		_, err := apiClient.MovingAverage("ma_series", 7, 0, "ma_too_large")
+		assertErrorContains(t, err, "window 7 must be between 1 and the axis length 6")
+	})
+
+	t.Run("Large_Value_Leaves_Window", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("ma_large", []int{4}, []float64{1e301, 1, 1, 1}), false)
+		_, err := apiClient.MovingAverage("ma_large", 2, 0, "ma_large_out")
+		assertError(t, err, false)
+		result, errLoad := apiClient.LoadTensorFloat64("ma_large_out")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, result.Data, []float64{5e300, 1, 1})
+		}
+	})
+
+	t.Run("Inf_Leaves_Window", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("ma_inf", []int{4}, []float64{math.Inf(1), 2, 4, 6}), false)
+		_, err := apiClient.MovingAverage("ma_inf", 2, 0, "ma_inf_out")
+		assertError(t, err, false)
+		result, errLoad := apiClient.LoadTensorFloat64("ma_inf_out")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, result.Data, []float64{math.Inf(1), 3, 5})
+		}
+	})
+}
+
+func TestNanReductions(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	nan := math.NaN()
+	assertError(t, apiClient.CreateFromData("nan_vec", []int{5}, []float64{1, nan, 3, nan, 8}), false)
+	assertError(t, apiClient.CreateFromData("nan_all", []int{2}, []float32{float32(nan), float32(nan)}), false)
+	assertError(t, apiClient.CreateFromData("nan_grid", []int{2, 3}, []float64{1, nan, 3, nan, nan, 6}), false)
+
+	loadScalar := func(t *testing.T, name string) []float64 {
+		t.Helper()
+		loaded, err := apiClient.LoadTensorFloat64(name)
+		assertError(t, err, false)
+		if err != nil {
+			return nil
+		}
+		return loaded.Data
+	}
+
+	t.Run("NanSum_Vector", func(t *testing.T) {
+		_, err := apiClient.NanSum("nan_vec", nil, "nan_vec_sum")
+		assertError(t, err, false)
+		assertEqual(t, loadScalar(t, "nan_vec_sum"), []float64{12})
+	})
+
+	t.Run("NanMean_Vector", func(t *testing.T) {
+		_, err := apiClient.NanMean("nan_vec", nil, "nan_vec_mean")
+		assertError(t, err, false)
+		assertEqual(t, loadScalar(t, "nan_vec_mean"), []float64{4})
+	})
+
+	t.Run("Regular_Sum_Propagates_NaN", func(t *testing.T) {
+		_, err := apiClient.Sum("nan_vec", "nan_vec_plain_sum")
+		assertError(t, err, false)
+		data := loadScalar(t, "nan_vec_plain_sum")
+		assertTrue(t, len(data) == 1 && math.IsNaN(data[0]), "SUM biasa harus menghasilkan NaN")
+	})
+
+	t.Run("All_NaN", func(t *testing.T) {
+		_, err := apiClient.NanSum("nan_all", nil, "nan_all_sum")
+		assertError(t, err, false)
+		sum, errLoad := apiClient.LoadTensorFloat32("nan_all_sum")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, sum.Data, []float32{0})
+		}
+
+		_, err = apiClient.NanMean("nan_all", nil, "nan_all_mean")
+		assertError(t, err, false)
+		mean := loadScalar(t, "nan_all_mean")
+		assertTrue(t, len(mean) == 1 && math.IsNaN(mean[0]), "NANMEAN dari semua-NaN harus NaN")
+	})
+
+	t.Run("NanMean_Along_Axis", func(t *testing.T) {
+		parser := &tensor.Parser{}
+		query, err := parser.Parse("NANMEAN TENSOR nan_grid AXIS 1 INTO nan_grid_mean")
+		assertError(t, err, false)
+		if err == nil {
+			assertTrue(t, query.Axis != nil && *query.Axis == 1, "Axis harus 1")
+		}
+
+		axis := 1
+		_, err = apiClient.NanMean("nan_grid", &axis, "nan_grid_mean")
+		assertError(t, err, false)
+		loaded, errLoad := apiClient.LoadTensorFloat64("nan_grid_mean")
+		assertError(t, errLoad, false)
+		if errLoad == nil {
+			assertEqual(t, loaded.Shape, []int{2})
+			assertEqual(t, loaded.Data, []float64{2, 6})
+		}
+	})
+
+	t.Run("Int_Rejected", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("nan_ints", []int{2}, []int32{1, 2}), false)
+		_, err := apiClient.NanSum("nan_ints", nil, "nan_ints_sum")
+		assertErrorContains(t, err, "operation NANSUM does not support dtype int32")
+	})
+}
+
+func TestBatchTensors(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	samples := map[string][]string{
+		"sample_0": {"1", "2", "3"},
+		"sample_1": {"4", "5", "6"},
+		"sample_2": {"7", "8", "9"},
+	}
+	for _, name := range []string{"sample_0", "sample_1", "sample_2"} {
+		_, err := executor.Execute(&tensor.Query{Type: tensor.CreateTensorQuery, TensorNames: []string{name}, Shape: []int{3}, DataType: tensor.DataTypeFloat32})
+		assertError(t, err, false, "CREATE %s", name)
+		_, err = executor.Execute(&tensor.Query{Type: tensor.InsertTensorQuery, TensorNames: []string{name}, Data: samples[name]})
+		assertError(t, err, false, "INSERT %s", name)
+	}
+
+	t.Run("Explicit_List", func(t *testing.T) {
+		_, err := run("BATCH TENSORS sample_0, sample_1, sample_2 INTO batch")
+		assertError(t, err, false)
+		result, err := run("SELECT batch FROM batch")
+		assertError(t, err, false)
+		assertFormattedEqual(t, result, []interface{}{
+			[]interface{}{float32(1), float32(2), float32(3)},
+			[]interface{}{float32(4), float32(5), float32(6)},
+			[]interface{}{float32(7), float32(8), float32(9)},
+		})
+	})
+
+	t.Run("Order_Follows_List", func(t *testing.T) {
+		_, err := run("BATCH TENSORS sample_2, sample_0 INTO batch_reordered")
+		assertError(t, err, false)
+		result, err := run("SELECT batch_reordered FROM batch_reordered")
+		assertError(t, err, false)
+		assertFormattedEqual(t, result, []interface{}{
+			[]interface{}{float32(7), float32(8), float32(9)},
+			[]interface{}{float32(1), float32(2), float32(3)},
+		})
+	})
+
+	t.Run("Glob_Pattern", func(t *testing.T) {
+		query, err := parser.Parse("BATCH TENSORS sample_* INTO batch_glob")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		_, err = executor.Execute(query)
+		assertError(t, err, false)
+		// Ekspansi pola tidak boleh mengubah Query milik pemanggil.
+		assertEqual(t, query.InputTensorNames, []string{"sample_*"})
+		result, err := run("SELECT batch_glob FROM batch_glob")
+		assertError(t, err, false)
+		assertFormattedEqual(t, result, []interface{}{
+			[]interface{}{float32(1), float32(2), float32(3)},
+			[]interface{}{float32(4), float32(5), float32(6)},
+			[]interface{}{float32(7), float32(8), float32(9)},
+		})
+
+		_, err = run("BATCH TENSORS nothing_* INTO batch_empty")
+		assertErrorContains(t, err, "matched no tensors")
+	})
+
+	t.Run("Mismatched_Shape_And_DataType", func(t *testing.T) {
+		_, err := executor.Execute(&tensor.Query{Type: tensor.CreateTensorQuery, TensorNames: []string{"wide"}, Shape: []int{4}, DataType: tensor.DataTypeFloat32})
+		assertError(t, err, false)
+		_, err = run("BATCH TENSORS sample_0, wide INTO batch_bad_shape")
+		assertErrorContains(t, err, "does not match shape")
+
+		_, err = executor.Execute(&tensor.Query{Type: tensor.CreateTensorQuery, TensorNames: []string{"ints"}, Shape: []int{3}, DataType: tensor.DataTypeInt32})
+		assertError(t, err, false)
+		_, err = run("BATCH TENSORS sample_0, ints INTO batch_bad_dtype")
+		assertErrorContains(t, err, "do not match")
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("norm_vec", []int{5}, []float32{2, 4, 6, 8, 10}), false)
+	assertError(t, apiClient.CreateFromData("norm_mat", []int{2, 3}, []int32{1, 2, 3, 10, 10, 40}), false)
+	assertError(t, apiClient.CreateFromData("norm_const", []int{3}, []float64{7, 7, 7}), false)
+
+	t.Run("Vector_Default_Range", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("NORMALIZE TENSOR norm_vec INTO norm_vec_01")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.RangeMin, "")
+			assertEqual(t, query.Axis == nil, true)
+		}
+		_, err = apiClient.Normalize("norm_vec", 0, 1, nil, "norm_vec_01")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("norm_vec_01")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []float32{0, 0.25, 0.5, 0.75, 1})
+		}
+	})
+
+	t.Run("Custom_Range_Parsed", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("NORMALIZE TENSOR norm_vec MIN -1 MAX 1 AXIS 0 INTO norm_vec_pm1")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.RangeMin, "-1")
+			assertEqual(t, query.RangeMax, "1")
+			assertEqual(t, *query.Axis, 0)
+		}
+		_, err = apiClient.Normalize("norm_vec", -1, 1, nil, "norm_vec_pm1")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("norm_vec_pm1")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []float32{-1, -0.5, 0, 0.5, 1})
+		}
+	})
+
+	t.Run("Integer_Per_Axis_Promotes", func(t *testing.T) {
+		axis := 1
+		_, err := apiClient.Normalize("norm_mat", 0, 1, &axis, "norm_mat_rows")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("norm_mat_rows")
+		assertError(t, err, false)
+		if err == nil {
+			// Setiap baris memakai min/max-nya sendiri: [1,2,3] dan [10,10,40].
+			assertEqual(t, result.Data, []float64{0, 0.5, 1, 0, 0, 1})
+		}
+	})
+
+	t.Run("Constant_Data_Maps_To_Min", func(t *testing.T) {
+		_, err := apiClient.Normalize("norm_const", 5, 6, nil, "norm_const_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("norm_const_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []float64{5, 5, 5})
+		}
+	})
+
+	t.Run("Invalid_Range", func(t *testing.T) {
+		_, err := apiClient.Normalize("norm_vec", 1, 1, nil, "norm_bad_range")
+		assertErrorContains(t, err, "must be less than max")
+		_, err = (&tensor.Parser{}).Parse("NORMALIZE TENSOR norm_vec MIN a MAX 1 INTO norm_bad")
+		assertErrorContains(t, err, "invalid scalar operand")
+	})
+}
+
+func TestOneHot(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("onehot_labels", []int{3}, []int64{0, 2, 1}), false)
+
+	t.Run("Default_Float32", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("ONEHOT TENSOR onehot_labels CLASSES 3 INTO onehot_f32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.ScalarOperand, "3")
+			assertEqual(t, query.DataType, "")
+		}
+		_, err = apiClient.OneHot("onehot_labels", 3, "", "onehot_f32")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("onehot_f32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{3, 3})
+			assertEqual(t, result.Data, []float32{1, 0, 0, 0, 0, 1, 0, 1, 0})
+		}
+	})
+
+	t.Run("Configurable_Output_Type", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("ONEHOT TENSOR onehot_labels CLASSES 4 TYPE INT32 INTO onehot_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.DataType, tensor.DataTypeInt32)
+		}
+		_, err = apiClient.OneHot("onehot_labels", 4, tensor.DataTypeInt32, "onehot_i32")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("onehot_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{3, 4})
+			assertEqual(t, result.Data, []int32{1, 0, 0, 0, 0, 0, 1, 0, 0, 1, 0, 0})
+		}
+	})
+
+	t.Run("Label_Out_Of_Range", func(t *testing.T) {
+		_, err := apiClient.OneHot("onehot_labels", 2, "", "onehot_too_few")
+		assertErrorContains(t, err, "out of range [0, 2)")
+	})
+
+	t.Run("Huge_Uint64_Label_Out_Of_Range", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("onehot_u64_labels", []int{2}, []uint64{1, 1 << 63}), false)
+		_, err := apiClient.OneHot("onehot_u64_labels", 3, "", "onehot_from_u64")
+		assertErrorContains(t, err, "at index 1 is out of range [0, 3)")
+	})
+
+	t.Run("Float_Labels_Rejected", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("onehot_float_labels", []int{2}, []float32{0, 1}), false)
+		_, err := apiClient.OneHot("onehot_float_labels", 2, "", "onehot_from_float")
+		assertErrorContains(t, err, "does not support dtype float32")
+	})
+}
+
+func TestMultiplyTensors(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("mul_a_i32", []int{2, 2}, []int32{1, 2, 3, 4}), false)
+	assertError(t, apiClient.CreateFromData("mul_b_i32", []int{2, 2}, []int32{5, -6, 7, 0}), false)
+
+	t.Run("Int32_2x2", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("MULTIPLY TENSOR mul_a_i32 WITH TENSOR mul_b_i32 INTO mul_ab_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "MULTIPLY_TENSORS")
+			assertEqual(t, query.InputTensorNames, []string{"mul_a_i32", "mul_b_i32"})
+		}
+		_, err = apiClient.MultiplyTensors("mul_a_i32", "mul_b_i32", "mul_ab_i32")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("mul_ab_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 2})
+			assertEqual(t, result.Data, []int32{5, -12, 21, 0})
+		}
+	})
+
+	t.Run("Empty_Tensors", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("mul_empty_a", []int{0, 3}, tensor.DataTypeFloat64), false)
+		assertError(t, apiClient.CreateTensor("mul_empty_b", []int{0, 3}, tensor.DataTypeFloat64), false)
+		_, err := apiClient.MultiplyTensors("mul_empty_a", "mul_empty_b", "mul_empty_out")
+		assertError(t, err, false)
+		meta, err := apiClient.GetTensorMetadata("mul_empty_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, meta.Shape, []int{0, 3})
+		}
+	})
+
+	t.Run("Shape_Mismatch", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("mul_c_i32", []int{4}, []int32{1, 2, 3, 4}), false)
+		_, err := apiClient.MultiplyTensors("mul_a_i32", "mul_c_i32", "mul_bad")
+		assertErrorContains(t, err, "bentuk tensor tidak sama")
+	})
+}
+
+func TestReplaceValue(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("replace_i32", []int{2, 3}, []int32{0, 5, 0, 2, 0, 7}), false)
+	assertError(t, apiClient.CreateFromData("replace_f64", []int{4}, []float64{0.1, 0.1000001, 0.2, 0.0999}), false)
+
+	t.Run("Zeros_To_Ones_Int", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("REPLACE VALUE 0 WITH 1 IN TENSOR replace_i32 INTO replace_i32_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MatchValue, "0")
+			assertEqual(t, query.ScalarOperand, "1")
+			assertEqual(t, query.InPlace, false)
+		}
+		_, err = apiClient.ReplaceValue(0, 1, 0, "replace_i32", "replace_i32_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("replace_i32_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []int32{1, 5, 1, 2, 1, 7})
+		}
+		source, err := apiClient.LoadTensorInt32("replace_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, source.Data, []int32{0, 5, 0, 2, 0, 7}, "Tensor sumber tidak boleh berubah")
+		}
+	})
+
+	t.Run("In_Place", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("REPLACE VALUE 7 WITH -7 IN TENSOR replace_i32 IN PLACE")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.InPlace, true)
+		}
+		_, err = apiClient.ReplaceValueInPlace(7, -7, 0, "replace_i32")
+		assertError(t, err, false)
+		source, err := apiClient.LoadTensorInt32("replace_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, source.Data, []int32{0, 5, 0, 2, 0, -7})
+		}
+	})
+
+	t.Run("Float_Tolerance", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("REPLACE VALUE 0.1 WITH 0 TOLERANCE 1e-3 IN TENSOR replace_f64 INTO replace_f64_tol")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Tolerance, "1e-3")
+		}
+		_, err = apiClient.ReplaceValue(0.1, 0, 1e-3, "replace_f64", "replace_f64_tol")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("replace_f64_tol")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []float64{0, 0, 0.2, 0})
+		}
+
+		_, err = apiClient.ReplaceValue(0.1, 0, 0, "replace_f64", "replace_f64_exact")
+		assertError(t, err, false)
+		exact, err := apiClient.LoadTensorFloat64("replace_f64_exact")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, exact.Data, []float64{0, 0.1000001, 0.2, 0.0999})
+		}
+	})
+
+	t.Run("Tolerance_Rejected_For_Int", func(t *testing.T) {
+		_, err := apiClient.ReplaceValue(0, 1, 0.5, "replace_i32", "replace_i32_tol")
+		assertErrorContains(t, err, "tolerance is only supported for float data types")
+	})
+}
+
+func TestDivideTensors(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Int32_Exact", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("div_a_i32", []int{2, 2}, []int32{10, -9, 7, 0}), false)
+		assertError(t, apiClient.CreateFromData("div_b_i32", []int{2, 2}, []int32{2, 3, 2, 5}), false)
+		query, err := (&tensor.Parser{}).Parse("DIVIDE TENSOR div_a_i32 WITH TENSOR div_b_i32 INTO div_ab_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "DIVIDE_TENSORS")
+		}
+		_, err = apiClient.DivideTensors("div_a_i32", "div_b_i32", "div_ab_i32")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("div_ab_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []int32{5, -3, 3, 0})
+		}
+	})
+
+	t.Run("Int32_Divide_By_Zero", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("div_zero_i32", []int{2, 2}, []int32{1, 1, 0, 1}), false)
+		_, err := apiClient.DivideTensors("div_a_i32", "div_zero_i32", "div_by_zero_i32")
+		assertErrorContains(t, err, "integer division by zero at flat index 2")
+		_, errMeta := apiClient.GetTensorMetadata("div_by_zero_i32")
+		assertError(t, errMeta, true, "Tensor output tidak boleh dibuat ketika pembagian gagal")
+	})
+
+	t.Run("Float64_Inf_And_NaN", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("div_a_f64", []int{3}, []float64{1, -1, 0}), false)
+		assertError(t, apiClient.CreateFromData("div_b_f64", []int{3}, []float64{0, 0, 0}), false)
+		_, err := apiClient.DivideTensors("div_a_f64", "div_b_f64", "div_ab_f64")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("div_ab_f64")
+		assertError(t, err, false)
+		if err == nil {
+			assertTrue(t, math.IsInf(result.Data[0], 1), "1/0 harus +Inf, didapat %v", result.Data[0])
+			assertTrue(t, math.IsInf(result.Data[1], -1), "-1/0 harus -Inf, didapat %v", result.Data[1])
+			assertTrue(t, math.IsNaN(result.Data[2]), "0/0 harus NaN, didapat %v", result.Data[2])
+		}
+	})
+
+	t.Run("DataType_Mismatch", func(t *testing.T) {
+		_, err := apiClient.DivideTensors("div_a_i32", "div_a_f64", "div_mixed")
+		assertErrorContains(t, err, "do not match")
+	})
+}
+
+func TestMatMul(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("mm_a", []int{2, 3}, []float64{1, 2, 3, 4, 5, 6}), false)
+	assertError(t, apiClient.CreateFromData("mm_b", []int{3, 2}, []float64{7, 8, 9, 10, 11, 12}), false)
+
+	t.Run("Known_Product", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("MATMUL TENSOR mm_a WITH TENSOR mm_b INTO mm_ab")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "MATMUL_TENSORS")
+		}
+		_, err = apiClient.MatMul("mm_a", "mm_b", "mm_ab")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("mm_ab")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 2})
+			assertEqual(t, result.Strides, []int{2, 1})
+			assertEqual(t, result.Data, []float64{58, 64, 139, 154})
+		}
+	})
+
+	t.Run("Int32_Identity", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("mm_i", []int{2, 2}, []int32{1, 0, 0, 1}), false)
+		assertError(t, apiClient.CreateFromData("mm_x", []int{2, 3}, []int32{3, -1, 4, 1, 5, -9}), false)
+		_, err := apiClient.MatMul("mm_i", "mm_x", "mm_ix")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("mm_ix")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 3})
+			assertEqual(t, result.Data, []int32{3, -1, 4, 1, 5, -9})
+		}
+	})
+
+	t.Run("Invalid_Shapes", func(t *testing.T) {
+		_, err := apiClient.MatMul("mm_a", "mm_a", "mm_bad_inner")
+		assertErrorContains(t, err, "inner dimensions do not match")
+
+		assertError(t, apiClient.CreateFromData("mm_vec", []int{3}, []float64{1, 2, 3}), false)
+		_, err = apiClient.MatMul("mm_a", "mm_vec", "mm_bad_rank")
+		assertErrorContains(t, err, "matmul requires 2-D tensors")
+	})
+}
+
+func TestReinterpret(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	source := []float32{1.0, -2.5, 0}
+	assertError(t, apiClient.CreateFromData("reinterp_f32", []int{3}, source), false)
+
+	t.Run("Float32_Bits_As_Int32", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("REINTERPRET TENSOR reinterp_f32 AS INT32 INTO reinterp_i32")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.DataType, tensor.DataTypeInt32)
+		}
+		_, err = apiClient.Reinterpret("reinterp_f32", tensor.DataTypeInt32, "reinterp_i32")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("reinterp_i32")
+		assertError(t, err, false)
+		if err == nil {
+			expected := make([]int32, len(source))
+			for i, v := range source {
+				expected[i] = int32(math.Float32bits(v))
+			}
+			assertEqual(t, result.Data, expected)
+			assertEqual(t, result.Data[0], int32(0x3f800000))
+		}
+	})
+
+	t.Run("Round_Trip", func(t *testing.T) {
+		_, err := apiClient.Reinterpret("reinterp_i32", tensor.DataTypeFloat32, "reinterp_back")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("reinterp_back")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, source)
+		}
+	})
+
+	t.Run("Size_Mismatch_Rejected", func(t *testing.T) {
+		_, err := apiClient.Reinterpret("reinterp_f32", tensor.DataTypeInt64, "reinterp_bad")
+		assertErrorContains(t, err, "element sizes differ")
+		_, err = (&tensor.Parser{}).Parse("REINTERPRET TENSOR reinterp_f32 AS bogus INTO reinterp_bad")
+		assertErrorContains(t, err, "invalid data type 'bogus' in REINTERPRET")
+	})
+}
+
+func TestMultiplyAndSubtractScalar(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(queryStr string) (interface{}, error) {
+		q, err := parser.Parse(queryStr)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(q)
+	}
+
+	_, err := run("CREATE TENSOR scalar_ops_f32 4 TYPE float32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO scalar_ops_f32 VALUES (1, -2, 3.5, 0)")
+	assertError(t, err, false)
+	_, err = run("CREATE TENSOR scalar_ops_i64 3 TYPE int64")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO scalar_ops_i64 VALUES (10, 20, -30)")
+	assertError(t, err, false)
+
+	t.Run("Multiply", func(t *testing.T) {
+		_, err := run("MULTIPLY SCALAR 2 TO TENSOR scalar_ops_f32 INTO scalar_mul_f32")
+		assertError(t, err, false)
+		result, err := run("SELECT scalar_mul_f32 FROM scalar_mul_f32")
+		assertError(t, err, false)
+		assertFormattedEqual(t, result, []interface{}{float32(2), float32(-4), float32(7), float32(0)})
+
+		_, err = run("MULTIPLY SCALAR -3 TO TENSOR scalar_ops_i64 INTO scalar_mul_i64")
+		assertError(t, err, false)
+		result, err = run("SELECT scalar_mul_i64 FROM scalar_mul_i64")
+		assertError(t, err, false)
+		assertFormattedEqual(t, result, []interface{}{int64(-30), int64(-60), int64(90)})
+	})
+
+	t.Run("Subtract", func(t *testing.T) {
+		_, err := run("SUBTRACT SCALAR 1.5 FROM TENSOR scalar_ops_f32 INTO scalar_sub_f32")
+		assertError(t, err, false)
+		result, err := run("SELECT scalar_sub_f32 FROM scalar_sub_f32")
+		assertError(t, err, false)
+		assertFormattedEqual(t, result, []interface{}{float32(-0.5), float32(-3.5), float32(2), float32(-1.5)})
+	})
+
+	t.Run("Operand_Errors_Match_ADD_SCALAR", func(t *testing.T) {
+		for _, form := range []string{
+			"ADD SCALAR %s TO TENSOR scalar_ops_i64 INTO scalar_err_out",
+			"MULTIPLY SCALAR %s TO TENSOR scalar_ops_i64 INTO scalar_err_out",
+			"SUBTRACT SCALAR %s FROM TENSOR scalar_ops_i64 INTO scalar_err_out",
+		} {
+			_, errParse := parser.Parse(fmt.Sprintf(form, "1.2.3"))
+			assertErrorContains(t, errParse, "invalid scalar operand '1.2.3': expected a number such as -1.5 or 1e-3", form)
+
+			_, errExec := run(fmt.Sprintf(form, "2.5"))
+			assertErrorContains(t, errExec, "failed to parse scalar operand '2.5' as int64", form)
+		}
+	})
+}
+
+func TestSumAlongAxis(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("sum_axis_src", []int{2, 3}, []float64{1, 2, 3, 4, 5, 6}), false)
+
+	t.Run("Parse_Along_Axis", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("SUM TENSOR sum_axis_src ALONG AXIS 1 INTO sum_axis_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "SUM")
+			assertTrue(t, query.Axis != nil && *query.Axis == 1, "Axis harus 1")
+		}
+		query, err = (&tensor.Parser{}).Parse("SUM TENSOR sum_axis_src INTO sum_axis_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertTrue(t, query.Axis == nil, "Tanpa AXIS, Axis harus nil")
+		}
+	})
+
+	t.Run("Axis_0", func(t *testing.T) {
+		_, err := apiClient.SumAlongAxis("sum_axis_src", 0, "sum_axis_0")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("sum_axis_0")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{3})
+			assertEqual(t, result.Strides, []int{1})
+			assertEqual(t, result.Data, []float64{5, 7, 9})
+		}
+	})
+
+	t.Run("Axis_1", func(t *testing.T) {
+		_, err := apiClient.SumAlongAxis("sum_axis_src", 1, "sum_axis_1")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("sum_axis_1")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2})
+			assertEqual(t, result.Data, []float64{6, 15})
+		}
+	})
+
+	t.Run("No_Axis_Is_Scalar", func(t *testing.T) {
+		_, err := apiClient.Sum("sum_axis_src", "sum_axis_all")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("sum_axis_all")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{})
+			assertEqual(t, result.Data, []float64{21})
+		}
+	})
+
+	t.Run("Axis_Out_Of_Range", func(t *testing.T) {
+		_, err := apiClient.SumAlongAxis("sum_axis_src", 2, "sum_axis_bad")
+		assertErrorContains(t, err, "axis 2 out of range: valid axes are [0, 2)")
+	})
+
+	t.Run("Library_Function", func(t *testing.T) {
+		src, err := tensor.NewTensor[int32]("lib_src", []int{2, 2, 2}, tensor.DataTypeInt32)
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertError(t, src.SetData([]int32{1, 2, 3, 4, 5, 6, 7, 8}), false)
+		result, err := tensor.SumAlongAxis(src, 1)
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 2})
+			assertEqual(t, result.Strides, []int{2, 1})
+			assertEqual(t, result.Data, []int32{4, 6, 12, 14})
+		}
+	})
+}
+
+func TestMaxMinAlongAxis(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	// [[3, -1, 7],
+	//  [2,  8, 7]]
+	assertError(t, apiClient.CreateFromData("extreme_src", []int{2, 3}, []int32{3, -1, 7, 2, 8, 7}), false)
+
+	t.Run("Parse", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("MAX TENSOR extreme_src ALONG AXIS 1 INTO extreme_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "MAX")
+			assertTrue(t, query.Axis != nil && *query.Axis == 1, "Axis harus 1")
+		}
+		query, err = (&tensor.Parser{}).Parse("min tensor extreme_src along axis 0 into extreme_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "MIN")
+		}
+	})
+
+	cases := []struct {
+		name     string
+		isMax    bool
+		axis     int
+		expShape []int
+		expData  []int32
+	}{
+		{"Max_Axis_0", true, 0, []int{3}, []int32{3, 8, 7}},
+		{"Max_Axis_1", true, 1, []int{2}, []int32{7, 8}},
+		{"Min_Axis_0", false, 0, []int{3}, []int32{2, -1, 7}},
+		{"Min_Axis_1", false, 1, []int{2}, []int32{-1, 2}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			outName := "extreme_" + strings.ToLower(tc.name)
+			var err error
+			if tc.isMax {
+				_, err = apiClient.MaxAlongAxis("extreme_src", tc.axis, outName)
+			} else {
+				_, err = apiClient.MinAlongAxis("extreme_src", tc.axis, outName)
+			}
+			assertError(t, err, false)
+			result, err := apiClient.LoadTensorInt32(outName)
+			assertError(t, err, false)
+			if err == nil {
+				assertEqual(t, result.Shape, tc.expShape)
+				assertEqual(t, result.Data, tc.expData)
+			}
+		})
+	}
+
+	t.Run("Empty_Tensor_Errors", func(t *testing.T) {
+		assertError(t, apiClient.CreateTensor("extreme_empty", []int{3, 0}, tensor.DataTypeInt32), false)
+		_, err := apiClient.MaxAlongAxis("extreme_empty", 0, "extreme_empty_max")
+		assertErrorContains(t, err, "no identity element")
+		_, err = apiClient.MinAlongAxis("extreme_empty", 1, "extreme_empty_min")
+		assertErrorContains(t, err, "no identity element")
+	})
+
+	t.Run("Axis_Out_Of_Range", func(t *testing.T) {
+		_, err := apiClient.MaxAlongAxis("extreme_src", 5, "extreme_bad_axis")
+		assertErrorContains(t, err, "valid axes are [0, 2)")
+	})
+}
+
+func TestTranspose(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+
+	assertError(t, apiClient.CreateFromData("transpose_2d", []int{2, 3}, []float32{1, 2, 3, 4, 5, 6}), false)
+	// Tensor [2,3,4] bernilai 0..23 sehingga setiap nilai sama dengan offset row-major-nya.
+	cube := make([]int64, 24)
+	for i := range cube {
+		cube[i] = int64(i)
+	}
+	assertError(t, apiClient.CreateFromData("transpose_3d", []int{2, 3, 4}, cube), false)
+
+	t.Run("Matrix_Default_Perm", func(t *testing.T) {
+		query, err := parser.Parse("TRANSPOSE TENSOR transpose_2d INTO transpose_2d_t")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, len(query.Perm), 0)
+		}
+		_, err = apiClient.Transpose("transpose_2d", nil, "transpose_2d_t")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("transpose_2d_t")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{3, 2})
+			assertEqual(t, result.Data, []float32{1, 4, 2, 5, 3, 6})
+		}
+	})
+
+	t.Run("Matrix_Explicit_Perm_Query", func(t *testing.T) {
+		query, err := parser.Parse("TRANSPOSE TENSOR transpose_2d PERM 1, 0 INTO transpose_2d_q")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, query.Perm, []int{1, 0})
+		_, err = apiClient.Transpose("transpose_2d", query.Perm, "transpose_2d_q")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("transpose_2d_q")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{3, 2})
+			assertEqual(t, result.Data, []float32{1, 4, 2, 5, 3, 6})
+		}
+	})
+
+	t.Run("Permute_3D", func(t *testing.T) {
+		perm := []int{2, 0, 1}
+		_, err := apiClient.Transpose("transpose_3d", perm, "transpose_3d_p")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt64("transpose_3d_p")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, result.Shape, []int{4, 2, 3})
+		// result[k][i][j] == source[i][j][k] == i*12 + j*4 + k
+		for k := 0; k < 4; k++ {
+			for i := 0; i < 2; i++ {
+				for j := 0; j < 3; j++ {
+					got := result.Data[k*6+i*3+j]
+					if want := int64(i*12 + j*4 + k); got != want {
+						t.Fatalf("transpose_3d_p[%d][%d][%d] = %d, diharapkan %d", k, i, j, got, want)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("Invalid_Perm", func(t *testing.T) {
+		_, err := apiClient.Transpose("transpose_3d", []int{0, 0, 1}, "transpose_bad")
+		assertErrorContains(t, err, "duplicate axis 0")
+		_, err = apiClient.Transpose("transpose_3d", []int{0, 1, 3}, "transpose_bad")
+		assertErrorContains(t, err, "axis 3 out of range [0, 3)")
+		_, err = apiClient.Transpose("transpose_3d", []int{1, 0}, "transpose_bad")
+		assertErrorContains(t, err, "has 2 entries, but tensor has 3 dimension(s)")
+	})
+}
+
+func TestReshape(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("reshape_2x3", []int{2, 3}, []float64{1, 2, 3, 4, 5, 6}), false)
+	assertError(t, apiClient.CreateFromData("reshape_flat", []int{6}, []int32{1, 2, 3, 4, 5, 6}), false)
+
+	t.Run("Shares_Data_In_Memory", func(t *testing.T) {
+		source, err := tensor.NewTensor[float32]("reshape_mem", []int{2, 3}, tensor.DataTypeFloat32)
+		assertError(t, err, false)
+		reshaped, err := tensor.Reshape(source, []int{3, 2})
+		assertError(t, err, false)
+		assertEqual(t, reshaped.Strides, []int{2, 1})
+		reshaped.Data[0] = 42
+		assertEqual(t, source.Data[0], float32(42))
+	})
+
+	t.Run("2x3_To_3x2", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("RESHAPE TENSOR reshape_2x3 TO 3,2 INTO reshape_3x2")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Shape, []int{3, 2})
+		}
+		_, err = apiClient.Reshape("reshape_2x3", []int{3, 2}, "reshape_3x2")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("reshape_3x2")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{3, 2})
+			assertEqual(t, result.Strides, []int{2, 1})
+			assertEqual(t, result.Data, []float64{1, 2, 3, 4, 5, 6})
+		}
+	})
+
+	t.Run("6_To_2x3", func(t *testing.T) {
+		_, err := apiClient.Reshape("reshape_flat", []int{2, 3}, "reshape_flat_2x3")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("reshape_flat_2x3")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 3})
+			assertEqual(t, result.Data, []int32{1, 2, 3, 4, 5, 6})
+		}
+	})
+
+	t.Run("Invalid_Target", func(t *testing.T) {
+		_, err := apiClient.Reshape("reshape_flat", []int{4, 2}, "reshape_bad")
+		assertErrorContains(t, err, "element counts differ")
+		_, err = apiClient.Reshape("reshape_flat", []int{0, 6}, "reshape_bad")
+		assertErrorContains(t, err, "a shape with a zero dimension can only be reshaped to another shape with a zero dimension")
+		empty, err := tensor.NewTensor[int32]("reshape_empty", []int{0, 3}, tensor.DataTypeInt32)
+		assertError(t, err, false)
+		_, err = tensor.Reshape(empty, []int{3})
+		assertErrorContains(t, err, "zero dimension")
+		reshaped, err := tensor.Reshape(empty, []int{3, 0})
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, reshaped.Shape, []int{3, 0})
+		}
+	})
+}
+
+func TestPairwiseDistances(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	// Titik (0,0), (3,4), (6,8), (0,1): jarak 5, 10, 1, 5, sqrt(18), sqrt(85).
+	points := []float64{0, 0, 3, 4, 6, 8, 0, 1}
+	assertError(t, apiClient.CreateFromData("pdist_points", []int{4, 2}, points), false)
+	assertError(t, apiClient.CreateFromData("pdist_ints", []int{2, 2}, []int32{0, 0, 3, 4}), false)
+
+	t.Run("Hand_Computed", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("PDIST TENSOR pdist_points INTO pdist_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "PDIST")
+		}
+		_, err = apiClient.PairwiseDistances("pdist_points", "pdist_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("pdist_out")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, result.Shape, []int{4, 4})
+		expected := []float64{
+			0, 5, 10, 1,
+			5, 0, 5, math.Sqrt(18),
+			10, 5, 0, math.Sqrt(85),
+			1, math.Sqrt(18), math.Sqrt(85), 0,
+		}
+		for i, want := range expected {
+			if math.Abs(result.Data[i]-want) > 1e-12 {
+				t.Fatalf("pdist_out[%d][%d] = %v, diharapkan %v", i/4, i%4, result.Data[i], want)
+			}
+		}
+	})
+
+	t.Run("Float32", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("pdist_f32", []int{2, 3}, []float32{1, 2, 3, 1, 2, 5}), false)
+		_, err := apiClient.PairwiseDistances("pdist_f32", "pdist_f32_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("pdist_f32_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []float32{0, 2, 2, 0})
+		}
+	})
+
+	t.Run("Invalid_Input", func(t *testing.T) {
+		_, err := apiClient.PairwiseDistances("pdist_ints", "pdist_bad")
+		assertErrorContains(t, err, "operation PDIST does not support dtype int32")
+		assertError(t, apiClient.CreateFromData("pdist_1d", []int{3}, []float64{1, 2, 3}), false)
+		_, err = apiClient.PairwiseDistances("pdist_1d", "pdist_bad")
+		assertErrorContains(t, err, "pairwise distance requires a 2-D tensor")
+	})
+}
+
+func TestCovariance(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	// Kolom x = [1,2,3,4], y = 2x, w = [4,1,3,2], z konstan. Kovarians sampel (pembagi n-1 = 3):
+	// var(x) = 5/3, cov(x,y) = 10/3, var(y) = 20/3, cov(x,w) = -2/3, cov(y,w) = -4/3, var(w) = 5/3.
+	data := []int32{
+		1, 2, 4, 7,
+		2, 4, 1, 7,
+		3, 6, 3, 7,
+		4, 8, 2, 7,
+	}
+	assertError(t, apiClient.CreateFromData("cov_data", []int{4, 4}, data), false)
+
+	t.Run("Covariance", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("cov TENSOR cov_data INTO cov_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "COV")
+		}
+		_, err = apiClient.Covariance("cov_data", "cov_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("cov_out")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, result.Shape, []int{4, 4})
+		expected := []float64{
+			5.0 / 3, 10.0 / 3, -2.0 / 3, 0,
+			10.0 / 3, 20.0 / 3, -4.0 / 3, 0,
+			-2.0 / 3, -4.0 / 3, 5.0 / 3, 0,
+			0, 0, 0, 0,
+		}
+		for i, want := range expected {
+			if math.Abs(result.Data[i]-want) > 1e-12 {
+				t.Fatalf("cov_out[%d][%d] = %v, diharapkan %v", i/4, i%4, result.Data[i], want)
+			}
+		}
+	})
+
+	t.Run("Correlation", func(t *testing.T) {
+		_, err := apiClient.Correlation("cov_data", "corr_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("corr_out")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		nan := math.NaN()
+		expected := []float64{
+			1, 1, -0.4, nan,
+			1, 1, -0.4, nan,
+			-0.4, -0.4, 1, nan,
+			nan, nan, nan, nan,
+		}
+		for i, want := range expected {
+			got := result.Data[i]
+			if math.IsNaN(want) {
+				if !math.IsNaN(got) {
+					t.Fatalf("corr_out[%d][%d] = %v, diharapkan NaN untuk kolom dengan varians nol", i/4, i%4, got)
+				}
+				continue
+			}
+			if math.Abs(got-want) > 1e-12 {
+				t.Fatalf("corr_out[%d][%d] = %v, diharapkan %v", i/4, i%4, got, want)
+			}
+		}
+	})
+
+	t.Run("Float32", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("cov_f32", []int{2, 2}, []float32{1, 3, 3, 1}), false)
+		_, err := apiClient.Covariance("cov_f32", "cov_f32_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("cov_f32_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []float32{2, -2, -2, 2})
+		}
+	})
+
+	t.Run("Invalid_Input", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("cov_1d", []int{3}, []float64{1, 2, 3}), false)
+		_, err := apiClient.Covariance("cov_1d", "cov_bad")
+		assertErrorContains(t, err, "covariance requires a 2-D tensor")
+		assertError(t, apiClient.CreateFromData("cov_one_row", []int{1, 3}, []float64{1, 2, 3}), false)
+		_, err = apiClient.Correlation("cov_one_row", "cov_bad")
+		assertErrorContains(t, err, "covariance requires at least 2 rows")
+	})
+}
+
+func TestClipNorm(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Scaled_To_Max", func(t *testing.T) {
+		// Norma [3, 4] = 5; dengan MAX 1 hasilnya [0.6, 0.8].
+		assertError(t, apiClient.CreateFromData("clip_big", []int{2}, []float64{3, 4}), false)
+		query, err := (&tensor.Parser{}).Parse("CLIP_NORM TENSOR clip_big MAX 1.0 INTO clip_big_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "CLIP_NORM")
+			assertEqual(t, query.ScalarOperand, "1.0")
+		}
+		_, err = apiClient.ClipNorm("clip_big", 1.0, "clip_big_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat64("clip_big_out")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		norm := math.Hypot(result.Data[0], result.Data[1])
+		if math.Abs(norm-1.0) > 1e-12 || math.Abs(result.Data[0]-0.6) > 1e-12 || math.Abs(result.Data[1]-0.8) > 1e-12 {
+			t.Fatalf("clip_big_out = %v (norma %v), diharapkan [0.6 0.8] dengan norma 1", result.Data, norm)
+		}
+	})
+
+	t.Run("Extreme_Magnitudes", func(t *testing.T) {
+		// Kuadrat 3e200 overflow dan kuadrat 3e-200 underflow pada float64; norma berskala tetap
+		// memberi arah [0.6, 0.8].
+		for _, c := range []struct {
+			name    string
+			data    []float64
+			maxNorm float64
+		}{
+			{"clip_huge", []float64{3e200, -4e200}, 1},
+			{"clip_tiny", []float64{3e-200, -4e-200}, 1e-201},
+		} {
+			assertError(t, apiClient.CreateFromData(c.name, []int{2}, c.data), false)
+			_, err := apiClient.ClipNorm(c.name, c.maxNorm, c.name+"_out")
+			assertError(t, err, false)
+			result, err := apiClient.LoadTensorFloat64(c.name + "_out")
+			assertError(t, err, false)
+			if err != nil {
+				continue
+			}
+			got := []float64{result.Data[0] / c.maxNorm, result.Data[1] / c.maxNorm}
+			if math.Abs(got[0]-0.6) > 1e-12 || math.Abs(got[1]+0.8) > 1e-12 {
+				t.Errorf("%s_out = %v, diharapkan [0.6 -0.8] kali %v", c.name, result.Data, c.maxNorm)
+			}
+		}
+	})
+
+	t.Run("Below_Max_Unchanged", func(t *testing.T) {
+		data := []float32{0.1, -0.2, 0.2, 0}
+		assertError(t, apiClient.CreateFromData("clip_small", []int{2, 2}, data), false)
+		_, err := apiClient.ClipNorm("clip_small", 1.0, "clip_small_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("clip_small_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 2})
+			assertEqual(t, result.Data, data)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("clip_int", []int{2}, []int32{3, 4}), false)
+		_, err := apiClient.ClipNorm("clip_int", 1.0, "clip_int_out")
+		assertErrorContains(t, err, "operation CLIP_NORM does not support dtype int32")
+		_, err = apiClient.ClipNorm("clip_big", -1, "clip_neg_out")
+		assertErrorContains(t, err, "clip norm MAX must be a non-negative number")
+	})
+}
+
+func TestInterleave(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("il_a", []int{2, 3}, []int32{1, 2, 3, 4, 5, 6}), false)
+	assertError(t, apiClient.CreateFromData("il_b", []int{2, 3}, []int32{10, 20, 30, 40, 50, 60}), false)
+
+	t.Run("Axis0", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("INTERLEAVE TENSOR il_a WITH TENSOR il_b AXIS 0 INTO il_rows")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "INTERLEAVE")
+			assertEqual(t, query.InputTensorNames, []string{"il_a", "il_b"})
+			assertEqual(t, *query.Axis, 0)
+		}
+		_, err = apiClient.Interleave("il_a", "il_b", 0, "il_rows")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("il_rows")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{4, 3})
+			assertEqual(t, result.Data, []int32{1, 2, 3, 10, 20, 30, 4, 5, 6, 40, 50, 60})
+		}
+	})
+
+	t.Run("Axis1", func(t *testing.T) {
+		_, err := apiClient.Interleave("il_a", "il_b", 1, "il_cols")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("il_cols")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 6})
+			assertEqual(t, result.Data, []int32{1, 10, 2, 20, 3, 30, 4, 40, 5, 50, 6, 60})
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("il_c", []int{3, 2}, []int32{1, 2, 3, 4, 5, 6}), false)
+		_, err := apiClient.Interleave("il_a", "il_c", 0, "il_bad_shape")
+		assertErrorContains(t, err, "does not match shape")
+		_, err = apiClient.Interleave("il_a", "il_b", 2, "il_bad_axis")
+		assertErrorContains(t, err, "axis 2 is out of range")
+	})
+}
+
+func TestConcat(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("cc_a", []int{2, 2}, []int32{1, 2, 3, 4}), false)
+	assertError(t, apiClient.CreateFromData("cc_b", []int{2, 2}, []int32{5, 6, 7, 8}), false)
+
+	t.Run("Axis0", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("CONCAT TENSOR cc_a WITH TENSOR cc_b ALONG AXIS 0 INTO cc_rows")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "CONCAT")
+			assertEqual(t, query.InputTensorNames, []string{"cc_a", "cc_b"})
+			assertEqual(t, query.OutputTensorName, "cc_rows")
+			assertEqual(t, *query.Axis, 0)
+		}
+		_, err = apiClient.Concat("cc_a", "cc_b", 0, "cc_rows")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("cc_rows")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{4, 2})
+			assertEqual(t, result.Data, []int32{1, 2, 3, 4, 5, 6, 7, 8})
+		}
+	})
+
+	t.Run("Axis1", func(t *testing.T) {
+		_, err := apiClient.Concat("cc_a", "cc_b", 1, "cc_cols")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("cc_cols")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 4})
+			assertEqual(t, result.Data, []int32{1, 2, 5, 6, 3, 4, 7, 8})
+		}
+	})
+
+	t.Run("Unequal_Concat_Dimension", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("cc_wide", []int{2, 3}, []int32{9, 10, 11, 12, 13, 14}), false)
+		_, err := apiClient.Concat("cc_a", "cc_wide", 1, "cc_mixed")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("cc_mixed")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 5})
+			assertEqual(t, result.Data, []int32{1, 2, 9, 10, 11, 3, 4, 12, 13, 14})
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := apiClient.Concat("cc_a", "cc_wide", 0, "cc_bad_shape")
+		assertErrorContains(t, err, "does not match shape")
+		assertError(t, apiClient.CreateFromData("cc_f32", []int{2, 2}, []float32{1, 2, 3, 4}), false)
+		_, err = apiClient.Concat("cc_a", "cc_f32", 0, "cc_bad_type")
+		assertErrorContains(t, err, "do not match")
+		_, err = apiClient.Concat("cc_a", "cc_b", 2, "cc_bad_axis")
+		assertErrorContains(t, err, "axis 2 is out of range")
+	})
+}
+
+func TestAddTensorsBroadcast(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("bc_matrix", []int{2, 3}, []float32{1, 2, 3, 4, 5, 6}), false)
+	assertError(t, apiClient.CreateFromData("bc_bias", []int{3}, []float32{10, 20, 30}), false)
+
+	t.Run("Row_Vector", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("ADD TENSOR bc_matrix WITH TENSOR bc_bias BROADCAST INTO bc_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "ADD_TENSORS")
+			assertEqual(t, query.OutputTensorName, "bc_out")
+			assertTrue(t, query.Broadcast, "Kata kunci BROADCAST harus mengaktifkan Broadcast")
+		}
+		_, err = apiClient.AddTensorsBroadcast("bc_matrix", "bc_bias", "bc_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("bc_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 3})
+			assertEqual(t, result.Data, []float32{11, 22, 33, 14, 25, 36})
+		}
+	})
+
+	t.Run("Column_Vector_Both_Sides", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("bc_col", []int{2, 1}, []float32{100, 200}), false)
+		_, err := apiClient.AddTensorsBroadcast("bc_col", "bc_bias", "bc_outer")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("bc_outer")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 3})
+			assertEqual(t, result.Data, []float32{110, 120, 130, 210, 220, 230})
+		}
+	})
+
+	t.Run("Requires_Keyword", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("ADD TENSOR bc_matrix WITH TENSOR bc_bias INTO bc_no_keyword")
+		assertError(t, err, false)
+		if err == nil {
+			assertTrue(t, !query.Broadcast, "Tanpa BROADCAST, Broadcast harus false")
+		}
+		_, err = apiClient.AddTensors("bc_matrix", "bc_bias", "bc_no_keyword")
+		assertErrorContains(t, err, "does not match shape")
+	})
+
+	t.Run("Incompatible_Shapes", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("bc_bad", []int{2}, []float32{1, 2}), false)
+		_, err := apiClient.AddTensorsBroadcast("bc_matrix", "bc_bad", "bc_bad_out")
+		assertErrorContains(t, err, "cannot be broadcast")
+	})
+}
+
+func TestSegmentSum(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("seg_x", []int{4, 2}, []float32{1, 2, 3, 4, 5, 6, 7, 8}), false)
+	assertError(t, apiClient.CreateFromData("seg_ids", []int{4}, []int32{0, 0, 1, 1}), false)
+
+	t.Run("Sum_Per_Segment", func(t *testing.T) {
+		query, err := (&tensor.Parser{}).Parse("SEGMENT_SUM TENSOR seg_x SEGMENTS seg_ids INTO seg_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "SEGMENT_SUM")
+			assertEqual(t, query.InputTensorNames, []string{"seg_x", "seg_ids"})
+		}
+		_, err = apiClient.SegmentSum("seg_x", "seg_ids", "seg_out")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("seg_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Shape, []int{2, 2})
+			assertEqual(t, result.Data, []float32{4, 6, 12, 14})
+		}
+	})
+
+	t.Run("Unordered_Segments", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("seg_ids_mixed", []int{4}, []uint8{1, 0, 1, 0}), false)
+		_, err := apiClient.SegmentSum("seg_x", "seg_ids_mixed", "seg_out_mixed")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorFloat32("seg_out_mixed")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []float32{10, 12, 6, 8})
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("seg_ids_gap", []int{4}, []int64{0, 0, 2, 2}), false)
+		_, err := apiClient.SegmentSum("seg_x", "seg_ids_gap", "seg_out_gap")
+		assertErrorContains(t, err, "segment ids must be contiguous from 0, but id 1 is missing")
+
+		assertError(t, apiClient.CreateFromData("seg_ids_short", []int{3}, []int32{0, 0, 1}), false)
+		_, err = apiClient.SegmentSum("seg_x", "seg_ids_short", "seg_out_short")
+		assertErrorContains(t, err, "segment ids have 3 entries, but tensor 'seg_x' has 4 rows")
+
+		assertError(t, apiClient.CreateFromData("seg_ids_float", []int{4}, []float32{0, 0, 1, 1}), false)
+		_, err = apiClient.SegmentSum("seg_x", "seg_ids_float", "seg_out_float")
+		assertErrorContains(t, err, "requires an integer segment id tensor")
+
+		assertError(t, apiClient.CreateFromData("seg_ids_huge", []int{4}, []int64{0, 0, 1, math.MaxInt64}), false)
+		_, err = apiClient.SegmentSum("seg_x", "seg_ids_huge", "seg_out_huge")
+		assertErrorContains(t, err, "segment id 9223372036854775807 at index 3 must be less than the number of rows (4)")
+	})
+}
+
+func TestNamedParameters(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(queryStr string) (interface{}, error) {
+		q, err := parser.Parse(queryStr)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(q)
+	}
+
+	_, err := run("CREATE TENSOR grad 3 TYPE float64")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO grad VALUES (1, 2, 4)")
+	assertError(t, err, false)
+
+	template, err := parser.Parse("MULTIPLY SCALAR :lr TO TENSOR grad INTO step -- langkah SGD")
+	assertError(t, err, false)
+	if err != nil {
+		return
+	}
+	assertEqual(t, template.ScalarOperand, ":lr")
+	assertEqual(t, template.Params, []string{":lr"})
+
+	t.Run("Bound", func(t *testing.T) {
+		_, err := executor.ExecuteWithParams(template, map[string]string{"lr": "0.5"})
+		assertError(t, err, false)
+		result, err := run("SELECT step FROM step")
+		assertError(t, err, false)
+		assertFormattedEqual(t, result, []interface{}{0.5, 1.0, 2.0})
+		// Template tidak berubah sehingga dapat dipakai ulang dengan nilai lain.
+		assertEqual(t, template.ScalarOperand, ":lr")
+	})
+
+	t.Run("Reuse_Template", func(t *testing.T) {
+		reused := *template
+		reused.OutputTensorName = "step_small"
+		_, err := executor.ExecuteWithParams(&reused, map[string]string{"lr": "0.25"})
+		assertError(t, err, false)
+		result, err := run("SELECT step_small FROM step_small")
+		assertError(t, err, false)
+		assertFormattedEqual(t, result, []interface{}{0.25, 0.5, 1.0})
+	})
+
+	t.Run("Comments", func(t *testing.T) {
+		query, err := parser.Parse("-- skala gradien\nADD SCALAR 1 TO TENSOR grad -- tambah satu\n INTO grad_plus")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "ADD_SCALAR")
+			assertEqual(t, query.OutputTensorName, "grad_plus")
+			assertEqual(t, len(query.Params), 0)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := executor.Execute(template)
+		assertErrorContains(t, err, "query has unbound parameter(s) :lr")
+		_, err = executor.ExecuteWithParams(template, map[string]string{"momentum": "0.9"})
+		assertErrorContains(t, err, "missing value for parameter ':lr'")
+		_, err = executor.ExecuteWithParams(template, map[string]string{"lr": "1; DROP TENSOR grad"})
+		assertErrorContains(t, err, "parameter ':lr': invalid scalar operand '1; DROP TENSOR grad'")
+		_, err = executor.ExecuteWithParams(template, map[string]string{"lr": ":lr"})
+		assertErrorContains(t, err, "value ':lr' for parameter ':lr' must be a number, not a parameter")
+		_, err = parser.Parse("ADD SCALAR :1x TO TENSOR grad INTO bad")
+		assertErrorContains(t, err, "invalid scalar operand ':1x'")
+	})
+}
+
+func TestBinaryOpRegistry(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("bo_a", []int{4}, []int32{7, -7, 3, 10}), false)
+	assertError(t, apiClient.CreateFromData("bo_b", []int{4}, []int32{3, 3, 9, 4}), false)
+
+	t.Run("Custom_Op", func(t *testing.T) {
+		absDiff := func(a, b int32) int32 {
+			if a > b {
+				return a - b
+			}
+			return b - a
+		}
+		assertError(t, tensor.RegisterBinaryOp("test_absdiff", absDiff), false)
+		t.Cleanup(func() { assertError(t, tensor.UnregisterBinaryOp[int32]("test_absdiff"), false) })
+		assertErrorContains(t, tensor.RegisterBinaryOp("TEST_ABSDIFF", absDiff), "already registered")
+
+		query, err := (&tensor.Parser{}).Parse("OP test_absdiff TENSOR bo_a WITH TENSOR bo_b INTO bo_absdiff")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "TEST_ABSDIFF")
+			assertEqual(t, query.InputTensorNames, []string{"bo_a", "bo_b"})
+			assertEqual(t, query.OutputTensorName, "bo_absdiff")
+		}
+		_, err = apiClient.BinaryOp("test_absdiff", "bo_a", "bo_b", "bo_absdiff")
+		assertError(t, err, false)
+		result, err := apiClient.LoadTensorInt32("bo_absdiff")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, result.Data, []int32{4, 10, 6, 6})
+		}
+
+		// Operasi hanya didaftarkan untuk int32.
+		assertError(t, apiClient.CreateFromData("bo_f", []int{4}, []float32{1, 2, 3, 4}), false)
+		_, err = apiClient.BinaryOp("test_absdiff", "bo_f", "bo_f", "bo_absdiff_f")
+		assertErrorContains(t, err, "does not support dtype float32")
+	})
+
+	t.Run("Builtin_Mod_And_Maximum", func(t *testing.T) {
+		_, err := apiClient.BinaryOp("MOD", "bo_a", "bo_b", "bo_mod")
+		assertError(t, err, false)
+		mod, err := apiClient.LoadTensorInt32("bo_mod")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, mod.Data, []int32{1, -1, 3, 2})
+		}
+
+		_, err = apiClient.BinaryOp("maximum", "bo_a", "bo_b", "bo_max")
+		assertError(t, err, false)
+		maximum, err := apiClient.LoadTensorInt32("bo_max")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, maximum.Data, []int32{7, 3, 9, 10})
+		}
+
+		assertError(t, apiClient.CreateFromData("bo_x", []int{3}, []float64{5.5, -5.5, 1}), false)
+		assertError(t, apiClient.CreateFromData("bo_y", []int{3}, []float64{2, 2, math.NaN()}), false)
+		_, err = apiClient.BinaryOp("MOD", "bo_x", "bo_y", "bo_fmod")
+		assertErrorContains(t, err, "operation MOD does not support dtype float64")
+		_, err = apiClient.BinaryOp("MAXIMUM", "bo_x", "bo_y", "bo_fmax")
+		assertError(t, err, false)
+		fmax, err := apiClient.LoadTensorFloat64("bo_fmax")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, fmax.Data[:2], []float64{5.5, 2})
+			assertTrue(t, math.IsNaN(fmax.Data[2]), "MAXIMUM dengan NaN harus menghasilkan NaN")
+		}
+	})
+
+	t.Run("Add_Uses_Registry", func(t *testing.T) {
+		_, err := apiClient.AddTensors("bo_a", "bo_b", "bo_add")
+		assertError(t, err, false)
+		viaAdd, err := apiClient.LoadTensorInt32("bo_add")
+		assertError(t, err, false)
+		_, err = apiClient.BinaryOp("ADD", "bo_a", "bo_b", "bo_op_add")
+		assertError(t, err, false)
+		viaOp, err := apiClient.LoadTensorInt32("bo_op_add")
+		assertError(t, err, false)
+		if viaAdd != nil && viaOp != nil {
+			assertEqual(t, viaAdd.Data, []int32{10, -4, 12, 14})
+			assertEqual(t, viaOp.Data, viaAdd.Data)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("bo_zero", []int{4}, []int32{1, 0, 1, 1}), false)
+		_, err := apiClient.BinaryOp("MOD", "bo_a", "bo_zero", "bo_mod_zero")
+		assertErrorContains(t, err, "integer division by zero at flat index 1")
+		_, err = apiClient.LoadTensorInt32("bo_mod_zero")
+		assertError(t, err, true, "Tensor hasil tidak boleh dibuat jika operasi gagal")
+
+		_, err = apiClient.BinaryOp("NO_SUCH_OP", "bo_a", "bo_b", "bo_unknown")
+		assertErrorContains(t, err, "unsupported mathematical operator")
+
+		assertErrorContains(t, tensor.RegisterBinaryOp("SQRT", func(a, b float32) float32 { return a }), "conflicts with a built-in")
+		assertErrorContains(t, tensor.RegisterBinaryOp("bad name", func(a, b float32) float32 { return a }), "invalid binary operation name")
+		assertErrorContains(t, tensor.UnregisterBinaryOp[int32]("mod"), "MOD is built in and cannot be unregistered")
+		assertErrorContains(t, tensor.UnregisterBinaryOp[float32]("NO_SUCH_OP"), "NO_SUCH_OP is not registered for dtype float32")
+	})
+}
+
+func TestParallelElementwiseOps(t *testing.T) {
+	// Di atas ambang paralel (1<<20 elemen) dan tidak habis dibagi jumlah CPU pada umumnya.
+	shape := []int{1025, 1023}
+	n := shape[0] * shape[1]
+	a, err := tensor.NewTensor[float32]("par_a", shape, tensor.DataTypeFloat32)
+	assertError(t, err, false)
+	b, err := tensor.NewTensor[float32]("par_b", shape, tensor.DataTypeFloat32)
+	assertError(t, err, false)
+	if a == nil || b == nil {
+		return
+	}
+	for i := 0; i < n; i++ {
+		a.Data[i] = float32(i) * 0.37
+		b.Data[i] = float32(n-i) / 3
+	}
+
+	t.Run("AddTensors_Matches_Serial", func(t *testing.T) {
+		result, err := tensor.AddTensors(a, b)
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		for i := 0; i < n; i++ {
+			if result.Data[i] != a.Data[i]+b.Data[i] {
+				t.Fatalf("Elemen %d berbeda dari hasil serial: %v != %v", i, result.Data[i], a.Data[i]+b.Data[i])
+			}
+		}
+	})
+
+	t.Run("AddScalarToTensor_Matches_Serial", func(t *testing.T) {
+		result, err := tensor.AddScalarToTensor(a, float32(1.5))
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		for i := 0; i < n; i++ {
+			if result.Data[i] != a.Data[i]+1.5 {
+				t.Fatalf("Elemen %d berbeda dari hasil serial: %v != %v", i, result.Data[i], a.Data[i]+1.5)
+			}
+		}
+	})
+
+	t.Run("Panic_In_Worker_Is_Error", func(t *testing.T) {
+		x, err := tensor.NewTensor[int32]("par_x", shape, tensor.DataTypeInt32)
+		assertError(t, err, false)
+		zeros, err := tensor.NewTensor[int32]("par_zeros", shape, tensor.DataTypeInt32)
+		assertError(t, err, false)
+		if x == nil || zeros == nil {
+			return
+		}
+		// Operasi kustom tanpa pemeriksaan pembagi nol: panic di goroutine pekerja menjadi error.
+		assertError(t, tensor.RegisterBinaryOp("test_par_div", func(a, b int32) int32 { return a / b }), false)
+		t.Cleanup(func() { assertError(t, tensor.UnregisterBinaryOp[int32]("test_par_div"), false) })
+		_, err = tensor.ApplyBinaryOp("test_par_div", x, zeros)
+		assertErrorContains(t, err, "operation TEST_PAR_DIV failed")
+	})
+}
+
+func TestModAndFloorDiv(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("md_a", []int{2, 3}, []int32{7, -7, 7, -7, 6, 0}), false)
+	assertError(t, apiClient.CreateFromData("md_b", []int{2, 3}, []int32{2, 2, -2, -2, 3, 5}), false)
+
+	t.Run("Parse", func(t *testing.T) {
+		for _, op := range []string{"MOD", "FLOORDIV"} {
+			query, err := (&tensor.Parser{}).Parse(strings.ToLower(op) + " TENSOR md_a WITH TENSOR md_b INTO md_out")
+			assertError(t, err, false)
+			if err == nil {
+				assertEqual(t, query.MathOperator, op)
+				assertEqual(t, query.InputTensorNames, []string{"md_a", "md_b"})
+				assertEqual(t, query.OutputTensorName, "md_out")
+			}
+		}
+	})
+
+	t.Run("Int32", func(t *testing.T) {
+		_, err := apiClient.Mod("md_a", "md_b", "md_mod")
+		assertError(t, err, false)
+		mod, err := apiClient.LoadTensorInt32("md_mod")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, mod.Shape, []int{2, 3})
+			assertEqual(t, mod.Data, []int32{1, -1, 1, -1, 0, 0})
+		}
+
+		_, err = apiClient.FloorDiv("md_a", "md_b", "md_floordiv")
+		assertError(t, err, false)
+		floorDiv, err := apiClient.LoadTensorInt32("md_floordiv")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, floorDiv.Data, []int32{3, -4, -4, 3, 2, 0})
+		}
+	})
+
+	t.Run("Unsigned", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("md_u", []int{3}, []uint8{250, 7, 9}), false)
+		assertError(t, apiClient.CreateFromData("md_v", []int{3}, []uint8{7, 250, 3}), false)
+		_, err := apiClient.Mod("md_u", "md_v", "md_umod")
+		assertError(t, err, false)
+		umod, err := apiClient.LoadTensorUint8("md_umod")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, umod.Data, []uint8{5, 7, 0})
+		}
+		_, err = apiClient.FloorDiv("md_u", "md_v", "md_ufloordiv")
+		assertError(t, err, false)
+		ufloorDiv, err := apiClient.LoadTensorUint8("md_ufloordiv")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, ufloorDiv.Data, []uint8{35, 0, 3})
+		}
+	})
+
+	t.Run("Division_By_Zero", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("md_zero", []int{2, 3}, []int32{1, 1, 1, 1, 0, 1}), false)
+		_, err := apiClient.Mod("md_a", "md_zero", "md_mod_zero")
+		assertErrorContains(t, err, "operation MOD: integer division by zero at flat index 4")
+		_, err = apiClient.FloorDiv("md_a", "md_zero", "md_floordiv_zero")
+		assertErrorContains(t, err, "operation FLOORDIV: integer division by zero at flat index 4")
+		_, err = apiClient.LoadTensorInt32("md_floordiv_zero")
+		assertError(t, err, true, "Tensor hasil tidak boleh dibuat jika pembagi nol")
+	})
+
+	t.Run("Float_Rejected", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("md_f", []int{2}, []float32{1.5, 2}), false)
+		_, err := apiClient.Mod("md_f", "md_f", "md_fmod")
+		assertErrorContains(t, err, "operation MOD does not support dtype float32")
+		_, err = apiClient.FloorDiv("md_f", "md_f", "md_ffloordiv")
+		assertErrorContains(t, err, "operation FLOORDIV does not support dtype float32")
+	})
+}
+
+func TestBitwiseOperations(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	// 12 = 0b1100, 10 = 0b1010
+	assertError(t, apiClient.CreateFromData("bw_a", []int{2, 2}, []int32{12, 0, -1, 0x0F0F}), false)
+	assertError(t, apiClient.CreateFromData("bw_b", []int{2, 2}, []int32{10, 7, 255, 0x00FF}), false)
+
+	t.Run("Parse", func(t *testing.T) {
+		parser := &tensor.Parser{}
+		for _, op := range []string{"AND", "OR", "XOR"} {
+			query, err := parser.Parse("bitwise " + strings.ToLower(op) + " TENSOR bw_a WITH TENSOR bw_b INTO bw_out")
+			assertError(t, err, false)
+			if err == nil {
+				assertEqual(t, query.MathOperator, "BITWISE_"+op)
+				assertEqual(t, query.InputTensorNames, []string{"bw_a", "bw_b"})
+				assertEqual(t, query.OutputTensorName, "bw_out")
+			}
+		}
+		query, err := parser.Parse("BITWISE NOT TENSOR bw_a INTO bw_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "BITWISE_NOT")
+			assertEqual(t, query.InputTensorNames, []string{"bw_a"})
+		}
+	})
+
+	t.Run("Int32", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			run      func(a, b, out string) (string, error)
+			expected []int32
+		}{
+			{"bw_and", apiClient.BitwiseAnd, []int32{8, 0, 255, 0x000F}},
+			{"bw_or", apiClient.BitwiseOr, []int32{14, 7, -1, 0x0FFF}},
+			{"bw_xor", apiClient.BitwiseXor, []int32{6, 7, -256, 0x0FF0}},
+		}
+		for _, c := range cases {
+			_, err := c.run("bw_a", "bw_b", c.name)
+			assertError(t, err, false, c.name)
+			result, err := apiClient.LoadTensorInt32(c.name)
+			assertError(t, err, false, c.name)
+			if err == nil {
+				assertEqual(t, result.Shape, []int{2, 2})
+				assertEqual(t, result.Data, c.expected, c.name)
+			}
+		}
+
+		_, err := apiClient.BitwiseNot("bw_a", "bw_not")
+		assertError(t, err, false)
+		not, err := apiClient.LoadTensorInt32("bw_not")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, not.Data, []int32{-13, -1, 0, -3856})
+		}
+	})
+
+	t.Run("Unsigned_Not", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("bw_u", []int{3}, []uint8{0, 5, 255}), false)
+		_, err := apiClient.BitwiseNot("bw_u", "bw_u_not")
+		assertError(t, err, false)
+		not, err := apiClient.LoadTensorUint8("bw_u_not")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, not.Data, []uint8{255, 250, 0})
+		}
+	})
+
+	t.Run("Float_Rejected", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("bw_f", []int{2}, []float32{1, 2}), false)
+		_, err := apiClient.BitwiseAnd("bw_f", "bw_f", "bw_f_and")
+		assertErrorContains(t, err, "operation BITWISE_AND does not support dtype float32")
+		_, err = apiClient.BitwiseNot("bw_f", "bw_f_not")
+		assertErrorContains(t, err, "operation BITWISE_NOT does not support dtype float32")
+	})
+}
+
+func TestShiftOperations(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateFromData("sh_a", []int{2, 2}, []int32{1, 5, -8, 0x40000000}), false)
+
+	t.Run("Parse", func(t *testing.T) {
+		parser := &tensor.Parser{}
+		query, err := parser.Parse("shift left TENSOR sh_a BY 2 INTO sh_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "SHIFT_LEFT")
+			assertEqual(t, query.InputTensorNames, []string{"sh_a"})
+			assertEqual(t, query.ScalarOperand, "2")
+			assertEqual(t, query.OutputTensorName, "sh_out")
+		}
+		query, err = parser.Parse("SHIFT RIGHT TENSOR sh_a BY 1 INTO sh_out")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.MathOperator, "SHIFT_RIGHT")
+		}
+	})
+
+	t.Run("Int32", func(t *testing.T) {
+		_, err := apiClient.ShiftLeft("sh_a", 2, "sh_left")
+		assertError(t, err, false)
+		left, err := apiClient.LoadTensorInt32("sh_left")
+		assertError(t, err, false)
+		if err == nil {
+			// Bit yang tergeser keluar dibuang: 0x40000000 << 2 menjadi 0.
+			assertEqual(t, left.Shape, []int{2, 2})
+			assertEqual(t, left.Data, []int32{4, 20, -32, 0})
+		}
+
+		_, err = apiClient.ShiftRight("sh_a", 1, "sh_right")
+		assertError(t, err, false)
+		right, err := apiClient.LoadTensorInt32("sh_right")
+		assertError(t, err, false)
+		if err == nil {
+			// Geser kanan aritmetika mempertahankan tanda: -8 >> 1 = -4.
+			assertEqual(t, right.Data, []int32{0, 2, -4, 0x20000000})
+		}
+	})
+
+	t.Run("Unsigned_Right_Is_Logical", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("sh_u", []int{2}, []uint8{255, 16}), false)
+		_, err := apiClient.ShiftRight("sh_u", 4, "sh_u_right")
+		assertError(t, err, false)
+		right, err := apiClient.LoadTensorUint8("sh_u_right")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, right.Data, []uint8{15, 1})
+		}
+	})
+
+	t.Run("Invalid_Amount", func(t *testing.T) {
+		_, err := apiClient.ShiftLeft("sh_a", -1, "sh_bad")
+		assertErrorContains(t, err, "shift amount -1 is out of range for dtype int32: must be between 0 and 31")
+		_, err = apiClient.ShiftRight("sh_a", 32, "sh_bad")
+		assertErrorContains(t, err, "shift amount 32 is out of range for dtype int32: must be between 0 and 31")
+	})
+
+	t.Run("Float_Rejected", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("sh_f", []int{2}, []float32{1, 2}), false)
+		_, err := apiClient.ShiftLeft("sh_f", 1, "sh_f_left")
+		assertErrorContains(t, err, "operation SHIFT_LEFT does not support dtype float32")
+	})
+}
@@ -101,6 +101,44 @@ func BenchmarkInsertData(b *testing.B) {
 	b.StopTimer()
 }
 
+// benchmarkInsertDurability mengukur throughput INSERT pada tingkat durabilitas tertentu.
+// Bandingkan BenchmarkInsertData_DurabilityNone, _DurabilityFlush, dan _DurabilityFullSync.
+func benchmarkInsertDurability(b *testing.B, durability tensor.Durability) {
+	apiClient, cleanup := setupBenchmarkClient(b, tensor.WithDurability(durability))
+	defer cleanup()
+
+	tensorName := "bench_insert_durability_tensor"
+	shape := []int{256, 256}
+	if err := apiClient.CreateTensor(tensorName, shape, tensor.DataTypeFloat32); err != nil {
+		b.Fatalf("Gagal membuat tensor %s untuk benchmark: %v", tensorName, err)
+	}
+	dataToInsert := make([]float32, shape[0]*shape[1])
+	for i := range dataToInsert {
+		dataToInsert[i] = float32(i % 100)
+	}
+
+	b.SetBytes(int64(len(dataToInsert) * 4))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := apiClient.InsertFloat32Data(tensorName, dataToInsert); err != nil {
+			b.Fatalf("Gagal menyisipkan data: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+func BenchmarkInsertData_DurabilityNone(b *testing.B) {
+	benchmarkInsertDurability(b, tensor.DurabilityNone)
+}
+
+func BenchmarkInsertData_DurabilityFlush(b *testing.B) {
+	benchmarkInsertDurability(b, tensor.DurabilityFlush)
+}
+
+func BenchmarkInsertData_DurabilityFullSync(b *testing.B) {
+	benchmarkInsertDurability(b, tensor.DurabilityFullSync)
+}
+
 // Benchmark untuk operasi LOAD TENSOR
 func BenchmarkLoadTensor(b *testing.B) {
 	apiClient, cleanup := setupBenchmarkClient(b)
@@ -139,6 +177,133 @@ func BenchmarkSelectData_Full(b *testing.B) {
 	b.StopTimer()
 }
 
+// Benchmark untuk SELECT seluruh tensor dengan petunjuk madvise(MADV_SEQUENTIAL)
+// Bandingkan dengan BenchmarkSelectData_Full (tanpa petunjuk).
+func BenchmarkSelectData_Full_SequentialAdvice(b *testing.B) {
+	apiClient, cleanup := setupBenchmarkClient(b, tensor.WithMmapAdvice())
+	defer cleanup()
+
+	tensorName := "bench_select_full_advice_tensor"
+	shape := []int{256, 256}
+	createAndFillFloat32Tensor(b, apiClient, tensorName, shape)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := apiClient.SelectData(tensorName, nil)
+		if err != nil {
+			// b.Errorf("Error selecting data in benchmark: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+// Benchmark SUM streaming atas mmap (memori dibatasi per jendela).
+// Bandingkan dengan BenchmarkSum_FullLoad yang memuat seluruh tensor terlebih dahulu.
+func BenchmarkSum_Streaming(b *testing.B) {
+	apiClient, cleanup := setupBenchmarkClient(b)
+	defer cleanup()
+
+	tensorName := "bench_sum_stream_tensor"
+	shape := []int{1024, 1024}
+	createAndFillFloat32Tensor(b, apiClient, tensorName, shape)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := apiClient.Sum(tensorName, fmt.Sprintf("bench_sum_stream_out_%d", i)); err != nil {
+			b.Fatalf("Error SUM streaming: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+// Benchmark SUM dengan memuat seluruh tensor ke memori lalu menjumlahkan.
+func BenchmarkSum_FullLoad(b *testing.B) {
+	apiClient, cleanup := setupBenchmarkClient(b)
+	defer cleanup()
+
+	tensorName := "bench_sum_full_tensor"
+	shape := []int{1024, 1024}
+	createAndFillFloat32Tensor(b, apiClient, tensorName, shape)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		loaded, err := apiClient.LoadTensorFloat32(tensorName)
+		if err != nil {
+			b.Fatalf("Error loading tensor: %v", err)
+		}
+		var sum float32
+		for _, v := range loaded.Data {
+			sum += v
+		}
+		_ = sum
+	}
+	b.StopTimer()
+}
+
+// Benchmark ADD SCALAR yang membuat tensor output baru.
+// Bandingkan dengan BenchmarkAddScalar_InPlace.
+func BenchmarkAddScalar_OutOfPlace(b *testing.B) {
+	apiClient, cleanup := setupBenchmarkClient(b)
+	defer cleanup()
+
+	tensorName := "bench_add_scalar_out_tensor"
+	shape := []int{1024, 1024}
+	createAndFillFloat32Tensor(b, apiClient, tensorName, shape)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := apiClient.AddScalarToTensor(1.5, tensorName, fmt.Sprintf("bench_add_scalar_out_%d", i)); err != nil {
+			b.Fatalf("Error ADD SCALAR: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+// Benchmark ADD SCALAR ... IN PLACE yang menulis langsung ke file data tensor melalui mmap.
+func BenchmarkAddScalar_InPlace(b *testing.B) {
+	apiClient, cleanup := setupBenchmarkClient(b)
+	defer cleanup()
+
+	tensorName := "bench_add_scalar_inplace_tensor"
+	shape := []int{1024, 1024}
+	createAndFillFloat32Tensor(b, apiClient, tensorName, shape)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := apiClient.AddScalarToTensorInPlace(1.5, tensorName); err != nil {
+			b.Fatalf("Error ADD SCALAR IN PLACE: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+// benchmarkUpdateElement mengukur UPDATE satu elemen. Elemen ditulis langsung ke mmap, tetapi checksum
+// CRC32 di .meta dihitung ulang atas seluruh file data, sehingga biayanya naik seiring ukuran tensor.
+func benchmarkUpdateElement(b *testing.B, shape []int) {
+	apiClient, cleanup := setupBenchmarkClient(b)
+	defer cleanup()
+
+	tensorName := "bench_update_element_tensor"
+	createAndFillFloat32Tensor(b, apiClient, tensorName, shape)
+	b.SetBytes(int64(shape[0] * shape[1] * 4))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := apiClient.UpdateElement(tensorName, []int{i % shape[0], i % shape[1]}, float64(i)); err != nil {
+			b.Fatalf("Error UPDATE: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+func BenchmarkUpdateElement_Small(b *testing.B) {
+	benchmarkUpdateElement(b, []int{64, 64})
+}
+
+func BenchmarkUpdateElement_Large(b *testing.B) {
+	benchmarkUpdateElement(b, []int{2048, 2048})
+}
+
 // Benchmark untuk operasi LIST TENSORS (tanpa filter)
 func BenchmarkListTensors_NoFilter(b *testing.B) {
 	apiClient, cleanup := setupBenchmarkClient(b)
@@ -370,3 +535,155 @@ func BenchmarkGetData_EmptyTensor(b *testing.B) {
 	}
 	b.StopTimer()
 }
+
+// Benchmark crop [256:768, 128:640] dari tensor 2048x1024 langsung antar file dengan
+// Storage.SliceToNewTensor. Bandingkan dengan BenchmarkCropTensor_LoadThenSlice.
+func BenchmarkCropTensor_StorageSlice(b *testing.B) {
+	storage, apiClient, cleanup := setupBenchmarkStorage(b)
+	defer cleanup()
+
+	tensorName := "bench_crop_src"
+	createAndFillFloat32Tensor(b, apiClient, tensorName, []int{2048, 1024})
+	ranges := [][2]int{{256, 768}, {128, 640}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := storage.SliceToNewTensor(tensorName, fmt.Sprintf("bench_crop_storage_%d", i), ranges); err != nil {
+			b.Fatalf("Error SliceToNewTensor: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+// Benchmark crop yang sama lewat CREATE TENSOR ... FROM SELECT, yang memuat seluruh tensor sumber
+// ke memori sebelum mengambil slice.
+func BenchmarkCropTensor_LoadThenSlice(b *testing.B) {
+	_, apiClient, cleanup := setupBenchmarkStorage(b)
+	defer cleanup()
+
+	tensorName := "bench_crop_src"
+	createAndFillFloat32Tensor(b, apiClient, tensorName, []int{2048, 1024})
+	ranges := [][2]int{{256, 768}, {128, 640}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := apiClient.CreateFromSelect(fmt.Sprintf("bench_crop_load_%d", i), tensorName, ranges); err != nil {
+			b.Fatalf("Error CreateFromSelect: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+// benchmarkRepeatedSelect menjalankan SELECT slice yang sama berulang kali pada tensor 512x512
+// melalui executor dengan opsi yang diberikan.
+func benchmarkRepeatedSelect(b *testing.B, opts ...tensor.ExecutorOption) {
+	storage, apiClient, cleanup := setupBenchmarkStorage(b)
+	defer cleanup()
+
+	tensorName := "bench_repeat_select"
+	createAndFillFloat32Tensor(b, apiClient, tensorName, []int{512, 512})
+	cachedClient := client.NewClient(tensor.NewExecutor(storage, opts...))
+	defer cachedClient.Close()
+	ranges := [][2]int{{0, 256}, {0, 256}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := cachedClient.SelectData(tensorName, ranges); err != nil {
+			b.Fatalf("Error SelectData: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+func BenchmarkRepeatedSelect_QueryCacheOff(b *testing.B) {
+	benchmarkRepeatedSelect(b)
+}
+
+func BenchmarkRepeatedSelect_QueryCacheOn(b *testing.B) {
+	benchmarkRepeatedSelect(b, tensor.WithQueryCache(64))
+}
+
+// benchmarkRepeatedLoad memuat tensor float32 1024x1024 yang sama berulang kali dengan decoding
+// ReadDataUnsafe, sehingga biaya membuka file, mmap, dan verifikasi checksum terlihat jelas. Dengan
+// cache handle (default) mmap yang sudah diverifikasi dipakai ulang.
+func benchmarkRepeatedLoad(b *testing.B, opts ...tensor.ExecutorOption) {
+	storage, apiClient, cleanup := setupBenchmarkStorage(b)
+	defer cleanup()
+
+	tensorName := "bench_repeat_load"
+	createAndFillFloat32Tensor(b, apiClient, tensorName, []int{1024, 1024})
+	cachedClient := client.NewClient(tensor.NewExecutor(storage, append(opts, tensor.WithUnsafeReads())...))
+	defer cachedClient.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := cachedClient.LoadTensorFloat32(tensorName); err != nil {
+			b.Fatalf("Error LoadTensorFloat32: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+func BenchmarkRepeatedLoad_HandleCacheOff(b *testing.B) {
+	benchmarkRepeatedLoad(b, tensor.WithMaxOpenFiles(0))
+}
+
+func BenchmarkRepeatedLoad_HandleCacheOn(b *testing.B) {
+	benchmarkRepeatedLoad(b)
+}
+
+// benchmarkReadData mengukur decoding 1M elemen float32 dari byte mentah dengan fungsi read.
+func benchmarkReadData(b *testing.B, read func([]byte, int, string) ([]float32, error)) {
+	const numElements = 1 << 20
+	raw := make([]byte, numElements*4)
+	for i := range raw {
+		raw[i] = byte(i)
+	}
+	b.SetBytes(int64(len(raw)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := read(raw, numElements, tensor.DataTypeFloat32); err != nil {
+			b.Fatalf("Error membaca data: %v", err)
+		}
+	}
+	b.StopTimer()
+}
+
+// Benchmark ReadData yang mendekode per elemen dengan binary.Read.
+// Bandingkan dengan BenchmarkReadData_Unsafe.
+func BenchmarkReadData_BinaryRead(b *testing.B) {
+	benchmarkReadData(b, func(raw []byte, n int, dt string) ([]float32, error) {
+		return tensor.ReadData[float32](raw, n, dt)
+	})
+}
+
+// Benchmark ReadDataUnsafe yang menyalin byte mmap sebagai []float32 dalam satu copy.
+func BenchmarkReadData_Unsafe(b *testing.B) {
+	benchmarkReadData(b, func(raw []byte, n int, dt string) ([]float32, error) {
+		return tensor.ReadDataUnsafe[float32](raw, n, dt)
+	})
+}
+
+// Benchmark AddTensors float32 [2048, 2048]; di atas ambang elemen ini dijalankan paralel per CPU.
+func BenchmarkAddTensors_2048x2048(b *testing.B) {
+	shape := []int{2048, 2048}
+	t1, err := tensor.NewTensor[float32]("bench_add_a", shape, tensor.DataTypeFloat32)
+	if err != nil {
+		b.Fatalf("Error NewTensor: %v", err)
+	}
+	t2, err := tensor.NewTensor[float32]("bench_add_b", shape, tensor.DataTypeFloat32)
+	if err != nil {
+		b.Fatalf("Error NewTensor: %v", err)
+	}
+	for i := range t1.Data {
+		t1.Data[i] = float32(i)
+		t2.Data[i] = float32(i) * 0.5
+	}
+	b.SetBytes(int64(len(t1.Data) * 4))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := tensor.AddTensors(t1, t2); err != nil {
+			b.Fatalf("Error AddTensors: %v", err)
+		}
+	}
+}
@@ -1,9 +1,19 @@
 package tests
 
 import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
 	"sort" // Import paket sort
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/sciefylab/tensordb/pkg/tensor"
@@ -318,3 +328,2290 @@ func TestParserSpecificCases(t *testing.T) {
 		}
 	})
 }
+
+func TestCreateTensorFromSelect(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateTensor("src_sel", []int{2, 3}, tensor.DataTypeInt32), false)
+	assertError(t, apiClient.InsertInt32Data("src_sel", []int32{1, 2, 3, 4, 5, 6}), false)
+
+	t.Run("Parse_Short_Form", func(t *testing.T) {
+		parser := &tensor.Parser{}
+		query, err := parser.Parse("CREATE TENSOR derived FROM SELECT src_sel [0:1, 0:3]")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Type, tensor.CreateTensorQuery)
+			assertEqual(t, query.TensorNames, []string{"derived"})
+			assertTrue(t, query.SourceQuery != nil, "SourceQuery harus terisi")
+			if query.SourceQuery != nil {
+				assertEqual(t, query.SourceQuery.TensorNames, []string{"src_sel"})
+				assertEqual(t, query.SourceQuery.Slices[0], [][2]int{{0, 1}, {0, 3}})
+			}
+		}
+	})
+
+	t.Run("Materialize_Slice", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromSelect("derived_row", "src_sel", [][2]int{{1, 2}, {1, 3}}), false)
+		loaded, err := apiClient.LoadTensorInt32("derived_row")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{1, 2})
+			assertEqual(t, loaded.Data, []int32{5, 6})
+		}
+	})
+
+	t.Run("Materialize_Full", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromSelect("derived_full", "src_sel", nil), false)
+		loaded, err := apiClient.LoadTensorInt32("derived_full")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{2, 3})
+			assertEqual(t, loaded.Data, []int32{1, 2, 3, 4, 5, 6})
+		}
+	})
+
+	t.Run("Duplicate_Destination_Rejected", func(t *testing.T) {
+		err := apiClient.CreateFromSelect("derived_row", "src_sel", [][2]int{{0, 1}, {0, 1}})
+		assertErrorContains(t, err, "already exists")
+	})
+
+	t.Run("Missing_Source", func(t *testing.T) {
+		err := apiClient.CreateFromSelect("derived_missing", "no_such_src", nil)
+		assertErrorContains(t, err, "source tensor 'no_such_src' not found")
+	})
+}
+
+func TestCreateTensorZeroFilled(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	assertError(t, apiClient.CreateTensor("zeros_i64", []int{2, 3}, tensor.DataTypeInt64), false)
+
+	info, err := os.Stat(filepath.Join(dataDir, "zeros_i64.data"))
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, info.Size(), int64(2*3*8), "File data harus berukuran shape×elementSize")
+	}
+
+	selected, err := apiClient.SelectData("zeros_i64", nil)
+	assertError(t, err, false)
+	assertFormattedEqual(t, selected, []interface{}{
+		[]interface{}{int64(0), int64(0), int64(0)},
+		[]interface{}{int64(0), int64(0), int64(0)},
+	})
+
+	loaded, err := apiClient.LoadTensorInt64("zeros_i64")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, loaded.Shape, []int{2, 3})
+		assertEqual(t, loaded.Data, make([]int64, 6))
+	}
+}
+
+// TestConcurrentCreateSameName memastikan CREATE bersamaan dengan nama yang sama hanya berhasil sekali
+// (jalankan dengan -race). Pembuat lain harus gagal dengan "already exists", bukan menimpa diam-diam.
+func TestConcurrentCreateSameName(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	const creators = 16
+	var wg sync.WaitGroup
+	errs := make([]error, creators)
+	for i := 0; i < creators; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = apiClient.CreateTensor("race_tensor", []int{4, 4}, tensor.DataTypeFloat32)
+		}(i)
+	}
+	wg.Wait()
+
+	successes := 0
+	for i, err := range errs {
+		if err == nil {
+			successes++
+			continue
+		}
+		assertErrorContains(t, err, "already exists", "Pembuat #%d", i)
+	}
+	assertEqual(t, successes, 1, "Tepat satu CREATE bersamaan harus berhasil")
+
+	meta, err := apiClient.GetTensorMetadata("race_tensor")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, meta.Shape, []int{4, 4})
+	}
+}
+
+// TestLargeTensorHighOffsetSlice memeriksa slicing di offset tinggi pada tensor 1-D yang besar,
+// serta penolakan shape yang jumlah elemen atau ukuran datanya melampaui int.
+func TestLargeTensorHighOffsetSlice(t *testing.T) {
+	_, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	const n = 1 << 22 // 4Mi elemen int64 (32 MiB)
+	data := make([]int64, n)
+	for i := range data {
+		data[i] = int64(i) * 3
+	}
+	assertError(t, apiClient.CreateFromData("large_1d", []int{n}, data), false)
+
+	selected, err := apiClient.SelectData("large_1d", [][2]int{{n - 4, n}})
+	assertError(t, err, false)
+	assertFormattedEqual(t, selected, []interface{}{
+		int64((n - 4) * 3), int64((n - 3) * 3), int64((n - 2) * 3), int64((n - 1) * 3),
+	})
+
+	loaded, err := apiClient.LoadTensorInt64("large_1d")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, len(loaded.Data), n)
+		assertEqual(t, loaded.Data[n-1], int64((n-1)*3))
+		slice, errSlice := loaded.GetSlice([][2]int{{n - 2, n}})
+		assertError(t, errSlice, false)
+		assertEqual(t, slice, []int64{int64((n - 2) * 3), int64((n - 1) * 3)})
+	}
+
+	t.Run("Overflowing_Shape_Rejected", func(t *testing.T) {
+		_, err := tensor.NewTensor[float32]("too_many", []int{1 << 40, 1 << 40}, tensor.DataTypeFloat32)
+		assertErrorContains(t, err, "element count overflows int")
+		_, err = tensor.NewTensor[int64]("too_many_bytes", []int{1 << 31, 1 << 30}, tensor.DataTypeInt64)
+		assertErrorContains(t, err, "overflows int")
+	})
+}
+
+func TestShapeParsingConsistency(t *testing.T) {
+	dataDir, _, cleanup := setupTest(t)
+	defer cleanup()
+
+	storage, err := tensor.NewStorage(dataDir)
+	assertError(t, err, false)
+	parser := &tensor.Parser{}
+
+	cases := []struct {
+		shapeStr    string
+		expected    []int
+		shouldError bool
+	}{
+		{shapeStr: "2,3", expected: []int{2, 3}},
+		{shapeStr: "2, 3", expected: []int{2, 3}},
+		{shapeStr: "2 , 3,4", expected: []int{2, 3, 4}},
+		{shapeStr: "", expected: []int{}},
+		{shapeStr: "0", expected: []int{0}},
+		{shapeStr: "0,5", expected: []int{0, 5}},
+		{shapeStr: "1,", shouldError: true},
+		{shapeStr: ",", shouldError: true},
+		{shapeStr: "2,,3", shouldError: true},
+		{shapeStr: "-1", shouldError: true},
+		{shapeStr: "2 3", shouldError: true},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("Shape_%q", tc.shapeStr), func(t *testing.T) {
+			direct, errDirect := tensor.ParseShape(tc.shapeStr)
+			assertError(t, errDirect, tc.shouldError, "ParseShape(%q)", tc.shapeStr)
+
+			query, errParse := parser.Parse(fmt.Sprintf("CREATE TENSOR shape_case_%d %s TYPE float32", i, tc.shapeStr))
+			assertError(t, errParse, tc.shouldError, "CREATE dengan shape %q", tc.shapeStr)
+
+			name := fmt.Sprintf("shape_meta_%d", i)
+			metaContent := fmt.Sprintf("name:%s\nshape:%s\ndatatype:float32\n", name, tc.shapeStr)
+			assertError(t, os.WriteFile(filepath.Join(dataDir, name+".meta"), []byte(metaContent), 0644), false)
+			meta, errMeta := storage.LoadTensorMetadata(name)
+			assertError(t, errMeta, tc.shouldError, "Metadata dengan shape %q", tc.shapeStr)
+
+			if !tc.shouldError && errDirect == nil && errParse == nil && errMeta == nil {
+				assertEqual(t, direct, tc.expected)
+				assertEqual(t, query.Shape, tc.expected, "Shape dari parser untuk %q", tc.shapeStr)
+				assertEqual(t, meta.Shape, tc.expected, "Shape dari metadata untuk %q", tc.shapeStr)
+			}
+		})
+	}
+}
+
+func TestCompareFormatted(t *testing.T) {
+	expected := []interface{}{
+		[]interface{}{1, 2, 3},
+		[]interface{}{4, 5, 5},
+	}
+	actual := []interface{}{
+		[]interface{}{1, 2, 3},
+		[]interface{}{4, 5, 6},
+	}
+
+	ok, diff := tensor.CompareFormatted(expected, expected)
+	assertTrue(t, ok, "Struktur identik harus dianggap sama")
+	assertEqual(t, diff, "")
+
+	ok, diff = tensor.CompareFormatted(expected, actual)
+	assertTrue(t, !ok, "Struktur berbeda tidak boleh dianggap sama")
+	assertEqual(t, diff, "[1][2]: expected 5 got 6")
+
+	_, diff = tensor.CompareFormatted(expected, []interface{}{[]interface{}{1, 2, 3}})
+	assertEqual(t, diff, "<root>: expected length 2 got 1")
+
+	_, diff = tensor.CompareFormatted([]interface{}{float32(1)}, []interface{}{float64(1)})
+	assertEqual(t, diff, "[0]: expected 1 (float32) got 1 (float64)")
+}
+
+func TestInsertAppendAlongAxis(t *testing.T) {
+	dataDir, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+
+	run := func(queryStr string) (interface{}, error) {
+		q, err := parser.Parse(queryStr)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(q)
+	}
+
+	_, err := run("CREATE TENSOR append_grid 2,2 TYPE int32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO append_grid VALUES (1, 2, 3, 4)")
+	assertError(t, err, false)
+
+	t.Run("Parse_Append_Axis", func(t *testing.T) {
+		query, err := parser.Parse("INSERT INTO append_grid APPEND AXIS 1 VALUES (5, 6)")
+		assertError(t, err, false)
+		if err == nil {
+			assertTrue(t, query.Append, "Append harus true")
+			assertTrue(t, query.Axis != nil && *query.Axis == 1, "Axis harus 1")
+			assertEqual(t, query.Data, []string{"5", "6"})
+		}
+	})
+
+	t.Run("Append_Column_2x2_To_2x3", func(t *testing.T) {
+		result, err := run("INSERT INTO append_grid APPEND AXIS 1 VALUES (5, 6)")
+		assertError(t, err, false)
+		assertEqual(t, result, "Data appended to append_grid along axis 1, new shape [2 3]")
+		selected, errSel := run("SELECT append_grid FROM append_grid")
+		assertError(t, errSel, false)
+		assertFormattedEqual(t, selected, []interface{}{
+			[]interface{}{int32(1), int32(2), int32(5)},
+			[]interface{}{int32(3), int32(4), int32(6)},
+		})
+	})
+
+	t.Run("Append_Row_Default_Axis", func(t *testing.T) {
+		_, err := run("INSERT INTO append_grid APPEND VALUES (7, 8, 9)")
+		assertError(t, err, false)
+		storage, errStorage := tensor.NewStorage(dataDir)
+		assertError(t, errStorage, false)
+		meta, errMeta := storage.LoadTensorMetadata("append_grid")
+		assertError(t, errMeta, false)
+		if errMeta == nil {
+			assertEqual(t, meta.Shape, []int{3, 3})
+			assertEqual(t, meta.Strides, []int{3, 1})
+		}
+		selected, errSel := run("SELECT append_grid FROM append_grid [2:3, 0:3]")
+		assertError(t, errSel, false)
+		assertFormattedEqual(t, selected, []interface{}{[]interface{}{int32(7), int32(8), int32(9)}})
+	})
+
+	t.Run("Count_Mismatch_Rejected", func(t *testing.T) {
+		_, err := run("INSERT INTO append_grid APPEND AXIS 1 VALUES (1, 2)")
+		assertErrorContains(t, err, "must be a non-zero multiple of 3")
+	})
+
+	t.Run("Axis_Out_Of_Range", func(t *testing.T) {
+		_, err := run("INSERT INTO append_grid APPEND AXIS 2 VALUES (1, 2, 3)")
+		assertErrorContains(t, err, "append axis 2 out of range")
+	})
+
+	t.Run("Inner_Axis_3D", func(t *testing.T) {
+		_, err := run("CREATE TENSOR append_cube 2,1,2 TYPE float64")
+		assertError(t, err, false)
+		_, err = run("INSERT INTO append_cube VALUES (1, 2, 3, 4)")
+		assertError(t, err, false)
+		_, err = run("INSERT INTO append_cube APPEND AXIS 1 VALUES (10, 20, 30, 40)")
+		assertError(t, err, false)
+		selected, errSel := run("SELECT append_cube FROM append_cube")
+		assertError(t, errSel, false)
+		assertFormattedEqual(t, selected, []interface{}{
+			[]interface{}{[]interface{}{1.0, 2.0}, []interface{}{10.0, 20.0}},
+			[]interface{}{[]interface{}{3.0, 4.0}, []interface{}{30.0, 40.0}},
+		})
+	})
+}
+
+// TestInsertEmptyValues memeriksa kasus tepi VALUES () untuk tensor skalar, tensor kosong, dan tensor biasa.
+func TestInsertEmptyValues(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	_, err := run("CREATE TENSOR empty_vals_scalar TYPE float32")
+	assertError(t, err, false)
+	_, err = run("CREATE TENSOR empty_vals_zero 0,3 TYPE int32")
+	assertError(t, err, false)
+	_, err = run("CREATE TENSOR empty_vals_normal 2,2 TYPE float64")
+	assertError(t, err, false)
+
+	t.Run("Into_Scalar", func(t *testing.T) {
+		_, err := run("INSERT INTO empty_vals_scalar VALUES ()")
+		assertErrorContains(t, err, "scalar tensor 'empty_vals_scalar' requires exactly one value, but string data provides 0")
+		_, err = run("INSERT INTO empty_vals_scalar VALUES (1, 2)")
+		assertErrorContains(t, err, "requires exactly one value, but string data provides 2")
+		_, err = run("INSERT INTO empty_vals_scalar VALUES (1.5)")
+		assertError(t, err, false)
+	})
+
+	t.Run("Into_Empty_Tensor", func(t *testing.T) {
+		result, err := run("INSERT INTO empty_vals_zero VALUES ()")
+		assertError(t, err, false)
+		assertEqual(t, result, "Data inserted into empty_vals_zero (0 elements from string)")
+		_, err = run("INSERT INTO empty_vals_zero VALUES (1)")
+		assertErrorContains(t, err, "string data provides 1 elements, but tensor 'empty_vals_zero' of shape [0 3] requires 0 elements")
+	})
+
+	t.Run("Into_Normal_Tensor", func(t *testing.T) {
+		_, err := run("INSERT INTO empty_vals_normal VALUES ()")
+		assertErrorContains(t, err, "string data provides no values, but tensor 'empty_vals_normal' of shape [2 2] requires 4 elements")
+		_, err = run("INSERT INTO empty_vals_normal VALUES (1, 2, 3)")
+		assertErrorContains(t, err, "string data provides 3 elements, but tensor 'empty_vals_normal' of shape [2 2] requires 4 elements")
+	})
+}
+
+func TestInsertValuesSeparator(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+
+	t.Run("Parse_Tab_Separated", func(t *testing.T) {
+		query, err := parser.Parse("INSERT INTO sep_t VALUES SEP '\\t' (1.5\t2\t\t3)")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Data, []string{"1.5", "2", "3"})
+		}
+	})
+
+	t.Run("Parse_Semicolon_Separated", func(t *testing.T) {
+		query, err := parser.Parse("INSERT INTO sep_t VALUES SEP ';' (1; 2;3)")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Data, []string{"1", "2", "3"})
+		}
+	})
+
+	t.Run("Parse_Default_Comma", func(t *testing.T) {
+		query, err := parser.Parse("INSERT INTO sep_t VALUES (1, 2, 3)")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Data, []string{"1", "2", "3"})
+		}
+	})
+
+	t.Run("Invalid_Sep_Clause", func(t *testing.T) {
+		_, err := parser.Parse("INSERT INTO sep_t VALUES SEPARATOR ';' (1;2)")
+		assertErrorContains(t, err, "expected 'VALUES [SEP 'separator'] (...)'")
+	})
+
+	t.Run("Insert_Tab_Separated", func(t *testing.T) {
+		q, err := parser.Parse("CREATE TENSOR sep_t 2,2 TYPE float32")
+		assertError(t, err, false)
+		_, err = executor.Execute(q)
+		assertError(t, err, false)
+
+		q, err = parser.Parse("INSERT INTO sep_t VALUES SEP '\\t' (1.5\t2\t3\t4)")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		_, err = executor.Execute(q)
+		assertError(t, err, false)
+
+		q, _ = parser.Parse("SELECT sep_t FROM sep_t")
+		selected, errSel := executor.Execute(q)
+		assertError(t, errSel, false)
+		assertFormattedEqual(t, selected, []interface{}{
+			[]interface{}{float32(1.5), float32(2)},
+			[]interface{}{float32(3), float32(4)},
+		})
+	})
+}
+
+func TestInsertSparse(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+
+	run := func(queryStr string) (interface{}, error) {
+		q, err := parser.Parse(queryStr)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(q)
+	}
+
+	_, err := run("CREATE TENSOR sparse_3x3 3,3 TYPE float64")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO sparse_3x3 VALUES (9, 9, 9, 9, 9, 9, 9, 9, 9)")
+	assertError(t, err, false)
+
+	t.Run("Parse_Entries", func(t *testing.T) {
+		query, err := parser.Parse("INSERT INTO sparse_3x3 SPARSE (0,0)=1.0, ( 1 , 2 )=-3")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Sparse, []tensor.SparseEntry{
+				{Coordinate: []int{0, 0}, Value: "1.0"},
+				{Coordinate: []int{1, 2}, Value: "-3"},
+			})
+		}
+	})
+
+	t.Run("Sets_Exactly_Specified_Elements", func(t *testing.T) {
+		result, err := run("INSERT INTO sparse_3x3 SPARSE (0,0)=1.0, (1,2)=3.0")
+		assertError(t, err, false)
+		assertEqual(t, result, "Sparse data inserted into sparse_3x3 (2 elements set)")
+		selected, errSel := run("SELECT sparse_3x3 FROM sparse_3x3")
+		assertError(t, errSel, false)
+		assertFormattedEqual(t, selected, []interface{}{
+			[]interface{}{1.0, 0.0, 0.0},
+			[]interface{}{0.0, 0.0, 3.0},
+			[]interface{}{0.0, 0.0, 0.0},
+		})
+	})
+
+	t.Run("Out_Of_Bounds", func(t *testing.T) {
+		_, err := run("INSERT INTO sparse_3x3 SPARSE (3,0)=1")
+		assertErrorContains(t, err, "sparse coordinate [3 0] out of bounds")
+	})
+
+	t.Run("Wrong_Rank", func(t *testing.T) {
+		_, err := run("INSERT INTO sparse_3x3 SPARSE (1)=1")
+		assertErrorContains(t, err, "has 1 dimension(s), tensor 'sparse_3x3' has 2")
+	})
+
+	t.Run("Duplicate_Coordinate", func(t *testing.T) {
+		_, err := run("INSERT INTO sparse_3x3 SPARSE (1,1)=1, (1,1)=2")
+		assertErrorContains(t, err, "duplicate sparse coordinate [1 1]")
+	})
+
+	t.Run("Malformed_Entry", func(t *testing.T) {
+		_, err := parser.Parse("INSERT INTO sparse_3x3 SPARSE (0,0)=1 (1,1)=2")
+		assertErrorContains(t, err, "invalid SPARSE entry")
+	})
+}
+
+func TestMaxOpenFilesEviction(t *testing.T) {
+	const maxOpen = 3
+	const numTensors = 10
+
+	loadAll := func(t *testing.T, executor *tensor.Executor, check func(loaded int)) {
+		t.Helper()
+		parser := &tensor.Parser{}
+		run := func(q string) (interface{}, error) {
+			query, err := parser.Parse(q)
+			if err != nil {
+				return nil, err
+			}
+			return executor.Execute(query)
+		}
+		for i := 0; i < numTensors; i++ {
+			_, err := run(fmt.Sprintf("CREATE TENSOR lru_t%d 2 TYPE float32", i))
+			assertError(t, err, false)
+			_, err = run(fmt.Sprintf("INSERT INTO lru_t%d VALUES (%d, %d)", i, i, i+1))
+			assertError(t, err, false)
+		}
+		for i := 0; i < numTensors; i++ {
+			result, err := run(fmt.Sprintf("SELECT lru_t%d FROM lru_t%d", i, i))
+			assertError(t, err, false)
+			assertEqual(t, result, []interface{}{float32(i), float32(i + 1)})
+			check(i + 1)
+		}
+	}
+
+	t.Run("Bounded_By_Cap", func(t *testing.T) {
+		_, executor, cleanup := setupTest(t, tensor.WithMaxOpenFiles(maxOpen))
+		defer cleanup()
+		loadAll(t, executor, func(loaded int) {
+			expected := loaded
+			if expected > maxOpen {
+				expected = maxOpen
+			}
+			if got := executor.OpenHandleCount(); got != expected {
+				t.Fatalf("Setelah memuat %d tensor, jumlah handle terbuka = %d, diharapkan %d", loaded, got, expected)
+			}
+		})
+	})
+
+	t.Run("Caching_Disabled", func(t *testing.T) {
+		_, executor, cleanup := setupTest(t, tensor.WithMaxOpenFiles(0))
+		defer cleanup()
+		loadAll(t, executor, func(loaded int) {
+			if got := executor.OpenHandleCount(); got != 0 {
+				t.Fatalf("Cache dinonaktifkan, tetapi %d handle masih terbuka setelah memuat %d tensor", got, loaded)
+			}
+		})
+	})
+}
+
+func TestLoadedTensorHandleCache(t *testing.T) {
+	dataDir, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	runOn := func(ex *tensor.Executor, q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return ex.Execute(query)
+	}
+	run := func(q string) (interface{}, error) { return runOn(executor, q) }
+
+	_, err := run("CREATE TENSOR hc_t 3 TYPE int32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO hc_t VALUES (1, 2, 3)")
+	assertError(t, err, false)
+
+	t.Run("Repeated_Select_Reuses_Handle", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			result, err := run("SELECT hc_t FROM hc_t")
+			assertError(t, err, false)
+			assertEqual(t, result, []interface{}{int32(1), int32(2), int32(3)})
+			assertEqual(t, executor.OpenHandleCount(), 1)
+		}
+	})
+
+	t.Run("Select_After_Write_Is_Fresh", func(t *testing.T) {
+		writes := []struct {
+			query    string
+			expected []interface{}
+		}{
+			{"INSERT INTO hc_t VALUES (4, 5, 6)", []interface{}{int32(4), int32(5), int32(6)}},
+			{"UPDATE hc_t[1] = 50", []interface{}{int32(4), int32(50), int32(6)}},
+			{"ADD SCALAR 1 TO TENSOR hc_t IN PLACE", []interface{}{int32(5), int32(51), int32(7)}},
+			{"INSERT INTO hc_t APPEND AXIS 0 VALUES (8)", []interface{}{int32(5), int32(51), int32(7), int32(8)}},
+		}
+		for _, w := range writes {
+			_, err := run(w.query)
+			assertError(t, err, false, w.query)
+			result, err := run("SELECT hc_t FROM hc_t")
+			assertError(t, err, false)
+			assertEqual(t, result, w.expected, "SELECT setelah "+w.query)
+		}
+	})
+
+	t.Run("Write_By_Other_Executor_Is_Detected", func(t *testing.T) {
+		_, err := run("SELECT hc_t FROM hc_t")
+		assertError(t, err, false)
+		storage, err := tensor.NewStorage(dataDir)
+		assertError(t, err, false)
+		other := tensor.NewExecutor(storage)
+		defer other.Close()
+		_, err = runOn(other, "INSERT INTO hc_t VALUES (9, 9, 9, 9)")
+		assertError(t, err, false)
+
+		result, err := run("SELECT hc_t FROM hc_t")
+		assertError(t, err, false)
+		assertEqual(t, result, []interface{}{int32(9), int32(9), int32(9), int32(9)})
+	})
+
+	t.Run("Concurrent_Loads_With_Eviction", func(t *testing.T) {
+		// Dengan batas 1 handle, setiap pemuatan tensor lain mengusir handle yang mungkin sedang dibaca.
+		_, small, cleanupSmall := setupTest(t, tensor.WithMaxOpenFiles(1))
+		defer cleanupSmall()
+		for i := 0; i < 2; i++ {
+			_, err := runOn(small, fmt.Sprintf("CREATE TENSOR hc_e%d 4 TYPE int32", i))
+			assertError(t, err, false)
+			_, err = runOn(small, fmt.Sprintf("INSERT INTO hc_e%d VALUES (%d, %d, %d, %d)", i, i, i, i, i))
+			assertError(t, err, false)
+		}
+		var wg sync.WaitGroup
+		for g := 0; g < 8; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					i := (g + j) % 2
+					result, err := runOn(small, fmt.Sprintf("SELECT hc_e%d FROM hc_e%d", i, i))
+					if err != nil {
+						t.Errorf("SELECT hc_e%d gagal: %v", i, err)
+						return
+					}
+					expected := []interface{}{int32(i), int32(i), int32(i), int32(i)}
+					if !reflect.DeepEqual(result, expected) {
+						t.Errorf("SELECT hc_e%d = %v, diharapkan %v", i, result, expected)
+						return
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		assertEqual(t, small.OpenHandleCount(), 1)
+	})
+
+	t.Run("Borrowed_Mmap_Survives_Select", func(t *testing.T) {
+		_, err := run("CREATE TENSOR hc_b 2 TYPE int32")
+		assertError(t, err, false)
+		_, err = run("INSERT INTO hc_b VALUES (7, 8)")
+		assertError(t, err, false)
+		_, _, borrowed, release, err := executor.GetTensorMmap("hc_b")
+		assertError(t, err, false)
+
+		// SELECT atas tensor yang sama (cache miss lalu handle baru di-cache) tidak boleh melepas mmap
+		// pinjaman; pembacaan di bawah akan crash jika mmap tersebut sudah di-unmap.
+		result, err := run("SELECT hc_b FROM hc_b")
+		assertError(t, err, false)
+		assertEqual(t, result, []interface{}{int32(7), int32(8)})
+		assertEqual(t, int32(binary.LittleEndian.Uint32(borrowed[4:8])), int32(8))
+
+		open := executor.OpenHandleCount()
+		assertError(t, release(), false)
+		assertError(t, release(), false, "cleanup kedua seharusnya no-op")
+		assertEqual(t, executor.OpenHandleCount(), open-1)
+	})
+}
+
+func TestInsertIntegerOutOfRange(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	_, err := run("CREATE TENSOR range_i32 3 TYPE int32")
+	assertError(t, err, false)
+	_, err = run("CREATE TENSOR range_i64 2 TYPE int64")
+	assertError(t, err, false)
+
+	t.Run("Int32", func(t *testing.T) {
+		_, err := run("INSERT INTO range_i32 VALUES (1, 2147483648, 3)")
+		assertErrorContains(t, err, "value '2147483648' at index 1 is out of range for int32: valid range is [-2147483648, 2147483647]")
+		_, err = run("INSERT INTO range_i32 VALUES (1, 2, -2147483649)")
+		assertErrorContains(t, err, "value '-2147483649' at index 2 is out of range for int32")
+		_, err = run("INSERT INTO range_i32 VALUES (-2147483648, 0, 2147483647)")
+		assertError(t, err, false)
+	})
+
+	t.Run("Int64", func(t *testing.T) {
+		_, err := run("INSERT INTO range_i64 VALUES (9223372036854775808, 0)")
+		assertErrorContains(t, err, "value '9223372036854775808' at index 0 is out of range for int64: valid range is [-9223372036854775808, 9223372036854775807]")
+		_, err = run("INSERT INTO range_i64 VALUES (-9223372036854775808, 9223372036854775807)")
+		assertError(t, err, false)
+	})
+
+	t.Run("Append_And_Sparse", func(t *testing.T) {
+		_, err := run("INSERT INTO range_i32 VALUES (4, 5, 2147483648) APPEND")
+		assertErrorContains(t, err, "value '2147483648' at index 2 is out of range for int32")
+		_, err = run("INSERT INTO range_i64 SPARSE (1)=-9223372036854775809")
+		assertErrorContains(t, err, "value '-9223372036854775809' at index 1 is out of range for int64")
+	})
+
+	t.Run("Malformed_Value_Keeps_Parse_Error", func(t *testing.T) {
+		_, err := run("INSERT INTO range_i32 VALUES (1, x, 3)")
+		assertErrorContains(t, err, "error parsing 'x' at index 1 as int32")
+	})
+}
+
+func TestDropTensor(t *testing.T) {
+	dataDir, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	_, err := run("CREATE TENSOR drop_me 2 TYPE int32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO drop_me VALUES (1, 2)")
+	assertError(t, err, false)
+	// Muat sekali agar executor menyimpan handle file/mmap yang harus dilepas oleh DROP.
+	_, err = run("SELECT drop_me FROM drop_me")
+	assertError(t, err, false)
+
+	t.Run("Drop_Removes_Files_And_Index", func(t *testing.T) {
+		result, err := run("DROP TENSOR drop_me")
+		assertError(t, err, false)
+		assertEqual(t, result, "Tensor drop_me dropped")
+		for _, ext := range []string{".meta", ".data"} {
+			if _, statErr := os.Stat(filepath.Join(dataDir, "drop_me"+ext)); !os.IsNotExist(statErr) {
+				t.Errorf("File drop_me%s seharusnya sudah dihapus, stat error: %v", ext, statErr)
+			}
+		}
+		assertEqual(t, executor.OpenHandleCount(), 0)
+		listed, err := run("LIST TENSORS")
+		assertError(t, err, false)
+		assertEqual(t, len(listed.([]tensor.TensorMetadata)), 0)
+
+		_, err = run("SELECT drop_me FROM drop_me")
+		assertErrorContains(t, err, "tensor 'drop_me' not found")
+	})
+
+	t.Run("Drop_Nonexistent", func(t *testing.T) {
+		_, err := run("DROP TENSOR drop_me")
+		assertErrorContains(t, err, "tensor 'drop_me' not found for drop")
+		_, err = parser.Parse("DROP TENSOR")
+		assertErrorContains(t, err, "invalid DROP syntax")
+	})
+
+	t.Run("Partial_Failure_Updates_Index", func(t *testing.T) {
+		// Direktori tidak kosong di jalur .data membuat penghapusan data gagal setelah .meta terhapus.
+		_, err := run("CREATE TENSOR drop_partial 2 TYPE int32")
+		assertError(t, err, false)
+		dataPath := filepath.Join(dataDir, "drop_partial.data")
+		assertError(t, os.Remove(dataPath), false)
+		assertError(t, os.MkdirAll(filepath.Join(dataPath, "blocker"), 0755), false)
+		defer os.RemoveAll(dataPath)
+
+		_, err = run("DROP TENSOR drop_partial")
+		assertErrorContains(t, err, "failed to drop tensor 'drop_partial'")
+		_, indexed := executor.IndexedTensorMetadata("drop_partial")
+		assertTrue(t, !indexed, "Tensor tanpa .meta tidak boleh tersisa di indeks")
+	})
+
+	t.Run("Recreate_After_Drop", func(t *testing.T) {
+		_, err := run("CREATE TENSOR drop_me 3 TYPE float64")
+		assertError(t, err, false)
+		result, err := run("SELECT drop_me FROM drop_me")
+		assertError(t, err, false)
+		assertEqual(t, result, []interface{}{0.0, 0.0, 0.0})
+	})
+}
+
+// tempFileSuffix cocok dengan akhiran acak file sementara Storage (mis. "t.meta.tmp123" -> "t.meta.tmp").
+var tempFileSuffix = regexp.MustCompile(`\.tmp\d+$`)
+
+// recordingSyncer mencatat panggilan fsync tanpa benar-benar menyentuh disk. Nama file sementara
+// dicatat tanpa akhiran acaknya. Jika failOn tidak kosong, fsync file yang namanya mengandung failOn gagal.
+type recordingSyncer struct {
+	mu     sync.Mutex
+	files  []string
+	dirs   int
+	failOn string
+}
+
+func (r *recordingSyncer) SyncFile(f *os.File) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := tempFileSuffix.ReplaceAllString(filepath.Base(f.Name()), ".tmp")
+	r.files = append(r.files, name)
+	if r.failOn != "" && strings.Contains(name, r.failOn) {
+		return fmt.Errorf("simulated fsync failure for %s", name)
+	}
+	return nil
+}
+
+func (r *recordingSyncer) SyncDir(dir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dirs++
+	return nil
+}
+
+func (r *recordingSyncer) reset() (files []string, dirs int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	files, dirs = r.files, r.dirs
+	r.files, r.dirs = nil, 0
+	return files, dirs
+}
+
+func TestDurabilityModes(t *testing.T) {
+	t.Run("FullSync", func(t *testing.T) {
+		syncer := &recordingSyncer{}
+		_, apiClient, cleanup := setupTestClient(t, tensor.WithDurability(tensor.DurabilityFullSync), tensor.WithFileSyncer(syncer))
+		defer cleanup()
+
+		assertError(t, apiClient.CreateTensor("durable", []int{2, 2}, tensor.DataTypeFloat64), false)
+		files, dirs := syncer.reset()
+		assertEqual(t, files, []string{"durable.data.tmp", "durable.meta.tmp"})
+		assertTrue(t, dirs >= 1, "Direktori data harus di-fsync setelah file dibuat")
+
+		assertError(t, apiClient.InsertFloat64Data("durable", []float64{1.5, 2.5, 3.5, 4.5}), false)
+		files, _ = syncer.reset()
+		assertEqual(t, files, []string{"durable.data.tmp", "durable.meta.tmp"})
+		loaded, err := apiClient.LoadTensorFloat64("durable")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []float64{1.5, 2.5, 3.5, 4.5})
+		}
+
+		_, err = apiClient.AddScalarToTensorInPlace(1, "durable")
+		assertError(t, err, false)
+		files, _ = syncer.reset()
+		// Perubahan in-place menulis ulang .meta agar checksum data tetap sesuai.
+		assertEqual(t, files, []string{"durable.data", "durable.meta.tmp"})
+		loaded, err = apiClient.LoadTensorFloat64("durable")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []float64{2.5, 3.5, 4.5, 5.5})
+		}
+
+		assertError(t, apiClient.RenameTensor("durable", "durable_renamed"), false)
+		files, dirs = syncer.reset()
+		assertEqual(t, files, []string{"durable_renamed.meta.tmp"})
+		assertTrue(t, dirs >= 1, "Direktori data harus di-fsync setelah rename")
+
+		assertError(t, apiClient.DropTensor("durable_renamed"), false)
+		files, dirs = syncer.reset()
+		assertEqual(t, len(files), 0)
+		assertTrue(t, dirs >= 1, "Direktori data harus di-fsync setelah drop")
+	})
+
+	for _, mode := range []struct {
+		name       string
+		durability tensor.Durability
+	}{{"Flush", tensor.DurabilityFlush}, {"None", tensor.DurabilityNone}} {
+		t.Run(mode.name, func(t *testing.T) {
+			syncer := &recordingSyncer{}
+			_, apiClient, cleanup := setupTestClient(t, tensor.WithDurability(mode.durability), tensor.WithFileSyncer(syncer))
+			defer cleanup()
+
+			assertError(t, apiClient.CreateFromData("durable", []int{3}, []int32{7, 8, 9}), false)
+			loaded, err := apiClient.LoadTensorInt32("durable")
+			assertError(t, err, false)
+			if err == nil {
+				assertEqual(t, loaded.Data, []int32{7, 8, 9})
+			}
+			files, dirs := syncer.reset()
+			assertEqual(t, len(files), 0)
+			assertEqual(t, dirs, 0)
+		})
+	}
+}
+
+// recordingAdvisor mencatat petunjuk pola akses mmap tanpa memanggil madvise.
+type recordingAdvisor struct {
+	mu       sync.Mutex
+	patterns []tensor.AccessPattern
+}
+
+func (r *recordingAdvisor) Advise(b []byte, pattern tensor.AccessPattern) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.patterns = append(r.patterns, pattern)
+	return nil
+}
+
+func (r *recordingAdvisor) reset() []tensor.AccessPattern {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	patterns := r.patterns
+	r.patterns = nil
+	return patterns
+}
+
+func TestMmapAccessAdvice(t *testing.T) {
+	advisor := &recordingAdvisor{}
+	dataDir, err := os.MkdirTemp("", "tensordb_test_advice_")
+	assertError(t, err, false)
+	defer os.RemoveAll(dataDir)
+	storage, err := tensor.NewStorage(dataDir, tensor.WithMmapAdvisor(advisor))
+	assertError(t, err, false)
+	executor := tensor.NewExecutor(storage)
+	defer executor.Close()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	_, err = run("CREATE TENSOR adv 3,3 TYPE float32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO adv VALUES (1, 2, 3, 4, 5, 6, 7, 8, 9)")
+	assertError(t, err, false)
+	advisor.reset()
+
+	steps := []struct {
+		name     string
+		action   func() error
+		expected []tensor.AccessPattern
+	}{
+		{"Select_Sequential", func() error { _, err := run("SELECT adv FROM adv"); return err }, []tensor.AccessPattern{tensor.AccessSequential}},
+		{"Update_Random", func() error { _, err := run("UPDATE adv[1, 1] = 50"); return err }, []tensor.AccessPattern{tensor.AccessRandom}},
+		{"Insert_Slice_Random", func() error { _, err := run("INSERT INTO adv[0:3, 1:2] VALUES (20, 50, 80)"); return err }, []tensor.AccessPattern{tensor.AccessRandom}},
+		{"Slice_To_New_Tensor_Random", func() error { return storage.SliceToNewTensor("adv", "adv_crop", [][2]int{{0, 2}, {1, 3}}) }, []tensor.AccessPattern{tensor.AccessRandom}},
+	}
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			assertError(t, step.action(), false)
+			assertEqual(t, advisor.reset(), step.expected)
+		})
+	}
+}
+
+// TestAtomicSaveOnWriteFailure mensimulasikan penulisan parsial dengan fsync yang gagal pada file
+// sementara, lalu memastikan file tensor lama tetap utuh dan tidak ada file sementara yang tertinggal.
+func TestAtomicSaveOnWriteFailure(t *testing.T) {
+	syncer := &recordingSyncer{}
+	dataDir, apiClient, cleanup := setupTestClient(t, tensor.WithDurability(tensor.DurabilityFullSync), tensor.WithFileSyncer(syncer))
+	defer cleanup()
+
+	original := []float64{1, 2, 3, 4}
+	assertError(t, apiClient.CreateFromData("atomic", []int{2, 2}, original), false)
+	metaBefore, err := os.ReadFile(filepath.Join(dataDir, "atomic.meta"))
+	assertError(t, err, false)
+
+	for _, failOn := range []string{".data.tmp", ".meta.tmp"} {
+		t.Run(failOn, func(t *testing.T) {
+			syncer.failOn = failOn
+			err := apiClient.InsertFloat64Data("atomic", []float64{9, 9, 9, 9})
+			syncer.failOn = ""
+			assertErrorContains(t, err, "simulated fsync failure")
+
+			metaAfter, err := os.ReadFile(filepath.Join(dataDir, "atomic.meta"))
+			assertError(t, err, false)
+			assertEqual(t, string(metaAfter), string(metaBefore))
+			loaded, err := apiClient.LoadTensorFloat64("atomic")
+			assertError(t, err, false)
+			if err == nil {
+				assertEqual(t, loaded.Data, original)
+			}
+			leftovers, err := filepath.Glob(filepath.Join(dataDir, "*.tmp*"))
+			assertError(t, err, false)
+			assertEqual(t, len(leftovers), 0, "File sementara tertinggal: %v", leftovers)
+		})
+	}
+
+	// Pembuatan tensor baru yang gagal tidak boleh meninggalkan tensor setengah jadi.
+	syncer.failOn = ".data.tmp"
+	assertError(t, apiClient.CreateTensor("atomic_new", []int{2}, tensor.DataTypeInt32), true)
+	syncer.failOn = ""
+	_, err = os.Stat(filepath.Join(dataDir, "atomic_new.meta"))
+	assertTrue(t, os.IsNotExist(err), "atomic_new.meta tidak boleh ada setelah penulisan gagal")
+
+	// Penggantian file .data yang gagal (di sini karena path-nya berupa direktori tidak kosong) terjadi
+	// setelah metadata baru dipublikasikan; metadata harus dikembalikan ke versi lama.
+	blockDataFile := func(t *testing.T, name string) {
+		t.Helper()
+		dataPath := filepath.Join(dataDir, name+".data")
+		assertError(t, os.RemoveAll(dataPath), false)
+		assertError(t, os.MkdirAll(filepath.Join(dataPath, "blocker"), 0755), false)
+	}
+	t.Run("Data_Rename_Overwrite", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("atomic_ren", []int{2}, []float64{1, 2}), false)
+		metaBefore, err := os.ReadFile(filepath.Join(dataDir, "atomic_ren.meta"))
+		assertError(t, err, false)
+		blockDataFile(t, "atomic_ren")
+		err = apiClient.InsertFloat64Data("atomic_ren", []float64{7, 8})
+		assertErrorContains(t, err, "failed to replace data file")
+		metaAfter, err := os.ReadFile(filepath.Join(dataDir, "atomic_ren.meta"))
+		assertError(t, err, false)
+		assertEqual(t, string(metaAfter), string(metaBefore))
+	})
+	t.Run("Data_Rename_Create", func(t *testing.T) {
+		blockDataFile(t, "atomic_ren_new")
+		err := apiClient.CreateTensor("atomic_ren_new", []int{2}, tensor.DataTypeInt32)
+		assertErrorContains(t, err, "failed to replace data file")
+		_, err = os.Stat(filepath.Join(dataDir, "atomic_ren_new.meta"))
+		assertTrue(t, os.IsNotExist(err), "atomic_ren_new.meta tidak boleh ada setelah penggantian data gagal")
+	})
+	leftovers, err := filepath.Glob(filepath.Join(dataDir, "*.tmp*"))
+	assertError(t, err, false)
+	assertEqual(t, len(leftovers), 0, "File sementara tertinggal: %v", leftovers)
+}
+
+func TestInsertSlice(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	_, err := run("CREATE TENSOR grid 3,3 TYPE float64")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO grid VALUES (1, 2, 3, 4, 5, 6, 7, 8, 9)")
+	assertError(t, err, false)
+	// Muat sekali agar handle mmap ter-cache; tulisan slice harus tetap terlihat lewat handle itu.
+	_, err = run("SELECT grid FROM grid")
+	assertError(t, err, false)
+
+	t.Run("Middle_Row", func(t *testing.T) {
+		query, err := parser.Parse("INSERT INTO grid[1:2, 0:3] VALUES (40, 50, 60)")
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+		assertEqual(t, query.TensorNames, []string{"grid"})
+		assertEqual(t, query.Slices, [][][2]int{{{1, 2}, {0, 3}}})
+		result, err := executor.Execute(query)
+		assertError(t, err, false)
+		assertEqual(t, result, "Data inserted into grid[[1 2] [0 3]] (3 elements set)")
+
+		data, err := run("SELECT grid FROM grid")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{
+			[]interface{}{float64(1), float64(2), float64(3)},
+			[]interface{}{float64(40), float64(50), float64(60)},
+			[]interface{}{float64(7), float64(8), float64(9)},
+		})
+	})
+
+	t.Run("Sub_Block", func(t *testing.T) {
+		_, err := run("INSERT INTO grid[0:2,1:3] VALUES (-1, -2, -3, -4)")
+		assertError(t, err, false)
+		data, err := run("SELECT grid FROM grid")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{
+			[]interface{}{float64(1), float64(-1), float64(-2)},
+			[]interface{}{float64(40), float64(-3), float64(-4)},
+			[]interface{}{float64(7), float64(8), float64(9)},
+		})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := run("INSERT INTO grid[1:2,0:3] VALUES (1, 2)")
+		assertErrorContains(t, err, "insert provides 2 values, but slice [[1 2] [0 3]] of tensor 'grid' (shape [1 3]) requires 3 elements")
+		_, err = run("INSERT INTO grid[0:4,0:1] VALUES (1, 2, 3, 4)")
+		assertErrorContains(t, err, "invalid slice range [0:4] for dimension 0 with size 3")
+		_, err = run("INSERT INTO grid[0:1] VALUES (1)")
+		assertErrorContains(t, err, "slice [[0 1]] has 1 dimension(s), tensor 'grid' has 2")
+		_, err = run("INSERT INTO grid[0-1,0:1] VALUES (1)")
+		assertErrorContains(t, err, "invalid slice format '0-1' for INSERT")
+		_, err = run("INSERT INTO grid[0:1,0:1] VALUES (abc)")
+		assertErrorContains(t, err, "error parsing 'abc' at index 0 as float64")
+
+		data, err := run("SELECT grid FROM grid")
+		assertError(t, err, false)
+		assertEqual(t, data.([]interface{})[0], []interface{}{float64(1), float64(-1), float64(-2)})
+	})
+}
+
+func TestSmallIntegerDataTypes(t *testing.T) {
+	dataDir, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	t.Run("Int8_Create_Insert_Select", func(t *testing.T) {
+		_, err := run("CREATE TENSOR small_i8 5 TYPE int8")
+		assertError(t, err, false)
+		_, err = run("INSERT INTO small_i8 VALUES (-128, -1, 0, 1, 127)")
+		assertError(t, err, false)
+		result, err := run("SELECT small_i8 FROM small_i8")
+		assertError(t, err, false)
+		assertEqual(t, result, []interface{}{int8(-128), int8(-1), int8(0), int8(1), int8(127)})
+
+		info, err := os.Stat(filepath.Join(dataDir, "small_i8.data"))
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, info.Size(), int64(5))
+		}
+		meta, raw, err := executor.ReadTensorRaw("small_i8")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, meta.DataType, tensor.DataTypeInt8)
+			assertEqual(t, raw, []byte{0x80, 0xff, 0x00, 0x01, 0x7f})
+		}
+	})
+
+	t.Run("Int8_Out_Of_Range", func(t *testing.T) {
+		_, err := run("INSERT INTO small_i8 VALUES (1, 2, 3, 4, 128)")
+		assertErrorContains(t, err, "value '128' at index 4 is out of range for int8: valid range is [-128, 127]")
+	})
+
+	t.Run("Int16_Math", func(t *testing.T) {
+		_, err := run("CREATE TENSOR small_i16 3 TYPE int16")
+		assertError(t, err, false)
+		_, err = run("INSERT INTO small_i16 VALUES (-300, 2, 300)")
+		assertError(t, err, false)
+		_, err = run("MULTIPLY SCALAR 100 TO TENSOR small_i16 INTO small_i16_out")
+		assertError(t, err, false)
+		result, err := run("SELECT small_i16_out FROM small_i16_out")
+		assertError(t, err, false)
+		assertEqual(t, result, []interface{}{int16(-30000), int16(200), int16(30000)})
+
+		info, err := os.Stat(filepath.Join(dataDir, "small_i16.data"))
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, info.Size(), int64(6))
+		}
+	})
+}
+
+func TestSliceToNewTensor(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	// Sumber [3, 4, 5] berisi 0..59 sehingga nilai elemen sama dengan offset datarnya.
+	source := make([]float64, 60)
+	for i := range source {
+		source[i] = float64(i)
+	}
+	assertError(t, apiClient.CreateFromData("crop_src", []int{3, 4, 5}, source), false)
+	storage, err := tensor.NewStorage(dataDir)
+	assertError(t, err, false)
+	if err != nil {
+		return
+	}
+
+	t.Run("Sub_Region", func(t *testing.T) {
+		ranges := [][2]int{{1, 3}, {1, 3}, {2, 5}}
+		assertError(t, storage.SliceToNewTensor("crop_src", "crop_dst", ranges), false)
+		var expected []float64
+		for i := 1; i < 3; i++ {
+			for j := 1; j < 3; j++ {
+				for k := 2; k < 5; k++ {
+					expected = append(expected, float64(i*20+j*5+k))
+				}
+			}
+		}
+		loaded, err := apiClient.LoadTensorFloat64("crop_dst")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Shape, []int{2, 2, 3})
+			assertEqual(t, loaded.Strides, []int{6, 3, 1})
+			assertEqual(t, loaded.Data, expected)
+		}
+		info, err := os.Stat(filepath.Join(dataDir, "crop_dst.data"))
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, info.Size(), int64(len(expected)*8))
+		}
+	})
+
+	t.Run("Full_Range_Equals_Source", func(t *testing.T) {
+		assertError(t, storage.SliceToNewTensor("crop_src", "crop_full", [][2]int{{0, 3}, {0, 4}, {0, 5}}), false)
+		loaded, err := apiClient.LoadTensorFloat64("crop_full")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, source)
+		}
+	})
+
+	t.Run("Empty_Slice", func(t *testing.T) {
+		assertError(t, storage.SliceToNewTensor("crop_src", "crop_empty", [][2]int{{1, 1}, {0, 4}, {0, 5}}), false)
+		meta, err := storage.LoadTensorMetadata("crop_empty")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, meta.Shape, []int{0, 4, 5})
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		err := storage.SliceToNewTensor("crop_src", "crop_dst", [][2]int{{0, 1}, {0, 1}, {0, 1}})
+		assertErrorContains(t, err, "tensor 'crop_dst' already exists")
+		err = storage.SliceToNewTensor("crop_src", "crop_bad", [][2]int{{0, 4}, {0, 1}, {0, 1}})
+		assertErrorContains(t, err, "invalid slice range [0:4] for dimension 0 with size 3")
+		err = storage.SliceToNewTensor("crop_src", "crop_bad", [][2]int{{0, 1}})
+		assertErrorContains(t, err, "slice ranges length 1 does not match tensor dimensions 3")
+		err = storage.SliceToNewTensor("no_such_src", "crop_bad", [][2]int{{0, 1}})
+		assertErrorContains(t, err, "failed to load metadata for source tensor no_such_src")
+		if _, statErr := os.Stat(filepath.Join(dataDir, "crop_bad.meta")); !os.IsNotExist(statErr) {
+			t.Errorf("crop_bad.meta seharusnya tidak dibuat, stat error: %v", statErr)
+		}
+		leftovers, err := filepath.Glob(filepath.Join(dataDir, "*.tmp*"))
+		assertError(t, err, false)
+		assertEqual(t, len(leftovers), 0, "File sementara tertinggal: %v", leftovers)
+	})
+
+	t.Run("Dedup", func(t *testing.T) {
+		dedupDir, dedupClient, cleanupDedup := setupTestClient(t, tensor.WithDedup())
+		defer cleanupDedup()
+		assertError(t, dedupClient.CreateFromData("crop_src", []int{3, 4, 5}, source), false)
+		dedupStorage, err := tensor.NewStorage(dedupDir, tensor.WithDedup())
+		assertError(t, err, false)
+		if err != nil {
+			return
+		}
+
+		// Salinan penuh berisi byte identik sehingga berbagi blob sumber.
+		assertError(t, dedupStorage.SliceToNewTensor("crop_src", "crop_full", [][2]int{{0, 3}, {0, 4}, {0, 5}}), false)
+		sharing, err := dedupStorage.TensorsSharingData("crop_full")
+		assertError(t, err, false)
+		assertEqual(t, sharing, []string{"crop_src"})
+
+		assertError(t, dedupStorage.SliceToNewTensor("crop_src", "crop_dst", [][2]int{{1, 3}, {1, 3}, {2, 5}}), false)
+		meta, err := dedupStorage.LoadTensorMetadata("crop_dst")
+		assertError(t, err, false)
+		if err == nil {
+			assertTrue(t, meta.DataRef != "", "crop_dst seharusnya merujuk blob")
+			assertTrue(t, meta.Checksum != nil, "Metadata crop_dst seharusnya memiliki checksum")
+		}
+		loaded, err := dedupClient.LoadTensorFloat64("crop_dst")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data[:3], []float64{27, 28, 29})
+		}
+		for _, pattern := range []string{"crop_*.data", "*.tmp*", filepath.Join(tensor.BlobDirName, "*.tmp*")} {
+			matches, err := filepath.Glob(filepath.Join(dedupDir, pattern))
+			assertError(t, err, false)
+			assertEqual(t, len(matches), 0, "File tidak terduga untuk pola %s: %v", pattern, matches)
+		}
+	})
+}
+
+func TestNegativeSliceIndices(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+	row := func(values ...float64) []interface{} {
+		r := make([]interface{}, len(values))
+		for i, v := range values {
+			r[i] = v
+		}
+		return r
+	}
+
+	_, err := run("CREATE TENSOR t 3,3 TYPE float64")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO t VALUES (1, 2, 3, 4, 5, 6, 7, 8, 9)")
+	assertError(t, err, false)
+
+	t.Run("Parse_Keeps_Relative_Bounds", func(t *testing.T) {
+		query, err := parser.Parse("SELECT t FROM t [-1:, :]")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Slices, [][][2]int{{{-1, tensor.SliceEnd}, {0, tensor.SliceEnd}}})
+		}
+	})
+
+	t.Run("Last_Row", func(t *testing.T) {
+		data, err := run("SELECT t FROM t [-1:, :]")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{row(7, 8, 9)})
+	})
+
+	t.Run("Mixed_Bounds", func(t *testing.T) {
+		data, err := run("SELECT t FROM t [:2, -2:]")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{row(2, 3), row(5, 6)})
+		data, err = run("SELECT t FROM t [-3:-1, 1:-1]")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{row(2), row(5)})
+	})
+
+	t.Run("Insert_And_Create_From_Select", func(t *testing.T) {
+		_, err := run("INSERT INTO t[-1:, -1:] VALUES (90)")
+		assertError(t, err, false)
+		_, err = run("CREATE TENSOR t_tail FROM SELECT t [-2:, :]")
+		assertError(t, err, false)
+		data, err := run("SELECT t_tail FROM t_tail")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{row(4, 5, 6), row(7, 8, 90)})
+	})
+
+	t.Run("Out_Of_Range", func(t *testing.T) {
+		_, err := run("SELECT t FROM t [-4:, :]")
+		assertErrorContains(t, err, "invalid slice range [-4:] for dimension 0 with size 3")
+		_, err = run("SELECT t FROM t [2:-2, :]")
+		assertErrorContains(t, err, "invalid slice range [2:-2] for dimension 0 with size 3")
+		_, err = run("INSERT INTO t[0:1, -5:-4] VALUES (1)")
+		assertErrorContains(t, err, "invalid slice range [-5:-4] for dimension 1 with size 3")
+		_, err = run("SELECT t FROM t [1:, 0:4]")
+		assertErrorContains(t, err, "invalid slice range [0:4] for dimension 1 with size 3")
+	})
+}
+
+func TestOpenEndedSliceBounds(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	_, err := run("CREATE TENSOR m 3,4 TYPE int32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO m VALUES (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11)")
+	assertError(t, err, false)
+
+	t.Run("Parse", func(t *testing.T) {
+		query, err := parser.Parse("SELECT m FROM m [ : , 1 : ]")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Slices, [][][2]int{{{0, tensor.SliceEnd}, {1, tensor.SliceEnd}}})
+		}
+		query, err = parser.Parse("GET DATA FROM m[:2, :], m[1:, 3:]")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Slices, [][][2]int{{{0, 2}, {0, tensor.SliceEnd}}, {{1, tensor.SliceEnd}, {3, tensor.SliceEnd}}})
+		}
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		data, err := run("SELECT m FROM m [ : , 1 : ]")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{
+			[]interface{}{int32(1), int32(2), int32(3)},
+			[]interface{}{int32(5), int32(6), int32(7)},
+			[]interface{}{int32(9), int32(10), int32(11)},
+		})
+		data, err = run("SELECT m FROM m [:1, :2]")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{[]interface{}{int32(0), int32(1)}})
+	})
+
+	t.Run("Get_Data_Resolved_Shape", func(t *testing.T) {
+		result, err := run("GET DATA FROM m[1:, :]")
+		assertError(t, err, false)
+		batches, ok := result.([]tensor.TensorDataResult)
+		assertTrue(t, ok, "Hasil GET DATA satu tensor bukan []tensor.TensorDataResult: %T", result)
+		if ok && len(batches) == 1 {
+			assertEqual(t, batches[0].Shape, []int{2, 4})
+			assertEqual(t, batches[0].Data, []int32{4, 5, 6, 7, 8, 9, 10, 11})
+		}
+	})
+
+	t.Run("Insert_Reports_Resolved_Ranges", func(t *testing.T) {
+		result, err := run("INSERT INTO m[2:, :] VALUES (-1, -2, -3, -4)")
+		assertError(t, err, false)
+		assertEqual(t, result, "Data inserted into m[[2 3] [0 4]] (4 elements set)")
+	})
+}
+
+func TestSliceStep(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+	ints := func(values ...int32) []interface{} {
+		r := make([]interface{}, len(values))
+		for i, v := range values {
+			r[i] = v
+		}
+		return r
+	}
+
+	_, err := run("CREATE TENSOR v 6 TYPE int32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO v VALUES (0, 1, 2, 3, 4, 5)")
+	assertError(t, err, false)
+	// grid [4,4] berisi 0..15 sehingga nilai elemen sama dengan offset datarnya.
+	_, err = run("CREATE TENSOR grid 4,4 TYPE int32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO grid VALUES (0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15)")
+	assertError(t, err, false)
+
+	t.Run("Parse", func(t *testing.T) {
+		query, err := parser.Parse("SELECT grid FROM grid [0:4:2, 1:]")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.Slices, [][][2]int{{{0, 4}, {1, tensor.SliceEnd}}})
+			assertEqual(t, query.SliceSteps, [][]int{{2, 1}})
+		}
+		query, err = parser.Parse("SELECT grid FROM grid [0:4, 1:]")
+		assertError(t, err, false)
+		if err == nil {
+			assertTrue(t, query.SliceSteps == nil, "SliceSteps harus nil tanpa langkah, aktual: %v", query.SliceSteps)
+		}
+		query, err = parser.Parse("GET DATA FROM v, grid[::3, ::2]")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, query.SliceSteps, [][]int{nil, {3, 2}})
+		}
+	})
+
+	t.Run("Vector_Step_2", func(t *testing.T) {
+		data, err := run("SELECT v FROM v [0:6:2]")
+		assertError(t, err, false)
+		assertEqual(t, data, ints(0, 2, 4))
+		data, err = run("SELECT v FROM v [1::2]")
+		assertError(t, err, false)
+		assertEqual(t, data, ints(1, 3, 5))
+		// ceil((5-0)/2) = 3 elemen.
+		data, err = run("SELECT v FROM v [0:5:2]")
+		assertError(t, err, false)
+		assertEqual(t, data, ints(0, 2, 4))
+		// Langkah mendekati MaxInt hanya mengambil elemen pertama tanpa overflow.
+		data, err = run("SELECT v FROM v [0:6:9223372036854775807]")
+		assertError(t, err, false)
+		assertEqual(t, data, ints(0))
+	})
+
+	t.Run("Matrix_Mixed_Steps", func(t *testing.T) {
+		data, err := run("SELECT grid FROM grid [0:4:2, 1:4]")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{ints(1, 2, 3), ints(9, 10, 11)})
+		data, err = run("SELECT grid FROM grid [::3, ::2]")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{ints(0, 2), ints(12, 14)})
+		data, err = run("SELECT grid FROM grid [-3:, 0:4:3]")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{ints(4, 7), ints(8, 11), ints(12, 15)})
+	})
+
+	t.Run("Get_Data_And_Create_From_Select", func(t *testing.T) {
+		result, err := run("GET DATA FROM grid[1::2, ::3]")
+		assertError(t, err, false)
+		batches, ok := result.([]tensor.TensorDataResult)
+		assertTrue(t, ok, "Hasil GET DATA satu tensor bukan []tensor.TensorDataResult: %T", result)
+		if ok && len(batches) == 1 {
+			assertEqual(t, batches[0].Shape, []int{2, 2})
+			assertEqual(t, batches[0].Data, []int32{4, 7, 12, 15})
+		}
+
+		_, err = run("CREATE TENSOR grid_even FROM SELECT grid [::2, ::2]")
+		assertError(t, err, false)
+		data, err := run("SELECT grid_even FROM grid_even")
+		assertError(t, err, false)
+		assertEqual(t, data, []interface{}{ints(0, 2), ints(8, 10)})
+	})
+
+	t.Run("Invalid_Step", func(t *testing.T) {
+		_, err := run("SELECT v FROM v [0:6:0]")
+		assertErrorContains(t, err, "invalid slice step '0' for SELECT: must be a positive integer")
+		_, err = run("SELECT v FROM v [0:6:-1]")
+		assertErrorContains(t, err, "invalid slice step '-1' for SELECT: must be a positive integer")
+		_, err = run("GET DATA FROM grid[0:4:x, :]")
+		assertErrorContains(t, err, "invalid slice step 'x' for tensor 'grid'")
+		_, err = run("INSERT INTO v[0:6:2] VALUES (1, 2, 3)")
+		assertErrorContains(t, err, "slice step is not supported for INSERT")
+
+		vec, err := tensor.NewTensor[int32]("vec", []int{6}, tensor.DataTypeInt32)
+		assertError(t, err, false)
+		if err == nil {
+			_, err = vec.GetSliceStep([][2]int{{0, 6}}, []int{0})
+			assertErrorContains(t, err, "invalid slice step 0 for dimension 0: must be a positive integer")
+		}
+	})
+}
+
+func TestParseErrorPositions(t *testing.T) {
+	parser := &tensor.Parser{}
+	parseError := func(t *testing.T, query string) *tensor.ParseError {
+		t.Helper()
+		_, err := parser.Parse(query)
+		var pe *tensor.ParseError
+		if !errors.As(err, &pe) {
+			t.Fatalf("Error untuk '%s' diharapkan *tensor.ParseError, aktual: %v", query, err)
+		}
+		return pe
+	}
+
+	t.Run("Malformed_Slice", func(t *testing.T) {
+		query := "SELECT t FROM t [0:2, 1-3]"
+		pe := parseError(t, query)
+		assertEqual(t, pe.Clause, "slice")
+		assertEqual(t, pe.Text, "1-3")
+		assertEqual(t, pe.Pos, strings.Index(query, "1-3"))
+		assertEqual(t, pe.Error(), "invalid slice format '1-3' for SELECT")
+	})
+
+	t.Run("Slice_Bound_Keeps_Cause", func(t *testing.T) {
+		query := "  SELECT t FROM t [0:x]"
+		pe := parseError(t, query)
+		assertEqual(t, pe.Text, "0:x")
+		assertEqual(t, pe.Pos, strings.Index(query, "0:x"))
+		assertErrorContains(t, pe, "invalid slice end 'x' for SELECT: strconv.Atoi")
+	})
+
+	t.Run("Insert_And_Get_Data_Slice", func(t *testing.T) {
+		query := "INSERT INTO t[0:1, 5:2] VALUES (1)"
+		pe := parseError(t, query)
+		assertEqual(t, pe.Text, "5:2")
+		assertEqual(t, pe.Pos, strings.Index(query, "5:2"))
+
+		query = "GET DATA FROM a[0:1], b[0:1,  z] BATCH 2"
+		pe = parseError(t, query)
+		assertEqual(t, pe.Text, "z")
+		assertEqual(t, pe.Pos, strings.Index(query, "z"))
+		assertEqual(t, pe.Error(), "invalid slice format 'z' for tensor 'b'")
+	})
+
+	t.Run("Shape_Type_Values_Where", func(t *testing.T) {
+		query := "CREATE TENSOR t 2,,3 TYPE float32"
+		pe := parseError(t, query)
+		assertEqual(t, pe.Clause, "shape")
+		assertEqual(t, pe.Text, "2,,3")
+		assertEqual(t, pe.Pos, 16)
+
+		query = "CREATE TENSOR t 2 TYPE float16"
+		pe = parseError(t, query)
+		assertEqual(t, pe.Clause, "type")
+		assertEqual(t, pe.Pos, strings.Index(query, "float16"))
+
+		query = "INSERT INTO t VALUES (1, 2"
+		pe = parseError(t, query)
+		assertEqual(t, pe.Clause, "values")
+		assertEqual(t, pe.Pos, strings.Index(query, "("))
+
+		query = "LIST TENSORS WHERE DATATYPE = 'bogus'"
+		pe = parseError(t, query)
+		assertEqual(t, pe.Clause, "where")
+		assertEqual(t, pe.Text, "bogus")
+		assertEqual(t, pe.Pos, strings.Index(query, "bogus"))
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		assertTrue(t, parser.Validate("SELECT t FROM t [0:1]") == nil, "kueri valid tidak boleh menghasilkan ParseError")
+
+		query := "CREATE TENSOR c FROM SELECT src [0:1, a:2]"
+		pe := parser.Validate(query)
+		assertTrue(t, pe != nil, "ParseError diharapkan untuk slice yang salah")
+		if pe != nil {
+			assertEqual(t, pe.Text, "a:2")
+			assertEqual(t, pe.Pos, strings.Index(query, "a:2"))
+			assertErrorContains(t, pe, "invalid SELECT in CREATE TENSOR ... FROM: invalid slice start 'a' for SELECT")
+		}
+
+		pe = parser.Validate("FROBNICATE t")
+		assertTrue(t, pe != nil, "ParseError diharapkan untuk kueri yang tidak dikenal")
+		if pe != nil {
+			assertEqual(t, pe.Pos, -1)
+		}
+	})
+}
+
+func TestBoolDataType(t *testing.T) {
+	dataDir, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	t.Run("Create_Insert_Select", func(t *testing.T) {
+		_, err := run("CREATE TENSOR mask 2,2 TYPE bool")
+		assertError(t, err, false)
+		_, err = run("INSERT INTO mask VALUES (true, FALSE, 0, 1)")
+		assertError(t, err, false)
+		result, err := run("SELECT mask FROM mask")
+		assertError(t, err, false)
+		assertEqual(t, result, []interface{}{
+			[]interface{}{true, false},
+			[]interface{}{false, true},
+		})
+
+		info, err := os.Stat(filepath.Join(dataDir, "mask.data"))
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, info.Size(), int64(4))
+		}
+		meta, raw, err := executor.ReadTensorRaw("mask")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, meta.DataType, tensor.DataTypeBool)
+			assertEqual(t, raw, []byte{1, 0, 0, 1})
+		}
+	})
+
+	t.Run("Sliced_Select", func(t *testing.T) {
+		result, err := run("SELECT mask FROM mask [0:2, 1:2]")
+		assertError(t, err, false)
+		assertEqual(t, result, []interface{}{
+			[]interface{}{false},
+			[]interface{}{true},
+		})
+	})
+
+	t.Run("Update_Element", func(t *testing.T) {
+		_, err := run("UPDATE mask[1,0] = true")
+		assertError(t, err, false)
+		result, err := run("SELECT mask FROM mask [1:2, 0:2]")
+		assertError(t, err, false)
+		assertEqual(t, result, []interface{}{[]interface{}{true, true}})
+	})
+
+	t.Run("Invalid_Value", func(t *testing.T) {
+		_, err := run("INSERT INTO mask VALUES (true, false, 2, 1)")
+		assertErrorContains(t, err, "invalid bool value '2' at index 2: expected true, false, 1 or 0")
+	})
+
+	t.Run("Caller_Query_Unchanged", func(t *testing.T) {
+		insert, err := parser.Parse("INSERT INTO mask VALUES (TRUE, false, false, true)")
+		assertError(t, err, false)
+		update, errUpdate := parser.Parse("UPDATE mask[0,1] = True")
+		assertError(t, errUpdate, false)
+		create, errCreate := parser.Parse("CREATE TENSOR mask_filled 2 TYPE bool FILL true")
+		assertError(t, errCreate, false)
+		if err != nil || errUpdate != nil || errCreate != nil {
+			return
+		}
+		for _, query := range []*tensor.Query{insert, update, create} {
+			_, err := executor.Execute(query)
+			assertError(t, err, false)
+		}
+		assertEqual(t, insert.Data, []string{"TRUE", "false", "false", "true"})
+		assertEqual(t, update.ScalarOperand, "True")
+		assertEqual(t, create.ScalarOperand, "true")
+	})
+}
+
+func TestQueryCache(t *testing.T) {
+	_, executor, cleanup := setupTest(t, tensor.WithQueryCache(2))
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+
+	_, err := run("CREATE TENSOR qc 2,2 TYPE int32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO qc VALUES (1, 2, 3, 4)")
+	assertError(t, err, false)
+
+	t.Run("Served_From_Cache", func(t *testing.T) {
+		first, err := run("SELECT qc FROM qc")
+		assertError(t, err, false)
+		second, err := run("SELECT qc FROM qc")
+		assertError(t, err, false)
+		assertEqual(t, second, first)
+		assertEqual(t, executor.QueryCacheStats(), tensor.QueryCacheStats{Hits: 1, Misses: 1, Entries: 1})
+	})
+
+	t.Run("Invalidated_After_Insert", func(t *testing.T) {
+		_, err := run("INSERT INTO qc VALUES (5, 6, 7, 8)")
+		assertError(t, err, false)
+		assertEqual(t, executor.QueryCacheStats().Entries, 0)
+		result, err := run("SELECT qc FROM qc")
+		assertError(t, err, false)
+		assertEqual(t, result, []interface{}{
+			[]interface{}{int32(5), int32(6)},
+			[]interface{}{int32(7), int32(8)},
+		})
+		assertEqual(t, executor.QueryCacheStats().Misses, 2)
+	})
+
+	t.Run("Bounded_And_Unrelated_Writes", func(t *testing.T) {
+		_, err := run("CREATE TENSOR qc_other 2 TYPE int32")
+		assertError(t, err, false)
+		_, err = run("SELECT qc FROM qc [0:1, 0:2]")
+		assertError(t, err, false)
+		_, err = run("SELECT qc FROM qc [1:2, 0:2]")
+		assertError(t, err, false)
+		// Batas dua entri: entri SELECT penuh yang paling lama dipakai diusir.
+		assertEqual(t, executor.QueryCacheStats().Entries, 2)
+		_, err = run("INSERT INTO qc_other VALUES (1, 2)")
+		assertError(t, err, false)
+		assertEqual(t, executor.QueryCacheStats().Entries, 2)
+		_, err = run("DROP TENSOR qc")
+		assertError(t, err, false)
+		assertEqual(t, executor.QueryCacheStats().Entries, 0)
+	})
+}
+
+func TestExportImportShards(t *testing.T) {
+	_, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(q string) (interface{}, error) {
+		query, err := parser.Parse(q)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(query)
+	}
+	shardDir := t.TempDir()
+	prefix := filepath.Join(shardDir, "parts", "features")
+
+	_, err := run("CREATE TENSOR sharded 8,2 TYPE float32")
+	assertError(t, err, false)
+	_, err = run("INSERT INTO sharded VALUES (0, 1, 10, 11, 20, 21, 30, 31, 40, 41, 50, 51, 60, 61, 70, 71)")
+	assertError(t, err, false)
+	original, err := run("SELECT sharded FROM sharded")
+	assertError(t, err, false)
+
+	t.Run("Export_Four_Shards", func(t *testing.T) {
+		_, err := run(fmt.Sprintf("EXPORT TENSOR sharded TO SHARDS '%s' COUNT 4", prefix))
+		assertError(t, err, false)
+		indexBytes, err := os.ReadFile(prefix + ".index.json")
+		assertError(t, err, false)
+		var index tensor.ShardIndex
+		assertError(t, json.Unmarshal(indexBytes, &index), false)
+		assertEqual(t, index.Name, "sharded")
+		assertEqual(t, index.DataType, tensor.DataTypeFloat32)
+		assertEqual(t, index.Shape, []int{8, 2})
+		assertEqual(t, len(index.Shards), 4)
+		for i, shard := range index.Shards {
+			assertEqual(t, [2]int{shard.Start, shard.End}, [2]int{2 * i, 2*i + 2})
+			assertEqual(t, shard.Shape, []int{2, 2})
+			info, err := os.Stat(filepath.Join(shardDir, "parts", shard.File))
+			assertError(t, err, false)
+			if err == nil {
+				assertEqual(t, info.Size(), int64(16))
+			}
+		}
+	})
+
+	t.Run("Import_Reproduces_Original", func(t *testing.T) {
+		_, err := run(fmt.Sprintf("IMPORT FROM SHARDS '%s' INTO sharded_copy", prefix))
+		assertError(t, err, false)
+		result, err := run("SELECT sharded_copy FROM sharded_copy")
+		assertError(t, err, false)
+		assertEqual(t, result, original)
+
+		// Tanpa INTO nama tensor diambil dari indeks, yang sudah ada.
+		_, err = run(fmt.Sprintf("IMPORT FROM SHARDS '%s'", prefix))
+		assertErrorContains(t, err, "tensor 'sharded' already exists")
+	})
+
+	t.Run("Uneven_Split", func(t *testing.T) {
+		unevenPrefix := filepath.Join(shardDir, "uneven")
+		_, err := run(fmt.Sprintf("EXPORT TENSOR sharded TO SHARDS '%s' COUNT 3", unevenPrefix))
+		assertError(t, err, false)
+		_, err = run(fmt.Sprintf("IMPORT FROM SHARDS '%s' INTO sharded_uneven", unevenPrefix))
+		assertError(t, err, false)
+		result, err := run("SELECT sharded_uneven FROM sharded_uneven")
+		assertError(t, err, false)
+		assertEqual(t, result, original)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := run(fmt.Sprintf("EXPORT TENSOR sharded TO SHARDS '%s' COUNT 9", prefix))
+		assertErrorContains(t, err, "shard count 9 is invalid for tensor 'sharded'")
+		_, err = run(fmt.Sprintf("IMPORT FROM SHARDS '%s' INTO missing_shards", filepath.Join(shardDir, "missing")))
+		assertErrorContains(t, err, "failed to read shard index")
+		_, err = run("EXPORT TENSOR sharded TO SHARDS prefix COUNT 2")
+		assertErrorContains(t, err, "invalid EXPORT syntax")
+	})
+
+	t.Run("Untrusted_Index", func(t *testing.T) {
+		writeIndex := func(name, body string) string {
+			badPrefix := filepath.Join(shardDir, name)
+			assertError(t, os.WriteFile(badPrefix+".index.json", []byte(body), 0644), false)
+			return badPrefix
+		}
+		negative := writeIndex("negative", `{"name":"neg_shape","dtype":"float32","shape":[-1],"shards":[{"file":"x.bin","start":0,"end":0,"shape":[0]}]}`)
+		_, err := run(fmt.Sprintf("IMPORT FROM SHARDS '%s'", negative))
+		assertErrorContains(t, err, "invalid dimension size -1 in shape [-1]")
+
+		traversal := writeIndex("traversal", `{"name":"../../escaped","dtype":"float32","shape":[0],"shards":[{"file":"x.bin","start":0,"end":0,"shape":[0]}]}`)
+		_, err = run(fmt.Sprintf("IMPORT FROM SHARDS '%s'", traversal))
+		assertErrorContains(t, err, `tensor name "../../escaped" is not a valid identifier`)
+
+		// Shape raksasa di indeks tidak boleh dialokasikan sebelum ukuran file shard diperiksa.
+		assertError(t, os.WriteFile(filepath.Join(shardDir, "huge.bin"), make([]byte, 8), 0644), false)
+		huge := writeIndex("huge", `{"name":"huge_shape","dtype":"float64","shape":[1099511627776],"shards":[{"file":"huge.bin","start":0,"end":1099511627776,"shape":[1099511627776]}]}`)
+		_, err = run(fmt.Sprintf("IMPORT FROM SHARDS '%s'", huge))
+		assertErrorContains(t, err, "has 8 bytes, expected 8796093022208")
+	})
+}
+
+func TestDataChecksum(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Corrupted_Data_File", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("crc_corrupt", []int{4}, []float32{1, 2, 3, 4}), false)
+		metaBytes, err := os.ReadFile(filepath.Join(dataDir, "crc_corrupt.meta"))
+		assertError(t, err, false)
+		assertTrue(t, strings.Contains(string(metaBytes), `"checksum":`), "File .meta harus berisi checksum")
+
+		dataPath := filepath.Join(dataDir, "crc_corrupt.data")
+		data, err := os.ReadFile(dataPath)
+		assertError(t, err, false)
+		data[5] ^= 0xFF
+		assertError(t, os.WriteFile(dataPath, data, 0644), false)
+
+		_, err = apiClient.LoadTensorFloat32("crc_corrupt")
+		assertErrorContains(t, err, "checksum mismatch for tensor crc_corrupt")
+	})
+
+	t.Run("Missing_Checksum_Is_Unverified", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("crc_legacy", []int{2}, []int32{7, 8}), false)
+		// Metadata format lama tanpa baris checksum.
+		legacyMeta := "name:crc_legacy\nshape:2\ndatatype:int32\nstrides:1\n"
+		assertError(t, os.WriteFile(filepath.Join(dataDir, "crc_legacy.meta"), []byte(legacyMeta), 0644), false)
+
+		loaded, err := apiClient.LoadTensorInt32("crc_legacy")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []int32{7, 8})
+		}
+	})
+
+	t.Run("In_Place_Write_Updates_Checksum", func(t *testing.T) {
+		assertError(t, apiClient.CreateFromData("crc_update", []int{2, 2}, []float64{1, 2, 3, 4}), false)
+		assertError(t, apiClient.UpdateElement("crc_update", []int{1, 0}, 9), false)
+		loaded, err := apiClient.LoadTensorFloat64("crc_update")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []float64{1, 2, 9, 4})
+		}
+	})
+}
+
+func TestMetadataJSONFormat(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	t.Run("Name_With_Colon_Round_Trips", func(t *testing.T) {
+		const name = "encoder:layer_0"
+		assertError(t, apiClient.CreateFromData(name, []int{2, 3}, []float32{1, 2, 3, 4, 5, 6}), false)
+		assertError(t, apiClient.Reopen(), false)
+
+		meta, err := apiClient.GetTensorMetadata(name)
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, meta.Name, name)
+			assertEqual(t, meta.Shape, []int{2, 3})
+			assertEqual(t, meta.DataType, tensor.DataTypeFloat32)
+			assertEqual(t, meta.Strides, []int{3, 1})
+		}
+		loaded, err := apiClient.LoadTensorFloat32(name)
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []float32{1, 2, 3, 4, 5, 6})
+		}
+		listed, err := apiClient.ListTensors(tensor.DataTypeFloat32, 2)
+		assertError(t, err, false)
+		assertEqual(t, len(listed), 1)
+		if len(listed) == 1 {
+			assertEqual(t, listed[0].Name, name)
+		}
+	})
+
+	t.Run("Legacy_Format_Is_Migrated_On_Rename", func(t *testing.T) {
+		legacyMeta := "name:legacy_meta\nshape:3\ndatatype:int32\nstrides:1\n"
+		assertError(t, os.WriteFile(filepath.Join(dataDir, "legacy_meta.meta"), []byte(legacyMeta), 0644), false)
+		assertError(t, os.WriteFile(filepath.Join(dataDir, "legacy_meta.data"), []byte{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}, 0644), false)
+		assertError(t, apiClient.Reopen(), false)
+
+		loaded, err := apiClient.LoadTensorInt32("legacy_meta")
+		assertError(t, err, false)
+		if err == nil {
+			assertEqual(t, loaded.Data, []int32{1, 2, 3})
+		}
+
+		assertError(t, apiClient.RenameTensor("legacy_meta", "migrated_meta"), false)
+		metaBytes, err := os.ReadFile(filepath.Join(dataDir, "migrated_meta.meta"))
+		assertError(t, err, false)
+		var fields map[string]interface{}
+		assertError(t, json.Unmarshal(metaBytes, &fields), false, "Metadata hasil rename harus berformat JSON")
+		assertEqual(t, fields["name"], "migrated_meta")
+		assertEqual(t, fields["datatype"], tensor.DataTypeInt32)
+	})
+
+	t.Run("Invalid_JSON_Is_Reported", func(t *testing.T) {
+		assertError(t, os.WriteFile(filepath.Join(dataDir, "broken_meta.meta"), []byte(`{"name":"broken_meta","shape":[2`), 0644), false)
+		_, err := apiClient.GetTensorMetadata("broken_meta")
+		assertErrorContains(t, err, "invalid metadata format")
+	})
+}
+
+func TestIndexRebuildPartialFailure(t *testing.T) {
+	dataDir, apiClient, cleanup := setupTestClient(t)
+	defer cleanup()
+
+	for _, name := range []string{"idx_a", "idx_b", "idx_c"} {
+		assertError(t, apiClient.CreateFromData(name, []int{2}, []float32{1, 2}), false)
+	}
+	assertError(t, os.WriteFile(filepath.Join(dataDir, "idx_broken.meta"), []byte("{not json"), 0644), false)
+	// Symlink .meta yang menggantung terlihat saat penelusuran tetapi tidak dapat dibaca.
+	assertError(t, os.Symlink(filepath.Join(dataDir, "missing_target"), filepath.Join(dataDir, "idx_dangling.meta")), false)
+
+	storage, err := tensor.NewStorage(dataDir)
+	assertError(t, err, false, "NewStorage tidak boleh gagal karena satu file .meta rusak")
+	index := tensor.NewInMemoryIndex()
+	err = index.Rebuild(dataDir, storage)
+	assertErrorContains(t, err, "failed to load metadata for idx_broken during index rebuild")
+	assertErrorContains(t, err, "failed to load metadata for idx_dangling during index rebuild")
+	names := index.Query("", -1)
+	sort.Strings(names)
+	assertEqual(t, names, []string{"idx_a", "idx_b", "idx_c"})
+
+	assertError(t, apiClient.Reopen(), false)
+	listed, err := apiClient.ListTensors("", -1)
+	assertError(t, err, false)
+	var listedNames []string
+	for _, meta := range listed {
+		listedNames = append(listedNames, meta.Name)
+	}
+	sort.Strings(listedNames)
+	assertEqual(t, listedNames, []string{"idx_a", "idx_b", "idx_c"})
+
+	t.Run("Unreadable_Subdirectory", func(t *testing.T) {
+		if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+			t.Skip("izin direktori tidak membatasi pembacaan untuk root atau di Windows")
+		}
+		locked := filepath.Join(dataDir, "locked")
+		assertError(t, os.Mkdir(locked, 0755), false)
+		assertError(t, os.WriteFile(filepath.Join(locked, "hidden.meta"), []byte("{}"), 0644), false)
+		assertError(t, os.Chmod(locked, 0), false)
+		defer os.Chmod(locked, 0755) // Agar direktori data dapat dihapus oleh cleanup
+
+		index := tensor.NewInMemoryIndex()
+		err := index.Rebuild(dataDir, storage)
+		assertErrorContains(t, err, fmt.Sprintf("failed to read %s during index rebuild", locked))
+		names := index.Query("", -1)
+		sort.Strings(names)
+		assertEqual(t, names, []string{"idx_a", "idx_b", "idx_c"})
+	})
+}
+
+func TestStorageSnapshot(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts []tensor.StorageOption
+	}{
+		{name: "Plain"},
+		{name: "Dedup", opts: []tensor.StorageOption{tensor.WithDedup()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			baseDir, err := os.MkdirTemp("", "tensordb_snapshot_")
+			if err != nil {
+				t.Fatalf("Gagal membuat direktori sementara: %v", err)
+			}
+			defer os.RemoveAll(baseDir)
+			dataDir := filepath.Join(baseDir, "data")
+			storage, err := tensor.NewStorage(dataDir, tc.opts...)
+			if err != nil {
+				t.Fatalf("Gagal membuat storage: %v", err)
+			}
+			executor := tensor.NewExecutor(storage)
+			defer executor.Close()
+			parser := &tensor.Parser{}
+			run := func(exec *tensor.Executor, q string) (interface{}, error) {
+				query, err := parser.Parse(q)
+				if err != nil {
+					return nil, err
+				}
+				return exec.Execute(query)
+			}
+			mustRun := func(q string) {
+				_, err := run(executor, q)
+				assertError(t, err, false, "Kueri %q", q)
+			}
+
+			mustRun("CREATE TENSOR kept 3 TYPE int32 TRACK_STATS")
+			mustRun("INSERT INTO kept VALUES (1, 2, 3)")
+			mustRun("CREATE TENSOR dropped 2 TYPE float32")
+			mustRun("INSERT INTO dropped VALUES (1.5, 2.5)")
+
+			snapDir := filepath.Join(baseDir, "snap")
+			assertError(t, storage.Snapshot(snapDir), false)
+			assertErrorContains(t, storage.Snapshot(snapDir), "already exists")
+
+			// Tulisan setelah snapshot tidak boleh terlihat di snapshot.
+			mustRun("UPDATE kept[0] = 100")
+			mustRun("INSERT INTO kept[1:3] VALUES (20, 30)")
+			mustRun("DROP TENSOR dropped")
+			mustRun("CREATE TENSOR added 2 TYPE int32")
+
+			snapStorage, err := tensor.NewStorage(snapDir)
+			if err != nil {
+				t.Fatalf("Gagal membuka snapshot: %v", err)
+			}
+			snapExecutor := tensor.NewExecutor(snapStorage)
+			defer snapExecutor.Close()
+
+			data, err := run(snapExecutor, "SELECT kept FROM kept")
+			assertError(t, err, false)
+			assertEqual(t, data, []interface{}{int32(1), int32(2), int32(3)})
+			data, err = run(snapExecutor, "SELECT dropped FROM dropped")
+			assertError(t, err, false)
+			assertEqual(t, data, []interface{}{float32(1.5), float32(2.5)})
+			_, err = run(snapExecutor, "SELECT added FROM added")
+			assertError(t, err, true, "Tensor yang dibuat setelah snapshot tidak boleh ada di snapshot")
+			stats, err := run(snapExecutor, "STATS TENSOR kept")
+			assertError(t, err, false)
+			s, ok := stats.(*tensor.TensorStats)
+			assertTrue(t, ok, "Hasil STATS bukan *tensor.TensorStats: %T", stats)
+			if ok {
+				assertEqual(t, s.Count, int64(3))
+			}
+
+			data, err = run(executor, "SELECT kept FROM kept")
+			assertError(t, err, false)
+			assertEqual(t, data, []interface{}{int32(100), int32(20), int32(30)})
+
+			// Tulisan ke snapshot juga tidak memengaruhi storage asal.
+			_, err = run(snapExecutor, "UPDATE dropped[1] = 9")
+			assertError(t, err, false)
+			_, err = run(executor, "SELECT dropped FROM dropped")
+			assertError(t, err, true, "Tensor yang dihapus tidak boleh muncul kembali di storage asal")
+		})
+	}
+
+	t.Run("Concurrent_Rename", func(t *testing.T) {
+		baseDir := t.TempDir()
+		storage, err := tensor.NewStorage(filepath.Join(baseDir, "data"))
+		if err != nil {
+			t.Fatalf("Gagal membuat storage: %v", err)
+		}
+		executor := tensor.NewExecutor(storage)
+		defer executor.Close()
+		parser := &tensor.Parser{}
+		run := func(q string) error {
+			query, err := parser.Parse(q)
+			if err != nil {
+				return err
+			}
+			_, err = executor.Execute(query)
+			return err
+		}
+		assertError(t, run("CREATE TENSOR ping 2 TYPE int32"), false)
+		// Tensor tambahan memperlebar jendela antara membaca direktori dan menyalin file.
+		for i := 0; i < 40; i++ {
+			assertError(t, run(fmt.Sprintf("CREATE TENSOR filler_%d 256 TYPE float64", i)), false)
+		}
+
+		stop := make(chan struct{})
+		done := make(chan error, 1)
+		go func() {
+			from, to := "ping", "pong"
+			for {
+				select {
+				case <-stop:
+					done <- nil
+					return
+				default:
+				}
+				if err := run(fmt.Sprintf("RENAME TENSOR %s TO %s", from, to)); err != nil {
+					done <- err
+					return
+				}
+				from, to = to, from
+			}
+		}()
+
+		for i := 0; i < 20; i++ {
+			snapDir := filepath.Join(baseDir, fmt.Sprintf("snap_%d", i))
+			assertError(t, storage.Snapshot(snapDir), false)
+			metas, _ := filepath.Glob(filepath.Join(snapDir, "p?ng.meta"))
+			assertEqual(t, len(metas), 1, "Snapshot %d harus memuat tepat satu dari ping/pong: %v", i, metas)
+		}
+		close(stop)
+		assertError(t, <-done, false)
+	})
+}
+
+func TestRunningStats(t *testing.T) {
+	dataDir, executor, cleanup := setupTest(t)
+	defer cleanup()
+	parser := &tensor.Parser{}
+	run := func(queryStr string) (interface{}, error) {
+		q, err := parser.Parse(queryStr)
+		if err != nil {
+			return nil, err
+		}
+		return executor.Execute(q)
+	}
+	statsOf := func(name string) *tensor.TensorStats {
+		result, err := run("STATS TENSOR " + name)
+		assertError(t, err, false)
+		stats, ok := result.(*tensor.TensorStats)
+		assertTrue(t, ok, "STATS harus mengembalikan *tensor.TensorStats, didapat %T", result)
+		if !ok {
+			return &tensor.TensorStats{}
+		}
+		return stats
+	}
+	assertClose := func(actual, expected float64, what string) {
+		t.Helper()
+		assertTrue(t, math.Abs(actual-expected) <= 1e-9*math.Max(1, math.Abs(expected)), "%s: didapat %v, diharapkan %v", what, actual, expected)
+	}
+
+	_, err := run("CREATE TENSOR feed 4 TYPE float64 TRACK_STATS")
+	assertError(t, err, false)
+	stats := statsOf("feed")
+	assertEqual(t, stats.Count, int64(0))
+
+	batches := [][]float64{{1, 2, 3, 4}, {10.5, -3, 7, 0.25}, {1e3, 2e3, -5e2, 42}}
+	var all []float64
+	for _, batch := range batches {
+		parts := make([]string, len(batch))
+		for i, v := range batch {
+			parts[i] = fmt.Sprint(v)
+		}
+		_, err := run("INSERT INTO feed VALUES (" + strings.Join(parts, ", ") + ")")
+		assertError(t, err, false)
+		all = append(all, batch...)
+	}
+
+	var sum float64
+	for _, v := range all {
+		sum += v
+	}
+	mean := sum / float64(len(all))
+	var sqDiff float64
+	for _, v := range all {
+		sqDiff += (v - mean) * (v - mean)
+	}
+	stats = statsOf("feed")
+	assertEqual(t, stats.Count, int64(len(all)))
+	assertClose(stats.Mean, mean, "Rata-rata berjalan")
+	assertClose(stats.Variance, sqDiff/float64(len(all)), "Variansi berjalan")
+
+	t.Run("Append_Counts_Only_New_Rows", func(t *testing.T) {
+		_, err := run("CREATE TENSOR grow 2 TYPE int32 TRACK_STATS")
+		assertError(t, err, false)
+		_, err = run("INSERT INTO grow VALUES (2, 4)")
+		assertError(t, err, false)
+		_, err = run("INSERT INTO grow APPEND VALUES (6, 8)")
+		assertError(t, err, false)
+		stats := statsOf("grow")
+		assertEqual(t, stats.Count, int64(4))
+		assertClose(stats.Mean, 5, "Rata-rata setelah APPEND")
+		assertClose(stats.Variance, 5, "Variansi setelah APPEND")
+	})
+
+	t.Run("Overwrite_Paths_Count_Written_Values", func(t *testing.T) {
+		_, err := run("CREATE TENSOR grid_stats 2,2 TYPE float64 TRACK_STATS")
+		assertError(t, err, false)
+		// Setiap nilai yang ditulis menjadi sampel, apa pun jalur penulisannya.
+		steps := []struct {
+			query   string
+			written []float64
+		}{
+			{"INSERT INTO grid_stats VALUES (1, 2, 3, 4)", []float64{1, 2, 3, 4}},
+			{"INSERT INTO grid_stats[0:1, 0:2] VALUES (10, 20)", []float64{10, 20}},
+			{"UPDATE grid_stats[1,1] = -6", []float64{-6}},
+			{"ADD SCALAR 1 TO TENSOR grid_stats IN PLACE", []float64{11, 21, 4, -5}},
+		}
+		var written []float64
+		for _, step := range steps {
+			_, err := run(step.query)
+			assertError(t, err, false, "Kueri %q", step.query)
+			written = append(written, step.written...)
+
+			var sum float64
+			for _, v := range written {
+				sum += v
+			}
+			mean := sum / float64(len(written))
+			var sqDiff float64
+			for _, v := range written {
+				sqDiff += (v - mean) * (v - mean)
+			}
+			stats := statsOf("grid_stats")
+			assertEqual(t, stats.Count, int64(len(written)), "Jumlah sampel setelah %q", step.query)
+			assertClose(stats.Mean, mean, "Rata-rata setelah "+step.query)
+			assertClose(stats.Variance, sqDiff/float64(len(written)), "Variansi setelah "+step.query)
+		}
+	})
+
+	t.Run("Persists_Across_Rename_And_Drop", func(t *testing.T) {
+		_, err := run("RENAME TENSOR feed TO feed_renamed")
+		assertError(t, err, false)
+		assertEqual(t, statsOf("feed_renamed").Count, int64(len(all)))
+		_, err = run("DROP TENSOR feed_renamed")
+		assertError(t, err, false)
+		_, statErr := os.Stat(filepath.Join(dataDir, "feed_renamed.stats"))
+		assertTrue(t, os.IsNotExist(statErr), "File .stats harus ikut terhapus saat DROP")
+	})
+
+	t.Run("Untracked_Tensor", func(t *testing.T) {
+		_, err := run("CREATE TENSOR plain 2 TYPE float32")
+		assertError(t, err, false)
+		_, err = run("INSERT INTO plain VALUES (1, 2)")
+		assertError(t, err, false)
+		_, err = run("STATS TENSOR plain")
+		assertErrorContains(t, err, "does not track statistics")
+		_, statErr := os.Stat(filepath.Join(dataDir, "plain.stats"))
+		assertTrue(t, os.IsNotExist(statErr), "Tensor tanpa TRACK_STATS tidak boleh memiliki file .stats")
+	})
+}
+
+func TestUnsafeReads(t *testing.T) {
+	dataDir, safeExecutor, cleanup := setupTest(t)
+	defer cleanup()
+	storage, err := tensor.NewStorage(dataDir)
+	assertError(t, err, false)
+	unsafeExecutor := tensor.NewExecutor(storage, tensor.WithUnsafeReads())
+	defer unsafeExecutor.Close()
+	parser := &tensor.Parser{}
+
+	tensors := []struct{ name, create, insert string }{
+		{"ur_f32", "CREATE TENSOR ur_f32 2,3 TYPE float32", "INSERT INTO ur_f32 VALUES (1.5, -2.25, 3e10, 0, -0.001, 7)"},
+		{"ur_f64", "CREATE TENSOR ur_f64 4 TYPE float64", "INSERT INTO ur_f64 VALUES (3.141592653589793, -1e-300, 2, 1e300)"},
+		{"ur_i16", "CREATE TENSOR ur_i16 3 TYPE int16", "INSERT INTO ur_i16 VALUES (-32768, 0, 32767)"},
+		{"ur_i64", "CREATE TENSOR ur_i64 2 TYPE int64", "INSERT INTO ur_i64 VALUES (-9223372036854775808, 9223372036854775807)"},
+		{"ur_u32", "CREATE TENSOR ur_u32 2,2 TYPE uint32", "INSERT INTO ur_u32 VALUES (0, 1, 4294967295, 123456)"},
+		{"ur_bool", "CREATE TENSOR ur_bool 3 TYPE bool", "INSERT INTO ur_bool VALUES (true, false, true)"},
+	}
+	for _, tc := range tensors {
+		for _, queryStr := range []string{tc.create, tc.insert} {
+			q, err := parser.Parse(queryStr)
+			assertError(t, err, false, "Parse %q", queryStr)
+			if err == nil {
+				_, err = safeExecutor.Execute(q)
+				assertError(t, err, false, "Execute %q", queryStr)
+			}
+		}
+	}
+
+	for _, tc := range tensors {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := parser.Parse("SELECT " + tc.name + " FROM " + tc.name)
+			assertError(t, err, false)
+			if err != nil {
+				return
+			}
+			expected, err := safeExecutor.Execute(q)
+			assertError(t, err, false)
+			actual, err := unsafeExecutor.Execute(q)
+			assertError(t, err, false)
+			assertEqual(t, actual, expected, "Hasil jalur unsafe harus identik dengan jalur default")
+		})
+	}
+
+	t.Run("ReadData_Equivalence", func(t *testing.T) {
+		raw := make([]byte, 64)
+		for i := range raw {
+			raw[i] = byte(i*37 + 11)
+		}
+		safeF64, err := tensor.ReadData[float64](raw, 8, tensor.DataTypeFloat64)
+		assertError(t, err, false)
+		unsafeF64, err := tensor.ReadDataUnsafe[float64](raw, 8, tensor.DataTypeFloat64)
+		assertError(t, err, false)
+		for i := range safeF64 {
+			assertEqual(t, math.Float64bits(unsafeF64[i]), math.Float64bits(safeF64[i]), "Elemen %d", i)
+		}
+		safeI32, err := tensor.ReadData[int32](raw, 16, tensor.DataTypeInt32)
+		assertError(t, err, false)
+		unsafeI32, err := tensor.ReadDataUnsafe[int32](raw, 16, tensor.DataTypeInt32)
+		assertError(t, err, false)
+		assertEqual(t, unsafeI32, safeI32)
+
+		// Hasil adalah salinan: mengubah byte sumber tidak mengubah slice yang sudah dibaca.
+		before := unsafeI32[0]
+		raw[0]++
+		assertEqual(t, unsafeI32[0], before)
+
+		_, err = tensor.ReadDataUnsafe[int32](raw, 17, tensor.DataTypeInt32)
+		assertErrorContains(t, err, "is less than expected data size")
+		_, err = tensor.ReadDataUnsafe[int32](raw, 4, tensor.DataTypeInt64)
+		assertErrorContains(t, err, "does not match data type")
+	})
+}
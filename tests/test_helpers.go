@@ -13,14 +13,14 @@ import (
 
 // setupTestClient menginisialisasi storage, executor, dan client untuk pengujian.
 // Ini spesifik untuk pengujian client, jadi mungkin tetap di client_test.go atau di sini jika umum.
-func setupTestClient(t *testing.T) (string, *client.Client, func()) {
+func setupTestClient(t *testing.T, opts ...tensor.StorageOption) (string, *client.Client, func()) {
 	t.Helper()
 	dataDir, err := os.MkdirTemp("", "tensordb_test_common_")
 	if err != nil {
 		t.Fatalf("Gagal membuat direktori data sementara: %v", err)
 	}
 
-	storage, errStorage := tensor.NewStorage(dataDir)
+	storage, errStorage := tensor.NewStorage(dataDir, opts...)
 	if errStorage != nil {
 		os.RemoveAll(dataDir)
 		t.Fatalf("Gagal membuat storage: %v", errStorage)
@@ -41,7 +41,7 @@ func setupTestClient(t *testing.T) (string, *client.Client, func()) {
 }
 
 // setupTest (untuk TestTensorDBOperations)
-func setupTest(t *testing.T) (string, *tensor.Executor, func()) {
+func setupTest(t *testing.T, opts ...tensor.ExecutorOption) (string, *tensor.Executor, func()) {
 	t.Helper()
 	dataDir, err := os.MkdirTemp("", "tensordb_testdir_") // Nama dir berbeda untuk menghindari konflik jika dijalankan bersamaan
 	if err != nil {
@@ -52,7 +52,7 @@ func setupTest(t *testing.T) (string, *tensor.Executor, func()) {
 		os.RemoveAll(dataDir)
 		t.Fatalf("Gagal membuat storage: %v", errStorage)
 	}
-	executor := tensor.NewExecutor(storage) // Menggunakan tensor.Executor dari tensordb/tensor
+	executor := tensor.NewExecutor(storage, opts...) // Menggunakan tensor.Executor dari tensordb/tensor
 	cleanup := func() {
 		if errClose := executor.Close(); errClose != nil {
 			t.Logf("Peringatan: Error saat menutup executor: %v", errClose)
@@ -77,6 +77,20 @@ func assertEqual(t *testing.T, actual, expected interface{}, msgAndArgs ...inter
 	}
 }
 
+// assertFormattedEqual membandingkan hasil SELECT yang sudah diformat (struktur []interface{} bersarang)
+// dan melaporkan path perbedaan pertama, bukan seluruh struktur.
+func assertFormattedEqual(t *testing.T, actual, expected interface{}, msgAndArgs ...interface{}) {
+	t.Helper()
+	if ok, diff := tensor.CompareFormatted(expected, actual); !ok {
+		message := "Assertion Failed: Hasil terformat berbeda pada " + diff
+		if len(msgAndArgs) > 0 {
+			customMsg := fmt.Sprintf(msgAndArgs[0].(string), msgAndArgs[1:]...)
+			message = customMsg + "\n" + message
+		}
+		t.Errorf("%s", message)
+	}
+}
+
 // assertError memeriksa apakah error terjadi (jika shouldError true) atau tidak (jika shouldError false).
 func assertError(t *testing.T, err error, shouldError bool, msgAndArgs ...interface{}) {
 	t.Helper()
@@ -120,14 +134,14 @@ func assertTrue(t *testing.T, condition bool, msgAndArgs ...interface{}) {
 	}
 }
 
-func setupBenchmarkClient(b *testing.B) (*client.Client, func()) {
+func setupBenchmarkClient(b *testing.B, opts ...tensor.StorageOption) (*client.Client, func()) {
 	b.Helper()
 	dataDir, err := os.MkdirTemp("", "tensordb_bench_")
 	if err != nil {
 		b.Fatalf("Gagal membuat direktori data sementara: %v", err)
 	}
 
-	storage, errStorage := tensor.NewStorage(dataDir)
+	storage, errStorage := tensor.NewStorage(dataDir, opts...)
 	if errStorage != nil {
 		os.RemoveAll(dataDir)
 		b.Fatalf("Gagal membuat storage: %v", errStorage)
@@ -144,3 +158,24 @@ func setupBenchmarkClient(b *testing.B) (*client.Client, func()) {
 	}
 	return apiClient, cleanup
 }
+
+// setupBenchmarkStorage seperti setupBenchmarkClient, tetapi juga mengembalikan Storage yang dipakai
+// client untuk benchmark operasi tingkat Storage.
+func setupBenchmarkStorage(b *testing.B) (*tensor.Storage, *client.Client, func()) {
+	b.Helper()
+	dataDir, err := os.MkdirTemp("", "tensordb_bench_")
+	if err != nil {
+		b.Fatalf("Gagal membuat direktori data sementara: %v", err)
+	}
+	storage, errStorage := tensor.NewStorage(dataDir)
+	if errStorage != nil {
+		os.RemoveAll(dataDir)
+		b.Fatalf("Gagal membuat storage: %v", errStorage)
+	}
+	apiClient := client.NewClient(tensor.NewExecutor(storage))
+	cleanup := func() {
+		apiClient.Close()
+		os.RemoveAll(dataDir)
+	}
+	return storage, apiClient, cleanup
+}
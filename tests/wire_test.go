@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"net"
+	"testing"
+
+	"github.com/sciefylab/tensordb/pkg/tensor"
+	"github.com/sciefylab/tensordb/pkg/wire"
+)
+
+// runWireRoundTrip menjalankan create/insert/select/execute/drop melalui klien wire yang terhubung ke server.
+func runWireRoundTrip(t *testing.T, wireClient *wire.Client) {
+	t.Helper()
+
+	assertError(t, wireClient.CreateTensor("wire_f32", []int{2, 3}, tensor.DataTypeFloat32), false)
+	assertError(t, wireClient.InsertFloat32Data("wire_f32", []float32{1, 2, 3, 4, 5, 6}), false)
+	loaded, err := wireClient.LoadTensorFloat32("wire_f32")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, loaded.Name, "wire_f32")
+		assertEqual(t, loaded.Shape, []int{2, 3})
+		assertEqual(t, loaded.Data, []float32{1, 2, 3, 4, 5, 6})
+	}
+
+	assertError(t, wireClient.CreateTensor("wire_i64", []int{3}, tensor.DataTypeInt64), false)
+	assertError(t, wireClient.InsertInt64Data("wire_i64", []int64{-1, 0, 1 << 40}), false)
+	loadedInt, err := wireClient.LoadTensorInt64("wire_i64")
+	assertError(t, err, false)
+	if err == nil {
+		assertEqual(t, loadedInt.Data, []int64{-1, 0, 1 << 40})
+	}
+
+	result, err := wireClient.Execute("SELECT wire_i64 FROM wire_i64 [1:3]")
+	assertError(t, err, false)
+	assertEqual(t, result, "[0 1099511627776]")
+
+	// Error eksekusi dikirim sebagai response dan koneksi tetap bisa dipakai.
+	_, err = wireClient.LoadTensorInt32("wire_f32")
+	assertErrorContains(t, err, "tidak cocok dengan tipe yang diminta")
+	err = wireClient.InsertFloat32Data("wire_f32", []float32{1})
+	assertErrorContains(t, err, "raw data provides 1 elements")
+	_, err = wireClient.LoadTensorFloat32("wire_missing")
+	assertErrorContains(t, err, "tensor 'wire_missing' not found")
+	// Nama dari jaringan tidak boleh keluar dari direktori data.
+	err = wireClient.CreateTensor("../wire_escape", []int{1}, tensor.DataTypeFloat32)
+	assertErrorContains(t, err, `invalid tensor name "../wire_escape"`)
+	err = wireClient.DropTensor("../wire_escape")
+	assertErrorContains(t, err, `invalid tensor name "../wire_escape"`)
+
+	assertError(t, wireClient.DropTensor("wire_f32"), false)
+	_, err = wireClient.LoadTensorFloat32("wire_f32")
+	assertErrorContains(t, err, "tensor 'wire_f32' not found")
+}
+
+func TestWireProtocol(t *testing.T) {
+	t.Run("Pipe", func(t *testing.T) {
+		_, executor, cleanup := setupTest(t)
+		defer cleanup()
+
+		serverConn, clientConn := net.Pipe()
+		done := make(chan error, 1)
+		go func() { done <- wire.NewServer(executor).ServeConn(serverConn) }()
+
+		wireClient := wire.NewClient(clientConn)
+		runWireRoundTrip(t, wireClient)
+		assertError(t, wireClient.Close(), false)
+		assertError(t, <-done, false)
+	})
+
+	t.Run("Loopback_TCP", func(t *testing.T) {
+		_, executor, cleanup := setupTest(t)
+		defer cleanup()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Skipf("Loopback TCP tidak tersedia: %v", err)
+		}
+		done := make(chan error, 1)
+		go func() { done <- wire.NewServer(executor).Serve(listener) }()
+
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		assertError(t, err, false)
+		if err != nil {
+			listener.Close()
+			return
+		}
+		wireClient := wire.NewClient(conn)
+		runWireRoundTrip(t, wireClient)
+		assertError(t, wireClient.Close(), false)
+		assertError(t, listener.Close(), false)
+		assertError(t, <-done, false)
+	})
+}